@@ -43,6 +43,10 @@ func (src *GCPCluster) ConvertTo(dstRaw conversion.Hub) error { // nolint
 				continue
 			}
 			dst.Spec.Network.Subnets[i].Purpose = restoredSubnet.Purpose
+			dst.Spec.Network.Subnets[i].Role = restoredSubnet.Role
+			dst.Spec.Network.Subnets[i].FlowLogs = restoredSubnet.FlowLogs
+			dst.Spec.Network.Subnets[i].StackType = restoredSubnet.StackType
+			dst.Spec.Network.Subnets[i].IPv6AccessType = restoredSubnet.IPv6AccessType
 
 			break
 		}
@@ -51,6 +55,75 @@ func (src *GCPCluster) ConvertTo(dstRaw conversion.Hub) error { // nolint
 	if restored.Spec.CredentialsRef != nil {
 		dst.Spec.CredentialsRef = restored.Spec.CredentialsRef
 	}
+	if restored.Spec.IdentityRef != nil {
+		dst.Spec.IdentityRef = restored.Spec.IdentityRef
+	}
+	if restored.Spec.ImpersonateServiceAccount != nil {
+		dst.Spec.ImpersonateServiceAccount = restored.Spec.ImpersonateServiceAccount
+	}
+	if restored.Spec.QuotaProject != nil {
+		dst.Spec.QuotaProject = restored.Spec.QuotaProject
+	}
+
+	if restored.Spec.Network.MTU != nil {
+		dst.Spec.Network.MTU = restored.Spec.Network.MTU
+	}
+
+	if restored.Spec.Network.UseExisting != nil {
+		dst.Spec.Network.UseExisting = restored.Spec.Network.UseExisting
+	}
+
+	if restored.Spec.Network.HostProject != nil {
+		dst.Spec.Network.HostProject = restored.Spec.Network.HostProject
+	}
+
+	if restored.Spec.Network.Router != nil {
+		dst.Spec.Network.Router = restored.Spec.Network.Router
+	}
+	if restored.Spec.Network.CloudNat != nil {
+		dst.Spec.Network.CloudNat = restored.Spec.Network.CloudNat.DeepCopy()
+	}
+	dst.Spec.Network.Routes = restored.Spec.Network.Routes
+
+	dst.Spec.LoadBalancer = restored.Spec.LoadBalancer
+
+	if restored.Spec.SnapshotSchedule != nil {
+		dst.Spec.SnapshotSchedule = restored.Spec.SnapshotSchedule
+	}
+
+	if restored.Spec.ControlPlaneFailureDomains != nil {
+		dst.Spec.ControlPlaneFailureDomains = restored.Spec.ControlPlaneFailureDomains
+	}
+
+	if restored.Spec.FailureDomainMachineTypes != nil {
+		dst.Spec.FailureDomainMachineTypes = restored.Spec.FailureDomainMachineTypes
+	}
+
+	if restored.Spec.UseExistingInfrastructure != nil {
+		dst.Spec.UseExistingInfrastructure = restored.Spec.UseExistingInfrastructure
+	}
+
+	if restored.Spec.DefaultFirewallRules != nil {
+		dst.Spec.DefaultFirewallRules = restored.Spec.DefaultFirewallRules
+	}
+
+	if restored.Spec.Bastion != nil {
+		dst.Spec.Bastion = restored.Spec.Bastion
+	}
+
+	if restored.Status.Bastion != nil {
+		dst.Status.Bastion = restored.Status.Bastion
+	}
+
+	if restored.Status.Network.Subnets != nil {
+		dst.Status.Network.Subnets = restored.Status.Network.Subnets
+	}
+
+	dst.Status.Network.APIInternalAddress = restored.Status.Network.APIInternalAddress
+	dst.Status.Network.APIInternalHealthCheck = restored.Status.Network.APIInternalHealthCheck
+	dst.Status.Network.APIInternalBackendService = restored.Status.Network.APIInternalBackendService
+	dst.Status.Network.APIInternalForwardingRule = restored.Status.Network.APIInternalForwardingRule
+	dst.Status.Network.APIInternalServiceAttachment = restored.Status.Network.APIInternalServiceAttachment
 
 	return nil
 }
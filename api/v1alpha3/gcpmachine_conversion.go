@@ -49,9 +49,81 @@ func (src *GCPMachine) ConvertTo(dstRaw conversion.Hub) error { // nolint
 		dst.Spec.OnHostMaintenance = restored.Spec.OnHostMaintenance
 	}
 
+	if restored.Spec.AutomaticRestart != nil {
+		dst.Spec.AutomaticRestart = restored.Spec.AutomaticRestart
+	}
+
+	if restored.Spec.MinCPUPlatform != nil {
+		dst.Spec.MinCPUPlatform = restored.Spec.MinCPUPlatform
+	}
+	if restored.Spec.GuestAccelerators != nil {
+		dst.Spec.GuestAccelerators = restored.Spec.GuestAccelerators
+	}
+	if restored.Spec.RootDeviceAutoDelete != nil {
+		dst.Spec.RootDeviceAutoDelete = restored.Spec.RootDeviceAutoDelete
+	}
+
 	if restored.Spec.ConfidentialCompute != nil {
 		dst.Spec.ConfidentialCompute = restored.Spec.ConfidentialCompute
 	}
+	if restored.Spec.ConfidentialInstanceType != nil {
+		dst.Spec.ConfidentialInstanceType = restored.Spec.ConfidentialInstanceType
+	}
+	if restored.Spec.AdvancedMachineFeatures != nil {
+		dst.Spec.AdvancedMachineFeatures = restored.Spec.AdvancedMachineFeatures
+	}
+	if restored.Spec.LocalSSDs != nil {
+		dst.Spec.LocalSSDs = restored.Spec.LocalSSDs.DeepCopy()
+	}
+	if restored.Spec.ProvisioningModel != nil {
+		dst.Spec.ProvisioningModel = restored.Spec.ProvisioningModel
+	}
+	if restored.Spec.InstanceTerminationAction != nil {
+		dst.Spec.InstanceTerminationAction = restored.Spec.InstanceTerminationAction
+	}
+	if restored.Spec.MaxRunDuration != nil {
+		dst.Spec.MaxRunDuration = restored.Spec.MaxRunDuration
+	}
+	if restored.Spec.NodeAffinities != nil {
+		dst.Spec.NodeAffinities = restored.Spec.NodeAffinities
+	}
+	if restored.Spec.StackType != nil {
+		dst.Spec.StackType = restored.Spec.StackType
+	}
+	if restored.Spec.IPv6AccessType != nil {
+		dst.Spec.IPv6AccessType = restored.Spec.IPv6AccessType
+	}
+	if restored.Spec.NicType != nil {
+		dst.Spec.NicType = restored.Spec.NicType
+	}
+	if restored.Spec.NetworkPerformanceConfig != nil {
+		dst.Spec.NetworkPerformanceConfig = restored.Spec.NetworkPerformanceConfig
+	}
+	if restored.Spec.OSLogin != nil {
+		dst.Spec.OSLogin = restored.Spec.OSLogin
+	}
+	if restored.Spec.SSHKeys != nil {
+		dst.Spec.SSHKeys = restored.Spec.SSHKeys
+	}
+	if restored.Spec.BlockProjectSSHKeys != nil {
+		dst.Spec.BlockProjectSSHKeys = restored.Spec.BlockProjectSSHKeys
+	}
+	if restored.Spec.RootDeviceProvisionedIOPS != nil {
+		dst.Spec.RootDeviceProvisionedIOPS = restored.Spec.RootDeviceProvisionedIOPS
+	}
+	if restored.Spec.RootDeviceProvisionedThroughput != nil {
+		dst.Spec.RootDeviceProvisionedThroughput = restored.Spec.RootDeviceProvisionedThroughput
+	}
+	if restored.Spec.RootDiskEncryptionKey != nil {
+		dst.Spec.RootDiskEncryptionKey = restored.Spec.RootDiskEncryptionKey
+	}
+	if restored.Spec.RootDeviceResourcePolicies != nil {
+		dst.Spec.RootDeviceResourcePolicies = restored.Spec.RootDeviceResourcePolicies
+	}
+	restoreAdditionalDisks(dst.Spec.AdditionalDisks, restored.Spec.AdditionalDisks)
+	if restored.Status.Disks != nil {
+		dst.Status.Disks = restored.Status.Disks
+	}
 
 	return nil
 }
@@ -87,3 +159,44 @@ func (dst *GCPMachineList) ConvertFrom(srcRaw conversion.Hub) error { // nolint
 func Convert_v1beta1_GCPMachineSpec_To_v1alpha3_GCPMachineSpec(in *v1beta1.GCPMachineSpec, out *GCPMachineSpec, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_GCPMachineSpec_To_v1alpha3_GCPMachineSpec(in, out, s)
 }
+
+// Convert_v1beta1_AttachedDiskSpec_To_v1alpha3_AttachedDiskSpec is an autogenerated conversion function.
+func Convert_v1beta1_AttachedDiskSpec_To_v1alpha3_AttachedDiskSpec(in *v1beta1.AttachedDiskSpec, out *AttachedDiskSpec, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_AttachedDiskSpec_To_v1alpha3_AttachedDiskSpec(in, out, s)
+}
+
+// restoreAdditionalDisks restores the hub-only fields of the additional disks that were not removed
+// or reordered since the down-conversion.
+func restoreAdditionalDisks(dst, restored []v1beta1.AttachedDiskSpec) {
+	if len(dst) != len(restored) {
+		return
+	}
+	for i := range dst {
+		if restored[i].ProvisionedIOPS != nil {
+			dst[i].ProvisionedIOPS = restored[i].ProvisionedIOPS
+		}
+		if restored[i].ProvisionedThroughput != nil {
+			dst[i].ProvisionedThroughput = restored[i].ProvisionedThroughput
+		}
+		if restored[i].EncryptionKey != nil {
+			dst[i].EncryptionKey = restored[i].EncryptionKey
+		}
+		if restored[i].DeviceName != nil {
+			dst[i].DeviceName = restored[i].DeviceName
+		}
+		if restored[i].AutoDelete != nil {
+			dst[i].AutoDelete = restored[i].AutoDelete
+		}
+		if restored[i].Labels != nil {
+			dst[i].Labels = restored[i].Labels
+		}
+		if restored[i].ResourcePolicies != nil {
+			dst[i].ResourcePolicies = restored[i].ResourcePolicies
+		}
+	}
+}
+
+// Convert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus is an autogenerated conversion function.
+func Convert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(in *v1beta1.GCPMachineStatus, out *GCPMachineStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(in, out, s)
+}
@@ -45,11 +45,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.AttachedDiskSpec)(nil), (*AttachedDiskSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_AttachedDiskSpec_To_v1alpha3_AttachedDiskSpec(a.(*v1beta1.AttachedDiskSpec), b.(*AttachedDiskSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*BuildParams)(nil), (*v1beta1.BuildParams)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_BuildParams_To_v1beta1_BuildParams(a.(*BuildParams), b.(*v1beta1.BuildParams), scope)
 	}); err != nil {
@@ -120,11 +115,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.GCPMachineStatus)(nil), (*GCPMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(a.(*v1beta1.GCPMachineStatus), b.(*GCPMachineStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*GCPMachineTemplate)(nil), (*v1beta1.GCPMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_GCPMachineTemplate_To_v1beta1_GCPMachineTemplate(a.(*GCPMachineTemplate), b.(*v1beta1.GCPMachineTemplate), scope)
 	}); err != nil {
@@ -205,6 +195,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.AttachedDiskSpec)(nil), (*AttachedDiskSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AttachedDiskSpec_To_v1alpha3_AttachedDiskSpec(a.(*v1beta1.AttachedDiskSpec), b.(*AttachedDiskSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*GCPClusterSpec)(nil), (*v1beta1.GCPClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_GCPClusterSpec_To_v1beta1_GCPClusterSpec(a.(*GCPClusterSpec), b.(*v1beta1.GCPClusterSpec), scope)
 	}); err != nil {
@@ -230,6 +225,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.GCPMachineStatus)(nil), (*GCPMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(a.(*v1beta1.GCPMachineStatus), b.(*GCPMachineStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.GCPMachineTemplateResource)(nil), (*GCPMachineTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_GCPMachineTemplateResource_To_v1alpha3_GCPMachineTemplateResource(a.(*v1beta1.GCPMachineTemplateResource), b.(*GCPMachineTemplateResource), scope)
 	}); err != nil {
@@ -257,14 +257,16 @@ func Convert_v1alpha3_AttachedDiskSpec_To_v1beta1_AttachedDiskSpec(in *AttachedD
 func autoConvert_v1beta1_AttachedDiskSpec_To_v1alpha3_AttachedDiskSpec(in *v1beta1.AttachedDiskSpec, out *AttachedDiskSpec, s conversion.Scope) error {
 	out.DeviceType = (*DiskType)(unsafe.Pointer(in.DeviceType))
 	out.Size = (*int64)(unsafe.Pointer(in.Size))
+	// WARNING: in.ProvisionedIOPS requires manual conversion: does not exist in peer-type
+	// WARNING: in.ProvisionedThroughput requires manual conversion: does not exist in peer-type
+	// WARNING: in.EncryptionKey requires manual conversion: does not exist in peer-type
+	// WARNING: in.DeviceName requires manual conversion: does not exist in peer-type
+	// WARNING: in.AutoDelete requires manual conversion: does not exist in peer-type
+	// WARNING: in.Labels requires manual conversion: does not exist in peer-type
+	// WARNING: in.ResourcePolicies requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_AttachedDiskSpec_To_v1alpha3_AttachedDiskSpec is an autogenerated conversion function.
-func Convert_v1beta1_AttachedDiskSpec_To_v1alpha3_AttachedDiskSpec(in *v1beta1.AttachedDiskSpec, out *AttachedDiskSpec, s conversion.Scope) error {
-	return autoConvert_v1beta1_AttachedDiskSpec_To_v1alpha3_AttachedDiskSpec(in, out, s)
-}
-
 func autoConvert_v1alpha3_BuildParams_To_v1beta1_BuildParams(in *BuildParams, out *v1beta1.BuildParams, s conversion.Scope) error {
 	out.Lifecycle = v1beta1.ResourceLifecycle(in.Lifecycle)
 	out.ClusterName = in.ClusterName
@@ -412,9 +414,19 @@ func autoConvert_v1beta1_GCPClusterSpec_To_v1alpha3_GCPClusterSpec(in *v1beta1.G
 	if err := Convert_v1beta1_NetworkSpec_To_v1alpha3_NetworkSpec(&in.Network, &out.Network, s); err != nil {
 		return err
 	}
+	// WARNING: in.UseExistingInfrastructure requires manual conversion: does not exist in peer-type
+	// WARNING: in.DefaultFirewallRules requires manual conversion: does not exist in peer-type
+	// WARNING: in.LoadBalancer requires manual conversion: does not exist in peer-type
+	// WARNING: in.SnapshotSchedule requires manual conversion: does not exist in peer-type
+	// WARNING: in.Bastion requires manual conversion: does not exist in peer-type
 	out.FailureDomains = *(*[]string)(unsafe.Pointer(&in.FailureDomains))
+	// WARNING: in.ControlPlaneFailureDomains requires manual conversion: does not exist in peer-type
+	// WARNING: in.FailureDomainMachineTypes requires manual conversion: does not exist in peer-type
 	out.AdditionalLabels = *(*Labels)(unsafe.Pointer(&in.AdditionalLabels))
 	// WARNING: in.CredentialsRef requires manual conversion: does not exist in peer-type
+	// WARNING: in.IdentityRef requires manual conversion: does not exist in peer-type
+	// WARNING: in.ImpersonateServiceAccount requires manual conversion: does not exist in peer-type
+	// WARNING: in.QuotaProject requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -456,6 +468,7 @@ func autoConvert_v1beta1_GCPClusterStatus_To_v1alpha3_GCPClusterStatus(in *v1bet
 	if err := Convert_v1beta1_Network_To_v1alpha3_Network(&in.Network, &out.Network, s); err != nil {
 		return err
 	}
+	// WARNING: in.Bastion requires manual conversion: does not exist in peer-type
 	out.Ready = in.Ready
 	return nil
 }
@@ -546,7 +559,17 @@ func autoConvert_v1alpha3_GCPMachineSpec_To_v1beta1_GCPMachineSpec(in *GCPMachin
 	out.AdditionalNetworkTags = *(*[]string)(unsafe.Pointer(&in.AdditionalNetworkTags))
 	out.RootDeviceSize = in.RootDeviceSize
 	out.RootDeviceType = (*v1beta1.DiskType)(unsafe.Pointer(in.RootDeviceType))
-	out.AdditionalDisks = *(*[]v1beta1.AttachedDiskSpec)(unsafe.Pointer(&in.AdditionalDisks))
+	if in.AdditionalDisks != nil {
+		in, out := &in.AdditionalDisks, &out.AdditionalDisks
+		*out = make([]v1beta1.AttachedDiskSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_AttachedDiskSpec_To_v1beta1_AttachedDiskSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AdditionalDisks = nil
+	}
 	out.ServiceAccount = (*v1beta1.ServiceAccount)(unsafe.Pointer(in.ServiceAccount))
 	out.Preemptible = in.Preemptible
 	return nil
@@ -565,17 +588,49 @@ func autoConvert_v1beta1_GCPMachineSpec_To_v1alpha3_GCPMachineSpec(in *v1beta1.G
 	out.Image = (*string)(unsafe.Pointer(in.Image))
 	out.AdditionalLabels = *(*Labels)(unsafe.Pointer(&in.AdditionalLabels))
 	out.AdditionalMetadata = *(*[]MetadataItem)(unsafe.Pointer(&in.AdditionalMetadata))
+	// WARNING: in.OSLogin requires manual conversion: does not exist in peer-type
+	// WARNING: in.SSHKeys requires manual conversion: does not exist in peer-type
+	// WARNING: in.BlockProjectSSHKeys requires manual conversion: does not exist in peer-type
 	out.PublicIP = (*bool)(unsafe.Pointer(in.PublicIP))
+	// WARNING: in.StackType requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPv6AccessType requires manual conversion: does not exist in peer-type
+	// WARNING: in.NicType requires manual conversion: does not exist in peer-type
+	// WARNING: in.NetworkPerformanceConfig requires manual conversion: does not exist in peer-type
 	out.AdditionalNetworkTags = *(*[]string)(unsafe.Pointer(&in.AdditionalNetworkTags))
 	out.RootDeviceSize = in.RootDeviceSize
 	out.RootDeviceType = (*DiskType)(unsafe.Pointer(in.RootDeviceType))
-	out.AdditionalDisks = *(*[]AttachedDiskSpec)(unsafe.Pointer(&in.AdditionalDisks))
+	// WARNING: in.RootDeviceProvisionedIOPS requires manual conversion: does not exist in peer-type
+	// WARNING: in.RootDeviceProvisionedThroughput requires manual conversion: does not exist in peer-type
+	// WARNING: in.RootDiskEncryptionKey requires manual conversion: does not exist in peer-type
+	// WARNING: in.RootDeviceAutoDelete requires manual conversion: does not exist in peer-type
+	// WARNING: in.RootDeviceResourcePolicies requires manual conversion: does not exist in peer-type
+	if in.AdditionalDisks != nil {
+		in, out := &in.AdditionalDisks, &out.AdditionalDisks
+		*out = make([]AttachedDiskSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_AttachedDiskSpec_To_v1alpha3_AttachedDiskSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AdditionalDisks = nil
+	}
+	// WARNING: in.LocalSSDs requires manual conversion: does not exist in peer-type
 	out.ServiceAccount = (*ServiceAccount)(unsafe.Pointer(in.ServiceAccount))
 	out.Preemptible = in.Preemptible
+	// WARNING: in.ProvisioningModel requires manual conversion: does not exist in peer-type
+	// WARNING: in.InstanceTerminationAction requires manual conversion: does not exist in peer-type
+	// WARNING: in.MaxRunDuration requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeAffinities requires manual conversion: does not exist in peer-type
 	// WARNING: in.IPForwarding requires manual conversion: does not exist in peer-type
 	// WARNING: in.ShieldedInstanceConfig requires manual conversion: does not exist in peer-type
 	// WARNING: in.OnHostMaintenance requires manual conversion: does not exist in peer-type
+	// WARNING: in.AutomaticRestart requires manual conversion: does not exist in peer-type
 	// WARNING: in.ConfidentialCompute requires manual conversion: does not exist in peer-type
+	// WARNING: in.ConfidentialInstanceType requires manual conversion: does not exist in peer-type
+	// WARNING: in.AdvancedMachineFeatures requires manual conversion: does not exist in peer-type
+	// WARNING: in.MinCPUPlatform requires manual conversion: does not exist in peer-type
+	// WARNING: in.GuestAccelerators requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -597,16 +652,12 @@ func autoConvert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(in *v1bet
 	out.Ready = in.Ready
 	out.Addresses = *(*[]v1.NodeAddress)(unsafe.Pointer(&in.Addresses))
 	out.InstanceStatus = (*InstanceStatus)(unsafe.Pointer(in.InstanceStatus))
+	// WARNING: in.Disks requires manual conversion: does not exist in peer-type
 	out.FailureReason = (*errors.MachineStatusError)(unsafe.Pointer(in.FailureReason))
 	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
 	return nil
 }
 
-// Convert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus is an autogenerated conversion function.
-func Convert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(in *v1beta1.GCPMachineStatus, out *GCPMachineStatus, s conversion.Scope) error {
-	return autoConvert_v1beta1_GCPMachineStatus_To_v1alpha3_GCPMachineStatus(in, out, s)
-}
-
 func autoConvert_v1alpha3_GCPMachineTemplate_To_v1beta1_GCPMachineTemplate(in *GCPMachineTemplate, out *v1beta1.GCPMachineTemplate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_GCPMachineTemplateSpec_To_v1beta1_GCPMachineTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -763,12 +814,18 @@ func autoConvert_v1beta1_Network_To_v1alpha3_Network(in *v1beta1.Network, out *N
 	out.SelfLink = (*string)(unsafe.Pointer(in.SelfLink))
 	out.FirewallRules = *(*map[string]string)(unsafe.Pointer(&in.FirewallRules))
 	out.Router = (*string)(unsafe.Pointer(in.Router))
+	// WARNING: in.Subnets requires manual conversion: does not exist in peer-type
 	out.APIServerAddress = (*string)(unsafe.Pointer(in.APIServerAddress))
 	out.APIServerHealthCheck = (*string)(unsafe.Pointer(in.APIServerHealthCheck))
 	out.APIServerInstanceGroups = *(*map[string]string)(unsafe.Pointer(&in.APIServerInstanceGroups))
 	out.APIServerBackendService = (*string)(unsafe.Pointer(in.APIServerBackendService))
 	out.APIServerTargetProxy = (*string)(unsafe.Pointer(in.APIServerTargetProxy))
 	out.APIServerForwardingRule = (*string)(unsafe.Pointer(in.APIServerForwardingRule))
+	// WARNING: in.APIInternalAddress requires manual conversion: does not exist in peer-type
+	// WARNING: in.APIInternalHealthCheck requires manual conversion: does not exist in peer-type
+	// WARNING: in.APIInternalBackendService requires manual conversion: does not exist in peer-type
+	// WARNING: in.APIInternalForwardingRule requires manual conversion: does not exist in peer-type
+	// WARNING: in.APIInternalServiceAttachment requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -814,8 +871,14 @@ func autoConvert_v1beta1_NetworkSpec_To_v1alpha3_NetworkSpec(in *v1beta1.Network
 	} else {
 		out.Subnets = nil
 	}
+	// WARNING: in.MTU requires manual conversion: does not exist in peer-type
 	out.LoadBalancerBackendPort = (*int32)(unsafe.Pointer(in.LoadBalancerBackendPort))
 	// WARNING: in.DatapathProvider requires manual conversion: does not exist in peer-type
+	// WARNING: in.UseExisting requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostProject requires manual conversion: does not exist in peer-type
+	// WARNING: in.Router requires manual conversion: does not exist in peer-type
+	// WARNING: in.CloudNat requires manual conversion: does not exist in peer-type
+	// WARNING: in.Routes requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -871,5 +934,9 @@ func autoConvert_v1beta1_SubnetSpec_To_v1alpha3_SubnetSpec(in *v1beta1.SubnetSpe
 	out.PrivateGoogleAccess = (*bool)(unsafe.Pointer(in.PrivateGoogleAccess))
 	out.EnableFlowLogs = (*bool)(unsafe.Pointer(in.EnableFlowLogs))
 	// WARNING: in.Purpose requires manual conversion: does not exist in peer-type
+	// WARNING: in.Role requires manual conversion: does not exist in peer-type
+	// WARNING: in.FlowLogs requires manual conversion: does not exist in peer-type
+	// WARNING: in.StackType requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPv6AccessType requires manual conversion: does not exist in peer-type
 	return nil
 }
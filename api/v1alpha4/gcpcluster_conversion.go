@@ -44,6 +44,10 @@ func (src *GCPCluster) ConvertTo(dstRaw conversion.Hub) error { // nolint
 				continue
 			}
 			dst.Spec.Network.Subnets[i].Purpose = restoredSubnet.Purpose
+			dst.Spec.Network.Subnets[i].Role = restoredSubnet.Role
+			dst.Spec.Network.Subnets[i].FlowLogs = restoredSubnet.FlowLogs
+			dst.Spec.Network.Subnets[i].StackType = restoredSubnet.StackType
+			dst.Spec.Network.Subnets[i].IPv6AccessType = restoredSubnet.IPv6AccessType
 
 			break
 		}
@@ -51,6 +55,81 @@ func (src *GCPCluster) ConvertTo(dstRaw conversion.Hub) error { // nolint
 	if restored.Spec.CredentialsRef != nil {
 		dst.Spec.CredentialsRef = restored.Spec.CredentialsRef.DeepCopy()
 	}
+	if restored.Spec.IdentityRef != nil {
+		dst.Spec.IdentityRef = restored.Spec.IdentityRef.DeepCopy()
+	}
+	if restored.Spec.ImpersonateServiceAccount != nil {
+		dst.Spec.ImpersonateServiceAccount = restored.Spec.ImpersonateServiceAccount
+	}
+	if restored.Spec.QuotaProject != nil {
+		dst.Spec.QuotaProject = restored.Spec.QuotaProject
+	}
+	if restored.Spec.Network.MTU != nil {
+		dst.Spec.Network.MTU = restored.Spec.Network.MTU
+	}
+	if restored.Spec.Network.UseExisting != nil {
+		dst.Spec.Network.UseExisting = restored.Spec.Network.UseExisting
+	}
+	if restored.Spec.Network.HostProject != nil {
+		dst.Spec.Network.HostProject = restored.Spec.Network.HostProject
+	}
+	if restored.Spec.Network.Router != nil {
+		dst.Spec.Network.Router = restored.Spec.Network.Router.DeepCopy()
+	}
+	if restored.Spec.Network.CloudNat != nil {
+		dst.Spec.Network.CloudNat = restored.Spec.Network.CloudNat.DeepCopy()
+	}
+	if restored.Spec.Network.Routes != nil {
+		dst.Spec.Network.Routes = restored.Spec.Network.Routes
+	}
+	dst.Spec.LoadBalancer = restored.Spec.LoadBalancer
+
+	if restored.Spec.SnapshotSchedule != nil {
+		dst.Spec.SnapshotSchedule = restored.Spec.SnapshotSchedule
+	}
+
+	if restored.Spec.ControlPlaneFailureDomains != nil {
+		dst.Spec.ControlPlaneFailureDomains = restored.Spec.ControlPlaneFailureDomains
+	}
+
+	if restored.Spec.FailureDomainMachineTypes != nil {
+		dst.Spec.FailureDomainMachineTypes = restored.Spec.FailureDomainMachineTypes
+	}
+
+	if restored.Spec.UseExistingInfrastructure != nil {
+		dst.Spec.UseExistingInfrastructure = restored.Spec.UseExistingInfrastructure
+	}
+
+	if restored.Spec.DefaultFirewallRules != nil {
+		dst.Spec.DefaultFirewallRules = restored.Spec.DefaultFirewallRules
+	}
+
+	if restored.Spec.Bastion != nil {
+		dst.Spec.Bastion = restored.Spec.Bastion
+	}
+
+	if restored.Status.Bastion != nil {
+		dst.Status.Bastion = restored.Status.Bastion
+	}
+
+	if restored.Status.Network.Subnets != nil {
+		dst.Status.Network.Subnets = restored.Status.Network.Subnets
+	}
+	if restored.Status.Network.APIInternalAddress != nil {
+		dst.Status.Network.APIInternalAddress = restored.Status.Network.APIInternalAddress
+	}
+	if restored.Status.Network.APIInternalHealthCheck != nil {
+		dst.Status.Network.APIInternalHealthCheck = restored.Status.Network.APIInternalHealthCheck
+	}
+	if restored.Status.Network.APIInternalBackendService != nil {
+		dst.Status.Network.APIInternalBackendService = restored.Status.Network.APIInternalBackendService
+	}
+	if restored.Status.Network.APIInternalForwardingRule != nil {
+		dst.Status.Network.APIInternalForwardingRule = restored.Status.Network.APIInternalForwardingRule
+	}
+	if restored.Status.Network.APIInternalServiceAttachment != nil {
+		dst.Status.Network.APIInternalServiceAttachment = restored.Status.Network.APIInternalServiceAttachment
+	}
 
 	return nil
 }
@@ -92,3 +171,8 @@ func Convert_v1beta1_SubnetSpec_To_v1alpha4_SubnetSpec(in *infrav1beta1.SubnetSp
 func Convert_v1beta1_GCPClusterSpec_To_v1alpha4_GCPClusterSpec(in *v1beta1.GCPClusterSpec, out *GCPClusterSpec, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_GCPClusterSpec_To_v1alpha4_GCPClusterSpec(in, out, s)
 }
+
+// Convert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus is an autogenerated conversion function.
+func Convert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus(in *v1beta1.GCPClusterStatus, out *GCPClusterStatus, s apiconversion.Scope) error {
+	return autoConvert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus(in, out, s)
+}
@@ -45,6 +45,10 @@ func (src *GCPClusterTemplate) ConvertTo(dstRaw conversion.Hub) error { // nolin
 				continue
 			}
 			dst.Spec.Template.Spec.Network.Subnets[i].Purpose = restoredSubnet.Purpose
+			dst.Spec.Template.Spec.Network.Subnets[i].Role = restoredSubnet.Role
+			dst.Spec.Template.Spec.Network.Subnets[i].FlowLogs = restoredSubnet.FlowLogs
+			dst.Spec.Template.Spec.Network.Subnets[i].StackType = restoredSubnet.StackType
+			dst.Spec.Template.Spec.Network.Subnets[i].IPv6AccessType = restoredSubnet.IPv6AccessType
 
 			break
 		}
@@ -53,6 +57,53 @@ func (src *GCPClusterTemplate) ConvertTo(dstRaw conversion.Hub) error { // nolin
 	if restored.Spec.Template.Spec.CredentialsRef != nil {
 		dst.Spec.Template.Spec.CredentialsRef = restored.Spec.Template.Spec.CredentialsRef.DeepCopy()
 	}
+	if restored.Spec.Template.Spec.IdentityRef != nil {
+		dst.Spec.Template.Spec.IdentityRef = restored.Spec.Template.Spec.IdentityRef.DeepCopy()
+	}
+	if restored.Spec.Template.Spec.ImpersonateServiceAccount != nil {
+		dst.Spec.Template.Spec.ImpersonateServiceAccount = restored.Spec.Template.Spec.ImpersonateServiceAccount
+	}
+	if restored.Spec.Template.Spec.QuotaProject != nil {
+		dst.Spec.Template.Spec.QuotaProject = restored.Spec.Template.Spec.QuotaProject
+	}
+	if restored.Spec.Template.Spec.Network.MTU != nil {
+		dst.Spec.Template.Spec.Network.MTU = restored.Spec.Template.Spec.Network.MTU
+	}
+	if restored.Spec.Template.Spec.Network.UseExisting != nil {
+		dst.Spec.Template.Spec.Network.UseExisting = restored.Spec.Template.Spec.Network.UseExisting
+	}
+	if restored.Spec.Template.Spec.Network.HostProject != nil {
+		dst.Spec.Template.Spec.Network.HostProject = restored.Spec.Template.Spec.Network.HostProject
+	}
+	if restored.Spec.Template.Spec.Network.Router != nil {
+		dst.Spec.Template.Spec.Network.Router = restored.Spec.Template.Spec.Network.Router.DeepCopy()
+	}
+	if restored.Spec.Template.Spec.Network.CloudNat != nil {
+		dst.Spec.Template.Spec.Network.CloudNat = restored.Spec.Template.Spec.Network.CloudNat.DeepCopy()
+	}
+	if restored.Spec.Template.Spec.Network.Routes != nil {
+		dst.Spec.Template.Spec.Network.Routes = restored.Spec.Template.Spec.Network.Routes
+	}
+	dst.Spec.Template.Spec.LoadBalancer = restored.Spec.Template.Spec.LoadBalancer
+	if restored.Spec.Template.Spec.SnapshotSchedule != nil {
+		dst.Spec.Template.Spec.SnapshotSchedule = restored.Spec.Template.Spec.SnapshotSchedule
+	}
+	if restored.Spec.Template.Spec.ControlPlaneFailureDomains != nil {
+		dst.Spec.Template.Spec.ControlPlaneFailureDomains = restored.Spec.Template.Spec.ControlPlaneFailureDomains
+	}
+	if restored.Spec.Template.Spec.FailureDomainMachineTypes != nil {
+		dst.Spec.Template.Spec.FailureDomainMachineTypes = restored.Spec.Template.Spec.FailureDomainMachineTypes
+	}
+	if restored.Spec.Template.Spec.UseExistingInfrastructure != nil {
+		dst.Spec.Template.Spec.UseExistingInfrastructure = restored.Spec.Template.Spec.UseExistingInfrastructure
+	}
+
+	if restored.Spec.Template.Spec.DefaultFirewallRules != nil {
+		dst.Spec.Template.Spec.DefaultFirewallRules = restored.Spec.Template.Spec.DefaultFirewallRules
+	}
+	if restored.Spec.Template.Spec.Bastion != nil {
+		dst.Spec.Template.Spec.Bastion = restored.Spec.Template.Spec.Bastion
+	}
 
 	return nil
 }
@@ -50,9 +50,78 @@ func (src *GCPMachineTemplate) ConvertTo(dstRaw conversion.Hub) error { // nolin
 		dst.Spec.Template.Spec.OnHostMaintenance = restored.Spec.Template.Spec.OnHostMaintenance
 	}
 
+	if restored.Spec.Template.Spec.AutomaticRestart != nil {
+		dst.Spec.Template.Spec.AutomaticRestart = restored.Spec.Template.Spec.AutomaticRestart
+	}
+
+	if restored.Spec.Template.Spec.MinCPUPlatform != nil {
+		dst.Spec.Template.Spec.MinCPUPlatform = restored.Spec.Template.Spec.MinCPUPlatform
+	}
+	if restored.Spec.Template.Spec.GuestAccelerators != nil {
+		dst.Spec.Template.Spec.GuestAccelerators = restored.Spec.Template.Spec.GuestAccelerators
+	}
+	if restored.Spec.Template.Spec.RootDeviceAutoDelete != nil {
+		dst.Spec.Template.Spec.RootDeviceAutoDelete = restored.Spec.Template.Spec.RootDeviceAutoDelete
+	}
+
 	if restored.Spec.Template.Spec.ConfidentialCompute != nil {
 		dst.Spec.Template.Spec.ConfidentialCompute = restored.Spec.Template.Spec.ConfidentialCompute
 	}
+	if restored.Spec.Template.Spec.ConfidentialInstanceType != nil {
+		dst.Spec.Template.Spec.ConfidentialInstanceType = restored.Spec.Template.Spec.ConfidentialInstanceType
+	}
+	if restored.Spec.Template.Spec.AdvancedMachineFeatures != nil {
+		dst.Spec.Template.Spec.AdvancedMachineFeatures = restored.Spec.Template.Spec.AdvancedMachineFeatures
+	}
+	if restored.Spec.Template.Spec.LocalSSDs != nil {
+		dst.Spec.Template.Spec.LocalSSDs = restored.Spec.Template.Spec.LocalSSDs.DeepCopy()
+	}
+	if restored.Spec.Template.Spec.ProvisioningModel != nil {
+		dst.Spec.Template.Spec.ProvisioningModel = restored.Spec.Template.Spec.ProvisioningModel
+	}
+	if restored.Spec.Template.Spec.InstanceTerminationAction != nil {
+		dst.Spec.Template.Spec.InstanceTerminationAction = restored.Spec.Template.Spec.InstanceTerminationAction
+	}
+	if restored.Spec.Template.Spec.MaxRunDuration != nil {
+		dst.Spec.Template.Spec.MaxRunDuration = restored.Spec.Template.Spec.MaxRunDuration
+	}
+	if restored.Spec.Template.Spec.NodeAffinities != nil {
+		dst.Spec.Template.Spec.NodeAffinities = restored.Spec.Template.Spec.NodeAffinities
+	}
+	if restored.Spec.Template.Spec.StackType != nil {
+		dst.Spec.Template.Spec.StackType = restored.Spec.Template.Spec.StackType
+	}
+	if restored.Spec.Template.Spec.IPv6AccessType != nil {
+		dst.Spec.Template.Spec.IPv6AccessType = restored.Spec.Template.Spec.IPv6AccessType
+	}
+	if restored.Spec.Template.Spec.NicType != nil {
+		dst.Spec.Template.Spec.NicType = restored.Spec.Template.Spec.NicType
+	}
+	if restored.Spec.Template.Spec.NetworkPerformanceConfig != nil {
+		dst.Spec.Template.Spec.NetworkPerformanceConfig = restored.Spec.Template.Spec.NetworkPerformanceConfig
+	}
+	if restored.Spec.Template.Spec.OSLogin != nil {
+		dst.Spec.Template.Spec.OSLogin = restored.Spec.Template.Spec.OSLogin
+	}
+	if restored.Spec.Template.Spec.SSHKeys != nil {
+		dst.Spec.Template.Spec.SSHKeys = restored.Spec.Template.Spec.SSHKeys
+	}
+	if restored.Spec.Template.Spec.BlockProjectSSHKeys != nil {
+		dst.Spec.Template.Spec.BlockProjectSSHKeys = restored.Spec.Template.Spec.BlockProjectSSHKeys
+	}
+	if restored.Spec.Template.Spec.RootDeviceProvisionedIOPS != nil {
+		dst.Spec.Template.Spec.RootDeviceProvisionedIOPS = restored.Spec.Template.Spec.RootDeviceProvisionedIOPS
+	}
+	if restored.Spec.Template.Spec.RootDeviceProvisionedThroughput != nil {
+		dst.Spec.Template.Spec.RootDeviceProvisionedThroughput = restored.Spec.Template.Spec.RootDeviceProvisionedThroughput
+	}
+	if restored.Spec.Template.Spec.RootDiskEncryptionKey != nil {
+		dst.Spec.Template.Spec.RootDiskEncryptionKey = restored.Spec.Template.Spec.RootDiskEncryptionKey
+	}
+	if restored.Spec.Template.Spec.RootDeviceResourcePolicies != nil {
+		dst.Spec.Template.Spec.RootDeviceResourcePolicies = restored.Spec.Template.Spec.RootDeviceResourcePolicies
+	}
+	restoreAdditionalDisks(dst.Spec.Template.Spec.AdditionalDisks, restored.Spec.Template.Spec.AdditionalDisks)
 
 	return nil
 }
@@ -45,11 +45,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.AttachedDiskSpec)(nil), (*AttachedDiskSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_AttachedDiskSpec_To_v1alpha4_AttachedDiskSpec(a.(*v1beta1.AttachedDiskSpec), b.(*AttachedDiskSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*BuildParams)(nil), (*v1beta1.BuildParams)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_BuildParams_To_v1beta1_BuildParams(a.(*BuildParams), b.(*v1beta1.BuildParams), scope)
 	}); err != nil {
@@ -100,11 +95,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.GCPClusterStatus)(nil), (*GCPClusterStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus(a.(*v1beta1.GCPClusterStatus), b.(*GCPClusterStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*GCPClusterTemplate)(nil), (*v1beta1.GCPClusterTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_GCPClusterTemplate_To_v1beta1_GCPClusterTemplate(a.(*GCPClusterTemplate), b.(*v1beta1.GCPClusterTemplate), scope)
 	}); err != nil {
@@ -170,11 +160,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.GCPMachineStatus)(nil), (*GCPMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_GCPMachineStatus_To_v1alpha4_GCPMachineStatus(a.(*v1beta1.GCPMachineStatus), b.(*GCPMachineStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*GCPMachineTemplate)(nil), (*v1beta1.GCPMachineTemplate)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_GCPMachineTemplate_To_v1beta1_GCPMachineTemplate(a.(*GCPMachineTemplate), b.(*v1beta1.GCPMachineTemplate), scope)
 	}); err != nil {
@@ -255,11 +240,21 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.AttachedDiskSpec)(nil), (*AttachedDiskSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_AttachedDiskSpec_To_v1alpha4_AttachedDiskSpec(a.(*v1beta1.AttachedDiskSpec), b.(*AttachedDiskSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.GCPClusterSpec)(nil), (*GCPClusterSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_GCPClusterSpec_To_v1alpha4_GCPClusterSpec(a.(*v1beta1.GCPClusterSpec), b.(*GCPClusterSpec), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.GCPClusterStatus)(nil), (*GCPClusterStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus(a.(*v1beta1.GCPClusterStatus), b.(*GCPClusterStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.GCPClusterTemplateResource)(nil), (*GCPClusterTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_GCPClusterTemplateResource_To_v1alpha4_GCPClusterTemplateResource(a.(*v1beta1.GCPClusterTemplateResource), b.(*GCPClusterTemplateResource), scope)
 	}); err != nil {
@@ -270,6 +265,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.GCPMachineStatus)(nil), (*GCPMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_GCPMachineStatus_To_v1alpha4_GCPMachineStatus(a.(*v1beta1.GCPMachineStatus), b.(*GCPMachineStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1beta1.GCPMachineTemplateResource)(nil), (*GCPMachineTemplateResource)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1beta1_GCPMachineTemplateResource_To_v1alpha4_GCPMachineTemplateResource(a.(*v1beta1.GCPMachineTemplateResource), b.(*GCPMachineTemplateResource), scope)
 	}); err != nil {
@@ -297,14 +297,16 @@ func Convert_v1alpha4_AttachedDiskSpec_To_v1beta1_AttachedDiskSpec(in *AttachedD
 func autoConvert_v1beta1_AttachedDiskSpec_To_v1alpha4_AttachedDiskSpec(in *v1beta1.AttachedDiskSpec, out *AttachedDiskSpec, s conversion.Scope) error {
 	out.DeviceType = (*DiskType)(unsafe.Pointer(in.DeviceType))
 	out.Size = (*int64)(unsafe.Pointer(in.Size))
+	// WARNING: in.ProvisionedIOPS requires manual conversion: does not exist in peer-type
+	// WARNING: in.ProvisionedThroughput requires manual conversion: does not exist in peer-type
+	// WARNING: in.EncryptionKey requires manual conversion: does not exist in peer-type
+	// WARNING: in.DeviceName requires manual conversion: does not exist in peer-type
+	// WARNING: in.AutoDelete requires manual conversion: does not exist in peer-type
+	// WARNING: in.Labels requires manual conversion: does not exist in peer-type
+	// WARNING: in.ResourcePolicies requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_AttachedDiskSpec_To_v1alpha4_AttachedDiskSpec is an autogenerated conversion function.
-func Convert_v1beta1_AttachedDiskSpec_To_v1alpha4_AttachedDiskSpec(in *v1beta1.AttachedDiskSpec, out *AttachedDiskSpec, s conversion.Scope) error {
-	return autoConvert_v1beta1_AttachedDiskSpec_To_v1alpha4_AttachedDiskSpec(in, out, s)
-}
-
 func autoConvert_v1alpha4_BuildParams_To_v1beta1_BuildParams(in *BuildParams, out *v1beta1.BuildParams, s conversion.Scope) error {
 	out.Lifecycle = v1beta1.ResourceLifecycle(in.Lifecycle)
 	out.ClusterName = in.ClusterName
@@ -457,9 +459,19 @@ func autoConvert_v1beta1_GCPClusterSpec_To_v1alpha4_GCPClusterSpec(in *v1beta1.G
 	if err := Convert_v1beta1_NetworkSpec_To_v1alpha4_NetworkSpec(&in.Network, &out.Network, s); err != nil {
 		return err
 	}
+	// WARNING: in.UseExistingInfrastructure requires manual conversion: does not exist in peer-type
+	// WARNING: in.DefaultFirewallRules requires manual conversion: does not exist in peer-type
+	// WARNING: in.LoadBalancer requires manual conversion: does not exist in peer-type
+	// WARNING: in.SnapshotSchedule requires manual conversion: does not exist in peer-type
+	// WARNING: in.Bastion requires manual conversion: does not exist in peer-type
 	out.FailureDomains = *(*[]string)(unsafe.Pointer(&in.FailureDomains))
+	// WARNING: in.ControlPlaneFailureDomains requires manual conversion: does not exist in peer-type
+	// WARNING: in.FailureDomainMachineTypes requires manual conversion: does not exist in peer-type
 	out.AdditionalLabels = *(*Labels)(unsafe.Pointer(&in.AdditionalLabels))
 	// WARNING: in.CredentialsRef requires manual conversion: does not exist in peer-type
+	// WARNING: in.IdentityRef requires manual conversion: does not exist in peer-type
+	// WARNING: in.ImpersonateServiceAccount requires manual conversion: does not exist in peer-type
+	// WARNING: in.QuotaProject requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -506,15 +518,11 @@ func autoConvert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus(in *v1bet
 	if err := Convert_v1beta1_Network_To_v1alpha4_Network(&in.Network, &out.Network, s); err != nil {
 		return err
 	}
+	// WARNING: in.Bastion requires manual conversion: does not exist in peer-type
 	out.Ready = in.Ready
 	return nil
 }
 
-// Convert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus is an autogenerated conversion function.
-func Convert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus(in *v1beta1.GCPClusterStatus, out *GCPClusterStatus, s conversion.Scope) error {
-	return autoConvert_v1beta1_GCPClusterStatus_To_v1alpha4_GCPClusterStatus(in, out, s)
-}
-
 func autoConvert_v1alpha4_GCPClusterTemplate_To_v1beta1_GCPClusterTemplate(in *GCPClusterTemplate, out *v1beta1.GCPClusterTemplate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha4_GCPClusterTemplateSpec_To_v1beta1_GCPClusterTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -713,7 +721,17 @@ func autoConvert_v1alpha4_GCPMachineSpec_To_v1beta1_GCPMachineSpec(in *GCPMachin
 	out.AdditionalNetworkTags = *(*[]string)(unsafe.Pointer(&in.AdditionalNetworkTags))
 	out.RootDeviceSize = in.RootDeviceSize
 	out.RootDeviceType = (*v1beta1.DiskType)(unsafe.Pointer(in.RootDeviceType))
-	out.AdditionalDisks = *(*[]v1beta1.AttachedDiskSpec)(unsafe.Pointer(&in.AdditionalDisks))
+	if in.AdditionalDisks != nil {
+		in, out := &in.AdditionalDisks, &out.AdditionalDisks
+		*out = make([]v1beta1.AttachedDiskSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_AttachedDiskSpec_To_v1beta1_AttachedDiskSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AdditionalDisks = nil
+	}
 	out.ServiceAccount = (*v1beta1.ServiceAccount)(unsafe.Pointer(in.ServiceAccount))
 	out.Preemptible = in.Preemptible
 	return nil
@@ -732,17 +750,49 @@ func autoConvert_v1beta1_GCPMachineSpec_To_v1alpha4_GCPMachineSpec(in *v1beta1.G
 	out.Image = (*string)(unsafe.Pointer(in.Image))
 	out.AdditionalLabels = *(*Labels)(unsafe.Pointer(&in.AdditionalLabels))
 	out.AdditionalMetadata = *(*[]MetadataItem)(unsafe.Pointer(&in.AdditionalMetadata))
+	// WARNING: in.OSLogin requires manual conversion: does not exist in peer-type
+	// WARNING: in.SSHKeys requires manual conversion: does not exist in peer-type
+	// WARNING: in.BlockProjectSSHKeys requires manual conversion: does not exist in peer-type
 	out.PublicIP = (*bool)(unsafe.Pointer(in.PublicIP))
+	// WARNING: in.StackType requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPv6AccessType requires manual conversion: does not exist in peer-type
+	// WARNING: in.NicType requires manual conversion: does not exist in peer-type
+	// WARNING: in.NetworkPerformanceConfig requires manual conversion: does not exist in peer-type
 	out.AdditionalNetworkTags = *(*[]string)(unsafe.Pointer(&in.AdditionalNetworkTags))
 	out.RootDeviceSize = in.RootDeviceSize
 	out.RootDeviceType = (*DiskType)(unsafe.Pointer(in.RootDeviceType))
-	out.AdditionalDisks = *(*[]AttachedDiskSpec)(unsafe.Pointer(&in.AdditionalDisks))
+	// WARNING: in.RootDeviceProvisionedIOPS requires manual conversion: does not exist in peer-type
+	// WARNING: in.RootDeviceProvisionedThroughput requires manual conversion: does not exist in peer-type
+	// WARNING: in.RootDiskEncryptionKey requires manual conversion: does not exist in peer-type
+	// WARNING: in.RootDeviceAutoDelete requires manual conversion: does not exist in peer-type
+	// WARNING: in.RootDeviceResourcePolicies requires manual conversion: does not exist in peer-type
+	if in.AdditionalDisks != nil {
+		in, out := &in.AdditionalDisks, &out.AdditionalDisks
+		*out = make([]AttachedDiskSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_AttachedDiskSpec_To_v1alpha4_AttachedDiskSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.AdditionalDisks = nil
+	}
+	// WARNING: in.LocalSSDs requires manual conversion: does not exist in peer-type
 	out.ServiceAccount = (*ServiceAccount)(unsafe.Pointer(in.ServiceAccount))
 	out.Preemptible = in.Preemptible
+	// WARNING: in.ProvisioningModel requires manual conversion: does not exist in peer-type
+	// WARNING: in.InstanceTerminationAction requires manual conversion: does not exist in peer-type
+	// WARNING: in.MaxRunDuration requires manual conversion: does not exist in peer-type
+	// WARNING: in.NodeAffinities requires manual conversion: does not exist in peer-type
 	// WARNING: in.IPForwarding requires manual conversion: does not exist in peer-type
 	// WARNING: in.ShieldedInstanceConfig requires manual conversion: does not exist in peer-type
 	// WARNING: in.OnHostMaintenance requires manual conversion: does not exist in peer-type
+	// WARNING: in.AutomaticRestart requires manual conversion: does not exist in peer-type
 	// WARNING: in.ConfidentialCompute requires manual conversion: does not exist in peer-type
+	// WARNING: in.ConfidentialInstanceType requires manual conversion: does not exist in peer-type
+	// WARNING: in.AdvancedMachineFeatures requires manual conversion: does not exist in peer-type
+	// WARNING: in.MinCPUPlatform requires manual conversion: does not exist in peer-type
+	// WARNING: in.GuestAccelerators requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -764,16 +814,12 @@ func autoConvert_v1beta1_GCPMachineStatus_To_v1alpha4_GCPMachineStatus(in *v1bet
 	out.Ready = in.Ready
 	out.Addresses = *(*[]v1.NodeAddress)(unsafe.Pointer(&in.Addresses))
 	out.InstanceStatus = (*InstanceStatus)(unsafe.Pointer(in.InstanceStatus))
+	// WARNING: in.Disks requires manual conversion: does not exist in peer-type
 	out.FailureReason = (*errors.MachineStatusError)(unsafe.Pointer(in.FailureReason))
 	out.FailureMessage = (*string)(unsafe.Pointer(in.FailureMessage))
 	return nil
 }
 
-// Convert_v1beta1_GCPMachineStatus_To_v1alpha4_GCPMachineStatus is an autogenerated conversion function.
-func Convert_v1beta1_GCPMachineStatus_To_v1alpha4_GCPMachineStatus(in *v1beta1.GCPMachineStatus, out *GCPMachineStatus, s conversion.Scope) error {
-	return autoConvert_v1beta1_GCPMachineStatus_To_v1alpha4_GCPMachineStatus(in, out, s)
-}
-
 func autoConvert_v1alpha4_GCPMachineTemplate_To_v1beta1_GCPMachineTemplate(in *GCPMachineTemplate, out *v1beta1.GCPMachineTemplate, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha4_GCPMachineTemplateSpec_To_v1beta1_GCPMachineTemplateSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -930,12 +976,18 @@ func autoConvert_v1beta1_Network_To_v1alpha4_Network(in *v1beta1.Network, out *N
 	out.SelfLink = (*string)(unsafe.Pointer(in.SelfLink))
 	out.FirewallRules = *(*map[string]string)(unsafe.Pointer(&in.FirewallRules))
 	out.Router = (*string)(unsafe.Pointer(in.Router))
+	// WARNING: in.Subnets requires manual conversion: does not exist in peer-type
 	out.APIServerAddress = (*string)(unsafe.Pointer(in.APIServerAddress))
 	out.APIServerHealthCheck = (*string)(unsafe.Pointer(in.APIServerHealthCheck))
 	out.APIServerInstanceGroups = *(*map[string]string)(unsafe.Pointer(&in.APIServerInstanceGroups))
 	out.APIServerBackendService = (*string)(unsafe.Pointer(in.APIServerBackendService))
 	out.APIServerTargetProxy = (*string)(unsafe.Pointer(in.APIServerTargetProxy))
 	out.APIServerForwardingRule = (*string)(unsafe.Pointer(in.APIServerForwardingRule))
+	// WARNING: in.APIInternalAddress requires manual conversion: does not exist in peer-type
+	// WARNING: in.APIInternalHealthCheck requires manual conversion: does not exist in peer-type
+	// WARNING: in.APIInternalBackendService requires manual conversion: does not exist in peer-type
+	// WARNING: in.APIInternalForwardingRule requires manual conversion: does not exist in peer-type
+	// WARNING: in.APIInternalServiceAttachment requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -981,8 +1033,14 @@ func autoConvert_v1beta1_NetworkSpec_To_v1alpha4_NetworkSpec(in *v1beta1.Network
 	} else {
 		out.Subnets = nil
 	}
+	// WARNING: in.MTU requires manual conversion: does not exist in peer-type
 	out.LoadBalancerBackendPort = (*int32)(unsafe.Pointer(in.LoadBalancerBackendPort))
 	// WARNING: in.DatapathProvider requires manual conversion: does not exist in peer-type
+	// WARNING: in.UseExisting requires manual conversion: does not exist in peer-type
+	// WARNING: in.HostProject requires manual conversion: does not exist in peer-type
+	// WARNING: in.Router requires manual conversion: does not exist in peer-type
+	// WARNING: in.CloudNat requires manual conversion: does not exist in peer-type
+	// WARNING: in.Routes requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1038,5 +1096,9 @@ func autoConvert_v1beta1_SubnetSpec_To_v1alpha4_SubnetSpec(in *v1beta1.SubnetSpe
 	out.PrivateGoogleAccess = (*bool)(unsafe.Pointer(in.PrivateGoogleAccess))
 	out.EnableFlowLogs = (*bool)(unsafe.Pointer(in.EnableFlowLogs))
 	// WARNING: in.Purpose requires manual conversion: does not exist in peer-type
+	// WARNING: in.Role requires manual conversion: does not exist in peer-type
+	// WARNING: in.FlowLogs requires manual conversion: does not exist in peer-type
+	// WARNING: in.StackType requires manual conversion: does not exist in peer-type
+	// WARNING: in.IPv6AccessType requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -43,12 +43,47 @@ type GCPClusterSpec struct {
 	// +optional
 	Network NetworkSpec `json:"network"`
 
+	// UseExistingInfrastructure indicates that the network, the firewall rules and the control plane load balancer
+	// of the cluster already exist and are managed outside of CAPG, e.g. with Terraform. They are looked up to
+	// populate the status but never created, updated nor deleted.
+	// +optional
+	UseExistingInfrastructure *bool `json:"useExistingInfrastructure,omitempty"`
+
+	// DefaultFirewallRules customizes or disables the firewall rules CAPG creates in the cluster network, which
+	// allow the health checks to reach the control plane and the machines of the cluster to reach each other.
+	// +optional
+	DefaultFirewallRules *DefaultFirewallRulesSpec `json:"defaultFirewallRules,omitempty"`
+
+	// LoadBalancer configures the load balancers fronting the control plane.
+	// +optional
+	LoadBalancer LoadBalancerSpec `json:"loadBalancer,omitempty"`
+
+	// SnapshotSchedule creates a snapshot schedule resource policy in the cluster region, which machine disks
+	// reference by name to get automatic snapshots.
+	// +optional
+	SnapshotSchedule *SnapshotScheduleSpec `json:"snapshotSchedule,omitempty"`
+
+	// Bastion provisions a bastion host in the cluster network, to reach machines without a public IP over SSH.
+	// The bastion host is deleted when unset.
+	// +optional
+	Bastion *BastionSpec `json:"bastion,omitempty"`
+
 	// FailureDomains is an optional field which is used to assign selected availability zones to a cluster
 	// FailureDomains if empty, defaults to all the zones in the selected region and if specified would override
 	// the default zones.
 	// +optional
 	FailureDomains []string `json:"failureDomains,omitempty"`
 
+	// ControlPlaneFailureDomains restricts the failure domains eligible for control plane machines to the given
+	// zones. The other failure domains are still published for worker machines. Defaults to all failure domains.
+	// +optional
+	ControlPlaneFailureDomains []string `json:"controlPlaneFailureDomains,omitempty"`
+
+	// FailureDomainMachineTypes are machine types which must all be available in a zone for it to be published
+	// as a failure domain, e.g. the machine types of the control plane and worker machines.
+	// +optional
+	FailureDomainMachineTypes []string `json:"failureDomainMachineTypes,omitempty"`
+
 	// AdditionalLabels is an optional set of tags to add to GCP resources managed by the GCP provider, in addition to the
 	// ones added by default.
 	// +optional
@@ -58,6 +93,23 @@ type GCPClusterSpec struct {
 	// supplied then the credentials of the controller will be used.
 	// +optional
 	CredentialsRef *ObjectReference `json:"credentialsRef,omitempty"`
+
+	// IdentityRef is a reference to a GCPClusterIdentity with the credentials to use for provisioning this
+	// cluster. The identity must allow the namespace of the cluster. It cannot be set along with CredentialsRef.
+	// +optional
+	IdentityRef *ObjectReference `json:"identityRef,omitempty"`
+
+	// ImpersonateServiceAccount is the email of a service account that the credentials of CredentialsRef, or of
+	// the controller, impersonate for provisioning this cluster. The credentials need the Service Account Token
+	// Creator role on it. Identities referenced by IdentityRef configure impersonation themselves.
+	// +optional
+	ImpersonateServiceAccount *string `json:"impersonateServiceAccount,omitempty"`
+
+	// QuotaProject is the project that the quota and billing of the API requests for provisioning this cluster
+	// are attributed to, instead of the project of the credentials. It overrides the quota project of the
+	// identity referenced by IdentityRef. The credentials need the serviceusage.services.use permission on it.
+	// +optional
+	QuotaProject *string `json:"quotaProject,omitempty"`
 }
 
 // GCPClusterStatus defines the observed state of GCPCluster.
@@ -65,7 +117,10 @@ type GCPClusterStatus struct {
 	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
 	Network        Network                  `json:"network,omitempty"`
 
-	// Bastion Instance `json:"bastion,omitempty"`
+	// Bastion is the bastion host of the cluster, when one is configured.
+	// +optional
+	Bastion *BastionStatus `json:"bastion,omitempty"`
+
 	Ready bool `json:"ready"`
 }
 
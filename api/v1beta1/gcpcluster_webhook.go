@@ -21,6 +21,7 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -52,8 +53,45 @@ func (c *GCPCluster) Default() {
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (c *GCPCluster) ValidateCreate() (admission.Warnings, error) {
 	clusterlog.Info("validate create", "name", c.Name)
+	var allErrs field.ErrorList
 
-	return nil, nil
+	if c.Spec.CredentialsRef != nil && c.Spec.IdentityRef != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "IdentityRef"), "cannot be set along with CredentialsRef"))
+	}
+
+	if c.Spec.IdentityRef != nil && c.Spec.ImpersonateServiceAccount != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "ImpersonateServiceAccount"), "cannot be set along with IdentityRef"))
+	}
+
+	if errs := c.Spec.Network.Router.Validate(field.NewPath("spec", "Network", "Router")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := c.Spec.Network.Subnets.Validate(field.NewPath("spec", "Network", "Subnets")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := c.Spec.Network.Routes.Validate(field.NewPath("spec", "Network", "Routes")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := c.Spec.LoadBalancer.Validate(field.NewPath("spec", "LoadBalancer")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateControlPlaneFailureDomains(c.Spec, field.NewPath("spec", "ControlPlaneFailureDomains")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := c.Spec.DefaultFirewallRules.Validate(field.NewPath("spec", "DefaultFirewallRules")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
+
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPCluster").GroupKind(), c.Name, allErrs)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
@@ -83,6 +121,100 @@ func (c *GCPCluster) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings,
 		)
 	}
 
+	if !reflect.DeepEqual(c.Spec.IdentityRef, old.Spec.IdentityRef) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "IdentityRef"),
+				c.Spec.IdentityRef, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(c.Spec.ImpersonateServiceAccount, old.Spec.ImpersonateServiceAccount) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "ImpersonateServiceAccount"),
+				c.Spec.ImpersonateServiceAccount, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(c.Spec.Network.MTU, old.Spec.Network.MTU) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "Network", "MTU"),
+				c.Spec.Network.MTU, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(c.Spec.Network.UseExisting, old.Spec.Network.UseExisting) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "Network", "UseExisting"),
+				c.Spec.Network.UseExisting, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(c.Spec.Network.HostProject, old.Spec.Network.HostProject) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "Network", "HostProject"),
+				c.Spec.Network.HostProject, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(c.Spec.UseExistingInfrastructure, old.Spec.UseExistingInfrastructure) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "UseExistingInfrastructure"),
+				c.Spec.UseExistingInfrastructure, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(c.Spec.LoadBalancer.LoadBalancerType, old.Spec.LoadBalancer.LoadBalancerType) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "LoadBalancer", "LoadBalancerType"),
+				c.Spec.LoadBalancer.LoadBalancerType, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(c.Spec.LoadBalancer.InternalLoadBalancer, old.Spec.LoadBalancer.InternalLoadBalancer) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "LoadBalancer", "InternalLoadBalancer"),
+				c.Spec.LoadBalancer.InternalLoadBalancer, "field is immutable"),
+		)
+	}
+
+	if !reflect.DeepEqual(c.Spec.SnapshotSchedule, old.Spec.SnapshotSchedule) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "SnapshotSchedule"),
+				c.Spec.SnapshotSchedule, "field is immutable"),
+		)
+	}
+
+	if c.Spec.LoadBalancer.HealthCheckPort() != old.Spec.LoadBalancer.HealthCheckPort() {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "LoadBalancer", "HealthCheck", "Port"),
+				c.Spec.LoadBalancer.HealthCheckPort(), "field is immutable"),
+		)
+	}
+
+	if errs := c.Spec.Network.Router.Validate(field.NewPath("spec", "Network", "Router")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := c.Spec.Network.Subnets.Validate(field.NewPath("spec", "Network", "Subnets")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := c.Spec.Network.Routes.Validate(field.NewPath("spec", "Network", "Routes")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := c.Spec.LoadBalancer.Validate(field.NewPath("spec", "LoadBalancer")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := validateControlPlaneFailureDomains(c.Spec, field.NewPath("spec", "ControlPlaneFailureDomains")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := c.Spec.DefaultFirewallRules.Validate(field.NewPath("spec", "DefaultFirewallRules")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -96,3 +228,21 @@ func (c *GCPCluster) ValidateDelete() (admission.Warnings, error) {
 
 	return nil, nil
 }
+
+// validateControlPlaneFailureDomains checks that the control plane failure domains are among the failure domains
+// of the cluster, when those are restricted.
+func validateControlPlaneFailureDomains(spec GCPClusterSpec, fldPath *field.Path) field.ErrorList {
+	if len(spec.FailureDomains) == 0 {
+		return nil
+	}
+
+	failureDomains := sets.New(spec.FailureDomains...)
+	var allErrs field.ErrorList
+	for i, zone := range spec.ControlPlaneFailureDomains {
+		if !failureDomains.Has(zone) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), zone, "must be one of the failure domains of the cluster"))
+		}
+	}
+
+	return allErrs
+}
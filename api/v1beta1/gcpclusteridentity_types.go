@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IdentityType is the type of the GCP credentials of a GCPClusterIdentity.
+type IdentityType string
+
+const (
+	// ServiceAccountKeyIdentity uses the JSON key of a service account stored in a Secret.
+	ServiceAccountKeyIdentity = IdentityType("ServiceAccountKey")
+
+	// WorkloadIdentity uses the ambient credentials of the controller, e.g. its GKE workload identity.
+	WorkloadIdentity = IdentityType("WorkloadIdentity")
+)
+
+// GCPClusterIdentitySpec defines the credentials and the allowed namespaces of a GCPClusterIdentity.
+type GCPClusterIdentitySpec struct {
+	// Type is the type of the credentials of the identity.
+	// +kubebuilder:validation:Enum=ServiceAccountKey;WorkloadIdentity
+	Type IdentityType `json:"type"`
+
+	// SecretRef is a reference to a Secret in the namespace of the identity with the JSON credentials in its
+	// credentials key. It is required for ServiceAccountKey identities.
+	// +optional
+	SecretRef *corev1.LocalObjectReference `json:"secretRef,omitempty"`
+
+	// ImpersonateServiceAccount is the email of a service account that the credentials of the identity
+	// impersonate, e.g. a least-privilege service account of the tenant or of another project. The credentials
+	// need the Service Account Token Creator role on it.
+	// +optional
+	ImpersonateServiceAccount *string `json:"impersonateServiceAccount,omitempty"`
+
+	// Scopes are the OAuth scopes requested with the credentials of the identity, e.g. compute and
+	// container scopes instead of cloud-platform. They default to the scopes of the controller.
+	// +optional
+	Scopes []string `json:"scopes,omitempty"`
+
+	// QuotaProject is the project that the quota and billing of the API requests made with the identity are
+	// attributed to, instead of the project of the credentials.
+	// +optional
+	QuotaProject *string `json:"quotaProject,omitempty"`
+
+	// Endpoints overrides the endpoints of the GCP APIs called with the identity, which default to the
+	// endpoints of the controller.
+	// +optional
+	Endpoints *APIEndpoints `json:"endpoints,omitempty"`
+
+	// AllowedNamespaces selects the namespaces of the clusters that can use the identity, in addition to the
+	// namespace of the identity itself. An empty object allows all namespaces, and no other namespace is allowed
+	// when it is not set. A namespace is allowed if it is listed or matches the selector.
+	// +optional
+	AllowedNamespaces *AllowedNamespaces `json:"allowedNamespaces,omitempty"`
+}
+
+// APIEndpoints are alternate endpoints of the GCP APIs, e.g. private.googleapis.com, regional endpoints or
+// local emulators.
+type APIEndpoints struct {
+	// Compute is the base URL of the compute API, e.g. https://compute.googleapis.com.
+	// +optional
+	Compute string `json:"compute,omitempty"`
+
+	// Container is the host and port of the gRPC container API, e.g. container.googleapis.com:443.
+	// +optional
+	Container string `json:"container,omitempty"`
+}
+
+// AllowedNamespaces selects namespaces by name or by labels.
+type AllowedNamespaces struct {
+	// NamespaceList is a list of namespace names.
+	// +optional
+	NamespaceList []string `json:"list,omitempty"`
+
+	// Selector selects namespaces by their labels. An empty selector matches all namespaces.
+	// +optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=gcpclusteridentities,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Type",type="string",JSONPath=".spec.type",description="Type of the GCP credentials"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of GCPClusterIdentity"
+
+// GCPClusterIdentity holds the GCP credentials that GCPClusters and GCPManagedClusters can reference, and
+// restricts the namespaces they can be used from.
+type GCPClusterIdentity struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec GCPClusterIdentitySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GCPClusterIdentityList contains a list of GCPClusterIdentity.
+type GCPClusterIdentityList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCPClusterIdentity `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GCPClusterIdentity{}, &GCPClusterIdentityList{})
+}
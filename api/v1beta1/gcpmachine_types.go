@@ -38,8 +38,21 @@ const (
 	PdSsdDiskType DiskType = "pd-ssd"
 	// LocalSsdDiskType defines the name for the local ssd disk.
 	LocalSsdDiskType DiskType = "local-ssd"
+	// HyperdiskBalancedDiskType defines the name for the hyperdisk balanced disk.
+	HyperdiskBalancedDiskType DiskType = "hyperdisk-balanced"
+	// HyperdiskExtremeDiskType defines the name for the hyperdisk extreme disk.
+	HyperdiskExtremeDiskType DiskType = "hyperdisk-extreme"
+	// HyperdiskThroughputDiskType defines the name for the hyperdisk throughput disk.
+	HyperdiskThroughputDiskType DiskType = "hyperdisk-throughput"
 )
 
+// hyperdiskSupportedMachineSeries lists the machine series supporting each hyperdisk type.
+var hyperdiskSupportedMachineSeries = map[DiskType][]string{
+	HyperdiskBalancedDiskType:   {"a3", "c3", "c3d", "c4", "h3", "m1", "m2", "m3", "n4", "x4"},
+	HyperdiskExtremeDiskType:    {"c3", "c3d", "m1", "m2", "m3", "n2", "x4"},
+	HyperdiskThroughputDiskType: {"c3", "c3d", "h3", "m3", "n2", "n2d", "t2d", "z3"},
+}
+
 // AttachedDiskSpec degined GCP machine disk.
 type AttachedDiskSpec struct {
 	// DeviceType is a device type of the attached disk.
@@ -47,13 +60,114 @@ type AttachedDiskSpec struct {
 	// 1. "pd-standard" - Standard (HDD) persistent disk
 	// 2. "pd-ssd" - SSD persistent disk
 	// 3. "local-ssd" - Local SSD disk (https://cloud.google.com/compute/docs/disks/local-ssd).
+	// 4. "hyperdisk-balanced" - Hyperdisk Balanced
+	// 5. "hyperdisk-extreme" - Hyperdisk Extreme
+	// 6. "hyperdisk-throughput" - Hyperdisk Throughput
+	// Hyperdisks are only supported by some machine series (https://cloud.google.com/compute/docs/disks/hyperdisks).
 	// Default is "pd-standard".
 	// +optional
 	DeviceType *DiskType `json:"deviceType,omitempty"`
 	// Size is the size of the disk in GBs.
 	// Defaults to 30GB. For "local-ssd" size is always 375GB.
+	// Hyperdisk Extreme and Hyperdisk Throughput require a larger size.
 	// +optional
 	Size *int64 `json:"size,omitempty"`
+	// ProvisionedIOPS is the number of I/O operations per second provisioned for the disk.
+	// Only supported by "hyperdisk-balanced" and "hyperdisk-extreme" disks.
+	// +optional
+	ProvisionedIOPS *int64 `json:"provisionedIOPS,omitempty"`
+	// ProvisionedThroughput is the throughput in MiB per second provisioned for the disk.
+	// Only supported by "hyperdisk-balanced" and "hyperdisk-throughput" disks.
+	// +optional
+	ProvisionedThroughput *int64 `json:"provisionedThroughput,omitempty"`
+	// EncryptionKey is the customer-managed key encrypting the disk. Local SSDs cannot be encrypted
+	// with a customer-managed key.
+	// +optional
+	EncryptionKey *CustomerEncryptionKey `json:"encryptionKey,omitempty"`
+	// DeviceName is the name exposing the disk to the guest OS at /dev/disk/by-id/google-<deviceName>.
+	// Defaults to persistent-disk-<index>, assigned by GCP.
+	// +kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	DeviceName *string `json:"deviceName,omitempty"`
+	// AutoDelete deletes the disk along with the instance. A disk that is not auto-deleted is left behind
+	// when the machine is deleted. Local SSDs are always auto-deleted.
+	// Defaults to true.
+	// +optional
+	AutoDelete *bool `json:"autoDelete,omitempty"`
+	// Labels are applied to the disk.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// ResourcePolicies are the names or self links of the resource policies, such as snapshot schedules,
+	// applied to the disk. Names refer to resource policies in the cluster region.
+	// +optional
+	ResourcePolicies []string `json:"resourcePolicies,omitempty"`
+}
+
+// AttachedDiskStatus describes a disk attached to the GCP instance.
+type AttachedDiskStatus struct {
+	// DeviceName is the name exposing the disk to the guest OS at /dev/disk/by-id/google-<deviceName>.
+	DeviceName string `json:"deviceName"`
+	// Source is the self link of the persistent disk. It is empty for local SSDs.
+	// +optional
+	Source string `json:"source,omitempty"`
+	// Type is the type of the disk, either PERSISTENT or SCRATCH.
+	Type string `json:"type"`
+	// Boot is true for the boot disk of the instance.
+	// +optional
+	Boot bool `json:"boot,omitempty"`
+	// AutoDelete is true when the disk is deleted along with the instance.
+	// +optional
+	AutoDelete bool `json:"autoDelete,omitempty"`
+	// SizeGb is the size of the disk in GB.
+	// +optional
+	SizeGb int64 `json:"sizeGb,omitempty"`
+}
+
+// CustomerEncryptionKey defines a Cloud KMS key encrypting a persistent disk.
+type CustomerEncryptionKey struct {
+	// KMSKeyName is the self link of the Cloud KMS key, in the form
+	// projects/<project>/locations/<location>/keyRings/<keyRing>/cryptoKeys/<key>.
+	// The Compute Engine service agent must be granted the cloudkms.cryptoKeyEncrypterDecrypter role on the key.
+	// +kubebuilder:validation:Pattern=`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$`
+	KMSKeyName string `json:"kmsKeyName"`
+	// KMSKeyServiceAccount is the service account used for the encryption requests to Cloud KMS.
+	// Defaults to the Compute Engine service agent.
+	// +optional
+	KMSKeyServiceAccount *string `json:"kmsKeyServiceAccount,omitempty"`
+}
+
+// LocalSSDSpec defines the local SSDs of a GCP machine.
+type LocalSSDSpec struct {
+	// Count is the number of 375GB local SSDs attached to the instance. The number of local SSDs
+	// supported depends on the instance type.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=24
+	Count int32 `json:"count"`
+
+	// Interface is the interface of the local SSDs. NVME is faster, while SCSI is supported by more images.
+	// +kubebuilder:validation:Enum=NVME;SCSI
+	// +kubebuilder:default=NVME
+	// +optional
+	Interface *string `json:"interface,omitempty"`
+
+	// Mount formats and mounts the local SSDs at boot through a startup script set in the instance metadata.
+	// Multiple local SSDs are assembled in a RAID 0 array. The local SSDs are left unformatted when unset.
+	// +optional
+	Mount *LocalSSDMount `json:"mount,omitempty"`
+}
+
+// LocalSSDMount defines how the local SSDs of a GCP machine are formatted and mounted.
+type LocalSSDMount struct {
+	// Path is the directory the local SSDs are mounted on.
+	// +kubebuilder:validation:Pattern=`^/[a-zA-Z0-9._/-]*$`
+	Path string `json:"path"`
+
+	// Filesystem is the filesystem the local SSDs are formatted with.
+	// +kubebuilder:validation:Enum=ext4;xfs
+	// +kubebuilder:default=ext4
+	// +optional
+	Filesystem *string `json:"filesystem,omitempty"`
 }
 
 // IPForwarding represents the IP forwarding configuration for the GCP machine.
@@ -122,6 +236,97 @@ type GCPShieldedInstanceConfig struct {
 	IntegrityMonitoring IntegrityMonitoringPolicy `json:"integrityMonitoring,omitempty"`
 }
 
+// ProvisioningModel represents the provisioning model of the GCP machine.
+type ProvisioningModel string
+
+const (
+	// ProvisioningModelStandard provisions a standard instance, or a preemptible one if Preemptible is set.
+	ProvisioningModelStandard ProvisioningModel = "Standard"
+	// ProvisioningModelSpot provisions a Spot instance.
+	ProvisioningModelSpot ProvisioningModel = "Spot"
+)
+
+// InstanceTerminationAction represents the action taken when the GCP machine is preempted.
+type InstanceTerminationAction string
+
+const (
+	// InstanceTerminationActionStop stops the instance when it is preempted.
+	InstanceTerminationActionStop InstanceTerminationAction = "Stop"
+	// InstanceTerminationActionDelete deletes the instance when it is preempted.
+	InstanceTerminationActionDelete InstanceTerminationAction = "Delete"
+)
+
+// NodeAffinityOperator is the operator of a sole-tenant node affinity.
+type NodeAffinityOperator string
+
+const (
+	// NodeAffinityOperatorIn requires the node label to have one of the values.
+	NodeAffinityOperatorIn NodeAffinityOperator = "In"
+	// NodeAffinityOperatorNotIn requires the node label to have none of the values.
+	NodeAffinityOperatorNotIn NodeAffinityOperator = "NotIn"
+)
+
+// NodeAffinity selects sole-tenant nodes by label.
+type NodeAffinity struct {
+	// Key is the label of the nodes. Use compute.googleapis.com/node-group-name to select a node group,
+	// compute.googleapis.com/node-name to select a node, or the key of an affinity label of a node template.
+	Key string `json:"key"`
+
+	// Operator is the operator applied to the values.
+	// +kubebuilder:validation:Enum=In;NotIn
+	Operator NodeAffinityOperator `json:"operator"`
+
+	// Values are the values of the label.
+	// +kubebuilder:validation:MinItems=1
+	Values []string `json:"values"`
+}
+
+// tier1NetworkingSupportedMachineSeries lists the machine series supporting TIER_1 networking.
+var tier1NetworkingSupportedMachineSeries = []string{"n2", "n2d", "c2", "c2d", "c3", "c3d", "m3"}
+
+// nestedVirtualizationUnsupportedMachineSeries lists the machine series not supporting nested virtualization.
+var nestedVirtualizationUnsupportedMachineSeries = []string{"e2", "n2d", "c2d", "c3d", "t2d", "t2a"}
+
+// minCPUPlatformUnsupportedMachineSeries lists the machine series not supporting a minimum CPU platform.
+var minCPUPlatformUnsupportedMachineSeries = []string{"e2", "t2a", "t2d"}
+
+// guestAcceleratorSupportedMachineSeries lists the machine series supporting guest accelerators.
+var guestAcceleratorSupportedMachineSeries = []string{"n1"}
+
+// guestAcceleratorCounts lists the numbers of accelerators which can be attached to an n1 instance, by
+// accelerator type.
+var guestAcceleratorCounts = map[string][]int64{
+	"nvidia-tesla-k80":      {1, 2, 4, 8},
+	"nvidia-tesla-p4":       {1, 2, 4},
+	"nvidia-tesla-p4-vws":   {1, 2, 4},
+	"nvidia-tesla-p100":     {1, 2, 4},
+	"nvidia-tesla-p100-vws": {1, 2, 4},
+	"nvidia-tesla-t4":       {1, 2, 4},
+	"nvidia-tesla-t4-vws":   {1, 2, 4},
+	"nvidia-tesla-v100":     {1, 2, 4, 8},
+}
+
+// OSLoginPolicy represents the OS Login configuration for the GCP machine.
+type OSLoginPolicy string
+
+const (
+	// OSLoginPolicyEnabled manages SSH access to the GCP machine with IAM.
+	OSLoginPolicyEnabled OSLoginPolicy = "Enabled"
+	// OSLoginPolicyDisabled manages SSH access to the GCP machine with SSH keys in metadata.
+	OSLoginPolicyDisabled OSLoginPolicy = "Disabled"
+)
+
+// SSHKey defines a public SSH key granting access to the GCP machine.
+type SSHKey struct {
+	// Username is the user the key grants access to.
+	// +kubebuilder:validation:Pattern=`^[a-z_][a-z0-9_.-]*$`
+	Username string `json:"username"`
+
+	// PublicKey is the public key in OpenSSH format.
+	// +kubebuilder:validation:MinLength=1
+	PublicKey string `json:"publicKey"`
+}
+
 // ConfidentialComputePolicy represents the confidential compute configuration for the GCP machine.
 type ConfidentialComputePolicy string
 
@@ -132,9 +337,19 @@ const (
 	ConfidentialComputePolicyDisabled ConfidentialComputePolicy = "Disabled"
 )
 
-// Confidential VM supports Compute Engine machine types in the following series:
+// ConfidentialInstanceType is the confidential computing technology of a confidential VM.
+type ConfidentialInstanceType string
+
+const (
+	// ConfidentialInstanceTypeSEV uses AMD Secure Encrypted Virtualization.
+	ConfidentialInstanceTypeSEV ConfidentialInstanceType = "SEV"
+)
+
+// Confidential VM supports Compute Engine machine types in the following series, depending on the technology:
 // reference: https://cloud.google.com/compute/confidential-vm/docs/os-and-machine-type#machine-type
-var confidentialComputeSupportedMachineSeries = []string{"n2d", "c2d"}
+var confidentialComputeSupportedMachineSeries = map[ConfidentialInstanceType][]string{
+	ConfidentialInstanceTypeSEV: {"n2d", "c2d", "c3d"},
+}
 
 // HostMaintenancePolicy represents the desired behavior ase of a host maintenance event.
 type HostMaintenancePolicy string
@@ -149,6 +364,8 @@ const (
 // GCPMachineSpec defines the desired state of GCPMachine.
 type GCPMachineSpec struct {
 	// InstanceType is the type of instance to create. Example: n1.standard-2
+	// Custom machine types have the format [SERIES-]custom-VCPUS-MEMORY[-ext] with the memory in MB,
+	// e.g. n2-custom-8-16384, and are supported in the e2, n1, n2 and n2d series.
 	InstanceType string `json:"instanceType"`
 
 	// Subnet is a reference to the subnetwork to use for this instance. If not specified,
@@ -182,6 +399,20 @@ type GCPMachineSpec struct {
 	// +optional
 	AdditionalMetadata []MetadataItem `json:"additionalMetadata,omitempty"`
 
+	// OSLogin sets the enable-oslogin metadata of the instance. When unset, the project setting applies.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	OSLogin *OSLoginPolicy `json:"osLogin,omitempty"`
+
+	// SSHKeys are public SSH keys set in the ssh-keys metadata of the instance.
+	// They are ignored by the instance when OS Login is enabled.
+	// +optional
+	SSHKeys []SSHKey `json:"sshKeys,omitempty"`
+
+	// BlockProjectSSHKeys prevents the SSH keys of the project metadata from granting access to the instance.
+	// +optional
+	BlockProjectSSHKeys *bool `json:"blockProjectSSHKeys,omitempty"`
+
 	// IAMInstanceProfile is a name of an IAM instance profile to assign to the instance
 	// +optional
 	// IAMInstanceProfile string `json:"iamInstanceProfile,omitempty"`
@@ -191,13 +422,37 @@ type GCPMachineSpec struct {
 	// +optional
 	PublicIP *bool `json:"publicIP,omitempty"`
 
+	// StackType is the IP stack of the network interface of the instance. IPV4_IPV6 requires a dual-stack
+	// subnet. Defaults to IPV4_ONLY.
+	// +kubebuilder:validation:Enum=IPV4_ONLY;IPV4_IPV6
+	// +optional
+	StackType *string `json:"stackType,omitempty"`
+
+	// IPv6AccessType is the IPv6 access type of the subnet of a dual-stack instance. An external IPv6 address
+	// is assigned to the instance when it is EXTERNAL, and an internal one when it is INTERNAL.
+	// It must match the IPv6 access type of the subnet.
+	// +kubebuilder:validation:Enum=INTERNAL;EXTERNAL
+	// +optional
+	IPv6AccessType *string `json:"ipv6AccessType,omitempty"`
+
+	// NicType is the type of the virtual network interface of the instance. GVNIC provides a higher network
+	// bandwidth, and requires an image supporting it. Defaults to VIRTIO_NET.
+	// +kubebuilder:validation:Enum=GVNIC;VIRTIO_NET
+	// +optional
+	NicType *string `json:"nicType,omitempty"`
+
+	// NetworkPerformanceConfig configures the network bandwidth of the instance.
+	// +optional
+	NetworkPerformanceConfig *NetworkPerformanceConfig `json:"networkPerformanceConfig,omitempty"`
+
 	// AdditionalNetworkTags is a list of network tags that should be applied to the
 	// instance. These tags are set in addition to any network tags defined
 	// at the cluster level or in the actuator.
 	// +optional
 	AdditionalNetworkTags []string `json:"additionalNetworkTags,omitempty"`
 
-	// RootDeviceSize is the size of the root volume in GB.
+	// RootDeviceSize is the size of the root volume in GB. It can be increased on an existing GCPMachine, the
+	// root volume is then resized online, but never decreased.
 	// Defaults to 30.
 	// +optional
 	RootDeviceSize int64 `json:"rootDeviceSize,omitempty"`
@@ -206,14 +461,45 @@ type GCPMachineSpec struct {
 	// Supported types of root volumes:
 	// 1. "pd-standard" - Standard (HDD) persistent disk
 	// 2. "pd-ssd" - SSD persistent disk
+	// 3. "hyperdisk-balanced" - Hyperdisk Balanced
 	// Default is "pd-standard".
 	// +optional
 	RootDeviceType *DiskType `json:"rootDeviceType,omitempty"`
 
+	// RootDeviceProvisionedIOPS is the number of I/O operations per second provisioned for a
+	// "hyperdisk-balanced" root volume.
+	// +optional
+	RootDeviceProvisionedIOPS *int64 `json:"rootDeviceProvisionedIOPS,omitempty"`
+
+	// RootDeviceProvisionedThroughput is the throughput in MiB per second provisioned for a
+	// "hyperdisk-balanced" root volume.
+	// +optional
+	RootDeviceProvisionedThroughput *int64 `json:"rootDeviceProvisionedThroughput,omitempty"`
+
+	// RootDiskEncryptionKey is the customer-managed key encrypting the root volume.
+	// +optional
+	RootDiskEncryptionKey *CustomerEncryptionKey `json:"rootDiskEncryptionKey,omitempty"`
+
+	// RootDeviceAutoDelete deletes the root volume along with the instance. A root volume that is not
+	// auto-deleted is left behind when the machine is deleted, e.g. to be inspected.
+	// Defaults to true.
+	// +optional
+	RootDeviceAutoDelete *bool `json:"rootDeviceAutoDelete,omitempty"`
+
+	// RootDeviceResourcePolicies are the names or self links of the resource policies, such as snapshot
+	// schedules, applied to the root volume. Names refer to resource policies in the cluster region.
+	// +optional
+	RootDeviceResourcePolicies []string `json:"rootDeviceResourcePolicies,omitempty"`
+
 	// AdditionalDisks are optional non-boot attached disks.
 	// +optional
 	AdditionalDisks []AttachedDiskSpec `json:"additionalDisks,omitempty"`
 
+	// LocalSSDs are local SSDs attached to the instance as scratch disks. Their data does not persist
+	// beyond the life of the instance.
+	// +optional
+	LocalSSDs *LocalSSDSpec `json:"localSSDs,omitempty"`
+
 	// ServiceAccount specifies the service account email and which scopes to assign to the machine.
 	// Defaults to: email: "default", scope: []{compute.CloudPlatformScope}
 	// +optional
@@ -223,6 +509,31 @@ type GCPMachineSpec struct {
 	// +optional
 	Preemptible bool `json:"preemptible,omitempty"`
 
+	// ProvisioningModel is the provisioning model of the instance. Spot instances are preemptible instances
+	// without the maximum runtime of 24 hours of Preemptible instances, and cannot be combined with Preemptible.
+	// Defaults to Standard.
+	// +kubebuilder:validation:Enum=Standard;Spot
+	// +optional
+	ProvisioningModel *ProvisioningModel `json:"provisioningModel,omitempty"`
+
+	// InstanceTerminationAction is the action taken when a Spot or Preemptible instance is preempted.
+	// A stopped instance is reported as failed, and is typically replaced through a MachineHealthCheck.
+	// If omitted, the platform chooses a default, which is subject to change over time, currently that default is "Stop".
+	// +kubebuilder:validation:Enum=Stop;Delete
+	// +optional
+	InstanceTerminationAction *InstanceTerminationAction `json:"instanceTerminationAction,omitempty"`
+
+	// MaxRunDuration bounds the lifetime of the machine, measured from the creation of the GCPMachine. Once it
+	// is reached, the Machine is deleted so that its node is drained and its instance removed, e.g. for
+	// cost-bounded CI workers. The compute API used by the provider does not expose the max run duration of
+	// instances, it is enforced by the controller.
+	// +optional
+	MaxRunDuration *metav1.Duration `json:"maxRunDuration,omitempty"`
+
+	// NodeAffinities schedule the instance on the sole-tenant nodes matching all of the affinities.
+	// +optional
+	NodeAffinities []NodeAffinity `json:"nodeAffinities,omitempty"`
+
 	// IPForwarding Allows this instance to send and receive packets with non-matching destination or source IPs.
 	// This is required if you plan to use this instance to forward routes. Defaults to enabled.
 	// +kubebuilder:validation:Enum=Enabled;Disabled
@@ -240,12 +551,76 @@ type GCPMachineSpec struct {
 	// +optional
 	OnHostMaintenance *HostMaintenancePolicy `json:"onHostMaintenance,omitempty"`
 
+	// AutomaticRestart restarts the instance when it is terminated by Compute Engine, for instance after a host
+	// maintenance event with OnHostMaintenance set to "Terminate". It cannot be enabled for preemptible and Spot
+	// instances. Defaults to true for standard instances.
+	// +optional
+	AutomaticRestart *bool `json:"automaticRestart,omitempty"`
+
 	// ConfidentialCompute Defines whether the instance should have confidential compute enabled.
 	// If enabled OnHostMaintenance is required to be set to "Terminate".
 	// If omitted, the platform chooses a default, which is subject to change over time, currently that default is false.
 	// +kubebuilder:validation:Enum=Enabled;Disabled
 	// +optional
 	ConfidentialCompute *ConfidentialComputePolicy `json:"confidentialCompute,omitempty"`
+
+	// ConfidentialInstanceType is the confidential computing technology of the instance. It requires
+	// ConfidentialCompute to be enabled, and restricts the instance type to the machine series supporting
+	// the technology. Only AMD SEV is available with the compute API used by the provider.
+	// Defaults to SEV when ConfidentialCompute is enabled.
+	// +kubebuilder:validation:Enum=SEV
+	// +optional
+	ConfidentialInstanceType *ConfidentialInstanceType `json:"confidentialInstanceType,omitempty"`
+
+	// AdvancedMachineFeatures configures the CPU features of the instance.
+	// +optional
+	AdvancedMachineFeatures *AdvancedMachineFeatures `json:"advancedMachineFeatures,omitempty"`
+
+	// MinCPUPlatform is the minimum CPU platform of the instance, e.g. "Intel Ice Lake".
+	// It is not supported by the e2, t2a and t2d series.
+	// See https://cloud.google.com/compute/docs/instances/specify-min-cpu-platform
+	// +optional
+	MinCPUPlatform *string `json:"minCPUPlatform,omitempty"`
+
+	// GuestAccelerators are the GPUs attached to the instance. They are only supported by the n1 series, the
+	// accelerator-optimized series come with their GPUs attached. Instances with GPUs cannot live migrate, so
+	// OnHostMaintenance must be set to "Terminate".
+	// See https://cloud.google.com/compute/docs/gpus
+	// +optional
+	GuestAccelerators []Accelerator `json:"guestAccelerators,omitempty"`
+}
+
+// Accelerator is a type of GPU attached to a GCP machine.
+type Accelerator struct {
+	// Type is the accelerator type, e.g. "nvidia-tesla-t4". The type must be offered in the zone of the machine.
+	Type string `json:"type"`
+
+	// Count is the number of accelerators of the type attached to the machine.
+	// +kubebuilder:validation:Minimum=1
+	Count int64 `json:"count"`
+}
+
+// NetworkPerformanceConfig defines the network bandwidth of a GCP machine.
+type NetworkPerformanceConfig struct {
+	// TotalEgressBandwidthTier is the egress bandwidth tier of the instance. TIER_1 raises the maximum egress
+	// bandwidth to 50-100 Gbps, depending on the instance type, and requires the GVNIC NicType.
+	// +kubebuilder:validation:Enum=DEFAULT;TIER_1
+	TotalEgressBandwidthTier string `json:"totalEgressBandwidthTier"`
+}
+
+// AdvancedMachineFeatures defines the CPU features of a GCP machine.
+type AdvancedMachineFeatures struct {
+	// EnableNestedVirtualization allows running virtual machines inside the instance.
+	// It is only supported by Intel machine series.
+	// +optional
+	EnableNestedVirtualization *bool `json:"enableNestedVirtualization,omitempty"`
+
+	// ThreadsPerCore is the number of threads per physical core. Setting it to 1 disables simultaneous
+	// multithreading. Defaults to the maximum supported by the instance type.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=2
+	// +optional
+	ThreadsPerCore *int64 `json:"threadsPerCore,omitempty"`
 }
 
 // MetadataItem defines a single piece of metadata associated with an instance.
@@ -269,6 +644,10 @@ type GCPMachineStatus struct {
 	// +optional
 	InstanceStatus *InstanceStatus `json:"instanceState,omitempty"`
 
+	// Disks are the disks attached to the GCP instance.
+	// +optional
+	Disks []AttachedDiskStatus `json:"disks,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
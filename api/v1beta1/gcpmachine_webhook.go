@@ -19,7 +19,6 @@ package v1beta1
 import (
 	"fmt"
 	"reflect"
-	"strings"
 
 	"k8s.io/utils/strings/slices"
 
@@ -50,6 +49,45 @@ var _ webhook.Validator = &GCPMachine{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (m *GCPMachine) ValidateCreate() (admission.Warnings, error) {
 	clusterlog.Info("validate create", "name", m.Name)
+	if err := validateInstanceType(m.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateShieldedInstanceConfig(m.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateLocalSSDs(m.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateProvisioningModel(m.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateStackType(m.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateAdditionalDisks(m.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateDiskTypes(m.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateSSHAccess(m.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateAdvancedMachineFeatures(m.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateMinCPUPlatform(m.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateNetworkPerformanceConfig(m.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateGuestAccelerators(m.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateMaxRunDuration(m.Spec); err != nil {
+		return nil, err
+	}
 	return nil, validateConfidentialCompute(m.Spec)
 }
 
@@ -83,6 +121,15 @@ func (m *GCPMachine) ValidateUpdate(old runtime.Object) (admission.Warnings, err
 	delete(oldGCPMachineSpec, "additionalNetworkTags")
 	delete(newGCPMachineSpec, "additionalNetworkTags")
 
+	// allow increases of rootDeviceSize, the root volume is resized online
+	if oldMachine, ok := old.(*GCPMachine); ok && m.Spec.RootDeviceSize < oldMachine.Spec.RootDeviceSize {
+		return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPMachine").GroupKind(), m.Name, field.ErrorList{
+			field.Forbidden(field.NewPath("spec", "rootDeviceSize"), "cannot be decreased"),
+		})
+	}
+	delete(oldGCPMachineSpec, "rootDeviceSize")
+	delete(newGCPMachineSpec, "rootDeviceSize")
+
 	if !reflect.DeepEqual(oldGCPMachineSpec, newGCPMachineSpec) {
 		return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPMachine").GroupKind(), m.Name, field.ErrorList{
 			field.Forbidden(field.NewPath("spec"), "cannot be modified"),
@@ -104,16 +151,252 @@ func (m *GCPMachine) Default() {
 	clusterlog.Info("default", "name", m.Name)
 }
 
+func validateInstanceType(spec GCPMachineSpec) error {
+	if err := ValidateCustomMachineType(spec.InstanceType); err != nil {
+		return fmt.Errorf("InstanceType is invalid: %w", err)
+	}
+	return nil
+}
+
 func validateConfidentialCompute(spec GCPMachineSpec) error {
-	if spec.ConfidentialCompute != nil && *spec.ConfidentialCompute == ConfidentialComputePolicyEnabled {
+	enabled := spec.ConfidentialCompute != nil && *spec.ConfidentialCompute == ConfidentialComputePolicyEnabled
+	if spec.ConfidentialInstanceType != nil && !enabled {
+		return fmt.Errorf("ConfidentialInstanceType require ConfidentialCompute to be set to %s", ConfidentialComputePolicyEnabled)
+	}
+
+	if enabled {
 		if spec.OnHostMaintenance == nil || *spec.OnHostMaintenance == HostMaintenancePolicyMigrate {
 			return fmt.Errorf("ConfidentialCompute require OnHostMaintenance to be set to %s, the current value is: %s", HostMaintenancePolicyTerminate, HostMaintenancePolicyMigrate)
 		}
 
-		machineSeries := strings.Split(spec.InstanceType, "-")[0]
-		if !slices.Contains(confidentialComputeSupportedMachineSeries, machineSeries) {
-			return fmt.Errorf("ConfidentialCompute require instance type in the following series: %s", confidentialComputeSupportedMachineSeries)
+		instanceType := ConfidentialInstanceTypeSEV
+		if spec.ConfidentialInstanceType != nil {
+			instanceType = *spec.ConfidentialInstanceType
+		}
+		machineSeries := MachineSeries(spec.InstanceType)
+		if !slices.Contains(confidentialComputeSupportedMachineSeries[instanceType], machineSeries) {
+			return fmt.Errorf("ConfidentialCompute with %s require instance type in the following series: %s", instanceType, confidentialComputeSupportedMachineSeries[instanceType])
+		}
+	}
+	return nil
+}
+
+func validateShieldedInstanceConfig(spec GCPMachineSpec) error {
+	config := spec.ShieldedInstanceConfig
+	if config == nil {
+		return nil
+	}
+
+	// Integrity monitoring relies on the measurements of the vTPM, and is enabled unless explicitly disabled.
+	if config.VirtualizedTrustedPlatformModule == VirtualizedTrustedPlatformModulePolicyDisabled && config.IntegrityMonitoring != IntegrityMonitoringPolicyDisabled {
+		return fmt.Errorf("IntegrityMonitoring require VirtualizedTrustedPlatformModule to be enabled, set IntegrityMonitoring to %s", IntegrityMonitoringPolicyDisabled)
+	}
+	return nil
+}
+
+func validateLocalSSDs(spec GCPMachineSpec) error {
+	if spec.LocalSSDs == nil || spec.LocalSSDs.Mount == nil {
+		return nil
+	}
+
+	for _, item := range spec.AdditionalMetadata {
+		if item.Key == "startup-script" {
+			return fmt.Errorf("LocalSSDs Mount sets the startup-script metadata, which is already set in AdditionalMetadata")
+		}
+	}
+	return nil
+}
+
+func validateProvisioningModel(spec GCPMachineSpec) error {
+	spot := spec.ProvisioningModel != nil && *spec.ProvisioningModel == ProvisioningModelSpot
+	if spot && spec.Preemptible {
+		return fmt.Errorf("ProvisioningModel %s cannot be combined with Preemptible", ProvisioningModelSpot)
+	}
+
+	if spot && spec.OnHostMaintenance != nil && *spec.OnHostMaintenance == HostMaintenancePolicyMigrate {
+		return fmt.Errorf("ProvisioningModel %s require OnHostMaintenance to be set to %s", ProvisioningModelSpot, HostMaintenancePolicyTerminate)
+	}
+
+	if spec.InstanceTerminationAction != nil && !spot && !spec.Preemptible {
+		return fmt.Errorf("InstanceTerminationAction require ProvisioningModel to be set to %s or Preemptible to be enabled", ProvisioningModelSpot)
+	}
+
+	if spec.AutomaticRestart != nil && *spec.AutomaticRestart && (spot || spec.Preemptible) {
+		return fmt.Errorf("AutomaticRestart cannot be enabled for %s or Preemptible instances", ProvisioningModelSpot)
+	}
+	return nil
+}
+
+func validateStackType(spec GCPMachineSpec) error {
+	if spec.IPv6AccessType != nil && (spec.StackType == nil || *spec.StackType != "IPV4_IPV6") {
+		return fmt.Errorf("IPv6AccessType requires StackType to be set to IPV4_IPV6")
+	}
+	return nil
+}
+
+func validateAdditionalDisks(spec GCPMachineSpec) error {
+	deviceNames := map[string]bool{}
+	for _, disk := range spec.AdditionalDisks {
+		if disk.DeviceType != nil && *disk.DeviceType == LocalSsdDiskType {
+			if disk.EncryptionKey != nil {
+				return fmt.Errorf("EncryptionKey cannot be set on %s AdditionalDisks", LocalSsdDiskType)
+			}
+			if disk.AutoDelete != nil && !*disk.AutoDelete {
+				return fmt.Errorf("AutoDelete cannot be disabled on %s AdditionalDisks", LocalSsdDiskType)
+			}
+		}
+		if len(disk.ResourcePolicies) > 0 && disk.DeviceType != nil && *disk.DeviceType == LocalSsdDiskType {
+			return fmt.Errorf("ResourcePolicies cannot be set on %s AdditionalDisks", LocalSsdDiskType)
+		}
+		if disk.DeviceName != nil {
+			if deviceNames[*disk.DeviceName] {
+				return fmt.Errorf("DeviceName %s is used by more than one of the AdditionalDisks", *disk.DeviceName)
+			}
+			deviceNames[*disk.DeviceName] = true
+		}
+	}
+	return nil
+}
+
+func validateDiskTypes(spec GCPMachineSpec) error {
+	rootDeviceType := PdStandardDiskType
+	if spec.RootDeviceType != nil {
+		rootDeviceType = *spec.RootDeviceType
+	}
+	if rootDeviceType == HyperdiskExtremeDiskType || rootDeviceType == HyperdiskThroughputDiskType {
+		return fmt.Errorf("RootDeviceType %s cannot be used for the boot disk", rootDeviceType)
+	}
+	if err := validateDiskType("RootDeviceType", spec.InstanceType, rootDeviceType, spec.RootDeviceProvisionedIOPS, spec.RootDeviceProvisionedThroughput); err != nil {
+		return err
+	}
+
+	for _, disk := range spec.AdditionalDisks {
+		deviceType := PdStandardDiskType
+		if disk.DeviceType != nil {
+			deviceType = *disk.DeviceType
+		}
+		if err := validateDiskType("AdditionalDisks DeviceType", spec.InstanceType, deviceType, disk.ProvisionedIOPS, disk.ProvisionedThroughput); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateDiskType(field, instanceType string, diskType DiskType, provisionedIOPS, provisionedThroughput *int64) error {
+	if provisionedIOPS != nil && diskType != HyperdiskBalancedDiskType && diskType != HyperdiskExtremeDiskType {
+		return fmt.Errorf("provisioned IOPS require %s to be %s or %s", field, HyperdiskBalancedDiskType, HyperdiskExtremeDiskType)
+	}
+	if provisionedThroughput != nil && diskType != HyperdiskBalancedDiskType && diskType != HyperdiskThroughputDiskType {
+		return fmt.Errorf("provisioned throughput require %s to be %s or %s", field, HyperdiskBalancedDiskType, HyperdiskThroughputDiskType)
+	}
+
+	supportedSeries, ok := hyperdiskSupportedMachineSeries[diskType]
+	if !ok {
+		return nil
+	}
+	machineSeries := MachineSeries(instanceType)
+	if !slices.Contains(supportedSeries, machineSeries) {
+		return fmt.Errorf("%s %s require instance type in the following series: %s", field, diskType, supportedSeries)
+	}
+	return nil
+}
+
+func validateSSHAccess(spec GCPMachineSpec) error {
+	if len(spec.SSHKeys) > 0 && spec.OSLogin != nil && *spec.OSLogin == OSLoginPolicyEnabled {
+		return fmt.Errorf("SSHKeys are ignored when OSLogin is set to %s", OSLoginPolicyEnabled)
+	}
+
+	keys := map[string]bool{
+		"enable-oslogin":         spec.OSLogin != nil,
+		"ssh-keys":               len(spec.SSHKeys) > 0,
+		"block-project-ssh-keys": spec.BlockProjectSSHKeys != nil && *spec.BlockProjectSSHKeys,
+	}
+	for _, item := range spec.AdditionalMetadata {
+		if keys[item.Key] {
+			return fmt.Errorf("the %s metadata is already set in AdditionalMetadata", item.Key)
+		}
+	}
+	return nil
+}
+
+func validateAdvancedMachineFeatures(spec GCPMachineSpec) error {
+	features := spec.AdvancedMachineFeatures
+	if features == nil || features.EnableNestedVirtualization == nil || !*features.EnableNestedVirtualization {
+		return nil
+	}
+
+	machineSeries := MachineSeries(spec.InstanceType)
+	if slices.Contains(nestedVirtualizationUnsupportedMachineSeries, machineSeries) {
+		return fmt.Errorf("EnableNestedVirtualization is not supported by instance types in the following series: %s", nestedVirtualizationUnsupportedMachineSeries)
+	}
+	return nil
+}
+
+func validateMinCPUPlatform(spec GCPMachineSpec) error {
+	if spec.MinCPUPlatform == nil {
+		return nil
+	}
+
+	if slices.Contains(minCPUPlatformUnsupportedMachineSeries, MachineSeries(spec.InstanceType)) {
+		return fmt.Errorf("MinCPUPlatform is not supported by instance types in the following series: %s", minCPUPlatformUnsupportedMachineSeries)
+	}
+	return nil
+}
+
+func validateNetworkPerformanceConfig(spec GCPMachineSpec) error {
+	config := spec.NetworkPerformanceConfig
+	if config == nil || config.TotalEgressBandwidthTier != "TIER_1" {
+		return nil
+	}
+
+	if spec.NicType == nil || *spec.NicType != "GVNIC" {
+		return fmt.Errorf("TIER_1 TotalEgressBandwidthTier require NicType to be set to GVNIC")
+	}
+	machineSeries := MachineSeries(spec.InstanceType)
+	if !slices.Contains(tier1NetworkingSupportedMachineSeries, machineSeries) {
+		return fmt.Errorf("TIER_1 TotalEgressBandwidthTier require instance type in the following series: %s", tier1NetworkingSupportedMachineSeries)
+	}
+	return nil
+}
+
+func validateGuestAccelerators(spec GCPMachineSpec) error {
+	if len(spec.GuestAccelerators) == 0 {
+		return nil
+	}
+
+	if !slices.Contains(guestAcceleratorSupportedMachineSeries, MachineSeries(spec.InstanceType)) {
+		return fmt.Errorf("GuestAccelerators require instance type in the following series: %s", guestAcceleratorSupportedMachineSeries)
+	}
+	// Preemptible and Spot instances are never migrated.
+	preemptible := spec.Preemptible || (spec.ProvisioningModel != nil && *spec.ProvisioningModel == ProvisioningModelSpot)
+	if !preemptible && (spec.OnHostMaintenance == nil || *spec.OnHostMaintenance != HostMaintenancePolicyTerminate) {
+		return fmt.Errorf("GuestAccelerators require OnHostMaintenance to be set to %s", HostMaintenancePolicyTerminate)
+	}
+
+	types := make(map[string]bool, len(spec.GuestAccelerators))
+	for _, accelerator := range spec.GuestAccelerators {
+		counts, ok := guestAcceleratorCounts[accelerator.Type]
+		if !ok {
+			return fmt.Errorf("GuestAccelerators type %s is not supported by the n1 series", accelerator.Type)
 		}
+		if types[accelerator.Type] {
+			return fmt.Errorf("GuestAccelerators type %s is set more than once", accelerator.Type)
+		}
+		types[accelerator.Type] = true
+		supported := false
+		for _, count := range counts {
+			supported = supported || count == accelerator.Count
+		}
+		if !supported {
+			return fmt.Errorf("GuestAccelerators of type %s require a count in the following values: %v", accelerator.Type, counts)
+		}
+	}
+	return nil
+}
+
+func validateMaxRunDuration(spec GCPMachineSpec) error {
+	if spec.MaxRunDuration != nil && spec.MaxRunDuration.Duration <= 0 {
+		return fmt.Errorf("MaxRunDuration must be positive")
 	}
 	return nil
 }
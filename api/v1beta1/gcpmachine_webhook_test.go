@@ -18,8 +18,11 @@ package v1beta1
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
 )
 
 func TestGCPMachine_ValidateCreate(t *testing.T) {
@@ -27,11 +30,130 @@ func TestGCPMachine_ValidateCreate(t *testing.T) {
 	confidentialComputeEnabled := ConfidentialComputePolicyEnabled
 	onHostMaintenanceTerminate := HostMaintenancePolicyTerminate
 	onHostMaintenanceMigrate := HostMaintenancePolicyMigrate
+	confidentialInstanceTypeSEV := ConfidentialInstanceTypeSEV
+	localSSDDiskType := LocalSsdDiskType
+	osLoginEnabled := OSLoginPolicyEnabled
+	pdSSDDiskType := PdSsdDiskType
+	hyperdiskBalancedDiskType := HyperdiskBalancedDiskType
+	hyperdiskExtremeDiskType := HyperdiskExtremeDiskType
+	hyperdiskThroughputDiskType := HyperdiskThroughputDiskType
+	provisioningModelSpot := ProvisioningModelSpot
+	instanceTerminationActionStop := InstanceTerminationActionStop
 	tests := []struct {
 		name string
 		*GCPMachine
 		wantErr bool
 	}{
+		{
+			name: "GCPMachine with custom machine type - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-custom-8-16384",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with N1 custom machine type with extended memory - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "custom-2-20480-ext",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with custom machine type with extended memory - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-custom-4-8192-ext",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with E2 custom machine type with extended memory - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "e2-custom-4-65536-ext",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with custom machine type with memory not a multiple of 256 MB - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-custom-4-10000",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with custom machine type with an unsupported number of vCPUs - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-custom-6-12288",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with custom machine type with too little memory per vCPU - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "custom-4-2048",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with custom machine type with too much memory per vCPU - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-custom-2-32768",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with custom machine type of an unsupported series - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "c2-custom-4-8192",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with malformed custom machine type - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-custom-4",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with MinCPUPlatform - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "n2-standard-4",
+					MinCPUPlatform: pointer.String("Intel Ice Lake"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with MinCPUPlatform and E2 instance type - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "e2-standard-4",
+					MinCPUPlatform: pointer.String("Intel Skylake"),
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "GCPMachined with OnHostMaintenance set to Terminate - valid",
 			GCPMachine: &GCPMachine{
@@ -85,6 +207,312 @@ func TestGCPMachine_ValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "GCPMachined with ConfidentialCompute enabled and SEV on a C3D instance type - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:             "c3d-standard-4",
+					ConfidentialCompute:      &confidentialComputeEnabled,
+					ConfidentialInstanceType: &confidentialInstanceTypeSEV,
+					OnHostMaintenance:        &onHostMaintenanceTerminate,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachined with ConfidentialInstanceType and ConfidentialCompute not enabled - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:             "n2d-standard-4",
+					ConfidentialInstanceType: &confidentialInstanceTypeSEV,
+					OnHostMaintenance:        &onHostMaintenanceTerminate,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with vTPM and IntegrityMonitoring disabled - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-standard-4",
+					ShieldedInstanceConfig: &GCPShieldedInstanceConfig{
+						SecureBoot:                       SecureBootPolicyEnabled,
+						VirtualizedTrustedPlatformModule: VirtualizedTrustedPlatformModulePolicyDisabled,
+						IntegrityMonitoring:              IntegrityMonitoringPolicyDisabled,
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachined with vTPM disabled and default IntegrityMonitoring (Enabled) - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-standard-4",
+					ShieldedInstanceConfig: &GCPShieldedInstanceConfig{
+						VirtualizedTrustedPlatformModule: VirtualizedTrustedPlatformModulePolicyDisabled,
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with Spot ProvisioningModel and Stop InstanceTerminationAction - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:              "n2d-standard-4",
+					ProvisioningModel:         &provisioningModelSpot,
+					InstanceTerminationAction: &instanceTerminationActionStop,
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachined with Spot ProvisioningModel and Preemptible - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:      "n2d-standard-4",
+					ProvisioningModel: &provisioningModelSpot,
+					Preemptible:       true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with Spot ProvisioningModel and OnHostMaintenance set to Migrate - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:      "n2d-standard-4",
+					ProvisioningModel: &provisioningModelSpot,
+					OnHostMaintenance: &onHostMaintenanceMigrate,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with InstanceTerminationAction on a standard instance - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:              "n2d-standard-4",
+					InstanceTerminationAction: &instanceTerminationActionStop,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with external IPv6 on a dual-stack interface - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "n2d-standard-4",
+					StackType:      pointer.String("IPV4_IPV6"),
+					IPv6AccessType: pointer.String("EXTERNAL"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachined with an encrypted local-ssd AdditionalDisk - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-standard-4",
+					AdditionalDisks: []AttachedDiskSpec{
+						{
+							DeviceType: &localSSDDiskType,
+							EncryptionKey: &CustomerEncryptionKey{
+								KMSKeyName: "projects/my-proj/locations/us-central1/keyRings/my-ring/cryptoKeys/my-key",
+							},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with AdditionalDisks sharing a DeviceName - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-standard-4",
+					AdditionalDisks: []AttachedDiskSpec{
+						{DeviceName: pointer.String("etcd")},
+						{DeviceName: pointer.String("etcd")},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with a hyperdisk-balanced root disk with provisioned IOPS - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:              "c3-standard-4",
+					RootDeviceType:            &hyperdiskBalancedDiskType,
+					RootDeviceProvisionedIOPS: pointer.Int64(5000),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachined with a hyperdisk-throughput root disk - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "c3-standard-4",
+					RootDeviceType: &hyperdiskThroughputDiskType,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with a hyperdisk-extreme AdditionalDisk on an unsupported machine series - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-standard-4",
+					AdditionalDisks: []AttachedDiskSpec{
+						{DeviceType: &hyperdiskExtremeDiskType},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with provisioned throughput on a pd-ssd AdditionalDisk - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "c3-standard-4",
+					AdditionalDisks: []AttachedDiskSpec{
+						{DeviceType: &pdSSDDiskType, ProvisionedThroughput: pointer.Int64(200)},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with SSHKeys and OSLogin enabled - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-standard-4",
+					OSLogin:      &osLoginEnabled,
+					SSHKeys: []SSHKey{
+						{Username: "admin", PublicKey: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIA"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with OSLogin and enable-oslogin AdditionalMetadata - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-standard-4",
+					OSLogin:      &osLoginEnabled,
+					AdditionalMetadata: []MetadataItem{
+						{Key: "enable-oslogin", Value: pointer.String("FALSE")},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with nested virtualization on an Intel machine series - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2-standard-8",
+					AdvancedMachineFeatures: &AdvancedMachineFeatures{
+						EnableNestedVirtualization: pointer.Bool(true),
+						ThreadsPerCore:             pointer.Int64(1),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachined with nested virtualization on an AMD machine series - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "n2d-standard-8",
+					AdvancedMachineFeatures: &AdvancedMachineFeatures{
+						EnableNestedVirtualization: pointer.Bool(true),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with TIER_1 networking on gVNIC - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "c3-standard-44",
+					NicType:      pointer.String("GVNIC"),
+					NetworkPerformanceConfig: &NetworkPerformanceConfig{
+						TotalEgressBandwidthTier: "TIER_1",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachined with TIER_1 networking without gVNIC - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType: "c3-standard-44",
+					NetworkPerformanceConfig: &NetworkPerformanceConfig{
+						TotalEgressBandwidthTier: "TIER_1",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with AutomaticRestart and Terminate OnHostMaintenance - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:      "n2d-standard-4",
+					OnHostMaintenance: &onHostMaintenanceTerminate,
+					AutomaticRestart:  pointer.Bool(true),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachined with AutomaticRestart on a preemptible instance - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:     "n2d-standard-4",
+					Preemptible:      true,
+					AutomaticRestart: pointer.Bool(true),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with MaxRunDuration - valid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "n2-standard-4",
+					MaxRunDuration: &metav1.Duration{Duration: 4 * time.Hour},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachined with a negative MaxRunDuration - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "n2-standard-4",
+					MaxRunDuration: &metav1.Duration{Duration: -time.Hour},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachined with IPv6AccessType on an IPv4 only interface - invalid",
+			GCPMachine: &GCPMachine{
+				Spec: GCPMachineSpec{
+					InstanceType:   "n2d-standard-4",
+					IPv6AccessType: pointer.String("INTERNAL"),
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -100,3 +528,57 @@ func TestGCPMachine_ValidateCreate(t *testing.T) {
 		})
 	}
 }
+
+func TestGCPMachine_ValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+	tests := []struct {
+		name       string
+		oldMachine *GCPMachine
+		newMachine *GCPMachine
+		wantErr    bool
+	}{
+		{
+			name: "GCPMachine with an increased RootDeviceSize - valid",
+			oldMachine: &GCPMachine{
+				Spec: GCPMachineSpec{InstanceType: "n2-standard-4", RootDeviceSize: 30},
+			},
+			newMachine: &GCPMachine{
+				Spec: GCPMachineSpec{InstanceType: "n2-standard-4", RootDeviceSize: 100},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachine with a decreased RootDeviceSize - invalid",
+			oldMachine: &GCPMachine{
+				Spec: GCPMachineSpec{InstanceType: "n2-standard-4", RootDeviceSize: 100},
+			},
+			newMachine: &GCPMachine{
+				Spec: GCPMachineSpec{InstanceType: "n2-standard-4", RootDeviceSize: 30},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachine with a changed InstanceType - invalid",
+			oldMachine: &GCPMachine{
+				Spec: GCPMachineSpec{InstanceType: "n2-standard-4"},
+			},
+			newMachine: &GCPMachine{
+				Spec: GCPMachineSpec{InstanceType: "n2-standard-8"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			warn, err := test.newMachine.ValidateUpdate(test.oldMachine)
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(warn).To(BeNil())
+		})
+	}
+}
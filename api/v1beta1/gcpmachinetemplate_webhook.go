@@ -47,6 +47,45 @@ var _ webhook.Validator = &GCPMachineTemplate{}
 func (r *GCPMachineTemplate) ValidateCreate() (admission.Warnings, error) {
 	clusterlog.Info("validate create", "name", r.Name)
 
+	if err := validateInstanceType(r.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateShieldedInstanceConfig(r.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateLocalSSDs(r.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateProvisioningModel(r.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateStackType(r.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateAdditionalDisks(r.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateDiskTypes(r.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateSSHAccess(r.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateAdvancedMachineFeatures(r.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateMinCPUPlatform(r.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateNetworkPerformanceConfig(r.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateGuestAccelerators(r.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
+	if err := validateMaxRunDuration(r.Spec.Template.Spec); err != nil {
+		return nil, err
+	}
 	return nil, validateConfidentialCompute(r.Spec.Template.Spec)
 }
 
@@ -100,6 +100,61 @@ func TestGCPMachineTemplate_ValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "GCPMachineTemplate with GuestAccelerators on an n1 instance type and OnHostMaintenance set to Terminate - valid",
+			template: &GCPMachineTemplate{
+				Spec: GCPMachineTemplateSpec{
+					Template: GCPMachineTemplateResource{
+						Spec: GCPMachineSpec{
+							InstanceType:      "n1-standard-8",
+							OnHostMaintenance: &onHostMaintenanceTerminate,
+							GuestAccelerators: []Accelerator{{Type: "nvidia-tesla-t4", Count: 2}},
+						}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachineTemplate with GuestAccelerators and default OnHostMaintenance (Migrate) - invalid",
+			template: &GCPMachineTemplate{
+				Spec: GCPMachineTemplateSpec{
+					Template: GCPMachineTemplateResource{
+						Spec: GCPMachineSpec{
+							InstanceType:      "n1-standard-8",
+							GuestAccelerators: []Accelerator{{Type: "nvidia-tesla-t4", Count: 1}},
+						}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachineTemplate with GuestAccelerators on an unsupported instance type - invalid",
+			template: &GCPMachineTemplate{
+				Spec: GCPMachineTemplateSpec{
+					Template: GCPMachineTemplateResource{
+						Spec: GCPMachineSpec{
+							InstanceType:      "n2-standard-8",
+							OnHostMaintenance: &onHostMaintenanceTerminate,
+							GuestAccelerators: []Accelerator{{Type: "nvidia-tesla-t4", Count: 1}},
+						}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachineTemplate with an unsupported GuestAccelerators count - invalid",
+			template: &GCPMachineTemplate{
+				Spec: GCPMachineTemplateSpec{
+					Template: GCPMachineTemplateResource{
+						Spec: GCPMachineSpec{
+							InstanceType:      "n1-standard-8",
+							OnHostMaintenance: &onHostMaintenanceTerminate,
+							GuestAccelerators: []Accelerator{{Type: "nvidia-tesla-t4", Count: 3}},
+						}},
+				},
+			},
+			wantErr: true,
+		},
 	}
 	for _, test := range tests {
 		test := test
@@ -111,6 +111,9 @@ const (
 
 	// APIServerRoleTagValue describes the value for the apiserver role.
 	APIServerRoleTagValue = "apiserver"
+
+	// BastionRoleTagValue describes the value for the bastion role.
+	BastionRoleTagValue = "bastion"
 )
 
 // ClusterTagKey generates the key for resources associated with a cluster.
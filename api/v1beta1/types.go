@@ -18,7 +18,14 @@ package v1beta1
 
 import (
 	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/strings/slices"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -56,6 +63,10 @@ type Network struct {
 	// +optional
 	Router *string `json:"router,omitempty"`
 
+	// Subnets is a map from the name of the subnet to its full reference.
+	// +optional
+	Subnets map[string]string `json:"subnets,omitempty"`
+
 	// APIServerAddress is the IPV4 global address assigned to the load balancer
 	// created for the API Server.
 	// +optional
@@ -85,6 +96,308 @@ type Network struct {
 	// created for the API Server.
 	// +optional
 	APIServerForwardingRule *string `json:"apiServerForwardingRule,omitempty"`
+
+	// APIInternalAddress is the private IPV4 regional address assigned to the internal load balancer
+	// created for the API Server.
+	// +optional
+	APIInternalAddress *string `json:"apiInternalIpAddress,omitempty"`
+
+	// APIInternalHealthCheck is the full reference to the regional health check
+	// created for the internal load balancer of the API Server.
+	// +optional
+	APIInternalHealthCheck *string `json:"apiInternalHealthCheck,omitempty"`
+
+	// APIInternalBackendService is the full reference to the regional backend service
+	// created for the internal load balancer of the API Server.
+	// +optional
+	APIInternalBackendService *string `json:"apiInternalBackendService,omitempty"`
+
+	// APIInternalForwardingRule is the full reference to the regional forwarding rule
+	// created for the internal load balancer of the API Server.
+	// +optional
+	APIInternalForwardingRule *string `json:"apiInternalForwardingRule,omitempty"`
+
+	// APIInternalServiceAttachment is the full reference to the Private Service Connect service attachment
+	// publishing the internal load balancer of the API Server.
+	// +optional
+	APIInternalServiceAttachment *string `json:"apiInternalServiceAttachment,omitempty"`
+}
+
+// LoadBalancerType defines the kind of load balancer fronting the control plane.
+type LoadBalancerType string
+
+const (
+	// LoadBalancerTypeExternal creates a global external TCP proxy load balancer with a public address.
+	// This is the default.
+	LoadBalancerTypeExternal LoadBalancerType = LoadBalancerType("External")
+	// LoadBalancerTypeInternal creates a regional internal passthrough load balancer with a private
+	// address in the cluster network instead of the external one.
+	LoadBalancerTypeInternal LoadBalancerType = LoadBalancerType("Internal")
+	// LoadBalancerTypeBoth creates both the external and the internal load balancers. The control plane
+	// endpoint uses the public address.
+	LoadBalancerTypeBoth LoadBalancerType = LoadBalancerType("Both")
+)
+
+// LoadBalancerSpec configures the load balancers fronting the control plane.
+type LoadBalancerSpec struct {
+	// LoadBalancerType selects the load balancers created for the control plane.
+	// Defaults to External.
+	// +kubebuilder:validation:Enum=External;Internal;Both
+	// +optional
+	LoadBalancerType *LoadBalancerType `json:"loadBalancerType,omitempty"`
+
+	// InternalLoadBalancer configures the internal load balancer, when LoadBalancerType is Internal or Both.
+	// +optional
+	InternalLoadBalancer *InternalLoadBalancerSpec `json:"internalLoadBalancer,omitempty"`
+
+	// BackendService configures the backend services distributing the traffic of the load balancers to the
+	// control plane instance groups.
+	// +optional
+	BackendService *BackendServiceSpec `json:"backendService,omitempty"`
+
+	// HealthCheck configures the health checks probing the control plane instances of the load balancers.
+	// +optional
+	HealthCheck *LoadBalancerHealthCheck `json:"healthCheck,omitempty"`
+}
+
+// Validate validates the load balancer spec.
+func (l *LoadBalancerSpec) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	lbType := LoadBalancerTypeExternal
+	if l.LoadBalancerType != nil {
+		lbType = *l.LoadBalancerType
+	}
+
+	if l.InternalLoadBalancer != nil && lbType == LoadBalancerTypeExternal {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("InternalLoadBalancer"), "requires an Internal or Both load balancer type"))
+	}
+
+	if l.InternalLoadBalancer != nil && l.InternalLoadBalancer.ServiceAttachment != nil {
+		attachment := l.InternalLoadBalancer.ServiceAttachment
+		manual := attachment.ConnectionPreference != nil && *attachment.ConnectionPreference == "ACCEPT_MANUAL"
+		if len(attachment.ConsumerAcceptList) > 0 && !manual {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("InternalLoadBalancer", "ServiceAttachment", "ConsumerAcceptList"), "requires the ACCEPT_MANUAL connection preference"))
+		}
+	}
+
+	if l.BackendService != nil && l.BackendService.FailoverPolicy != nil && lbType == LoadBalancerTypeExternal {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("BackendService", "FailoverPolicy"), "is only supported by internal load balancers"))
+	}
+
+	if l.BackendService != nil && l.BackendService.SessionAffinity != nil && lbType != LoadBalancerTypeInternal {
+		if affinity := *l.BackendService.SessionAffinity; affinity != "NONE" && affinity != "CLIENT_IP" {
+			allErrs = append(allErrs, field.NotSupported(fldPath.Child("BackendService", "SessionAffinity"), affinity, []string{"NONE", "CLIENT_IP"}))
+		}
+	}
+
+	if l.HealthCheck != nil {
+		allErrs = append(allErrs, l.HealthCheck.validate(fldPath.Child("HealthCheck"))...)
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return allErrs
+}
+
+// HealthCheckPort returns the port probed by the health checks of the load balancers.
+func (l *LoadBalancerSpec) HealthCheckPort() int64 {
+	if l.HealthCheck != nil && l.HealthCheck.Port != nil {
+		return *l.HealthCheck.Port
+	}
+	return 6443
+}
+
+// LoadBalancerHealthCheck configures the health checks of the control plane load balancers.
+type LoadBalancerHealthCheck struct {
+	// Type is the protocol of the health check.
+	// +kubebuilder:validation:Enum=HTTPS;HTTP;TCP
+	// +kubebuilder:default=HTTPS
+	// +optional
+	Type *string `json:"type,omitempty"`
+
+	// Port is the port probed on the control plane instances. Defaults to 6443.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Port *int64 `json:"port,omitempty"`
+
+	// RequestPath is the path of the HTTP and HTTPS health check requests. Defaults to /readyz.
+	// +optional
+	RequestPath *string `json:"requestPath,omitempty"`
+
+	// CheckIntervalSec is the time, in seconds, between two probes. Defaults to 10.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	CheckIntervalSec *int64 `json:"checkIntervalSec,omitempty"`
+
+	// TimeoutSec is the time, in seconds, to wait for a probe to succeed. It must not be greater than
+	// CheckIntervalSec. Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=300
+	// +optional
+	TimeoutSec *int64 `json:"timeoutSec,omitempty"`
+
+	// HealthyThreshold is the number of consecutive successful probes marking an instance healthy. Defaults to 5.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	HealthyThreshold *int64 `json:"healthyThreshold,omitempty"`
+
+	// UnhealthyThreshold is the number of consecutive failed probes marking an instance unhealthy. Defaults to 3.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=10
+	// +optional
+	UnhealthyThreshold *int64 `json:"unhealthyThreshold,omitempty"`
+
+	// ProxyHeader is the proxy protocol header prepended to the probes, for instances behind a local proxy
+	// expecting it.
+	// +kubebuilder:validation:Enum=NONE;PROXY_V1
+	// +kubebuilder:default=NONE
+	// +optional
+	ProxyHeader *string `json:"proxyHeader,omitempty"`
+}
+
+func (h *LoadBalancerHealthCheck) validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if h.Type != nil && *h.Type == "TCP" && h.RequestPath != nil {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("RequestPath"), "is not supported by TCP health checks"))
+	}
+
+	checkInterval := int64(10)
+	if h.CheckIntervalSec != nil {
+		checkInterval = *h.CheckIntervalSec
+	}
+	timeout := int64(5)
+	if h.TimeoutSec != nil {
+		timeout = *h.TimeoutSec
+	}
+	if timeout > checkInterval {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("TimeoutSec"), timeout, "must not be greater than the check interval"))
+	}
+
+	return allErrs
+}
+
+// BackendServiceSpec configures the backend services of the control plane load balancers.
+type BackendServiceSpec struct {
+	// ConnectionDrainingTimeoutSec is the time, in seconds, given to in-flight connections to complete when a
+	// control plane instance is removed from the load balancer. Defaults to the Compute Engine default.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	ConnectionDrainingTimeoutSec *int64 `json:"connectionDrainingTimeoutSec,omitempty"`
+
+	// SessionAffinity sends the connections of a client to the same control plane instance. The external load
+	// balancer only supports NONE and CLIENT_IP. Defaults to the Compute Engine default, NONE.
+	// +kubebuilder:validation:Enum=NONE;CLIENT_IP;CLIENT_IP_PROTO;CLIENT_IP_PORT_PROTO
+	// +optional
+	SessionAffinity *string `json:"sessionAffinity,omitempty"`
+
+	// IdleTimeoutSec is the time, in seconds, after which idle connections are closed, which has to exceed the
+	// keepalive interval of long-lived connections such as kubectl exec and watch. It is the backend timeout of
+	// the external load balancer, which defaults to 600 seconds, and the connection tracking idle timeout of the
+	// internal load balancer.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	IdleTimeoutSec *int64 `json:"idleTimeoutSec,omitempty"`
+
+	// FailoverPolicy configures the failover of the internal load balancer. It is not supported by the
+	// external load balancer.
+	// +optional
+	FailoverPolicy *BackendServiceFailoverPolicy `json:"failoverPolicy,omitempty"`
+
+	// Logging enables the logging of the connections handled by the load balancers.
+	// +optional
+	Logging *BackendServiceLogging `json:"logging,omitempty"`
+}
+
+// BackendServiceFailoverPolicy configures the failover of a backend service.
+type BackendServiceFailoverPolicy struct {
+	// DisableConnectionDrainOnFailover terminates the existing connections immediately on failover instead
+	// of draining them.
+	// +optional
+	DisableConnectionDrainOnFailover *bool `json:"disableConnectionDrainOnFailover,omitempty"`
+
+	// DropTrafficIfUnhealthy drops the traffic when all the instances are unhealthy instead of distributing it
+	// among all of them.
+	// +optional
+	DropTrafficIfUnhealthy *bool `json:"dropTrafficIfUnhealthy,omitempty"`
+
+	// FailoverRatio is the fraction of healthy primary instances, between 0.0 and 1.0, under which the traffic
+	// fails over.
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +optional
+	FailoverRatio *string `json:"failoverRatio,omitempty"`
+}
+
+// BackendServiceLogging configures the logging of a backend service.
+type BackendServiceLogging struct {
+	// SampleRate is the fraction of connections which are logged, between 0.0 and 1.0.
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +kubebuilder:default="1.0"
+	// +optional
+	SampleRate *string `json:"sampleRate,omitempty"`
+}
+
+// InternalLoadBalancerSpec configures the internal passthrough load balancer of the control plane.
+type InternalLoadBalancerSpec struct {
+	// Name is the name of the resources of the internal load balancer. Defaults to the name of the
+	// cluster suffixed with "-apiserver-internal".
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Subnet is the name of the subnet the private address of the load balancer is allocated from.
+	// Defaults to the first subnet of the cluster network in the cluster region.
+	// +optional
+	Subnet *string `json:"subnet,omitempty"`
+
+	// IPAddress is a static private address within the subnet to reserve for the load balancer.
+	// An address is allocated automatically when unset.
+	// +optional
+	IPAddress *string `json:"ipAddress,omitempty"`
+
+	// ServiceAttachment publishes the internal load balancer with Private Service Connect, so that other
+	// networks, e.g. the one of the management cluster, can reach the API server through Private Service
+	// Connect endpoints.
+	// +optional
+	ServiceAttachment *ServiceAttachmentSpec `json:"serviceAttachment,omitempty"`
+}
+
+// ServiceAttachmentSpec configures the Private Service Connect service attachment of the internal load balancer.
+type ServiceAttachmentSpec struct {
+	// NatSubnets are the names of the subnets of the cluster region, with the PRIVATE_SERVICE_CONNECT purpose,
+	// whose addresses are used to NAT the traffic of the consumers.
+	// +kubebuilder:validation:MinItems=1
+	NatSubnets []string `json:"natSubnets"`
+
+	// ConnectionPreference selects whether the connections of the consumers are accepted automatically, or
+	// only for the projects of ConsumerAcceptList.
+	// +kubebuilder:validation:Enum=ACCEPT_AUTOMATIC;ACCEPT_MANUAL
+	// +kubebuilder:default=ACCEPT_AUTOMATIC
+	// +optional
+	ConnectionPreference *string `json:"connectionPreference,omitempty"`
+
+	// ConsumerAcceptList are the projects whose connections are accepted when ConnectionPreference is
+	// ACCEPT_MANUAL.
+	// +optional
+	ConsumerAcceptList []ServiceAttachmentConsumer `json:"consumerAcceptList,omitempty"`
+
+	// ConsumerRejectList are the IDs or numbers of the projects whose connections are rejected.
+	// +optional
+	ConsumerRejectList []string `json:"consumerRejectList,omitempty"`
+}
+
+// ServiceAttachmentConsumer is a project allowed to connect to a service attachment.
+type ServiceAttachmentConsumer struct {
+	// Project is the ID or number of the consumer project.
+	Project string `json:"project"`
+
+	// ConnectionLimit is the number of Private Service Connect endpoints the project can connect.
+	// +kubebuilder:validation:Minimum=1
+	ConnectionLimit int64 `json:"connectionLimit"`
 }
 
 // DatapathProvider is the datapath provider selects the implementation of the Kubernetes networking
@@ -124,6 +437,12 @@ type NetworkSpec struct {
 	// +optional
 	Subnets Subnets `json:"subnets,omitempty"`
 
+	// MTU is the maximum transmission unit of the network, in bytes. It can only be set when the network
+	// is created by CAPG. Defaults to 1460.
+	// +kubebuilder:validation:Enum=1460;1500;8896
+	// +optional
+	MTU *int64 `json:"mtu,omitempty"`
+
 	// Allow for configuration of load balancer backend (useful for changing apiserver port)
 	// +optional
 	LoadBalancerBackendPort *int32 `json:"loadBalancerBackendPort,omitempty"`
@@ -132,6 +451,185 @@ type NetworkSpec struct {
 	// IPTables-based kube-proxy implementation (DatapathProviderLegacyDatapath).
 	// +optional
 	DatapathProvider *DatapathProvider `json:"datapathProvider,omitempty"`
+
+	// UseExisting indicates that the network and the subnets already exist and are not managed by CAPG. They are
+	// looked up to resolve their self-links but never created, updated nor deleted.
+	// +optional
+	UseExisting *bool `json:"useExisting,omitempty"`
+
+	// HostProject is the name of the project hosting the shared VPC network the cluster uses. When set, the
+	// network and the subnets are expected to exist in the host project and are not created nor deleted.
+	// +optional
+	HostProject *string `json:"hostProject,omitempty"`
+
+	// Router configures a Cloud Router in the network. The router can be used on its own, e.g. for hybrid
+	// connectivity, and also hosts the Cloud NAT of networks created by CAPG.
+	// +optional
+	Router *RouterSpec `json:"router,omitempty"`
+
+	// CloudNat configures the Cloud NAT of the network, which lets machines without a public IP reach the
+	// internet. It is enabled by default for networks created by CAPG.
+	// +optional
+	CloudNat *CloudNatSpec `json:"cloudNat,omitempty"`
+
+	// Routes are custom static routes created in the network, e.g. to send the egress traffic of the
+	// machines through NAT appliances. Routes removed from the list are deleted.
+	// +optional
+	Routes Routes `json:"routes,omitempty"`
+}
+
+// CloudNatSpec configures a Cloud NAT.
+type CloudNatSpec struct {
+	// Enabled creates the Cloud NAT, along with its Cloud Router. Defaults to true for networks created by
+	// CAPG, and to false for existing networks.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// NatIPs are the names of reserved external addresses of the cluster region used by the Cloud NAT.
+	// Addresses are allocated automatically when empty.
+	// +optional
+	NatIPs []string `json:"natIPs,omitempty"`
+
+	// MinPortsPerVM is the minimum number of ports allocated to a machine.
+	// +kubebuilder:validation:Minimum=2
+	// +kubebuilder:validation:Maximum=65536
+	// +optional
+	MinPortsPerVM *int64 `json:"minPortsPerVM,omitempty"`
+
+	// LogFilter enables the logging of the Cloud NAT translations and errors, and selects the logged entries.
+	// +kubebuilder:validation:Enum=ERRORS_ONLY;TRANSLATIONS_ONLY;ALL
+	// +optional
+	LogFilter *string `json:"logFilter,omitempty"`
+}
+
+// DefaultFirewallRulesSpec configures the default firewall rules of a cluster.
+type DefaultFirewallRulesSpec struct {
+	// HealthChecks configures the rule allowing the Google Cloud health checks, from 35.191.0.0/16 and
+	// 130.211.0.0/22, to reach the health check port of the control plane.
+	// +optional
+	HealthChecks *DefaultFirewallRuleSpec `json:"healthChecks,omitempty"`
+
+	// Cluster configures the rule allowing all traffic between the control plane and the nodes, identified
+	// by their network tags.
+	// +optional
+	Cluster *DefaultFirewallRuleSpec `json:"cluster,omitempty"`
+}
+
+// Validate validates the default firewall rules.
+func (r *DefaultFirewallRulesSpec) Validate(fldPath *field.Path) field.ErrorList {
+	if r == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, r.HealthChecks.Validate(fldPath.Child("HealthChecks"))...)
+	allErrs = append(allErrs, r.Cluster.Validate(fldPath.Child("Cluster"))...)
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return allErrs
+}
+
+// DefaultFirewallRuleSpec customizes a default firewall rule.
+type DefaultFirewallRuleSpec struct {
+	// Disabled prevents the creation of the rule, and deletes it if it was created before. The traffic it
+	// allows must then be allowed by other rules.
+	// +optional
+	Disabled bool `json:"disabled,omitempty"`
+
+	// SourceRanges replaces the default sources of the rule with the given IP ranges, in CIDR format.
+	// +optional
+	SourceRanges []string `json:"sourceRanges,omitempty"`
+
+	// Protocol replaces the IP protocol the rule allows, e.g. tcp or udp. All the ports of the protocol are
+	// allowed unless Ports are set.
+	// +optional
+	Protocol *string `json:"protocol,omitempty"`
+
+	// Ports replaces the ports or port ranges the rule allows, e.g. 6443 or 30000-32767. They require the
+	// protocol to be set to tcp, udp or sctp.
+	// +optional
+	Ports []string `json:"ports,omitempty"`
+}
+
+// Validate validates a default firewall rule.
+func (r *DefaultFirewallRuleSpec) Validate(fldPath *field.Path) field.ErrorList {
+	if r == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	for i, sourceRange := range r.SourceRanges {
+		if _, _, err := net.ParseCIDR(sourceRange); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("SourceRanges").Index(i), sourceRange, "must be a CIDR block"))
+		}
+	}
+
+	if len(r.Ports) > 0 {
+		switch {
+		case r.Protocol == nil:
+			allErrs = append(allErrs, field.Required(fldPath.Child("Protocol"), "required when ports are set"))
+		case !slices.Contains([]string{"tcp", "udp", "sctp"}, strings.ToLower(*r.Protocol)):
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("Protocol"), *r.Protocol, "must be tcp, udp or sctp when ports are set"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return allErrs
+}
+
+// RouterSpec configures a Cloud Router.
+type RouterSpec struct {
+	// Name is the name of the router. Defaults to the name of the network suffixed with "-router".
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// ASN is the local BGP autonomous system number of the router. It must be a private ASN, either
+	// in the 64512-65534 or in the 4200000000-4294967294 range. Required when AdvertisedIPRanges are set.
+	// +optional
+	ASN *int64 `json:"asn,omitempty"`
+
+	// AdvertisedIPRanges are custom IP ranges advertised to the BGP peers of the router in addition to
+	// the subnets of the network.
+	// +optional
+	AdvertisedIPRanges []RouterAdvertisedIPRange `json:"advertisedIpRanges,omitempty"`
+}
+
+// Validate validates the router spec.
+func (r *RouterSpec) Validate(fldPath *field.Path) field.ErrorList {
+	if r == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	if r.ASN != nil {
+		asn := *r.ASN
+		if !(asn >= 64512 && asn <= 65534) && !(asn >= 4200000000 && asn <= 4294967294) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("ASN"), asn, "must be a private ASN"))
+		}
+	} else if len(r.AdvertisedIPRanges) > 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("ASN"), "required when advertised IP ranges are set"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return allErrs
+}
+
+// RouterAdvertisedIPRange is a custom IP range advertised by a Cloud Router.
+type RouterAdvertisedIPRange struct {
+	// Range is the IP range to advertise, in CIDR format.
+	Range string `json:"range"`
+
+	// Description is an optional description of the advertised range.
+	// +optional
+	Description *string `json:"description,omitempty"`
 }
 
 // SubnetSpec configures an GCP Subnet.
@@ -159,7 +657,9 @@ type SubnetSpec struct {
 	Region string `json:"region,omitempty"`
 
 	// PrivateGoogleAccess defines whether VMs in this subnet can access
-	// Google services without assigning external IP addresses
+	// Google services without assigning external IP addresses.
+	// Subnets of a GCPManagedCluster enable it by default, except proxy-only subnets,
+	// so that private nodes can pull images from Container Registry and Artifact Registry.
 	// +optional
 	PrivateGoogleAccess *bool `json:"privateGoogleAccess,omitempty"`
 
@@ -187,6 +687,59 @@ type SubnetSpec struct {
 	// +kubebuilder:default=PRIVATE_RFC_1918
 	// +optional
 	Purpose *string `json:"purpose,omitempty"`
+
+	// Role is the role of a proxy-only subnet. Only the ACTIVE subnet of a region is used by the
+	// load balancer proxies, a BACKUP subnet can be promoted when the active one is drained.
+	// If unspecified, proxy-only subnets are created as ACTIVE.
+	// +kubebuilder:validation:Enum=ACTIVE;BACKUP
+	// +optional
+	Role *string `json:"role,omitempty"`
+
+	// FlowLogs configures the flow logs of the subnetwork. Flow logs are enabled when it is set.
+	// +optional
+	FlowLogs *SubnetFlowLogs `json:"flowLogs,omitempty"`
+
+	// StackType is the IP stack of the subnetwork. IPV4_IPV6 subnets are assigned an IPv6 range in addition
+	// to the IPv4 CIDR block.
+	// +kubebuilder:validation:Enum=IPV4_ONLY;IPV4_IPV6
+	// +kubebuilder:default=IPV4_ONLY
+	// +optional
+	StackType *string `json:"stackType,omitempty"`
+
+	// IPv6AccessType is the access type of the IPv6 range of a IPV4_IPV6 subnetwork. EXTERNAL ranges are
+	// reachable from the internet, INTERNAL ranges only from within the network.
+	// +kubebuilder:validation:Enum=INTERNAL;EXTERNAL
+	// +optional
+	IPv6AccessType *string `json:"ipv6AccessType,omitempty"`
+}
+
+// SubnetFlowLogs defines the flow logging configuration of a subnetwork.
+type SubnetFlowLogs struct {
+	// AggregationInterval is the interval over which flow logs are aggregated.
+	// +kubebuilder:validation:Enum=INTERVAL_5_SEC;INTERVAL_30_SEC;INTERVAL_1_MIN;INTERVAL_5_MIN;INTERVAL_10_MIN;INTERVAL_15_MIN
+	// +kubebuilder:default=INTERVAL_5_SEC
+	// +optional
+	AggregationInterval *string `json:"aggregationInterval,omitempty"`
+
+	// FlowSampling is the fraction of flows which are logged, between 0.0 and 1.0.
+	// +kubebuilder:validation:Pattern=`^(0(\.[0-9]+)?|1(\.0+)?)$`
+	// +kubebuilder:default="0.5"
+	// +optional
+	FlowSampling *string `json:"flowSampling,omitempty"`
+
+	// Metadata configures whether metadata fields are added to the flow logs.
+	// +kubebuilder:validation:Enum=INCLUDE_ALL_METADATA;EXCLUDE_ALL_METADATA;CUSTOM_METADATA
+	// +kubebuilder:default=INCLUDE_ALL_METADATA
+	// +optional
+	Metadata *string `json:"metadata,omitempty"`
+
+	// MetadataFields are the metadata fields added to the flow logs when Metadata is CUSTOM_METADATA.
+	// +optional
+	MetadataFields []string `json:"metadataFields,omitempty"`
+
+	// FilterExpr is a CEL expression selecting the flows which are logged.
+	// +optional
+	FilterExpr *string `json:"filterExpr,omitempty"`
 }
 
 // String returns a string representation of the subnet.
@@ -194,9 +747,133 @@ func (s *SubnetSpec) String() string {
 	return fmt.Sprintf("name=%s/region=%s", s.Name, s.Region)
 }
 
+// IsProxyOnlySubnetPurpose returns true if the subnet purpose is reserved for the proxies of Envoy-based load
+// balancers, as used by GKE Gateway and internal HTTP(S) load balancing.
+func IsProxyOnlySubnetPurpose(purpose string) bool {
+	return purpose == "REGIONAL_MANAGED_PROXY" || purpose == "INTERNAL_HTTPS_LOAD_BALANCER"
+}
+
+// RouteSpec configures a custom static route of the network.
+type RouteSpec struct {
+	// Name is the name of the route.
+	// +kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// DestRange is the destination range of the outgoing packets the route applies to, in CIDR format.
+	DestRange string `json:"destRange"`
+
+	// Priority breaks ties between routes of equal prefix length; lower values take precedence.
+	// Defaults to 1000.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=65535
+	// +optional
+	Priority *int64 `json:"priority,omitempty"`
+
+	// Tags restricts the route to the instances with any of the network tags. The route applies to
+	// all the instances of the network when empty.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+
+	// NextHopInstance is the instance that handles the matching packets, e.g. a NAT appliance, as
+	// zones/<zone>/instances/<name> or a full instance URL.
+	// +optional
+	NextHopInstance *string `json:"nextHopInstance,omitempty"`
+
+	// NextHopIP is the internal IP address of the instance that handles the matching packets.
+	// +optional
+	NextHopIP *string `json:"nextHopIP,omitempty"`
+
+	// NextHopILB is the internal passthrough Network Load Balancer forwarding rule that handles the
+	// matching packets, as a forwarding rule URL or IP address.
+	// +optional
+	NextHopILB *string `json:"nextHopILB,omitempty"`
+
+	// NextHopGateway sends the matching packets to the internet gateway of the network.
+	// +kubebuilder:validation:Enum=default-internet-gateway
+	// +optional
+	NextHopGateway *string `json:"nextHopGateway,omitempty"`
+}
+
+// Routes is a slice of RouteSpec.
+type Routes []RouteSpec
+
+// Validate validates the destination ranges and next hops of the routes.
+func (r Routes) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	names := map[string]bool{}
+	for i, route := range r {
+		path := fldPath.Index(i)
+		if names[route.Name] {
+			allErrs = append(allErrs, field.Duplicate(path.Child("Name"), route.Name))
+		}
+		names[route.Name] = true
+
+		if _, _, err := net.ParseCIDR(route.DestRange); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("DestRange"), route.DestRange, "must be a CIDR block"))
+		}
+
+		nextHops := 0
+		for _, nextHop := range []*string{route.NextHopInstance, route.NextHopIP, route.NextHopILB, route.NextHopGateway} {
+			if nextHop != nil {
+				nextHops++
+			}
+		}
+		if nextHops != 1 {
+			allErrs = append(allErrs, field.Invalid(path, route.Name, "exactly one next hop must be set"))
+		}
+
+		if route.NextHopIP != nil && net.ParseIP(*route.NextHopIP) == nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("NextHopIP"), *route.NextHopIP, "must be an IP address"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return allErrs
+}
+
 // Subnets is a slice of Subnet.
 type Subnets []SubnetSpec
 
+// Validate validates the role, IP stack and flow logs of the subnets.
+func (s Subnets) Validate(fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for i, subnet := range s {
+		path := fldPath.Index(i)
+		proxyOnly := subnet.Purpose != nil && IsProxyOnlySubnetPurpose(*subnet.Purpose)
+		if subnet.Role != nil && !proxyOnly {
+			allErrs = append(allErrs, field.Forbidden(path.Child("Role"), "only allowed for proxy-only subnets"))
+		}
+
+		dualStack := subnet.StackType != nil && *subnet.StackType == "IPV4_IPV6"
+		if dualStack && subnet.IPv6AccessType == nil {
+			allErrs = append(allErrs, field.Required(path.Child("IPv6AccessType"), "required for IPV4_IPV6 subnets"))
+		}
+		if !dualStack && subnet.IPv6AccessType != nil {
+			allErrs = append(allErrs, field.Forbidden(path.Child("IPv6AccessType"), "only allowed for IPV4_IPV6 subnets"))
+		}
+
+		if subnet.FlowLogs == nil {
+			continue
+		}
+		if proxyOnly {
+			allErrs = append(allErrs, field.Forbidden(path.Child("FlowLogs"), "not allowed for proxy-only subnets"))
+		}
+		if len(subnet.FlowLogs.MetadataFields) > 0 && (subnet.FlowLogs.Metadata == nil || *subnet.FlowLogs.Metadata != "CUSTOM_METADATA") {
+			allErrs = append(allErrs, field.Forbidden(path.Child("FlowLogs", "MetadataFields"), "only allowed with CUSTOM_METADATA metadata"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return allErrs
+}
+
 // ToMap returns a map from name to subnet.
 func (s Subnets) ToMap() map[string]*SubnetSpec {
 	res := make(map[string]*SubnetSpec)
@@ -307,3 +984,191 @@ type AddonsConfig struct {
 	// +optional
 	GcpFilestoreCsiDriverEnabled *bool `json:"gcpFilestoreCsiDriverEnabled,omitempty"`
 }
+
+// SnapshotScheduleSpec defines a snapshot schedule resource policy.
+type SnapshotScheduleSpec struct {
+	// Name is the name of the resource policy. Defaults to <cluster>-snapshot-schedule.
+	// +kubebuilder:validation:Pattern=`^[a-z]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// HoursInCycle takes a snapshot every given number of hours. Snapshots are taken daily when unset.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=23
+	// +optional
+	HoursInCycle *int64 `json:"hoursInCycle,omitempty"`
+
+	// StartTime is the UTC time of the first snapshot of the cycle, on the hour.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):00$`
+	// +kubebuilder:default="00:00"
+	// +optional
+	StartTime string `json:"startTime,omitempty"`
+
+	// RetentionDays is the number of days the snapshots are kept.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=14
+	// +optional
+	RetentionDays int64 `json:"retentionDays,omitempty"`
+
+	// StorageLocations is the Cloud Storage location of the snapshots, either regional or multi-regional.
+	// Defaults to the multi-region of the disk.
+	// +optional
+	StorageLocations []string `json:"storageLocations,omitempty"`
+
+	// Labels are applied to the snapshots.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// BastionSpec defines the bastion host of a cluster.
+type BastionSpec struct {
+	// InstanceType is the type of the bastion instance.
+	// +kubebuilder:default=e2-small
+	// +optional
+	InstanceType string `json:"instanceType,omitempty"`
+
+	// Image is the full reference to a valid image used for the boot disk of the bastion.
+	// Defaults to the latest Debian 12 image.
+	// +optional
+	Image *string `json:"image,omitempty"`
+
+	// Zone is the zone of the bastion instance. Defaults to the first failure domain of the cluster.
+	// +optional
+	Zone *string `json:"zone,omitempty"`
+
+	// Subnet is the name of the subnetwork of the bastion instance, in the cluster region.
+	// +optional
+	Subnet *string `json:"subnet,omitempty"`
+
+	// PublicIP assigns an external IP address to the bastion instance. Without one, the bastion is reached
+	// through Identity-Aware Proxy TCP forwarding, whose range 35.235.240.0/20 must then be allowed.
+	// +kubebuilder:default=true
+	// +optional
+	PublicIP *bool `json:"publicIP,omitempty"`
+
+	// AllowedCIDRBlocks are the CIDR blocks allowed to reach the bastion over SSH.
+	// +kubebuilder:validation:MinItems=1
+	AllowedCIDRBlocks []string `json:"allowedCIDRBlocks"`
+}
+
+// BastionStatus describes the bastion host of a cluster.
+type BastionStatus struct {
+	// Name is the name of the bastion instance.
+	Name string `json:"name"`
+
+	// Zone is the zone of the bastion instance.
+	Zone string `json:"zone"`
+
+	// PrivateIP is the internal IP address of the bastion instance.
+	// +optional
+	PrivateIP string `json:"privateIP,omitempty"`
+
+	// PublicIP is the external IP address of the bastion instance.
+	// +optional
+	PublicIP string `json:"publicIP,omitempty"`
+}
+
+// customMachineTypeRegex matches custom machine types, e.g. custom-4-8192 (N1), n2-custom-8-16384 or
+// n2d-custom-4-65536-ext with extended memory. The memory is given in MB.
+var customMachineTypeRegex = regexp.MustCompile(`^(?:([a-z0-9]+)-)?custom-(\d+)-(\d+)(-ext)?$`)
+
+// customMachineTypeLimits describes the custom machine types supported by a machine series.
+type customMachineTypeLimits struct {
+	// validVCPUs returns true if a custom machine type of the series can have the given number of vCPUs.
+	validVCPUs func(vCPUs int64) bool
+	// minMemoryPerVCPU and maxMemoryPerVCPU are the bounds of the memory per vCPU in MB.
+	minMemoryPerVCPU float64
+	maxMemoryPerVCPU float64
+	// extendedMemory is true if the series allows memory beyond maxMemoryPerVCPU.
+	extendedMemory bool
+}
+
+// customMachineTypeSeries lists the machine series supporting custom machine types.
+var customMachineTypeSeries = map[string]customMachineTypeLimits{
+	"n1": {
+		validVCPUs:       func(n int64) bool { return n == 1 || (n%2 == 0 && n <= 96) },
+		minMemoryPerVCPU: 0.9 * 1024,
+		maxMemoryPerVCPU: 6.5 * 1024,
+		extendedMemory:   true,
+	},
+	"n2": {
+		validVCPUs:       func(n int64) bool { return (n%2 == 0 && n <= 32) || (n%4 == 0 && n <= 128) },
+		minMemoryPerVCPU: 0.5 * 1024,
+		maxMemoryPerVCPU: 8 * 1024,
+		extendedMemory:   true,
+	},
+	"n2d": {
+		validVCPUs:       func(n int64) bool { return n == 2 || n == 4 || n == 8 || (n%16 == 0 && n <= 96) },
+		minMemoryPerVCPU: 0.5 * 1024,
+		maxMemoryPerVCPU: 8 * 1024,
+		extendedMemory:   true,
+	},
+	"e2": {
+		validVCPUs:       func(n int64) bool { return n%2 == 0 && n <= 32 },
+		minMemoryPerVCPU: 0.5 * 1024,
+		maxMemoryPerVCPU: 8 * 1024,
+	},
+}
+
+// IsCustomMachineType returns true if the given machine type is a custom machine type.
+func IsCustomMachineType(machineType string) bool {
+	return strings.HasPrefix(machineType, "custom-") || strings.Contains(machineType, "-custom-")
+}
+
+// MachineSeries returns the machine series of the given machine type, e.g. n2 for n2-standard-2 and
+// n2-custom-8-16384. Custom machine types without a series prefix belong to the N1 series.
+func MachineSeries(machineType string) string {
+	if strings.HasPrefix(machineType, "custom-") {
+		return "n1"
+	}
+	return strings.Split(machineType, "-")[0]
+}
+
+// ValidateCustomMachineType returns an error if the given custom machine type is malformed, or if its
+// number of vCPUs or amount of memory is not supported by its machine series. Predefined machine types
+// are not validated.
+func ValidateCustomMachineType(machineType string) error {
+	if !IsCustomMachineType(machineType) {
+		return nil
+	}
+
+	match := customMachineTypeRegex.FindStringSubmatch(machineType)
+	if match == nil {
+		return fmt.Errorf("custom machine type %q must have the format [SERIES-]custom-VCPUS-MEMORY[-ext]", machineType)
+	}
+
+	series := MachineSeries(machineType)
+	limits, ok := customMachineTypeSeries[series]
+	if !ok {
+		supported := make([]string, 0, len(customMachineTypeSeries))
+		for s := range customMachineTypeSeries {
+			supported = append(supported, s)
+		}
+		sort.Strings(supported)
+		return fmt.Errorf("custom machine type %q require a machine series in the following list: %s", machineType, supported)
+	}
+
+	vCPUs, err := strconv.ParseInt(match[2], 10, 64)
+	if err != nil || vCPUs < 1 || !limits.validVCPUs(vCPUs) {
+		return fmt.Errorf("custom machine type %q has a number of vCPUs not supported by the %s series", machineType, series)
+	}
+
+	memory, err := strconv.ParseInt(match[3], 10, 64)
+	if err != nil || memory%256 != 0 {
+		return fmt.Errorf("custom machine type %q require the memory to be a multiple of 256 MB", machineType)
+	}
+
+	extended := match[4] != ""
+	if extended && !limits.extendedMemory {
+		return fmt.Errorf("custom machine type %q has extended memory, which is not supported by the %s series", machineType, series)
+	}
+
+	memoryPerVCPU := float64(memory) / float64(vCPUs)
+	if memoryPerVCPU < limits.minMemoryPerVCPU || (!extended && memoryPerVCPU > limits.maxMemoryPerVCPU) {
+		return fmt.Errorf("custom machine type %q require between %.0f and %.0f MB of memory per vCPU for the %s series",
+			machineType, limits.minMemoryPerVCPU, limits.maxMemoryPerVCPU, series)
+	}
+
+	return nil
+}
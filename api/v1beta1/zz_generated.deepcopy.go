@@ -23,11 +23,42 @@ package v1beta1
 
 import (
 	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/errors"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *APIEndpoints) DeepCopyInto(out *APIEndpoints) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new APIEndpoints.
+func (in *APIEndpoints) DeepCopy() *APIEndpoints {
+	if in == nil {
+		return nil
+	}
+	out := new(APIEndpoints)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Accelerator) DeepCopyInto(out *Accelerator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Accelerator.
+func (in *Accelerator) DeepCopy() *Accelerator {
+	if in == nil {
+		return nil
+	}
+	out := new(Accelerator)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AddonsConfig) DeepCopyInto(out *AddonsConfig) {
 	*out = *in
@@ -64,286 +95,436 @@ func (in *AddonsConfig) DeepCopy() *AddonsConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *AttachedDiskSpec) DeepCopyInto(out *AttachedDiskSpec) {
+func (in *AdvancedMachineFeatures) DeepCopyInto(out *AdvancedMachineFeatures) {
 	*out = *in
-	if in.DeviceType != nil {
-		in, out := &in.DeviceType, &out.DeviceType
-		*out = new(DiskType)
+	if in.EnableNestedVirtualization != nil {
+		in, out := &in.EnableNestedVirtualization, &out.EnableNestedVirtualization
+		*out = new(bool)
 		**out = **in
 	}
-	if in.Size != nil {
-		in, out := &in.Size, &out.Size
+	if in.ThreadsPerCore != nil {
+		in, out := &in.ThreadsPerCore, &out.ThreadsPerCore
 		*out = new(int64)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttachedDiskSpec.
-func (in *AttachedDiskSpec) DeepCopy() *AttachedDiskSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdvancedMachineFeatures.
+func (in *AdvancedMachineFeatures) DeepCopy() *AdvancedMachineFeatures {
 	if in == nil {
 		return nil
 	}
-	out := new(AttachedDiskSpec)
+	out := new(AdvancedMachineFeatures)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *BuildParams) DeepCopyInto(out *BuildParams) {
+func (in *AllowedNamespaces) DeepCopyInto(out *AllowedNamespaces) {
 	*out = *in
-	if in.Role != nil {
-		in, out := &in.Role, &out.Role
-		*out = new(string)
-		**out = **in
+	if in.NamespaceList != nil {
+		in, out := &in.NamespaceList, &out.NamespaceList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.Additional != nil {
-		in, out := &in.Additional, &out.Additional
-		*out = make(Labels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildParams.
-func (in *BuildParams) DeepCopy() *BuildParams {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AllowedNamespaces.
+func (in *AllowedNamespaces) DeepCopy() *AllowedNamespaces {
 	if in == nil {
 		return nil
 	}
-	out := new(BuildParams)
+	out := new(AllowedNamespaces)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Filter) DeepCopyInto(out *Filter) {
+func (in *AttachedDiskSpec) DeepCopyInto(out *AttachedDiskSpec) {
 	*out = *in
-	if in.Values != nil {
-		in, out := &in.Values, &out.Values
+	if in.DeviceType != nil {
+		in, out := &in.DeviceType, &out.DeviceType
+		*out = new(DiskType)
+		**out = **in
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ProvisionedIOPS != nil {
+		in, out := &in.ProvisionedIOPS, &out.ProvisionedIOPS
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ProvisionedThroughput != nil {
+		in, out := &in.ProvisionedThroughput, &out.ProvisionedThroughput
+		*out = new(int64)
+		**out = **in
+	}
+	if in.EncryptionKey != nil {
+		in, out := &in.EncryptionKey, &out.EncryptionKey
+		*out = new(CustomerEncryptionKey)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DeviceName != nil {
+		in, out := &in.DeviceName, &out.DeviceName
+		*out = new(string)
+		**out = **in
+	}
+	if in.AutoDelete != nil {
+		in, out := &in.AutoDelete, &out.AutoDelete
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResourcePolicies != nil {
+		in, out := &in.ResourcePolicies, &out.ResourcePolicies
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Filter.
-func (in *Filter) DeepCopy() *Filter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttachedDiskSpec.
+func (in *AttachedDiskSpec) DeepCopy() *AttachedDiskSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Filter)
+	out := new(AttachedDiskSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPCluster) DeepCopyInto(out *GCPCluster) {
+func (in *AttachedDiskStatus) DeepCopyInto(out *AttachedDiskStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPCluster.
-func (in *GCPCluster) DeepCopy() *GCPCluster {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttachedDiskStatus.
+func (in *AttachedDiskStatus) DeepCopy() *AttachedDiskStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPCluster)
+	out := new(AttachedDiskStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPCluster) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterList) DeepCopyInto(out *GCPClusterList) {
+func (in *BackendServiceFailoverPolicy) DeepCopyInto(out *BackendServiceFailoverPolicy) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]GCPCluster, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.DisableConnectionDrainOnFailover != nil {
+		in, out := &in.DisableConnectionDrainOnFailover, &out.DisableConnectionDrainOnFailover
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DropTrafficIfUnhealthy != nil {
+		in, out := &in.DropTrafficIfUnhealthy, &out.DropTrafficIfUnhealthy
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FailoverRatio != nil {
+		in, out := &in.FailoverRatio, &out.FailoverRatio
+		*out = new(string)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterList.
-func (in *GCPClusterList) DeepCopy() *GCPClusterList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendServiceFailoverPolicy.
+func (in *BackendServiceFailoverPolicy) DeepCopy() *BackendServiceFailoverPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterList)
+	out := new(BackendServiceFailoverPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPClusterList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterSpec) DeepCopyInto(out *GCPClusterSpec) {
+func (in *BackendServiceLogging) DeepCopyInto(out *BackendServiceLogging) {
 	*out = *in
-	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
-	in.Network.DeepCopyInto(&out.Network)
-	if in.FailureDomains != nil {
-		in, out := &in.FailureDomains, &out.FailureDomains
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.AdditionalLabels != nil {
-		in, out := &in.AdditionalLabels, &out.AdditionalLabels
-		*out = make(Labels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.CredentialsRef != nil {
-		in, out := &in.CredentialsRef, &out.CredentialsRef
-		*out = new(ObjectReference)
+	if in.SampleRate != nil {
+		in, out := &in.SampleRate, &out.SampleRate
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterSpec.
-func (in *GCPClusterSpec) DeepCopy() *GCPClusterSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendServiceLogging.
+func (in *BackendServiceLogging) DeepCopy() *BackendServiceLogging {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterSpec)
+	out := new(BackendServiceLogging)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterStatus) DeepCopyInto(out *GCPClusterStatus) {
+func (in *BackendServiceSpec) DeepCopyInto(out *BackendServiceSpec) {
 	*out = *in
-	if in.FailureDomains != nil {
-		in, out := &in.FailureDomains, &out.FailureDomains
-		*out = make(apiv1beta1.FailureDomains, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+	if in.ConnectionDrainingTimeoutSec != nil {
+		in, out := &in.ConnectionDrainingTimeoutSec, &out.ConnectionDrainingTimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SessionAffinity != nil {
+		in, out := &in.SessionAffinity, &out.SessionAffinity
+		*out = new(string)
+		**out = **in
+	}
+	if in.IdleTimeoutSec != nil {
+		in, out := &in.IdleTimeoutSec, &out.IdleTimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FailoverPolicy != nil {
+		in, out := &in.FailoverPolicy, &out.FailoverPolicy
+		*out = new(BackendServiceFailoverPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Logging != nil {
+		in, out := &in.Logging, &out.Logging
+		*out = new(BackendServiceLogging)
+		(*in).DeepCopyInto(*out)
 	}
-	in.Network.DeepCopyInto(&out.Network)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterStatus.
-func (in *GCPClusterStatus) DeepCopy() *GCPClusterStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackendServiceSpec.
+func (in *BackendServiceSpec) DeepCopy() *BackendServiceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterStatus)
+	out := new(BackendServiceSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterTemplate) DeepCopyInto(out *GCPClusterTemplate) {
+func (in *BastionSpec) DeepCopyInto(out *BastionSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.Zone != nil {
+		in, out := &in.Zone, &out.Zone
+		*out = new(string)
+		**out = **in
+	}
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AllowedCIDRBlocks != nil {
+		in, out := &in.AllowedCIDRBlocks, &out.AllowedCIDRBlocks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplate.
-func (in *GCPClusterTemplate) DeepCopy() *GCPClusterTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BastionSpec.
+func (in *BastionSpec) DeepCopy() *BastionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterTemplate)
+	out := new(BastionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPClusterTemplate) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterTemplateList) DeepCopyInto(out *GCPClusterTemplateList) {
+func (in *BastionStatus) DeepCopyInto(out *BastionStatus) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]GCPClusterTemplate, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplateList.
-func (in *GCPClusterTemplateList) DeepCopy() *GCPClusterTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BastionStatus.
+func (in *BastionStatus) DeepCopy() *BastionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterTemplateList)
+	out := new(BastionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPClusterTemplateList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterTemplateResource) DeepCopyInto(out *GCPClusterTemplateResource) {
+func (in *BuildParams) DeepCopyInto(out *BuildParams) {
 	*out = *in
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
+	if in.Additional != nil {
+		in, out := &in.Additional, &out.Additional
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplateResource.
-func (in *GCPClusterTemplateResource) DeepCopy() *GCPClusterTemplateResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildParams.
+func (in *BuildParams) DeepCopy() *BuildParams {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterTemplateResource)
+	out := new(BuildParams)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPClusterTemplateSpec) DeepCopyInto(out *GCPClusterTemplateSpec) {
+func (in *CloudNatSpec) DeepCopyInto(out *CloudNatSpec) {
 	*out = *in
-	in.Template.DeepCopyInto(&out.Template)
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NatIPs != nil {
+		in, out := &in.NatIPs, &out.NatIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MinPortsPerVM != nil {
+		in, out := &in.MinPortsPerVM, &out.MinPortsPerVM
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LogFilter != nil {
+		in, out := &in.LogFilter, &out.LogFilter
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplateSpec.
-func (in *GCPClusterTemplateSpec) DeepCopy() *GCPClusterTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudNatSpec.
+func (in *CloudNatSpec) DeepCopy() *CloudNatSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPClusterTemplateSpec)
+	out := new(CloudNatSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachine) DeepCopyInto(out *GCPMachine) {
+func (in *CustomerEncryptionKey) DeepCopyInto(out *CustomerEncryptionKey) {
+	*out = *in
+	if in.KMSKeyServiceAccount != nil {
+		in, out := &in.KMSKeyServiceAccount, &out.KMSKeyServiceAccount
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CustomerEncryptionKey.
+func (in *CustomerEncryptionKey) DeepCopy() *CustomerEncryptionKey {
+	if in == nil {
+		return nil
+	}
+	out := new(CustomerEncryptionKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultFirewallRuleSpec) DeepCopyInto(out *DefaultFirewallRuleSpec) {
+	*out = *in
+	if in.SourceRanges != nil {
+		in, out := &in.SourceRanges, &out.SourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Protocol != nil {
+		in, out := &in.Protocol, &out.Protocol
+		*out = new(string)
+		**out = **in
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultFirewallRuleSpec.
+func (in *DefaultFirewallRuleSpec) DeepCopy() *DefaultFirewallRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultFirewallRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DefaultFirewallRulesSpec) DeepCopyInto(out *DefaultFirewallRulesSpec) {
+	*out = *in
+	if in.HealthChecks != nil {
+		in, out := &in.HealthChecks, &out.HealthChecks
+		*out = new(DefaultFirewallRuleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Cluster != nil {
+		in, out := &in.Cluster, &out.Cluster
+		*out = new(DefaultFirewallRuleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DefaultFirewallRulesSpec.
+func (in *DefaultFirewallRulesSpec) DeepCopy() *DefaultFirewallRulesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DefaultFirewallRulesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Filter) DeepCopyInto(out *Filter) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Filter.
+func (in *Filter) DeepCopy() *Filter {
+	if in == nil {
+		return nil
+	}
+	out := new(Filter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPCluster) DeepCopyInto(out *GCPCluster) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -351,18 +532,18 @@ func (in *GCPMachine) DeepCopyInto(out *GCPMachine) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachine.
-func (in *GCPMachine) DeepCopy() *GCPMachine {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPCluster.
+func (in *GCPCluster) DeepCopy() *GCPCluster {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachine)
+	out := new(GCPCluster)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPMachine) DeepCopyObject() runtime.Object {
+func (in *GCPCluster) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -370,31 +551,57 @@ func (in *GCPMachine) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachineList) DeepCopyInto(out *GCPMachineList) {
+func (in *GCPClusterIdentity) DeepCopyInto(out *GCPClusterIdentity) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterIdentity.
+func (in *GCPClusterIdentity) DeepCopy() *GCPClusterIdentity {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterIdentity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPClusterIdentity) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterIdentityList) DeepCopyInto(out *GCPClusterIdentityList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GCPMachine, len(*in))
+		*out = make([]GCPClusterIdentity, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineList.
-func (in *GCPMachineList) DeepCopy() *GCPMachineList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterIdentityList.
+func (in *GCPClusterIdentityList) DeepCopy() *GCPClusterIdentityList {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachineList)
+	out := new(GCPClusterIdentityList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPMachineList) DeepCopyObject() runtime.Object {
+func (in *GCPClusterIdentityList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -402,420 +609,1401 @@ func (in *GCPMachineList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachineSpec) DeepCopyInto(out *GCPMachineSpec) {
+func (in *GCPClusterIdentitySpec) DeepCopyInto(out *GCPClusterIdentitySpec) {
 	*out = *in
-	if in.Subnet != nil {
-		in, out := &in.Subnet, &out.Subnet
-		*out = new(string)
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		*out = new(v1.LocalObjectReference)
 		**out = **in
 	}
-	if in.ProviderID != nil {
-		in, out := &in.ProviderID, &out.ProviderID
+	if in.ImpersonateServiceAccount != nil {
+		in, out := &in.ImpersonateServiceAccount, &out.ImpersonateServiceAccount
 		*out = new(string)
 		**out = **in
 	}
-	if in.ImageFamily != nil {
-		in, out := &in.ImageFamily, &out.ImageFamily
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.QuotaProject != nil {
+		in, out := &in.QuotaProject, &out.QuotaProject
 		*out = new(string)
 		**out = **in
 	}
-	if in.Image != nil {
-		in, out := &in.Image, &out.Image
-		*out = new(string)
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = new(APIEndpoints)
 		**out = **in
 	}
-	if in.AdditionalLabels != nil {
-		in, out := &in.AdditionalLabels, &out.AdditionalLabels
-		*out = make(Labels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = new(AllowedNamespaces)
+		(*in).DeepCopyInto(*out)
 	}
-	if in.AdditionalMetadata != nil {
-		in, out := &in.AdditionalMetadata, &out.AdditionalMetadata
-		*out = make([]MetadataItem, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterIdentitySpec.
+func (in *GCPClusterIdentitySpec) DeepCopy() *GCPClusterIdentitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterIdentitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterList) DeepCopyInto(out *GCPClusterList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPCluster, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.PublicIP != nil {
-		in, out := &in.PublicIP, &out.PublicIP
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterList.
+func (in *GCPClusterList) DeepCopy() *GCPClusterList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPClusterList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterSpec) DeepCopyInto(out *GCPClusterSpec) {
+	*out = *in
+	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	in.Network.DeepCopyInto(&out.Network)
+	if in.UseExistingInfrastructure != nil {
+		in, out := &in.UseExistingInfrastructure, &out.UseExistingInfrastructure
 		*out = new(bool)
 		**out = **in
 	}
-	if in.AdditionalNetworkTags != nil {
-		in, out := &in.AdditionalNetworkTags, &out.AdditionalNetworkTags
+	if in.DefaultFirewallRules != nil {
+		in, out := &in.DefaultFirewallRules, &out.DefaultFirewallRules
+		*out = new(DefaultFirewallRulesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	in.LoadBalancer.DeepCopyInto(&out.LoadBalancer)
+	if in.SnapshotSchedule != nil {
+		in, out := &in.SnapshotSchedule, &out.SnapshotSchedule
+		*out = new(SnapshotScheduleSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Bastion != nil {
+		in, out := &in.Bastion, &out.Bastion
+		*out = new(BastionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.RootDeviceType != nil {
-		in, out := &in.RootDeviceType, &out.RootDeviceType
-		*out = new(DiskType)
-		**out = **in
+	if in.ControlPlaneFailureDomains != nil {
+		in, out := &in.ControlPlaneFailureDomains, &out.ControlPlaneFailureDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.AdditionalDisks != nil {
-		in, out := &in.AdditionalDisks, &out.AdditionalDisks
-		*out = make([]AttachedDiskSpec, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.FailureDomainMachineTypes != nil {
+		in, out := &in.FailureDomainMachineTypes, &out.FailureDomainMachineTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.ServiceAccount != nil {
-		in, out := &in.ServiceAccount, &out.ServiceAccount
-		*out = new(ServiceAccount)
-		(*in).DeepCopyInto(*out)
+	if in.AdditionalLabels != nil {
+		in, out := &in.AdditionalLabels, &out.AdditionalLabels
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
-	if in.IPForwarding != nil {
-		in, out := &in.IPForwarding, &out.IPForwarding
-		*out = new(IPForwarding)
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(ObjectReference)
 		**out = **in
 	}
-	if in.ShieldedInstanceConfig != nil {
-		in, out := &in.ShieldedInstanceConfig, &out.ShieldedInstanceConfig
-		*out = new(GCPShieldedInstanceConfig)
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(ObjectReference)
 		**out = **in
 	}
-	if in.OnHostMaintenance != nil {
-		in, out := &in.OnHostMaintenance, &out.OnHostMaintenance
-		*out = new(HostMaintenancePolicy)
+	if in.ImpersonateServiceAccount != nil {
+		in, out := &in.ImpersonateServiceAccount, &out.ImpersonateServiceAccount
+		*out = new(string)
 		**out = **in
 	}
-	if in.ConfidentialCompute != nil {
-		in, out := &in.ConfidentialCompute, &out.ConfidentialCompute
-		*out = new(ConfidentialComputePolicy)
+	if in.QuotaProject != nil {
+		in, out := &in.QuotaProject, &out.QuotaProject
+		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineSpec.
-func (in *GCPMachineSpec) DeepCopy() *GCPMachineSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterSpec.
+func (in *GCPClusterSpec) DeepCopy() *GCPClusterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterStatus) DeepCopyInto(out *GCPClusterStatus) {
+	*out = *in
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make(apiv1beta1.FailureDomains, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	in.Network.DeepCopyInto(&out.Network)
+	if in.Bastion != nil {
+		in, out := &in.Bastion, &out.Bastion
+		*out = new(BastionStatus)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterStatus.
+func (in *GCPClusterStatus) DeepCopy() *GCPClusterStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterTemplate) DeepCopyInto(out *GCPClusterTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplate.
+func (in *GCPClusterTemplate) DeepCopy() *GCPClusterTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPClusterTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterTemplateList) DeepCopyInto(out *GCPClusterTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPClusterTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplateList.
+func (in *GCPClusterTemplateList) DeepCopy() *GCPClusterTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPClusterTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterTemplateResource) DeepCopyInto(out *GCPClusterTemplateResource) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplateResource.
+func (in *GCPClusterTemplateResource) DeepCopy() *GCPClusterTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPClusterTemplateSpec) DeepCopyInto(out *GCPClusterTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPClusterTemplateSpec.
+func (in *GCPClusterTemplateSpec) DeepCopy() *GCPClusterTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPClusterTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachine) DeepCopyInto(out *GCPMachine) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachine.
+func (in *GCPMachine) DeepCopy() *GCPMachine {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachine)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPMachine) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachineList) DeepCopyInto(out *GCPMachineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPMachine, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineList.
+func (in *GCPMachineList) DeepCopy() *GCPMachineList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPMachineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachineSpec) DeepCopyInto(out *GCPMachineSpec) {
+	*out = *in
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.ProviderID != nil {
+		in, out := &in.ProviderID, &out.ProviderID
+		*out = new(string)
+		**out = **in
+	}
+	if in.ImageFamily != nil {
+		in, out := &in.ImageFamily, &out.ImageFamily
+		*out = new(string)
+		**out = **in
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(string)
+		**out = **in
+	}
+	if in.AdditionalLabels != nil {
+		in, out := &in.AdditionalLabels, &out.AdditionalLabels
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.AdditionalMetadata != nil {
+		in, out := &in.AdditionalMetadata, &out.AdditionalMetadata
+		*out = make([]MetadataItem, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OSLogin != nil {
+		in, out := &in.OSLogin, &out.OSLogin
+		*out = new(OSLoginPolicy)
+		**out = **in
+	}
+	if in.SSHKeys != nil {
+		in, out := &in.SSHKeys, &out.SSHKeys
+		*out = make([]SSHKey, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockProjectSSHKeys != nil {
+		in, out := &in.BlockProjectSSHKeys, &out.BlockProjectSSHKeys
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PublicIP != nil {
+		in, out := &in.PublicIP, &out.PublicIP
+		*out = new(bool)
+		**out = **in
+	}
+	if in.StackType != nil {
+		in, out := &in.StackType, &out.StackType
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPv6AccessType != nil {
+		in, out := &in.IPv6AccessType, &out.IPv6AccessType
+		*out = new(string)
+		**out = **in
+	}
+	if in.NicType != nil {
+		in, out := &in.NicType, &out.NicType
+		*out = new(string)
+		**out = **in
+	}
+	if in.NetworkPerformanceConfig != nil {
+		in, out := &in.NetworkPerformanceConfig, &out.NetworkPerformanceConfig
+		*out = new(NetworkPerformanceConfig)
+		**out = **in
+	}
+	if in.AdditionalNetworkTags != nil {
+		in, out := &in.AdditionalNetworkTags, &out.AdditionalNetworkTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RootDeviceType != nil {
+		in, out := &in.RootDeviceType, &out.RootDeviceType
+		*out = new(DiskType)
+		**out = **in
+	}
+	if in.RootDeviceProvisionedIOPS != nil {
+		in, out := &in.RootDeviceProvisionedIOPS, &out.RootDeviceProvisionedIOPS
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RootDeviceProvisionedThroughput != nil {
+		in, out := &in.RootDeviceProvisionedThroughput, &out.RootDeviceProvisionedThroughput
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RootDiskEncryptionKey != nil {
+		in, out := &in.RootDiskEncryptionKey, &out.RootDiskEncryptionKey
+		*out = new(CustomerEncryptionKey)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RootDeviceAutoDelete != nil {
+		in, out := &in.RootDeviceAutoDelete, &out.RootDeviceAutoDelete
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RootDeviceResourcePolicies != nil {
+		in, out := &in.RootDeviceResourcePolicies, &out.RootDeviceResourcePolicies
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalDisks != nil {
+		in, out := &in.AdditionalDisks, &out.AdditionalDisks
+		*out = make([]AttachedDiskSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LocalSSDs != nil {
+		in, out := &in.LocalSSDs, &out.LocalSSDs
+		*out = new(LocalSSDSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ServiceAccount != nil {
+		in, out := &in.ServiceAccount, &out.ServiceAccount
+		*out = new(ServiceAccount)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProvisioningModel != nil {
+		in, out := &in.ProvisioningModel, &out.ProvisioningModel
+		*out = new(ProvisioningModel)
+		**out = **in
+	}
+	if in.InstanceTerminationAction != nil {
+		in, out := &in.InstanceTerminationAction, &out.InstanceTerminationAction
+		*out = new(InstanceTerminationAction)
+		**out = **in
+	}
+	if in.MaxRunDuration != nil {
+		in, out := &in.MaxRunDuration, &out.MaxRunDuration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.NodeAffinities != nil {
+		in, out := &in.NodeAffinities, &out.NodeAffinities
+		*out = make([]NodeAffinity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.IPForwarding != nil {
+		in, out := &in.IPForwarding, &out.IPForwarding
+		*out = new(IPForwarding)
+		**out = **in
+	}
+	if in.ShieldedInstanceConfig != nil {
+		in, out := &in.ShieldedInstanceConfig, &out.ShieldedInstanceConfig
+		*out = new(GCPShieldedInstanceConfig)
+		**out = **in
+	}
+	if in.OnHostMaintenance != nil {
+		in, out := &in.OnHostMaintenance, &out.OnHostMaintenance
+		*out = new(HostMaintenancePolicy)
+		**out = **in
+	}
+	if in.AutomaticRestart != nil {
+		in, out := &in.AutomaticRestart, &out.AutomaticRestart
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConfidentialCompute != nil {
+		in, out := &in.ConfidentialCompute, &out.ConfidentialCompute
+		*out = new(ConfidentialComputePolicy)
+		**out = **in
+	}
+	if in.ConfidentialInstanceType != nil {
+		in, out := &in.ConfidentialInstanceType, &out.ConfidentialInstanceType
+		*out = new(ConfidentialInstanceType)
+		**out = **in
+	}
+	if in.AdvancedMachineFeatures != nil {
+		in, out := &in.AdvancedMachineFeatures, &out.AdvancedMachineFeatures
+		*out = new(AdvancedMachineFeatures)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MinCPUPlatform != nil {
+		in, out := &in.MinCPUPlatform, &out.MinCPUPlatform
+		*out = new(string)
+		**out = **in
+	}
+	if in.GuestAccelerators != nil {
+		in, out := &in.GuestAccelerators, &out.GuestAccelerators
+		*out = make([]Accelerator, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineSpec.
+func (in *GCPMachineSpec) DeepCopy() *GCPMachineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachineStatus) DeepCopyInto(out *GCPMachineStatus) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]v1.NodeAddress, len(*in))
+		copy(*out, *in)
+	}
+	if in.InstanceStatus != nil {
+		in, out := &in.InstanceStatus, &out.InstanceStatus
+		*out = new(InstanceStatus)
+		**out = **in
+	}
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]AttachedDiskStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailureReason != nil {
+		in, out := &in.FailureReason, &out.FailureReason
+		*out = new(errors.MachineStatusError)
+		**out = **in
+	}
+	if in.FailureMessage != nil {
+		in, out := &in.FailureMessage, &out.FailureMessage
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineStatus.
+func (in *GCPMachineStatus) DeepCopy() *GCPMachineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachineTemplate) DeepCopyInto(out *GCPMachineTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplate.
+func (in *GCPMachineTemplate) DeepCopy() *GCPMachineTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachineTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPMachineTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachineTemplateList) DeepCopyInto(out *GCPMachineTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPMachineTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplateList.
+func (in *GCPMachineTemplateList) DeepCopy() *GCPMachineTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachineTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPMachineTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachineTemplateResource) DeepCopyInto(out *GCPMachineTemplateResource) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplateResource.
+func (in *GCPMachineTemplateResource) DeepCopy() *GCPMachineTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachineTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachineTemplateSpec) DeepCopyInto(out *GCPMachineTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplateSpec.
+func (in *GCPMachineTemplateSpec) DeepCopy() *GCPMachineTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachineTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPShieldedInstanceConfig) DeepCopyInto(out *GCPShieldedInstanceConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPShieldedInstanceConfig.
+func (in *GCPShieldedInstanceConfig) DeepCopy() *GCPShieldedInstanceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPShieldedInstanceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InternalLoadBalancerSpec) DeepCopyInto(out *InternalLoadBalancerSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Subnet != nil {
+		in, out := &in.Subnet, &out.Subnet
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPAddress != nil {
+		in, out := &in.IPAddress, &out.IPAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServiceAttachment != nil {
+		in, out := &in.ServiceAttachment, &out.ServiceAttachment
+		*out = new(ServiceAttachmentSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InternalLoadBalancerSpec.
+func (in *InternalLoadBalancerSpec) DeepCopy() *InternalLoadBalancerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InternalLoadBalancerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in Labels) DeepCopyInto(out *Labels) {
+	{
+		in := &in
+		*out = make(Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Labels.
+func (in Labels) DeepCopy() Labels {
+	if in == nil {
+		return nil
+	}
+	out := new(Labels)
+	in.DeepCopyInto(out)
+	return *out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerHealthCheck) DeepCopyInto(out *LoadBalancerHealthCheck) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(string)
+		**out = **in
+	}
+	if in.Port != nil {
+		in, out := &in.Port, &out.Port
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RequestPath != nil {
+		in, out := &in.RequestPath, &out.RequestPath
+		*out = new(string)
+		**out = **in
+	}
+	if in.CheckIntervalSec != nil {
+		in, out := &in.CheckIntervalSec, &out.CheckIntervalSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TimeoutSec != nil {
+		in, out := &in.TimeoutSec, &out.TimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HealthyThreshold != nil {
+		in, out := &in.HealthyThreshold, &out.HealthyThreshold
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UnhealthyThreshold != nil {
+		in, out := &in.UnhealthyThreshold, &out.UnhealthyThreshold
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ProxyHeader != nil {
+		in, out := &in.ProxyHeader, &out.ProxyHeader
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerHealthCheck.
+func (in *LoadBalancerHealthCheck) DeepCopy() *LoadBalancerHealthCheck {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerHealthCheck)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LoadBalancerSpec) DeepCopyInto(out *LoadBalancerSpec) {
+	*out = *in
+	if in.LoadBalancerType != nil {
+		in, out := &in.LoadBalancerType, &out.LoadBalancerType
+		*out = new(LoadBalancerType)
+		**out = **in
+	}
+	if in.InternalLoadBalancer != nil {
+		in, out := &in.InternalLoadBalancer, &out.InternalLoadBalancer
+		*out = new(InternalLoadBalancerSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackendService != nil {
+		in, out := &in.BackendService, &out.BackendService
+		*out = new(BackendServiceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HealthCheck != nil {
+		in, out := &in.HealthCheck, &out.HealthCheck
+		*out = new(LoadBalancerHealthCheck)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LoadBalancerSpec.
+func (in *LoadBalancerSpec) DeepCopy() *LoadBalancerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LoadBalancerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalSSDMount) DeepCopyInto(out *LocalSSDMount) {
+	*out = *in
+	if in.Filesystem != nil {
+		in, out := &in.Filesystem, &out.Filesystem
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalSSDMount.
+func (in *LocalSSDMount) DeepCopy() *LocalSSDMount {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalSSDMount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalSSDSpec) DeepCopyInto(out *LocalSSDSpec) {
+	*out = *in
+	if in.Interface != nil {
+		in, out := &in.Interface, &out.Interface
+		*out = new(string)
+		**out = **in
+	}
+	if in.Mount != nil {
+		in, out := &in.Mount, &out.Mount
+		*out = new(LocalSSDMount)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalSSDSpec.
+func (in *LocalSSDSpec) DeepCopy() *LocalSSDSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalSSDSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetadataItem) DeepCopyInto(out *MetadataItem) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataItem.
+func (in *MetadataItem) DeepCopy() *MetadataItem {
+	if in == nil {
+		return nil
+	}
+	out := new(MetadataItem)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Network) DeepCopyInto(out *Network) {
+	*out = *in
+	if in.SelfLink != nil {
+		in, out := &in.SelfLink, &out.SelfLink
+		*out = new(string)
+		**out = **in
+	}
+	if in.FirewallRules != nil {
+		in, out := &in.FirewallRules, &out.FirewallRules
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Router != nil {
+		in, out := &in.Router, &out.Router
+		*out = new(string)
+		**out = **in
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.APIServerAddress != nil {
+		in, out := &in.APIServerAddress, &out.APIServerAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIServerHealthCheck != nil {
+		in, out := &in.APIServerHealthCheck, &out.APIServerHealthCheck
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIServerInstanceGroups != nil {
+		in, out := &in.APIServerInstanceGroups, &out.APIServerInstanceGroups
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.APIServerBackendService != nil {
+		in, out := &in.APIServerBackendService, &out.APIServerBackendService
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIServerTargetProxy != nil {
+		in, out := &in.APIServerTargetProxy, &out.APIServerTargetProxy
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIServerForwardingRule != nil {
+		in, out := &in.APIServerForwardingRule, &out.APIServerForwardingRule
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIInternalAddress != nil {
+		in, out := &in.APIInternalAddress, &out.APIInternalAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIInternalHealthCheck != nil {
+		in, out := &in.APIInternalHealthCheck, &out.APIInternalHealthCheck
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIInternalBackendService != nil {
+		in, out := &in.APIInternalBackendService, &out.APIInternalBackendService
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIInternalForwardingRule != nil {
+		in, out := &in.APIInternalForwardingRule, &out.APIInternalForwardingRule
+		*out = new(string)
+		**out = **in
+	}
+	if in.APIInternalServiceAttachment != nil {
+		in, out := &in.APIInternalServiceAttachment, &out.APIInternalServiceAttachment
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
+func (in *Network) DeepCopy() *Network {
+	if in == nil {
+		return nil
+	}
+	out := new(Network)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPerformanceConfig) DeepCopyInto(out *NetworkPerformanceConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPerformanceConfig.
+func (in *NetworkPerformanceConfig) DeepCopy() *NetworkPerformanceConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPerformanceConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.AutoCreateSubnetworks != nil {
+		in, out := &in.AutoCreateSubnetworks, &out.AutoCreateSubnetworks
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Subnets != nil {
+		in, out := &in.Subnets, &out.Subnets
+		*out = make(Subnets, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.LoadBalancerBackendPort != nil {
+		in, out := &in.LoadBalancerBackendPort, &out.LoadBalancerBackendPort
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DatapathProvider != nil {
+		in, out := &in.DatapathProvider, &out.DatapathProvider
+		*out = new(DatapathProvider)
+		**out = **in
+	}
+	if in.UseExisting != nil {
+		in, out := &in.UseExisting, &out.UseExisting
+		*out = new(bool)
+		**out = **in
+	}
+	if in.HostProject != nil {
+		in, out := &in.HostProject, &out.HostProject
+		*out = new(string)
+		**out = **in
+	}
+	if in.Router != nil {
+		in, out := &in.Router, &out.Router
+		*out = new(RouterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CloudNat != nil {
+		in, out := &in.CloudNat, &out.CloudNat
+		*out = new(CloudNatSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make(Routes, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
+func (in *NetworkSpec) DeepCopy() *NetworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAffinity) DeepCopyInto(out *NodeAffinity) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAffinity.
+func (in *NodeAffinity) DeepCopy() *NodeAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
+func (in *ObjectReference) DeepCopy() *ObjectReference {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachineSpec)
+	out := new(ObjectReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachineStatus) DeepCopyInto(out *GCPMachineStatus) {
+func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
 	*out = *in
-	if in.Addresses != nil {
-		in, out := &in.Addresses, &out.Addresses
-		*out = make([]v1.NodeAddress, len(*in))
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.InstanceStatus != nil {
-		in, out := &in.InstanceStatus, &out.InstanceStatus
-		*out = new(InstanceStatus)
+	if in.NextHopInstance != nil {
+		in, out := &in.NextHopInstance, &out.NextHopInstance
+		*out = new(string)
 		**out = **in
 	}
-	if in.FailureReason != nil {
-		in, out := &in.FailureReason, &out.FailureReason
-		*out = new(errors.MachineStatusError)
+	if in.NextHopIP != nil {
+		in, out := &in.NextHopIP, &out.NextHopIP
+		*out = new(string)
 		**out = **in
 	}
-	if in.FailureMessage != nil {
-		in, out := &in.FailureMessage, &out.FailureMessage
+	if in.NextHopILB != nil {
+		in, out := &in.NextHopILB, &out.NextHopILB
+		*out = new(string)
+		**out = **in
+	}
+	if in.NextHopGateway != nil {
+		in, out := &in.NextHopGateway, &out.NextHopGateway
 		*out = new(string)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineStatus.
-func (in *GCPMachineStatus) DeepCopy() *GCPMachineStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteSpec.
+func (in *RouteSpec) DeepCopy() *RouteSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachineStatus)
+	out := new(RouteSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachineTemplate) DeepCopyInto(out *GCPMachineTemplate) {
+func (in *RouterAdvertisedIPRange) DeepCopyInto(out *RouterAdvertisedIPRange) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Description != nil {
+		in, out := &in.Description, &out.Description
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplate.
-func (in *GCPMachineTemplate) DeepCopy() *GCPMachineTemplate {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterAdvertisedIPRange.
+func (in *RouterAdvertisedIPRange) DeepCopy() *RouterAdvertisedIPRange {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachineTemplate)
+	out := new(RouterAdvertisedIPRange)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPMachineTemplate) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachineTemplateList) DeepCopyInto(out *GCPMachineTemplateList) {
+func (in *RouterSpec) DeepCopyInto(out *RouterSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]GCPMachineTemplate, len(*in))
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.ASN != nil {
+		in, out := &in.ASN, &out.ASN
+		*out = new(int64)
+		**out = **in
+	}
+	if in.AdvertisedIPRanges != nil {
+		in, out := &in.AdvertisedIPRanges, &out.AdvertisedIPRanges
+		*out = make([]RouterAdvertisedIPRange, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplateList.
-func (in *GCPMachineTemplateList) DeepCopy() *GCPMachineTemplateList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouterSpec.
+func (in *RouterSpec) DeepCopy() *RouterSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachineTemplateList)
+	out := new(RouterSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPMachineTemplateList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachineTemplateResource) DeepCopyInto(out *GCPMachineTemplateResource) {
-	*out = *in
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+func (in Routes) DeepCopyInto(out *Routes) {
+	{
+		in := &in
+		*out = make(Routes, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplateResource.
-func (in *GCPMachineTemplateResource) DeepCopy() *GCPMachineTemplateResource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Routes.
+func (in Routes) DeepCopy() Routes {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachineTemplateResource)
+	out := new(Routes)
 	in.DeepCopyInto(out)
-	return out
+	return *out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPMachineTemplateSpec) DeepCopyInto(out *GCPMachineTemplateSpec) {
+func (in *SSHKey) DeepCopyInto(out *SSHKey) {
 	*out = *in
-	in.Template.DeepCopyInto(&out.Template)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachineTemplateSpec.
-func (in *GCPMachineTemplateSpec) DeepCopy() *GCPMachineTemplateSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHKey.
+func (in *SSHKey) DeepCopy() *SSHKey {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPMachineTemplateSpec)
+	out := new(SSHKey)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPShieldedInstanceConfig) DeepCopyInto(out *GCPShieldedInstanceConfig) {
+func (in *ServiceAccount) DeepCopyInto(out *ServiceAccount) {
 	*out = *in
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPShieldedInstanceConfig.
-func (in *GCPShieldedInstanceConfig) DeepCopy() *GCPShieldedInstanceConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccount.
+func (in *ServiceAccount) DeepCopy() *ServiceAccount {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPShieldedInstanceConfig)
+	out := new(ServiceAccount)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in Labels) DeepCopyInto(out *Labels) {
-	{
-		in := &in
-		*out = make(Labels, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
+func (in *ServiceAttachmentConsumer) DeepCopyInto(out *ServiceAttachmentConsumer) {
+	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Labels.
-func (in Labels) DeepCopy() Labels {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAttachmentConsumer.
+func (in *ServiceAttachmentConsumer) DeepCopy() *ServiceAttachmentConsumer {
 	if in == nil {
 		return nil
 	}
-	out := new(Labels)
+	out := new(ServiceAttachmentConsumer)
 	in.DeepCopyInto(out)
-	return *out
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MetadataItem) DeepCopyInto(out *MetadataItem) {
+func (in *ServiceAttachmentSpec) DeepCopyInto(out *ServiceAttachmentSpec) {
 	*out = *in
-	if in.Value != nil {
-		in, out := &in.Value, &out.Value
+	if in.NatSubnets != nil {
+		in, out := &in.NatSubnets, &out.NatSubnets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConnectionPreference != nil {
+		in, out := &in.ConnectionPreference, &out.ConnectionPreference
 		*out = new(string)
 		**out = **in
 	}
+	if in.ConsumerAcceptList != nil {
+		in, out := &in.ConsumerAcceptList, &out.ConsumerAcceptList
+		*out = make([]ServiceAttachmentConsumer, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConsumerRejectList != nil {
+		in, out := &in.ConsumerRejectList, &out.ConsumerRejectList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetadataItem.
-func (in *MetadataItem) DeepCopy() *MetadataItem {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAttachmentSpec.
+func (in *ServiceAttachmentSpec) DeepCopy() *ServiceAttachmentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MetadataItem)
+	out := new(ServiceAttachmentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Network) DeepCopyInto(out *Network) {
+func (in *SnapshotScheduleSpec) DeepCopyInto(out *SnapshotScheduleSpec) {
 	*out = *in
-	if in.SelfLink != nil {
-		in, out := &in.SelfLink, &out.SelfLink
-		*out = new(string)
-		**out = **in
-	}
-	if in.FirewallRules != nil {
-		in, out := &in.FirewallRules, &out.FirewallRules
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-	if in.Router != nil {
-		in, out := &in.Router, &out.Router
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
 		*out = new(string)
 		**out = **in
 	}
-	if in.APIServerAddress != nil {
-		in, out := &in.APIServerAddress, &out.APIServerAddress
-		*out = new(string)
+	if in.HoursInCycle != nil {
+		in, out := &in.HoursInCycle, &out.HoursInCycle
+		*out = new(int64)
 		**out = **in
 	}
-	if in.APIServerHealthCheck != nil {
-		in, out := &in.APIServerHealthCheck, &out.APIServerHealthCheck
-		*out = new(string)
-		**out = **in
+	if in.StorageLocations != nil {
+		in, out := &in.StorageLocations, &out.StorageLocations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.APIServerInstanceGroups != nil {
-		in, out := &in.APIServerInstanceGroups, &out.APIServerInstanceGroups
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
 		*out = make(map[string]string, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
-	if in.APIServerBackendService != nil {
-		in, out := &in.APIServerBackendService, &out.APIServerBackendService
-		*out = new(string)
-		**out = **in
-	}
-	if in.APIServerTargetProxy != nil {
-		in, out := &in.APIServerTargetProxy, &out.APIServerTargetProxy
-		*out = new(string)
-		**out = **in
-	}
-	if in.APIServerForwardingRule != nil {
-		in, out := &in.APIServerForwardingRule, &out.APIServerForwardingRule
-		*out = new(string)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Network.
-func (in *Network) DeepCopy() *Network {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SnapshotScheduleSpec.
+func (in *SnapshotScheduleSpec) DeepCopy() *SnapshotScheduleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Network)
+	out := new(SnapshotScheduleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NetworkSpec) DeepCopyInto(out *NetworkSpec) {
+func (in *SubnetFlowLogs) DeepCopyInto(out *SubnetFlowLogs) {
 	*out = *in
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
+	if in.AggregationInterval != nil {
+		in, out := &in.AggregationInterval, &out.AggregationInterval
 		*out = new(string)
 		**out = **in
 	}
-	if in.AutoCreateSubnetworks != nil {
-		in, out := &in.AutoCreateSubnetworks, &out.AutoCreateSubnetworks
-		*out = new(bool)
-		**out = **in
-	}
-	if in.Subnets != nil {
-		in, out := &in.Subnets, &out.Subnets
-		*out = make(Subnets, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.LoadBalancerBackendPort != nil {
-		in, out := &in.LoadBalancerBackendPort, &out.LoadBalancerBackendPort
-		*out = new(int32)
+	if in.FlowSampling != nil {
+		in, out := &in.FlowSampling, &out.FlowSampling
+		*out = new(string)
 		**out = **in
 	}
-	if in.DatapathProvider != nil {
-		in, out := &in.DatapathProvider, &out.DatapathProvider
-		*out = new(DatapathProvider)
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(string)
 		**out = **in
 	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkSpec.
-func (in *NetworkSpec) DeepCopy() *NetworkSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(NetworkSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
-func (in *ObjectReference) DeepCopy() *ObjectReference {
-	if in == nil {
-		return nil
-	}
-	out := new(ObjectReference)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ServiceAccount) DeepCopyInto(out *ServiceAccount) {
-	*out = *in
-	if in.Scopes != nil {
-		in, out := &in.Scopes, &out.Scopes
+	if in.MetadataFields != nil {
+		in, out := &in.MetadataFields, &out.MetadataFields
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.FilterExpr != nil {
+		in, out := &in.FilterExpr, &out.FilterExpr
+		*out = new(string)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccount.
-func (in *ServiceAccount) DeepCopy() *ServiceAccount {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetFlowLogs.
+func (in *SubnetFlowLogs) DeepCopy() *SubnetFlowLogs {
 	if in == nil {
 		return nil
 	}
-	out := new(ServiceAccount)
+	out := new(SubnetFlowLogs)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -850,6 +2038,26 @@ func (in *SubnetSpec) DeepCopyInto(out *SubnetSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Role != nil {
+		in, out := &in.Role, &out.Role
+		*out = new(string)
+		**out = **in
+	}
+	if in.FlowLogs != nil {
+		in, out := &in.FlowLogs, &out.FlowLogs
+		*out = new(SubnetFlowLogs)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StackType != nil {
+		in, out := &in.StackType, &out.StackType
+		*out = new(string)
+		**out = **in
+	}
+	if in.IPv6AccessType != nil {
+		in, out := &in.IPv6AccessType, &out.IPv6AccessType
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SubnetSpec.
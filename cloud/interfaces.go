@@ -22,6 +22,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"google.golang.org/api/compute/v1"
 	corev1 "k8s.io/api/core/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -51,11 +52,15 @@ type Client interface {
 // ClusterGetter is an interface which can get cluster information.
 type ClusterGetter interface {
 	Client
+	ComputeService() *compute.Service
 	Project() string
 	Region() string
 	Name() string
 	Namespace() string
 	NetworkName() string
+	NetworkProject() string
+	IsSharedVpc() bool
+	IsExistingNetwork() bool
 	Network() *infrav1.Network
 	AdditionalLabels() infrav1.Labels
 	FailureDomains() clusterv1.FailureDomains
@@ -91,12 +96,13 @@ type MachineGetter interface {
 
 // MachineSetter is an interface which can set machine information.
 type MachineSetter interface {
-	SetProviderID()
+	SetProviderID(instanceURL string)
 	SetInstanceStatus(v infrav1.InstanceStatus)
 	SetFailureMessage(v error)
 	SetFailureReason(v capierrors.MachineStatusError)
 	SetAnnotation(key, value string)
 	SetAddresses(addressList []corev1.NodeAddress)
+	SetDisks(disks []infrav1.AttachedDiskStatus)
 }
 
 // Machine is an interface which can get and set machine information.
@@ -104,3 +110,30 @@ type Machine interface {
 	MachineGetter
 	MachineSetter
 }
+
+// MachinePoolGetter is an interface which can get machine pool information.
+type MachinePoolGetter interface {
+	Client
+	Name() string
+	Namespace() string
+	ClusterName() string
+	Zone() string
+	Project() string
+	Replicas() int64
+	GetBootstrapData() (string, error)
+}
+
+// MachinePoolSetter is an interface which can set machine pool information.
+type MachinePoolSetter interface {
+	SetReady()
+	SetNotReady()
+	SetReplicas(replicas int32)
+	SetProviderIDList(providerIDs []string)
+	SetInstanceTemplate(name string)
+}
+
+// MachinePool is an interface which can get and set machine pool information.
+type MachinePool interface {
+	MachinePoolGetter
+	MachinePoolSetter
+}
@@ -15,4 +15,10 @@ limitations under the License.
 */
 
 // Package providerid implements functionality for creating kubernetes provider ids for nodes.
+//
+// Provider ids have the gce://<project>/<zone>/<instance name> format set by the GCP cloud provider on the
+// nodes. They are matched against the nodes as strings, so the machine and machine pool controllers build them
+// with this package only. The project is the one of the instance, which is taken from its self link whenever
+// possible since it can differ from the project configured for the cluster, e.g. when the cluster project is
+// given by number.
 package providerid
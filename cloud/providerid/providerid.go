@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"strings"
 
 	"sigs.k8s.io/cluster-api-provider-gcp/util/resourceurl"
 )
@@ -47,6 +48,20 @@ func NewFromResourceURL(url string) (ProviderID, error) {
 	return New(resourceURL.Project, resourceURL.Location, resourceURL.Name)
 }
 
+// Parse parses a provider id of the gce://<project>/<location>/<name> format.
+func Parse(id string) (ProviderID, error) {
+	if !strings.HasPrefix(id, Prefix) {
+		return nil, fmt.Errorf("provider id %q must start with %s", id, Prefix)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(id, Prefix), "/")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("provider id %q must have the %s<project>/<location>/<name> format", id, Prefix)
+	}
+
+	return New(parts[0], parts[1], parts[2])
+}
+
 // New creates a new provider id.
 func New(project, location, name string) (ProviderID, error) {
 	if project == "" {
@@ -114,3 +114,50 @@ func TestProviderID_NewFromResourceURL(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderID_Parse(t *testing.T) {
+	RegisterTestingT(t)
+
+	testCases := []struct {
+		testname    string
+		id          string
+		expectError bool
+	}{
+		{
+			testname:    "wrong prefix, should fail",
+			id:          "aws://proj1/eu-west4-a/vm1",
+			expectError: true,
+		},
+		{
+			testname:    "missing location, should fail",
+			id:          "gce://proj1/vm1",
+			expectError: true,
+		},
+		{
+			testname:    "empty name, should fail",
+			id:          "gce://proj1/eu-west4-a/",
+			expectError: true,
+		},
+		{
+			testname:    "with all details, should pass",
+			id:          "gce://proj1/eu-west4-a/vm1",
+			expectError: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testname, func(t *testing.T) {
+			providerID, err := providerid.Parse(tc.id)
+
+			if tc.expectError {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(providerID.Project()).To(Equal("proj1"))
+				Expect(providerID.Location()).To(Equal("eu-west4-a"))
+				Expect(providerID.Name()).To(Equal("vm1"))
+				Expect(providerID.String()).To(Equal(tc.id))
+			}
+		})
+	}
+}
@@ -19,6 +19,7 @@ package scope
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	computerest "cloud.google.com/go/compute/apiv1"
@@ -28,15 +29,44 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/option"
+	"google.golang.org/api/servicenetworking/v1"
 	"k8s.io/client-go/pkg/version"
 	"k8s.io/client-go/util/flowcontrol"
-	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 )
 
+var gcpEndpoints infrav1.APIEndpoints
+
+// SetAPIEndpoints overrides the endpoints of the GCP APIs called by the controller. Empty endpoints keep the
+// default endpoints of the clients.
+func SetAPIEndpoints(endpoints infrav1.APIEndpoints) {
+	gcpEndpoints = endpoints
+}
+
+// computeEndpoint returns the base URL of the compute API, or an empty string for the default endpoint.
+func (s *credentialsSource) computeEndpoint() string {
+	if s.endpoints.Compute != "" {
+		return s.endpoints.Compute
+	}
+
+	return gcpEndpoints.Compute
+}
+
+// containerEndpoint returns the address of the container API, or an empty string for the default endpoint.
+func (s *credentialsSource) containerEndpoint() string {
+	if s.endpoints.Container != "" {
+		return s.endpoints.Container
+	}
+
+	return gcpEndpoints.Container
+}
+
 // GCPServices contains all the gcp services used by the scopes.
 type GCPServices struct {
-	Compute *compute.Service
+	Compute           *compute.Service
+	ServiceNetworking *servicenetworking.APIService
 }
 
 // GCPRateLimiter implements cloud.RateLimiter.
@@ -72,28 +102,42 @@ func newCloud(project string, service GCPServices) cloud.Cloud {
 	})
 }
 
-func defaultClientOptions(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) ([]option.ClientOption, error) {
+func defaultClientOptions(ctx context.Context, source *credentialsSource, crClient client.Client) ([]option.ClientOption, error) {
 	opts := []option.ClientOption{
 		option.WithUserAgent(fmt.Sprintf("gcp.cluster.x-k8s.io/%s", version.Get())),
 	}
 
-	if credentialsRef != nil {
-		rawData, err := getCredentialDataFromRef(ctx, credentialsRef, crClient)
-		if err != nil {
-			return nil, fmt.Errorf("getting gcp credentials from reference %s: %w", credentialsRef, err)
-		}
-		opts = append(opts, option.WithCredentialsJSON(rawData.JSON))
+	// The credentials are always passed explicitly, so that the clients do not load the possibly empty
+	// credentials file of the controller on their own.
+	creds, err := getCredentialData(ctx, source, crClient)
+	if err != nil {
+		return nil, err
+	}
+
+	opts = append(opts, option.WithCredentials(creds))
+	if source.quotaProject != "" {
+		opts = append(opts, option.WithQuotaProject(source.quotaProject))
 	}
 
 	return opts, nil
 }
 
-func newComputeService(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) (*compute.Service, error) {
-	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
+func newComputeService(ctx context.Context, source *credentialsSource, crClient client.Client) (*compute.Service, error) {
+	opts, err := defaultClientOptions(ctx, source, crClient)
 	if err != nil {
 		return nil, fmt.Errorf("getting default gcp client options: %w", err)
 	}
 
+	if endpoint := source.computeEndpoint(); endpoint != "" {
+		// Unlike the REST clients, the compute service expects the base path of the API version.
+		opts = append(opts, option.WithEndpoint(strings.TrimSuffix(endpoint, "/")+"/compute/v1/"))
+	}
+
+	opts, err = withHTTPTransport(ctx, opts, computeRateLimiter)
+	if err != nil {
+		return nil, err
+	}
+
 	computeSvc, err := compute.NewService(ctx, opts...)
 	if err != nil {
 		return nil, fmt.Errorf("creating new compute service instance: %w", err)
@@ -102,12 +146,18 @@ func newComputeService(ctx context.Context, credentialsRef *infrav1.ObjectRefere
 	return computeSvc, nil
 }
 
-func newClusterManagerClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) (*container.ClusterManagerClient, error) {
-	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
+func newClusterManagerClient(ctx context.Context, source *credentialsSource, crClient client.Client) (*container.ClusterManagerClient, error) {
+	opts, err := defaultClientOptions(ctx, source, crClient)
 	if err != nil {
 		return nil, fmt.Errorf("getting default gcp client options: %w", err)
 	}
 
+	if endpoint := source.containerEndpoint(); endpoint != "" {
+		opts = append(opts, option.WithEndpoint(endpoint))
+	}
+
+	opts = withGRPCTransport(opts, containerRateLimiter)
+
 	managedClusterClient, err := container.NewClusterManagerClient(ctx, opts...)
 	if err != nil {
 		return nil, errors.Errorf("failed to create gcp cluster manager client: %v", err)
@@ -116,12 +166,14 @@ func newClusterManagerClient(ctx context.Context, credentialsRef *infrav1.Object
 	return managedClusterClient, nil
 }
 
-func newIamCredentialsClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) (*credentials.IamCredentialsClient, error) {
-	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
+func newIamCredentialsClient(ctx context.Context, source *credentialsSource, crClient client.Client) (*credentials.IamCredentialsClient, error) {
+	opts, err := defaultClientOptions(ctx, source, crClient)
 	if err != nil {
 		return nil, fmt.Errorf("getting default gcp client options: %w", err)
 	}
 
+	opts = withGRPCTransport(opts, nil)
+
 	credentialsClient, err := credentials.NewIamCredentialsClient(ctx, opts...)
 	if err != nil {
 		return nil, errors.Errorf("failed to create gcp ciam credentials client: %v", err)
@@ -130,12 +182,21 @@ func newIamCredentialsClient(ctx context.Context, credentialsRef *infrav1.Object
 	return credentialsClient, nil
 }
 
-func newInstanceGroupManagerClient(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) (*computerest.InstanceGroupManagersClient, error) {
-	opts, err := defaultClientOptions(ctx, credentialsRef, crClient)
+func newInstanceGroupManagerClient(ctx context.Context, source *credentialsSource, crClient client.Client) (*computerest.InstanceGroupManagersClient, error) {
+	opts, err := defaultClientOptions(ctx, source, crClient)
 	if err != nil {
 		return nil, fmt.Errorf("getting default gcp client options: %w", err)
 	}
 
+	if endpoint := source.computeEndpoint(); endpoint != "" {
+		opts = append(opts, option.WithEndpoint(endpoint))
+	}
+
+	opts, err = withHTTPTransport(ctx, opts, computeRateLimiter)
+	if err != nil {
+		return nil, err
+	}
+
 	instanceGroupManagersClient, err := computerest.NewInstanceGroupManagersRESTClient(ctx, opts...)
 	if err != nil {
 		return nil, errors.Errorf("failed to create gcp instance group managers rest client: %v", err)
@@ -143,3 +204,45 @@ func newInstanceGroupManagerClient(ctx context.Context, credentialsRef *infrav1.
 
 	return instanceGroupManagersClient, nil
 }
+
+func newMachineTypesClient(ctx context.Context, source *credentialsSource, crClient client.Client) (*computerest.MachineTypesClient, error) {
+	opts, err := defaultClientOptions(ctx, source, crClient)
+	if err != nil {
+		return nil, fmt.Errorf("getting default gcp client options: %w", err)
+	}
+
+	if endpoint := source.computeEndpoint(); endpoint != "" {
+		opts = append(opts, option.WithEndpoint(endpoint))
+	}
+
+	opts, err = withHTTPTransport(ctx, opts, computeRateLimiter)
+	if err != nil {
+		return nil, err
+	}
+
+	machineTypesClient, err := computerest.NewMachineTypesRESTClient(ctx, opts...)
+	if err != nil {
+		return nil, errors.Errorf("failed to create gcp machine types rest client: %v", err)
+	}
+
+	return machineTypesClient, nil
+}
+
+func newServiceNetworkingService(ctx context.Context, source *credentialsSource, crClient client.Client) (*servicenetworking.APIService, error) {
+	opts, err := defaultClientOptions(ctx, source, crClient)
+	if err != nil {
+		return nil, fmt.Errorf("getting default gcp client options: %w", err)
+	}
+
+	opts, err = withHTTPTransport(ctx, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	serviceNetworkingSvc, err := servicenetworking.NewService(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating new service networking service instance: %w", err)
+	}
+
+	return serviceNetworkingSvc, nil
+}
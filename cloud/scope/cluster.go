@@ -19,7 +19,9 @@ package scope
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -51,7 +53,12 @@ func NewClusterScope(ctx context.Context, params ClusterScopeParams) (*ClusterSc
 	}
 
 	if params.GCPServices.Compute == nil {
-		computeSvc, err := newComputeService(ctx, params.GCPCluster.Spec.CredentialsRef, params.Client)
+		credsSource, err := getClusterCredentialsSource(ctx, params.Client, params.GCPCluster.Namespace, params.GCPCluster.Spec.CredentialsRef, params.GCPCluster.Spec.IdentityRef, params.GCPCluster.Spec.ImpersonateServiceAccount, params.GCPCluster.Spec.QuotaProject)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get gcp credentials")
+		}
+
+		computeSvc, err := newComputeService(ctx, credsSource, params.Client)
 		if err != nil {
 			return nil, errors.Errorf("failed to create gcp compute client: %v", err)
 		}
@@ -90,6 +97,11 @@ func (s *ClusterScope) Cloud() cloud.Cloud {
 	return newCloud(s.Project(), s.GCPServices)
 }
 
+// ComputeService returns the compute service, for the resources not covered by Cloud.
+func (s *ClusterScope) ComputeService() *compute.Service {
+	return s.GCPServices.Compute
+}
+
 // Project returns the current project name.
 func (s *ClusterScope) Project() string {
 	return s.GCPCluster.Spec.Project
@@ -115,9 +127,30 @@ func (s *ClusterScope) NetworkName() string {
 	return pointer.StringDeref(s.GCPCluster.Spec.Network.Name, "default")
 }
 
+// NetworkProject returns the project of the cluster network, which is the host project for shared VPC networks.
+func (s *ClusterScope) NetworkProject() string {
+	return pointer.StringDeref(s.GCPCluster.Spec.Network.HostProject, s.Project())
+}
+
+// IsSharedVpc returns true if the cluster network is a shared VPC network of a host project.
+func (s *ClusterScope) IsSharedVpc() bool {
+	return s.NetworkProject() != s.Project()
+}
+
+// IsExistingNetwork returns true if the cluster network and subnets already exist and are not managed by CAPG.
+func (s *ClusterScope) IsExistingNetwork() bool {
+	return pointer.BoolDeref(s.GCPCluster.Spec.Network.UseExisting, false) || s.IsExistingInfrastructure()
+}
+
+// IsExistingInfrastructure returns true if the cluster network, firewall rules and load balancer already exist
+// and are not managed by CAPG.
+func (s *ClusterScope) IsExistingInfrastructure() bool {
+	return pointer.BoolDeref(s.GCPCluster.Spec.UseExistingInfrastructure, false)
+}
+
 // NetworkLink returns the partial URL for the network.
 func (s *ClusterScope) NetworkLink() string {
-	return fmt.Sprintf("projects/%s/global/networks/%s", s.Project(), s.NetworkName())
+	return fmt.Sprintf("projects/%s/global/networks/%s", s.NetworkProject(), s.NetworkName())
 }
 
 // Network returns the cluster network object.
@@ -137,14 +170,31 @@ func (s *ClusterScope) ControlPlaneEndpoint() clusterv1.APIEndpoint {
 	if c := s.Cluster.Spec.ClusterNetwork; c != nil {
 		endpoint.Port = pointer.Int32Deref(c.APIServerPort, 443)
 	}
+	if s.LoadBalancerType() == infrav1.LoadBalancerTypeInternal {
+		// Passthrough load balancers do not translate ports, the API server is reached on the backend port.
+		endpoint.Port = pointer.Int32Deref(s.GCPCluster.Spec.Network.LoadBalancerBackendPort, 6443)
+	}
 	return endpoint
 }
 
+// LoadBalancerType returns the kind of load balancer fronting the control plane.
+func (s *ClusterScope) LoadBalancerType() infrav1.LoadBalancerType {
+	if lbType := s.GCPCluster.Spec.LoadBalancer.LoadBalancerType; lbType != nil {
+		return *lbType
+	}
+	return infrav1.LoadBalancerTypeExternal
+}
+
 // FailureDomains returns the cluster failure domains.
 func (s *ClusterScope) FailureDomains() clusterv1.FailureDomains {
 	return s.GCPCluster.Status.FailureDomains
 }
 
+// Bastion returns the status of the cluster bastion host.
+func (s *ClusterScope) Bastion() *infrav1.BastionStatus {
+	return s.GCPCluster.Status.Bastion
+}
+
 // ANCHOR_END: ClusterGetter
 
 // ANCHOR: ClusterSetter
@@ -164,6 +214,11 @@ func (s *ClusterScope) SetControlPlaneEndpoint(endpoint clusterv1.APIEndpoint) {
 	s.GCPCluster.Spec.ControlPlaneEndpoint = endpoint
 }
 
+// SetBastion sets the status of the cluster bastion host.
+func (s *ClusterScope) SetBastion(bastion *infrav1.BastionStatus) {
+	s.GCPCluster.Status.Bastion = bastion
+}
+
 // ANCHOR_END: ClusterSetter
 
 // ANCHOR: ClusterNetworkSpec
@@ -175,6 +230,7 @@ func (s *ClusterScope) NetworkSpec() *compute.Network {
 		Name:                  s.NetworkName(),
 		Description:           infrav1.ClusterTagKey(s.Name()),
 		AutoCreateSubnetworks: createSubnet,
+		Mtu:                   pointer.Int64Deref(s.GCPCluster.Spec.Network.MTU, 0),
 		ForceSendFields:       []string{"AutoCreateSubnetworks"},
 	}
 
@@ -184,16 +240,82 @@ func (s *ClusterScope) NetworkSpec() *compute.Network {
 // NatRouterSpec returns google compute nat router spec.
 func (s *ClusterScope) NatRouterSpec() *compute.Router {
 	networkSpec := s.NetworkSpec()
+	router := s.RouterSpec()
+	if router == nil {
+		router = &compute.Router{
+			Name: fmt.Sprintf("%s-%s", networkSpec.Name, "router"),
+		}
+	}
+	router.Nats = []*compute.RouterNat{
+		cloudNatSpec(fmt.Sprintf("%s-%s", networkSpec.Name, "nat"), s.Project(), s.Region(), s.GCPCluster.Spec.Network.CloudNat),
+	}
+
+	return router
+}
+
+// CloudNatEnabled returns true if the Cloud NAT of the network is managed by CAPG.
+func (s *ClusterScope) CloudNatEnabled() bool {
+	return !s.IsExistingInfrastructure() && cloudNatEnabled(s.GCPCluster.Spec.Network)
+}
+
+// cloudNatEnabled returns true if the Cloud NAT is enabled, which is the default for networks created by CAPG.
+func cloudNatEnabled(network infrav1.NetworkSpec) bool {
+	if network.CloudNat != nil && network.CloudNat.Enabled != nil {
+		return *network.CloudNat.Enabled
+	}
+	return !pointer.BoolDeref(network.UseExisting, false) && network.HostProject == nil
+}
+
+// cloudNatSpec returns google compute router nat spec.
+func cloudNatSpec(name, project, region string, spec *infrav1.CloudNatSpec) *compute.RouterNat {
+	nat := &compute.RouterNat{
+		Name:                          name,
+		NatIpAllocateOption:           "AUTO_ONLY",
+		SourceSubnetworkIpRangesToNat: "ALL_SUBNETWORKS_ALL_IP_RANGES",
+	}
+	if spec == nil {
+		return nat
+	}
+
+	if len(spec.NatIPs) > 0 {
+		nat.NatIpAllocateOption = "MANUAL_ONLY"
+		for _, address := range spec.NatIPs {
+			nat.NatIps = append(nat.NatIps, fmt.Sprintf("projects/%s/regions/%s/addresses/%s", project, region, address))
+		}
+	}
+	nat.MinPortsPerVm = pointer.Int64Deref(spec.MinPortsPerVM, 0)
+	if spec.LogFilter != nil {
+		nat.LogConfig = &compute.RouterNatLogConfig{
+			Enable: true,
+			Filter: *spec.LogFilter,
+		}
+	}
+
+	return nat
+}
+
+// RouterSpec returns google compute router spec, or nil if no router is configured.
+func (s *ClusterScope) RouterSpec() *compute.Router {
+	routerSpec := s.GCPCluster.Spec.Network.Router
+	if routerSpec == nil || s.IsExistingInfrastructure() {
+		return nil
+	}
+
 	return &compute.Router{
-		Name: fmt.Sprintf("%s-%s", networkSpec.Name, "router"),
-		Nats: []*compute.RouterNat{
-			{
-				Name:                          fmt.Sprintf("%s-%s", networkSpec.Name, "nat"),
-				NatIpAllocateOption:           "AUTO_ONLY",
-				SourceSubnetworkIpRangesToNat: "ALL_SUBNETWORKS_ALL_IP_RANGES",
-			},
-		},
+		Name:        pointer.StringDeref(routerSpec.Name, fmt.Sprintf("%s-%s", s.NetworkName(), "router")),
+		Description: infrav1.ClusterTagKey(s.Name()),
+		Network:     s.NetworkLink(),
+		Bgp:         routerBgpSpec(routerSpec),
+	}
+}
+
+// RouteSpecs returns google compute routes spec.
+func (s *ClusterScope) RouteSpecs() []*compute.Route {
+	if s.IsExistingInfrastructure() {
+		return nil
 	}
+
+	return routesSpec(s.GCPCluster.Spec.Network.Routes, s.Project(), s.NetworkLink(), infrav1.ClusterTagKey(s.Name()))
 }
 
 // ANCHOR_END: ClusterNetworkSpec
@@ -206,69 +328,219 @@ func (s *ClusterScope) SubnetSpecs() []*compute.Subnetwork {
 		for _, secondaryCidrBlock := range subnetwork.SecondaryCidrBlocks {
 			secondaryIPRanges = append(secondaryIPRanges, &compute.SubnetworkSecondaryRange{IpCidrRange: secondaryCidrBlock})
 		}
+		purpose := pointer.StringDeref(subnetwork.Purpose, "PRIVATE_RFC_1918")
 		subnets = append(subnets, &compute.Subnetwork{
 			Name:                  subnetwork.Name,
 			Region:                subnetwork.Region,
-			EnableFlowLogs:        pointer.BoolDeref(subnetwork.EnableFlowLogs, false),
+			EnableFlowLogs:        pointer.BoolDeref(subnetwork.EnableFlowLogs, false) || subnetwork.FlowLogs != nil,
+			LogConfig:             subnetLogConfigSpec(subnetwork.FlowLogs),
 			PrivateIpGoogleAccess: pointer.BoolDeref(subnetwork.PrivateGoogleAccess, false),
 			IpCidrRange:           subnetwork.CidrBlock,
 			SecondaryIpRanges:     secondaryIPRanges,
 			Description:           pointer.StringDeref(subnetwork.Description, infrav1.ClusterTagKey(s.Name())),
 			Network:               s.NetworkLink(),
-			Purpose:               pointer.StringDeref(subnetwork.Purpose, "PRIVATE_RFC_1918"),
-			Role:                  "ACTIVE",
+			Purpose:               purpose,
+			Role:                  subnetRole(purpose, subnetwork.Role),
+			StackType:             pointer.StringDeref(subnetwork.StackType, ""),
+			Ipv6AccessType:        pointer.StringDeref(subnetwork.IPv6AccessType, ""),
 		})
 	}
 
 	return subnets
 }
 
+// routerBgpSpec returns the google compute BGP spec of the router, or nil if no BGP setting is configured.
+func routerBgpSpec(routerSpec *infrav1.RouterSpec) *compute.RouterBgp {
+	if routerSpec.ASN == nil && len(routerSpec.AdvertisedIPRanges) == 0 {
+		return nil
+	}
+
+	bgp := &compute.RouterBgp{
+		Asn:           pointer.Int64Deref(routerSpec.ASN, 0),
+		AdvertiseMode: "DEFAULT",
+	}
+	if len(routerSpec.AdvertisedIPRanges) > 0 {
+		bgp.AdvertiseMode = "CUSTOM"
+		bgp.AdvertisedGroups = []string{"ALL_SUBNETS"}
+		for _, advertisedIPRange := range routerSpec.AdvertisedIPRanges {
+			bgp.AdvertisedIpRanges = append(bgp.AdvertisedIpRanges, &compute.RouterAdvertisedIpRange{
+				Range:       advertisedIPRange.Range,
+				Description: pointer.StringDeref(advertisedIPRange.Description, ""),
+			})
+		}
+	}
+
+	return bgp
+}
+
+// routesSpec returns the google compute routes of the network. Partial instance URLs of the next hops are
+// resolved in the given project.
+func routesSpec(routes infrav1.Routes, project, network, description string) []*compute.Route {
+	specs := make([]*compute.Route, 0, len(routes))
+	for _, route := range routes {
+		spec := &compute.Route{
+			Name:        route.Name,
+			Description: description,
+			Network:     network,
+			DestRange:   route.DestRange,
+			Priority:    pointer.Int64Deref(route.Priority, 1000),
+			Tags:        route.Tags,
+			NextHopIp:   pointer.StringDeref(route.NextHopIP, ""),
+			NextHopIlb:  pointer.StringDeref(route.NextHopILB, ""),
+		}
+		// A zero priority is valid, so it is always sent.
+		spec.ForceSendFields = []string{"Priority"}
+		if route.NextHopInstance != nil {
+			spec.NextHopInstance = *route.NextHopInstance
+			if strings.HasPrefix(spec.NextHopInstance, "zones/") {
+				spec.NextHopInstance = fmt.Sprintf("projects/%s/%s", project, spec.NextHopInstance)
+			}
+		}
+		if route.NextHopGateway != nil {
+			spec.NextHopGateway = fmt.Sprintf("projects/%s/global/gateways/%s", project, *route.NextHopGateway)
+		}
+		specs = append(specs, spec)
+	}
+
+	return specs
+}
+
+// subnetRole returns the role of a subnet with the given purpose. Only proxy-only subnets have a role, which
+// defaults to ACTIVE.
+func subnetRole(purpose string, role *string) string {
+	if infrav1.IsProxyOnlySubnetPurpose(purpose) {
+		return pointer.StringDeref(role, "ACTIVE")
+	}
+	return ""
+}
+
+// subnetLogConfigSpec returns the google compute log config of the subnet, or nil if flow logs are not configured.
+func subnetLogConfigSpec(flowLogs *infrav1.SubnetFlowLogs) *compute.SubnetworkLogConfig {
+	if flowLogs == nil {
+		return nil
+	}
+
+	// The sampling is validated by the CRD pattern.
+	flowSampling, _ := strconv.ParseFloat(pointer.StringDeref(flowLogs.FlowSampling, "0.5"), 64)
+	return &compute.SubnetworkLogConfig{
+		Enable:              true,
+		AggregationInterval: pointer.StringDeref(flowLogs.AggregationInterval, "INTERVAL_5_SEC"),
+		FlowSampling:        flowSampling,
+		Metadata:            pointer.StringDeref(flowLogs.Metadata, "INCLUDE_ALL_METADATA"),
+		MetadataFields:      flowLogs.MetadataFields,
+		FilterExpr:          pointer.StringDeref(flowLogs.FilterExpr, ""),
+		ForceSendFields:     []string{"FlowSampling"},
+	}
+}
+
 // ANCHOR: ClusterFirewallSpec
 
-// FirewallRulesSpec returns google compute firewall spec.
+// FirewallRulesSpec returns google compute firewall spec, or nil when the firewall rules are not managed by CAPG.
+// Disabled default rules are left out.
 func (s *ClusterScope) FirewallRulesSpec() []*compute.Firewall {
-	firewallRules := []*compute.Firewall{
+	firewallRules, _ := s.defaultFirewallRules()
+	return firewallRules
+}
+
+// DisabledFirewallRuleNames returns the names of the default firewall rules which are disabled.
+func (s *ClusterScope) DisabledFirewallRuleNames() []string {
+	_, disabled := s.defaultFirewallRules()
+	return disabled
+}
+
+// defaultFirewallRules returns the enabled default firewall rules, with their customizations applied, and the
+// names of the disabled ones.
+func (s *ClusterScope) defaultFirewallRules() ([]*compute.Firewall, []string) {
+	if s.IsExistingInfrastructure() {
+		return nil, nil
+	}
+
+	var healthChecksSpec, clusterSpec *infrav1.DefaultFirewallRuleSpec
+	if rules := s.GCPCluster.Spec.DefaultFirewallRules; rules != nil {
+		healthChecksSpec = rules.HealthChecks
+		clusterSpec = rules.Cluster
+	}
+
+	defaultRules := []struct {
+		firewall *compute.Firewall
+		spec     *infrav1.DefaultFirewallRuleSpec
+	}{
 		{
-			Name:    fmt.Sprintf("allow-%s-healthchecks", s.Name()),
-			Network: s.NetworkLink(),
-			Allowed: []*compute.FirewallAllowed{
-				{
-					IPProtocol: "TCP",
-					Ports: []string{
-						strconv.FormatInt(6443, 10),
+			firewall: &compute.Firewall{
+				Name:        fmt.Sprintf("allow-%s-healthchecks", s.Name()),
+				Description: infrav1.ClusterTagKey(s.Name()),
+				Network:     s.NetworkLink(),
+				Allowed: []*compute.FirewallAllowed{
+					{
+						IPProtocol: "TCP",
+						Ports: []string{
+							strconv.FormatInt(s.GCPCluster.Spec.LoadBalancer.HealthCheckPort(), 10),
+						},
 					},
 				},
+				Direction: "INGRESS",
+				SourceRanges: []string{
+					"35.191.0.0/16",
+					"130.211.0.0/22",
+				},
+				TargetTags: []string{
+					fmt.Sprintf("%s-control-plane", s.Name()),
+				},
 			},
-			Direction: "INGRESS",
-			SourceRanges: []string{
-				"35.191.0.0/16",
-				"130.211.0.0/22",
-			},
-			TargetTags: []string{
-				fmt.Sprintf("%s-control-plane", s.Name()),
-			},
+			spec: healthChecksSpec,
 		},
 		{
-			Name:    fmt.Sprintf("allow-%s-cluster", s.Name()),
-			Network: s.NetworkLink(),
-			Allowed: []*compute.FirewallAllowed{
-				{
-					IPProtocol: "all",
+			firewall: &compute.Firewall{
+				Name:        fmt.Sprintf("allow-%s-cluster", s.Name()),
+				Description: infrav1.ClusterTagKey(s.Name()),
+				Network:     s.NetworkLink(),
+				Allowed: []*compute.FirewallAllowed{
+					{
+						IPProtocol: "all",
+					},
+				},
+				Direction: "INGRESS",
+				SourceTags: []string{
+					fmt.Sprintf("%s-control-plane", s.Name()),
+					fmt.Sprintf("%s-node", s.Name()),
+				},
+				TargetTags: []string{
+					fmt.Sprintf("%s-control-plane", s.Name()),
+					fmt.Sprintf("%s-node", s.Name()),
 				},
 			},
-			Direction: "INGRESS",
-			SourceTags: []string{
-				fmt.Sprintf("%s-control-plane", s.Name()),
-				fmt.Sprintf("%s-node", s.Name()),
-			},
-			TargetTags: []string{
-				fmt.Sprintf("%s-control-plane", s.Name()),
-				fmt.Sprintf("%s-node", s.Name()),
-			},
+			spec: clusterSpec,
 		},
 	}
 
-	return firewallRules
+	firewallRules := []*compute.Firewall{}
+	disabled := []string{}
+	for _, rule := range defaultRules {
+		if rule.spec == nil {
+			firewallRules = append(firewallRules, rule.firewall)
+			continue
+		}
+		if rule.spec.Disabled {
+			disabled = append(disabled, rule.firewall.Name)
+			continue
+		}
+
+		if len(rule.spec.SourceRanges) > 0 {
+			rule.firewall.SourceRanges = rule.spec.SourceRanges
+			rule.firewall.SourceTags = nil
+		}
+		if rule.spec.Protocol != nil {
+			rule.firewall.Allowed = []*compute.FirewallAllowed{
+				{
+					IPProtocol: *rule.spec.Protocol,
+					Ports:      rule.spec.Ports,
+				},
+			}
+		}
+		firewallRules = append(firewallRules, rule.firewall)
+	}
+
+	return firewallRules, disabled
 }
 
 // ANCHOR_END: ClusterFirewallSpec
@@ -279,6 +551,7 @@ func (s *ClusterScope) FirewallRulesSpec() []*compute.Firewall {
 func (s *ClusterScope) AddressSpec() *compute.Address {
 	return &compute.Address{
 		Name:        fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
+		Description: infrav1.ClusterTagKey(s.Name()),
 		AddressType: "EXTERNAL",
 		IpVersion:   "IPV4",
 	}
@@ -286,13 +559,68 @@ func (s *ClusterScope) AddressSpec() *compute.Address {
 
 // BackendServiceSpec returns google compute backend-service spec.
 func (s *ClusterScope) BackendServiceSpec() *compute.BackendService {
-	return &compute.BackendService{
+	backendsvc := &compute.BackendService{
 		Name:                fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
 		LoadBalancingScheme: "EXTERNAL",
 		PortName:            "apiserver",
 		Protocol:            "TCP",
 		TimeoutSec:          int64((10 * time.Minute).Seconds()),
 	}
+	if spec := s.GCPCluster.Spec.LoadBalancer.BackendService; spec != nil {
+		backendsvc.ConnectionDraining = connectionDrainingSpec(spec.ConnectionDrainingTimeoutSec)
+		backendsvc.LogConfig = backendServiceLogConfigSpec(spec.Logging)
+		backendsvc.SessionAffinity = pointer.StringDeref(spec.SessionAffinity, "")
+		if spec.IdleTimeoutSec != nil {
+			backendsvc.TimeoutSec = *spec.IdleTimeoutSec
+		}
+	}
+
+	return backendsvc
+}
+
+// connectionDrainingSpec returns the google compute connection draining of a backend service, or nil if the
+// timeout is not configured.
+func connectionDrainingSpec(timeoutSec *int64) *compute.ConnectionDraining {
+	if timeoutSec == nil {
+		return nil
+	}
+
+	return &compute.ConnectionDraining{
+		DrainingTimeoutSec: *timeoutSec,
+		ForceSendFields:    []string{"DrainingTimeoutSec"},
+	}
+}
+
+// backendServiceLogConfigSpec returns the google compute log config of a backend service, or nil if logging is
+// not configured.
+func backendServiceLogConfigSpec(logging *infrav1.BackendServiceLogging) *compute.BackendServiceLogConfig {
+	if logging == nil {
+		return nil
+	}
+
+	// The sample rate is validated by the CRD pattern.
+	sampleRate, _ := strconv.ParseFloat(pointer.StringDeref(logging.SampleRate, "1.0"), 64)
+	return &compute.BackendServiceLogConfig{
+		Enable:          true,
+		SampleRate:      sampleRate,
+		ForceSendFields: []string{"SampleRate"},
+	}
+}
+
+// backendServiceFailoverPolicySpec returns the google compute failover policy of a backend service, or nil if
+// failover is not configured.
+func backendServiceFailoverPolicySpec(policy *infrav1.BackendServiceFailoverPolicy) *compute.BackendServiceFailoverPolicy {
+	if policy == nil {
+		return nil
+	}
+
+	// The ratio is validated by the CRD pattern.
+	failoverRatio, _ := strconv.ParseFloat(pointer.StringDeref(policy.FailoverRatio, "0"), 64)
+	return &compute.BackendServiceFailoverPolicy{
+		DisableConnectionDrainOnFailover: pointer.BoolDeref(policy.DisableConnectionDrainOnFailover, false),
+		DropTrafficIfUnhealthy:           pointer.BoolDeref(policy.DropTrafficIfUnhealthy, false),
+		FailoverRatio:                    failoverRatio,
+	}
 }
 
 // ForwardingRuleSpec returns google compute forwarding-rule spec.
@@ -312,19 +640,47 @@ func (s *ClusterScope) ForwardingRuleSpec() *compute.ForwardingRule {
 
 // HealthCheckSpec returns google compute health-check spec.
 func (s *ClusterScope) HealthCheckSpec() *compute.HealthCheck {
-	return &compute.HealthCheck{
-		Name: fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
-		Type: "HTTPS",
-		HttpsHealthCheck: &compute.HTTPSHealthCheck{
-			Port:              6443,
+	config := s.GCPCluster.Spec.LoadBalancer.HealthCheck
+	if config == nil {
+		config = &infrav1.LoadBalancerHealthCheck{}
+	}
+
+	healthcheck := &compute.HealthCheck{
+		Name:               fmt.Sprintf("%s-%s", s.Name(), infrav1.APIServerRoleTagValue),
+		Type:               pointer.StringDeref(config.Type, "HTTPS"),
+		CheckIntervalSec:   pointer.Int64Deref(config.CheckIntervalSec, 10),
+		TimeoutSec:         pointer.Int64Deref(config.TimeoutSec, 5),
+		HealthyThreshold:   pointer.Int64Deref(config.HealthyThreshold, 5),
+		UnhealthyThreshold: pointer.Int64Deref(config.UnhealthyThreshold, 3),
+	}
+
+	port := s.GCPCluster.Spec.LoadBalancer.HealthCheckPort()
+	proxyHeader := pointer.StringDeref(config.ProxyHeader, "NONE")
+	requestPath := pointer.StringDeref(config.RequestPath, "/readyz")
+	switch healthcheck.Type {
+	case "TCP":
+		healthcheck.TcpHealthCheck = &compute.TCPHealthCheck{
+			Port:              port,
 			PortSpecification: "USE_FIXED_PORT",
-			RequestPath:       "/readyz",
-		},
-		CheckIntervalSec:   10,
-		TimeoutSec:         5,
-		HealthyThreshold:   5,
-		UnhealthyThreshold: 3,
+			ProxyHeader:       proxyHeader,
+		}
+	case "HTTP":
+		healthcheck.HttpHealthCheck = &compute.HTTPHealthCheck{
+			Port:              port,
+			PortSpecification: "USE_FIXED_PORT",
+			RequestPath:       requestPath,
+			ProxyHeader:       proxyHeader,
+		}
+	default:
+		healthcheck.HttpsHealthCheck = &compute.HTTPSHealthCheck{
+			Port:              port,
+			PortSpecification: "USE_FIXED_PORT",
+			RequestPath:       requestPath,
+			ProxyHeader:       proxyHeader,
+		}
 	}
+
+	return healthcheck
 }
 
 // InstanceGroupSpec returns google compute instance-group spec.
@@ -349,8 +705,303 @@ func (s *ClusterScope) TargetTCPProxySpec() *compute.TargetTcpProxy {
 	}
 }
 
+func (s *ClusterScope) internalLoadBalancerName() string {
+	if lb := s.GCPCluster.Spec.LoadBalancer.InternalLoadBalancer; lb != nil && lb.Name != nil {
+		return *lb.Name
+	}
+	return fmt.Sprintf("%s-%s-internal", s.Name(), infrav1.APIServerRoleTagValue)
+}
+
+// internalLoadBalancerSubnetLink returns the link of the subnet hosting the internal load balancer. Without
+// explicit configuration the first regular subnet of the cluster region is used, or the subnet of an auto mode
+// network.
+func (s *ClusterScope) internalLoadBalancerSubnetLink() string {
+	subnet := s.NetworkName()
+	if lb := s.GCPCluster.Spec.LoadBalancer.InternalLoadBalancer; lb != nil && lb.Subnet != nil {
+		subnet = *lb.Subnet
+	} else {
+		for _, spec := range s.GCPCluster.Spec.Network.Subnets {
+			if spec.Region != s.Region() || (spec.Purpose != nil && (infrav1.IsProxyOnlySubnetPurpose(*spec.Purpose) || *spec.Purpose == "PRIVATE_SERVICE_CONNECT")) {
+				continue
+			}
+			subnet = spec.Name
+			break
+		}
+	}
+
+	return fmt.Sprintf("projects/%s/regions/%s/subnetworks/%s", s.NetworkProject(), s.Region(), subnet)
+}
+
+// InternalAddressSpec returns google compute address spec of the internal load balancer.
+func (s *ClusterScope) InternalAddressSpec() *compute.Address {
+	address := &compute.Address{
+		Name:        s.internalLoadBalancerName(),
+		Description: infrav1.ClusterTagKey(s.Name()),
+		AddressType: "INTERNAL",
+		Purpose:     "GCE_ENDPOINT",
+		IpVersion:   "IPV4",
+		Subnetwork:  s.internalLoadBalancerSubnetLink(),
+	}
+	if lb := s.GCPCluster.Spec.LoadBalancer.InternalLoadBalancer; lb != nil && lb.IPAddress != nil {
+		address.Address = *lb.IPAddress
+	}
+
+	return address
+}
+
+// InternalBackendServiceSpec returns google compute regional backend-service spec of the internal load balancer.
+func (s *ClusterScope) InternalBackendServiceSpec() *compute.BackendService {
+	backendsvc := &compute.BackendService{
+		Name:                s.internalLoadBalancerName(),
+		LoadBalancingScheme: "INTERNAL",
+		Protocol:            "TCP",
+		Network:             s.NetworkLink(),
+	}
+	if spec := s.GCPCluster.Spec.LoadBalancer.BackendService; spec != nil {
+		backendsvc.ConnectionDraining = connectionDrainingSpec(spec.ConnectionDrainingTimeoutSec)
+		backendsvc.LogConfig = backendServiceLogConfigSpec(spec.Logging)
+		backendsvc.FailoverPolicy = backendServiceFailoverPolicySpec(spec.FailoverPolicy)
+		backendsvc.SessionAffinity = pointer.StringDeref(spec.SessionAffinity, "")
+		if spec.IdleTimeoutSec != nil {
+			backendsvc.ConnectionTrackingPolicy = &compute.BackendServiceConnectionTrackingPolicy{
+				IdleTimeoutSec: *spec.IdleTimeoutSec,
+			}
+		}
+	}
+
+	return backendsvc
+}
+
+// InternalForwardingRuleSpec returns google compute regional forwarding-rule spec of the internal load balancer.
+func (s *ClusterScope) InternalForwardingRuleSpec() *compute.ForwardingRule {
+	port := pointer.Int32Deref(s.GCPCluster.Spec.Network.LoadBalancerBackendPort, 6443)
+	return &compute.ForwardingRule{
+		Name:                s.internalLoadBalancerName(),
+		IPProtocol:          "TCP",
+		LoadBalancingScheme: "INTERNAL",
+		Ports:               []string{strconv.FormatInt(int64(port), 10)},
+		Network:             s.NetworkLink(),
+		Subnetwork:          s.internalLoadBalancerSubnetLink(),
+	}
+}
+
+// InternalHealthCheckSpec returns google compute regional health-check spec of the internal load balancer.
+func (s *ClusterScope) InternalHealthCheckSpec() *compute.HealthCheck {
+	healthcheck := s.HealthCheckSpec()
+	healthcheck.Name = s.internalLoadBalancerName()
+	return healthcheck
+}
+
+// ServiceAttachmentSpec returns google compute service-attachment spec publishing the internal load balancer,
+// or nil when the load balancer is not published. The target service is set once the forwarding rule exists.
+func (s *ClusterScope) ServiceAttachmentSpec() *compute.ServiceAttachment {
+	lb := s.GCPCluster.Spec.LoadBalancer.InternalLoadBalancer
+	if lb == nil || lb.ServiceAttachment == nil {
+		return nil
+	}
+
+	spec := lb.ServiceAttachment
+	attachment := &compute.ServiceAttachment{
+		Name:                 s.internalLoadBalancerName(),
+		ConnectionPreference: pointer.StringDeref(spec.ConnectionPreference, "ACCEPT_AUTOMATIC"),
+		ConsumerRejectLists:  spec.ConsumerRejectList,
+	}
+	for _, subnet := range spec.NatSubnets {
+		attachment.NatSubnets = append(attachment.NatSubnets, fmt.Sprintf("projects/%s/regions/%s/subnetworks/%s", s.NetworkProject(), s.Region(), subnet))
+	}
+	for _, consumer := range spec.ConsumerAcceptList {
+		attachment.ConsumerAcceptLists = append(attachment.ConsumerAcceptLists, &compute.ServiceAttachmentConsumerProjectLimit{
+			ProjectIdOrNum:  consumer.Project,
+			ConnectionLimit: consumer.ConnectionLimit,
+		})
+	}
+
+	return attachment
+}
+
 // ANCHOR_END: ClusterControlPlaneSpec
 
+// SnapshotScheduleSpec returns the snapshot schedule resource policy spec, or nil when the cluster has none.
+func (s *ClusterScope) SnapshotScheduleSpec() *compute.ResourcePolicy {
+	schedule := s.GCPCluster.Spec.SnapshotSchedule
+	if schedule == nil {
+		return nil
+	}
+
+	startTime := schedule.StartTime
+	if startTime == "" {
+		startTime = "00:00"
+	}
+	retentionDays := schedule.RetentionDays
+	if retentionDays == 0 {
+		retentionDays = 14
+	}
+
+	policy := &compute.ResourcePolicy{
+		Name:        pointer.StringDeref(schedule.Name, fmt.Sprintf("%s-snapshot-schedule", s.Name())),
+		Region:      s.Region(),
+		Description: infrav1.ClusterTagKey(s.Name()),
+		SnapshotSchedulePolicy: &compute.ResourcePolicySnapshotSchedulePolicy{
+			Schedule: &compute.ResourcePolicySnapshotSchedulePolicySchedule{},
+			RetentionPolicy: &compute.ResourcePolicySnapshotSchedulePolicyRetentionPolicy{
+				MaxRetentionDays:   retentionDays,
+				OnSourceDiskDelete: "KEEP_AUTO_SNAPSHOTS",
+			},
+			SnapshotProperties: &compute.ResourcePolicySnapshotSchedulePolicySnapshotProperties{
+				Labels: infrav1.Build(infrav1.BuildParams{
+					ClusterName: s.Name(),
+					Lifecycle:   infrav1.ResourceLifecycleOwned,
+					Additional:  s.AdditionalLabels().DeepCopy().AddLabels(schedule.Labels),
+				}),
+				StorageLocations: schedule.StorageLocations,
+			},
+		},
+	}
+	if schedule.HoursInCycle != nil {
+		policy.SnapshotSchedulePolicy.Schedule.HourlySchedule = &compute.ResourcePolicyHourlyCycle{
+			HoursInCycle: *schedule.HoursInCycle,
+			StartTime:    startTime,
+		}
+	} else {
+		policy.SnapshotSchedulePolicy.Schedule.DailySchedule = &compute.ResourcePolicyDailyCycle{
+			DaysInCycle: 1,
+			StartTime:   startTime,
+		}
+	}
+
+	return policy
+}
+
+// ANCHOR: ClusterBastionSpec
+
+func (s *ClusterScope) bastionName() string {
+	return fmt.Sprintf("%s-bastion", s.Name())
+}
+
+// bastionZone returns the zone of the bastion host, falling back to the first failure domain of the cluster.
+func (s *ClusterScope) bastionZone() string {
+	if zone := s.GCPCluster.Spec.Bastion.Zone; zone != nil {
+		return *zone
+	}
+
+	zones := make([]string, 0, len(s.FailureDomains()))
+	for zone := range s.FailureDomains() {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	if len(zones) == 0 {
+		return ""
+	}
+	return zones[0]
+}
+
+// BastionInstanceSpec returns google compute instance spec of the bastion host, or nil when the cluster has none.
+func (s *ClusterScope) BastionInstanceSpec() *compute.Instance {
+	spec := s.GCPCluster.Spec.Bastion
+	if spec == nil {
+		return nil
+	}
+
+	zone := s.bastionZone()
+	instanceType := spec.InstanceType
+	if instanceType == "" {
+		instanceType = "e2-small"
+	}
+
+	networkInterface := &compute.NetworkInterface{
+		Network: s.NetworkLink(),
+	}
+	if spec.Subnet != nil {
+		networkInterface.Subnetwork = fmt.Sprintf("projects/%s/regions/%s/subnetworks/%s", s.NetworkProject(), s.Region(), *spec.Subnet)
+	}
+	if pointer.BoolDeref(spec.PublicIP, true) {
+		networkInterface.AccessConfigs = []*compute.AccessConfig{
+			{
+				Type: "ONE_TO_ONE_NAT",
+				Name: "External NAT",
+			},
+		}
+	}
+
+	return &compute.Instance{
+		Name:        s.bastionName(),
+		Zone:        zone,
+		Description: infrav1.ClusterTagKey(s.Name()),
+		MachineType: fmt.Sprintf("zones/%s/machineTypes/%s", zone, instanceType),
+		Tags: &compute.Tags{
+			Items: []string{
+				s.bastionName(),
+				s.Name(),
+			},
+		},
+		Labels: infrav1.Build(infrav1.BuildParams{
+			ClusterName: s.Name(),
+			Lifecycle:   infrav1.ResourceLifecycleOwned,
+			Role:        pointer.String(infrav1.BastionRoleTagValue),
+			Additional:  s.AdditionalLabels(),
+		}),
+		Disks: []*compute.AttachedDisk{
+			{
+				AutoDelete: true,
+				Boot:       true,
+				InitializeParams: &compute.AttachedDiskInitializeParams{
+					SourceImage: pointer.StringDeref(spec.Image, "projects/debian-cloud/global/images/family/debian-12"),
+				},
+			},
+		},
+		NetworkInterfaces: []*compute.NetworkInterface{networkInterface},
+	}
+}
+
+// BastionFirewallRulesSpec returns google compute firewall spec of the bastion host. The rules are returned
+// even when the cluster has no bastion host, so that they can be cleaned up.
+func (s *ClusterScope) BastionFirewallRulesSpec() []*compute.Firewall {
+	var sourceRanges []string
+	if spec := s.GCPCluster.Spec.Bastion; spec != nil {
+		sourceRanges = spec.AllowedCIDRBlocks
+	}
+
+	return []*compute.Firewall{
+		{
+			Name:        fmt.Sprintf("allow-%s-bastion-ssh", s.Name()),
+			Description: infrav1.ClusterTagKey(s.Name()),
+			Network:     s.NetworkLink(),
+			Allowed: []*compute.FirewallAllowed{
+				{
+					IPProtocol: "TCP",
+					Ports:      []string{"22"},
+				},
+			},
+			Direction:    "INGRESS",
+			SourceRanges: sourceRanges,
+			TargetTags: []string{
+				s.bastionName(),
+			},
+		},
+		{
+			Name:        fmt.Sprintf("allow-%s-bastion-cluster-ssh", s.Name()),
+			Description: infrav1.ClusterTagKey(s.Name()),
+			Network:     s.NetworkLink(),
+			Allowed: []*compute.FirewallAllowed{
+				{
+					IPProtocol: "TCP",
+					Ports:      []string{"22"},
+				},
+			},
+			Direction: "INGRESS",
+			SourceTags: []string{
+				s.bastionName(),
+			},
+			TargetTags: []string{
+				fmt.Sprintf("%s-control-plane", s.Name()),
+				fmt.Sprintf("%s-node", s.Name()),
+			},
+		},
+	}
+}
+
+// ANCHOR_END: ClusterBastionSpec
+
 // PatchObject persists the cluster configuration and status.
 func (s *ClusterScope) PatchObject() error {
 	return s.patchHelper.Patch(context.TODO(), s.GCPCluster)
@@ -17,14 +17,23 @@ limitations under the License.
 package scope
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"os"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"google.golang.org/api/impersonate"
+	"google.golang.org/api/option"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	"k8s.io/utils/strings/slices"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -42,6 +51,14 @@ var (
 	}
 )
 
+// SetOAuthScopes replaces the OAuth scopes requested by the GCP clients of the controller, which default to
+// cloud-platform and userinfo.email. Empty scopes keep the defaults.
+func SetOAuthScopes(scopes []string) {
+	if len(scopes) > 0 {
+		gcpScopes = scopes
+	}
+}
+
 // Credential is a struct to hold GCP credential data.
 type Credential struct {
 	token oauth2.TokenSource
@@ -56,15 +73,45 @@ func (c *Credential) GetToken(_ context.Context) (string, error) {
 	return token.AccessToken, nil
 }
 
-func getCredentials(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) (*Credential, error) {
-	var credential *google.Credentials
-	var err error
+// GetTokenWithExpiry returns the access token of the loaded GCP credentials and its expiry, which is zero if
+// the token does not expire.
+func (c *Credential) GetTokenWithExpiry(_ context.Context) (string, time.Time, error) {
+	token, err := c.token.Token()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return token.AccessToken, token.Expiry, nil
+}
 
-	if credentialsRef != nil {
-		credential, err = getCredentialDataFromRef(ctx, credentialsRef, crClient)
-	} else {
-		credential, err = getCredentialDataUsingADC(ctx)
+// credentialsSource describes where the credentials of a cluster come from.
+type credentialsSource struct {
+	// secretRef is the Secret with the JSON credentials, or nil to use the credentials of the controller.
+	secretRef *infrav1.ObjectReference
+
+	// impersonateServiceAccount is the email of the service account the credentials impersonate, if any.
+	impersonateServiceAccount string
+
+	// scopes are the OAuth scopes requested with the credentials, or nil for the scopes of the controller.
+	scopes []string
+
+	// quotaProject is the project the API requests are attributed to, if any.
+	quotaProject string
+
+	// endpoints are the endpoints of the GCP APIs that override the endpoints of the controller.
+	endpoints infrav1.APIEndpoints
+}
+
+// oauthScopes returns the OAuth scopes requested with the credentials of the source.
+func (s *credentialsSource) oauthScopes() []string {
+	if len(s.scopes) > 0 {
+		return s.scopes
 	}
+
+	return gcpScopes
+}
+
+func getCredentials(ctx context.Context, source *credentialsSource, crClient client.Client) (*Credential, error) {
+	credential, err := getCredentialData(ctx, source, crClient)
 	if err != nil {
 		return nil, fmt.Errorf("getting credential data: %w", err)
 	}
@@ -81,7 +128,47 @@ func getCredentials(ctx context.Context, credentialsRef *infrav1.ObjectReference
 	return credentials, nil
 }
 
-func getCredentialDataFromRef(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client) (*google.Credentials, error) {
+// getCredentialData returns the credentials stored in the referenced Secret, or the default credentials of the
+// controller if there is no reference, impersonating the service account of the source if it has one.
+func getCredentialData(ctx context.Context, source *credentialsSource, crClient client.Client) (*google.Credentials, error) {
+	var creds *google.Credentials
+	var err error
+	ctx = withTokenTransport(ctx)
+	scopes := source.oauthScopes()
+	if source.secretRef == nil {
+		creds, err = getCredentialDataUsingADC(ctx, scopes)
+	} else {
+		creds, err = getCredentialDataFromRef(ctx, source.secretRef, crClient, scopes)
+		if err != nil {
+			err = fmt.Errorf("getting gcp credentials from reference %s: %w", source.secretRef, err)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if source.impersonateServiceAccount == "" {
+		return creds, nil
+	}
+
+	opts, err := withHTTPTransport(ctx, []option.ClientOption{option.WithCredentials(creds)}, nil)
+	if err != nil {
+		return nil, err
+	}
+	token, err := impersonate.CredentialsTokenSource(ctx, impersonate.CredentialsConfig{
+		TargetPrincipal: source.impersonateServiceAccount,
+		Scopes:          scopes,
+	}, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("impersonating service account %s: %w", source.impersonateServiceAccount, err)
+	}
+
+	return &google.Credentials{ProjectID: creds.ProjectID, TokenSource: token}, nil
+}
+
+// getCredentialDataFromRef parses the JSON credentials of the Secret, which can be a service account key or an
+// external account configuration for workload identity federation.
+func getCredentialDataFromRef(ctx context.Context, credentialsRef *infrav1.ObjectReference, crClient client.Client, scopes []string) (*google.Credentials, error) {
 	secretRefName := types.NamespacedName{
 		Name:      credentialsRef.Name,
 		Namespace: credentialsRef.Namespace,
@@ -97,7 +184,7 @@ func getCredentialDataFromRef(ctx context.Context, credentialsRef *infrav1.Objec
 		return nil, errors.New("no credentials key in secret")
 	}
 
-	creds, err := google.CredentialsFromJSON(ctx, rawData, gcpScopes...)
+	creds, err := google.CredentialsFromJSON(ctx, rawData, scopes...)
 	if err != nil {
 		return nil, fmt.Errorf("getting credentials from json: %w", err)
 	}
@@ -108,8 +195,29 @@ func getCredentialDataFromRef(ctx context.Context, credentialsRef *infrav1.Objec
 	return creds, nil
 }
 
-func getCredentialDataUsingADC(ctx context.Context) (*google.Credentials, error) {
-	creds, err := google.FindDefaultCredentials(ctx, gcpScopes...)
+// getCredentialDataUsingADC returns the application default credentials of the controller. The credentials file
+// of the controller is mounted from a Secret which is left empty when the controller relies on its workload
+// identity, in which case the credentials are fetched from the metadata server.
+func getCredentialDataUsingADC(ctx context.Context, scopes []string) (*google.Credentials, error) {
+	if path := os.Getenv(ConfigFileEnvVar); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("reading credentials file %s: %w", path, err)
+		}
+
+		if len(bytes.TrimSpace(data)) == 0 {
+			return &google.Credentials{TokenSource: google.ComputeTokenSource("", scopes...)}, nil
+		}
+
+		creds, err := google.CredentialsFromJSON(ctx, data, scopes...)
+		if err != nil {
+			return nil, fmt.Errorf("getting credentials from file %s: %w", path, err)
+		}
+
+		return creds, nil
+	}
+
+	creds, err := google.FindDefaultCredentials(ctx, scopes...)
 	if err != nil {
 		return nil, fmt.Errorf("getting credentials from json: %w", err)
 	}
@@ -119,3 +227,85 @@ func getCredentialDataUsingADC(ctx context.Context) (*google.Credentials, error)
 
 	return creds, nil
 }
+
+// getClusterCredentialsSource returns the source of the credentials of a cluster, which is read from its
+// GCPClusterIdentity when it references one.
+func getClusterCredentialsSource(ctx context.Context, crClient client.Client, namespace string, credentialsRef, identityRef *infrav1.ObjectReference, impersonateServiceAccount, quotaProject *string) (*credentialsSource, error) {
+	if identityRef == nil {
+		return &credentialsSource{
+			secretRef:                 credentialsRef,
+			impersonateServiceAccount: pointer.StringDeref(impersonateServiceAccount, ""),
+			quotaProject:              pointer.StringDeref(quotaProject, ""),
+		}, nil
+	}
+
+	identityName := types.NamespacedName{
+		Name:      identityRef.Name,
+		Namespace: identityRef.Namespace,
+	}
+	identity := &infrav1.GCPClusterIdentity{}
+	if err := crClient.Get(ctx, identityName, identity); err != nil {
+		return nil, fmt.Errorf("getting cluster identity %s: %w", identityName, err)
+	}
+
+	allowed, err := identityAllowsNamespace(ctx, crClient, identity, namespace)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, fmt.Errorf("cluster identity %s does not allow namespace %s", identityName, namespace)
+	}
+
+	source := &credentialsSource{
+		impersonateServiceAccount: pointer.StringDeref(identity.Spec.ImpersonateServiceAccount, ""),
+		scopes:                    identity.Spec.Scopes,
+		quotaProject:              pointer.StringDeref(quotaProject, pointer.StringDeref(identity.Spec.QuotaProject, "")),
+	}
+	if identity.Spec.Endpoints != nil {
+		source.endpoints = *identity.Spec.Endpoints
+	}
+	switch identity.Spec.Type {
+	case infrav1.ServiceAccountKeyIdentity:
+		if identity.Spec.SecretRef == nil {
+			return nil, fmt.Errorf("cluster identity %s has no secret reference", identityName)
+		}
+		source.secretRef = &infrav1.ObjectReference{
+			Namespace: identity.Namespace,
+			Name:      identity.Spec.SecretRef.Name,
+		}
+	case infrav1.WorkloadIdentity:
+	default:
+		return nil, fmt.Errorf("cluster identity %s has unsupported type %q", identityName, identity.Spec.Type)
+	}
+
+	return source, nil
+}
+
+// identityAllowsNamespace returns true if clusters of the namespace can use the identity.
+func identityAllowsNamespace(ctx context.Context, crClient client.Client, identity *infrav1.GCPClusterIdentity, namespace string) (bool, error) {
+	allowed := identity.Spec.AllowedNamespaces
+	switch {
+	case identity.Namespace == namespace:
+		return true, nil
+	case allowed == nil:
+		return false, nil
+	case len(allowed.NamespaceList) == 0 && allowed.Selector == nil:
+		return true, nil
+	case slices.Contains(allowed.NamespaceList, namespace):
+		return true, nil
+	case allowed.Selector == nil:
+		return false, nil
+	}
+
+	selector, err := metav1.LabelSelectorAsSelector(allowed.Selector)
+	if err != nil {
+		return false, fmt.Errorf("parsing allowed namespaces selector of cluster identity %s: %w", identity.Name, err)
+	}
+
+	ns := &corev1.Namespace{}
+	if err := crClient.Get(ctx, types.NamespacedName{Name: namespace}, ns); err != nil {
+		return false, fmt.Errorf("getting namespace %s: %w", namespace, err)
+	}
+
+	return selector.Matches(labels.Set(ns.Labels)), nil
+}
@@ -0,0 +1,206 @@
+package scope
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// This test verifies that the credentials of a cluster are read from its GCPClusterIdentity, and only
+// from the namespaces the identity allows.
+func TestClusterCredentialsSourceFromIdentity(t *testing.T) {
+	schema := runtime.NewScheme()
+	assert.Nil(t, corev1.AddToScheme(schema))
+	assert.Nil(t, infrav1.AddToScheme(schema))
+
+	identity := func(name string, identityType infrav1.IdentityType, allowed *infrav1.AllowedNamespaces) *infrav1.GCPClusterIdentity {
+		return &infrav1.GCPClusterIdentity{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "identities"},
+			Spec: infrav1.GCPClusterIdentitySpec{
+				Type:              identityType,
+				SecretRef:         &corev1.LocalObjectReference{Name: "gcp-credentials"},
+				AllowedNamespaces: allowed,
+			},
+		}
+	}
+	impersonating := identity("impersonating", infrav1.WorkloadIdentity, &infrav1.AllowedNamespaces{})
+	impersonating.Spec.ImpersonateServiceAccount = pointer.String("capg@tenant.iam.gserviceaccount.com")
+	scoped := identity("scoped", infrav1.WorkloadIdentity, &infrav1.AllowedNamespaces{})
+	scoped.Spec.Scopes = []string{"https://www.googleapis.com/auth/compute"}
+	billed := identity("billed", infrav1.WorkloadIdentity, &infrav1.AllowedNamespaces{})
+	billed.Spec.QuotaProject = pointer.String("billing-project")
+	private := identity("private-endpoints", infrav1.WorkloadIdentity, &infrav1.AllowedNamespaces{})
+	private.Spec.Endpoints = &infrav1.APIEndpoints{Compute: "https://compute-private.googleapis.com"}
+	testClient := fake.NewClientBuilder().WithScheme(schema).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-a", Labels: map[string]string{"team": "a"}}},
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-b"}},
+		identity("private", infrav1.ServiceAccountKeyIdentity, nil),
+		identity("everyone", infrav1.ServiceAccountKeyIdentity, &infrav1.AllowedNamespaces{}),
+		identity("listed", infrav1.ServiceAccountKeyIdentity, &infrav1.AllowedNamespaces{NamespaceList: []string{"tenant-b"}}),
+		identity("selected", infrav1.ServiceAccountKeyIdentity, &infrav1.AllowedNamespaces{
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+		}),
+		identity("workload", infrav1.WorkloadIdentity, &infrav1.AllowedNamespaces{}),
+		impersonating,
+		scoped,
+		billed,
+		private,
+	).Build()
+
+	credentialsRef := &infrav1.ObjectReference{Namespace: "tenant-a", Name: "my-credentials"}
+	secretRef := &credentialsSource{secretRef: &infrav1.ObjectReference{Namespace: "identities", Name: "gcp-credentials"}}
+	tests := []struct {
+		name        string
+		namespace   string
+		identity    string
+		want        *credentialsSource
+		expectError bool
+	}{
+		{name: "no identity", namespace: "tenant-a", want: &credentialsSource{secretRef: credentialsRef}},
+		{name: "identity namespace", namespace: "identities", identity: "private", want: secretRef},
+		{name: "namespace not allowed", namespace: "tenant-a", identity: "private", expectError: true},
+		{name: "all namespaces allowed", namespace: "tenant-a", identity: "everyone", want: secretRef},
+		{name: "listed namespace", namespace: "tenant-b", identity: "listed", want: secretRef},
+		{name: "unlisted namespace", namespace: "tenant-a", identity: "listed", expectError: true},
+		{name: "selected namespace", namespace: "tenant-a", identity: "selected", want: secretRef},
+		{name: "unselected namespace", namespace: "tenant-b", identity: "selected", expectError: true},
+		{name: "workload identity", namespace: "tenant-a", identity: "workload", want: &credentialsSource{}},
+		{name: "impersonation", namespace: "tenant-a", identity: "impersonating", want: &credentialsSource{
+			impersonateServiceAccount: "capg@tenant.iam.gserviceaccount.com",
+		}},
+		{name: "identity scopes", namespace: "tenant-a", identity: "scoped", want: &credentialsSource{
+			scopes: []string{"https://www.googleapis.com/auth/compute"},
+		}},
+		{name: "identity quota project", namespace: "tenant-a", identity: "billed", want: &credentialsSource{
+			quotaProject: "billing-project",
+		}},
+		{name: "identity endpoints", namespace: "tenant-a", identity: "private-endpoints", want: &credentialsSource{
+			endpoints: infrav1.APIEndpoints{Compute: "https://compute-private.googleapis.com"},
+		}},
+		{name: "missing identity", namespace: "tenant-a", identity: "missing", expectError: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ref := credentialsRef
+			var identityRef *infrav1.ObjectReference
+			if tt.identity != "" {
+				ref = nil
+				identityRef = &infrav1.ObjectReference{Namespace: "identities", Name: tt.identity}
+			}
+
+			got, err := getClusterCredentialsSource(context.TODO(), testClient, tt.namespace, ref, identityRef, nil, nil)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+// This test verifies that the controller falls back to its workload identity when its credentials file is
+// empty, and that external account configurations are accepted as credentials.
+func TestCredentialDataUsingADC(t *testing.T) {
+	dir := t.TempDir()
+	externalAccount := []byte(`{
+  "type": "external_account",
+  "audience": "//iam.googleapis.com/projects/123/locations/global/workloadIdentityPools/my-pool/providers/my-provider",
+  "subject_token_type": "urn:ietf:params:oauth:token-type:jwt",
+  "token_url": "https://sts.googleapis.com/v1/token",
+  "credential_source": {"file": "/var/run/secrets/tokens/gcp-token"}
+}`)
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "missing credentials file"},
+		{name: "empty credentials file", data: []byte("\n")},
+		{name: "external account credentials file", data: externalAccount},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, fmt.Sprintf("credentials-%d.json", i))
+			if tt.data != nil {
+				assert.Nil(t, os.WriteFile(path, tt.data, 0o600))
+			}
+			t.Setenv(ConfigFileEnvVar, path)
+
+			creds, err := getCredentialDataUsingADC(context.TODO(), gcpScopes)
+			assert.Nil(t, err)
+			assert.NotNil(t, creds.TokenSource)
+		})
+	}
+}
+
+// This test verifies that the credentials impersonate the service account of their source.
+func TestCredentialDataImpersonation(t *testing.T) {
+	t.Setenv(ConfigFileEnvVar, filepath.Join(t.TempDir(), "credentials.json"))
+
+	base, err := getCredentialData(context.TODO(), &credentialsSource{}, nil)
+	assert.Nil(t, err)
+
+	creds, err := getCredentialData(context.TODO(), &credentialsSource{
+		impersonateServiceAccount: "capg@tenant.iam.gserviceaccount.com",
+	}, nil)
+	assert.Nil(t, err)
+	assert.NotNil(t, creds.TokenSource)
+	assert.NotEqual(t, base.TokenSource, creds.TokenSource)
+}
+
+// This test verifies that the control plane and the machine pools of a managed cluster fall back to the
+// credentials of their parent objects.
+func TestManagedCredentialsSource(t *testing.T) {
+	clusterRef := &infrav1.ObjectReference{Namespace: "default", Name: "cluster-credentials"}
+	controlPlaneRef := &infrav1.ObjectReference{Namespace: "default", Name: "service-project-credentials"}
+	machinePoolRef := &infrav1.ObjectReference{Namespace: "default", Name: "node-pool-credentials"}
+
+	managedCluster := &infrav1exp.GCPManagedCluster{Spec: infrav1exp.GCPManagedClusterSpec{CredentialsRef: clusterRef}}
+	controlPlane := &infrav1exp.GCPManagedControlPlane{}
+	machinePool := &infrav1exp.GCPManagedMachinePool{}
+
+	source, err := getManagedMachinePoolCredentialsSource(context.TODO(), nil, managedCluster, controlPlane, machinePool)
+	assert.Nil(t, err)
+	assert.Equal(t, clusterRef, source.secretRef)
+
+	controlPlane.Spec.CredentialsRef = controlPlaneRef
+	source, err = getManagedMachinePoolCredentialsSource(context.TODO(), nil, managedCluster, controlPlane, machinePool)
+	assert.Nil(t, err)
+	assert.Equal(t, controlPlaneRef, source.secretRef)
+
+	machinePool.Spec.CredentialsRef = machinePoolRef
+	source, err = getManagedMachinePoolCredentialsSource(context.TODO(), nil, managedCluster, controlPlane, machinePool)
+	assert.Nil(t, err)
+	assert.Equal(t, machinePoolRef, source.secretRef)
+
+	managedCluster.Spec.QuotaProject = pointer.String("billing-project")
+	source, err = getManagedMachinePoolCredentialsSource(context.TODO(), nil, managedCluster, controlPlane, machinePool)
+	assert.Nil(t, err)
+	assert.Equal(t, "billing-project", source.quotaProject)
+}
+
+// This test verifies that the endpoints of an identity override the endpoints of the controller.
+func TestAPIEndpoints(t *testing.T) {
+	SetAPIEndpoints(infrav1.APIEndpoints{Container: "container-private.googleapis.com:443"})
+	defer SetAPIEndpoints(infrav1.APIEndpoints{})
+
+	source := &credentialsSource{}
+	assert.Equal(t, "", source.computeEndpoint())
+	assert.Equal(t, "container-private.googleapis.com:443", source.containerEndpoint())
+
+	source.endpoints = infrav1.APIEndpoints{Compute: "http://localhost:8080", Container: "europe-west1-container.googleapis.com:443"}
+	assert.Equal(t, "http://localhost:8080", source.computeEndpoint())
+	assert.Equal(t, "europe-west1-container.googleapis.com:443", source.containerEndpoint())
+}
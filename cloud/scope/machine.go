@@ -23,6 +23,7 @@ import (
 	"path"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 
@@ -36,7 +37,6 @@ import (
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/providerid"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
-	"sigs.k8s.io/cluster-api/controllers/noderefutil"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -94,6 +94,11 @@ func (m *MachineScope) Cloud() cloud.Cloud {
 	return m.ClusterGetter.Cloud()
 }
 
+// ComputeService returns the compute service of the cluster, for the operations not covered by Cloud.
+func (m *MachineScope) ComputeService() *compute.Service {
+	return m.ClusterGetter.ComputeService()
+}
+
 // Zone returns the FailureDomain for the GCPMachine.
 func (m *MachineScope) Zone() string {
 	if m.Machine.Spec.FailureDomain == nil {
@@ -136,6 +141,29 @@ func (m *MachineScope) IsControlPlane() bool {
 	return util.IsControlPlaneMachine(m.Machine)
 }
 
+// IsPreemptible returns true if the machine instance can be preempted, i.e. it is a preemptible or Spot instance.
+func (m *MachineScope) IsPreemptible() bool {
+	return m.GCPMachine.Spec.Preemptible ||
+		(m.GCPMachine.Spec.ProvisioningModel != nil && *m.GCPMachine.Spec.ProvisioningModel == infrav1.ProvisioningModelSpot)
+}
+
+// IsEphemeral returns true if the machine is not meant to outlive its instance, i.e. it is preemptible or has a
+// max run duration. The Machine of an ephemeral machine whose instance is gone is replaced rather than the
+// instance recreated.
+func (m *MachineScope) IsEphemeral() bool {
+	return m.IsPreemptible() || m.GCPMachine.Spec.MaxRunDuration != nil
+}
+
+// RunDeadline returns the time at which the machine reaches its max run duration, or nil if it has none.
+func (m *MachineScope) RunDeadline() *time.Time {
+	if m.GCPMachine.Spec.MaxRunDuration == nil {
+		return nil
+	}
+
+	deadline := m.GCPMachine.CreationTimestamp.Add(m.GCPMachine.Spec.MaxRunDuration.Duration)
+	return &deadline
+}
+
 // Role returns the machine role from the labels.
 func (m *MachineScope) Role() string {
 	if util.IsControlPlaneMachine(m.Machine) {
@@ -147,12 +175,12 @@ func (m *MachineScope) Role() string {
 
 // GetInstanceID returns the GCPMachine instance id by parsing Spec.ProviderID.
 func (m *MachineScope) GetInstanceID() *string {
-	parsed, err := noderefutil.NewProviderID(m.GetProviderID()) //nolint: staticcheck
+	parsed, err := providerid.Parse(m.GetProviderID())
 	if err != nil {
 		return nil
 	}
 
-	return pointer.String(parsed.ID()) //nolint: staticcheck
+	return pointer.String(parsed.Name())
 }
 
 // GetProviderID returns the GCPMachine providerID from the spec.
@@ -168,9 +196,14 @@ func (m *MachineScope) GetProviderID() string {
 
 // ANCHOR: MachineSetter
 
-// SetProviderID sets the GCPMachine providerID in spec.
-func (m *MachineScope) SetProviderID() {
-	providerID, _ := providerid.New(m.ClusterGetter.Project(), m.Zone(), m.Name())
+// SetProviderID sets the GCPMachine providerID in spec from the self link of its instance, so that it matches
+// the node even when the instance project is not written the same way as the cluster project. The cluster
+// project and the machine zone are used when the self link is not known.
+func (m *MachineScope) SetProviderID(instanceURL string) {
+	providerID, err := providerid.NewFromResourceURL(instanceURL)
+	if err != nil {
+		providerID, _ = providerid.New(m.ClusterGetter.Project(), m.Zone(), m.Name())
+	}
 	m.GCPMachine.Spec.ProviderID = pointer.String(providerID.String())
 }
 
@@ -212,6 +245,11 @@ func (m *MachineScope) SetAddresses(addressList []corev1.NodeAddress) {
 	m.GCPMachine.Status.Addresses = addressList
 }
 
+// SetDisks sets the disks field on the GCPMachine.
+func (m *MachineScope) SetDisks(disks []infrav1.AttachedDiskStatus) {
+	m.GCPMachine.Status.Disks = disks
+}
+
 // ANCHOR_END: MachineSetter
 
 // ANCHOR: MachineInstanceSpec
@@ -236,13 +274,48 @@ func (m *MachineScope) InstanceImageSpec() *compute.AttachedDisk {
 	}
 
 	return &compute.AttachedDisk{
-		AutoDelete: true,
+		AutoDelete: pointer.BoolDeref(m.GCPMachine.Spec.RootDeviceAutoDelete, true),
 		Boot:       true,
 		InitializeParams: &compute.AttachedDiskInitializeParams{
-			DiskSizeGb:  m.GCPMachine.Spec.RootDeviceSize,
-			DiskType:    path.Join("zones", m.Zone(), "diskTypes", string(diskType)),
-			SourceImage: sourceImage,
+			DiskSizeGb:            m.GCPMachine.Spec.RootDeviceSize,
+			DiskType:              path.Join("zones", m.Zone(), "diskTypes", string(diskType)),
+			SourceImage:           sourceImage,
+			ProvisionedIops:       pointer.Int64Deref(m.GCPMachine.Spec.RootDeviceProvisionedIOPS, 0),
+			ProvisionedThroughput: pointer.Int64Deref(m.GCPMachine.Spec.RootDeviceProvisionedThroughput, 0),
+			ResourcePolicies:      m.resourcePolicyLinks(m.GCPMachine.Spec.RootDeviceResourcePolicies),
 		},
+		DiskEncryptionKey: diskEncryptionKeySpec(m.GCPMachine.Spec.RootDiskEncryptionKey),
+	}
+}
+
+// resourcePolicyLinks returns the links of the resource policies applied to a disk. Names are resolved
+// to resource policies in the cluster region.
+func (m *MachineScope) resourcePolicyLinks(resourcePolicies []string) []string {
+	if len(resourcePolicies) == 0 {
+		return nil
+	}
+
+	links := make([]string, 0, len(resourcePolicies))
+	for _, resourcePolicy := range resourcePolicies {
+		if strings.Contains(resourcePolicy, "/") {
+			links = append(links, resourcePolicy)
+			continue
+		}
+		links = append(links, path.Join("projects", m.ClusterGetter.Project(), "regions", m.ClusterGetter.Region(), "resourcePolicies", resourcePolicy))
+	}
+
+	return links
+}
+
+// diskEncryptionKeySpec returns the compute customer encryption key of a disk, or nil for a Google-managed key.
+func diskEncryptionKeySpec(key *infrav1.CustomerEncryptionKey) *compute.CustomerEncryptionKey {
+	if key == nil {
+		return nil
+	}
+
+	return &compute.CustomerEncryptionKey{
+		KmsKeyName:           key.KMSKeyName,
+		KmsKeyServiceAccount: pointer.StringDeref(key.KMSKeyServiceAccount, ""),
 	}
 }
 
@@ -251,11 +324,17 @@ func (m *MachineScope) InstanceAdditionalDiskSpec() []*compute.AttachedDisk {
 	additionalDisks := make([]*compute.AttachedDisk, 0, len(m.GCPMachine.Spec.AdditionalDisks))
 	for _, disk := range m.GCPMachine.Spec.AdditionalDisks {
 		additionalDisk := &compute.AttachedDisk{
-			AutoDelete: true,
+			AutoDelete: pointer.BoolDeref(disk.AutoDelete, true),
+			DeviceName: pointer.StringDeref(disk.DeviceName, ""),
 			InitializeParams: &compute.AttachedDiskInitializeParams{
-				DiskSizeGb: pointer.Int64Deref(disk.Size, 30),
-				DiskType:   path.Join("zones", m.Zone(), "diskTypes", string(*disk.DeviceType)),
+				DiskSizeGb:            pointer.Int64Deref(disk.Size, 30),
+				DiskType:              path.Join("zones", m.Zone(), "diskTypes", string(*disk.DeviceType)),
+				Labels:                disk.Labels,
+				ProvisionedIops:       pointer.Int64Deref(disk.ProvisionedIOPS, 0),
+				ProvisionedThroughput: pointer.Int64Deref(disk.ProvisionedThroughput, 0),
+				ResourcePolicies:      m.resourcePolicyLinks(disk.ResourcePolicies),
 			},
+			DiskEncryptionKey: diskEncryptionKeySpec(disk.EncryptionKey),
 		}
 		if strings.HasSuffix(additionalDisk.InitializeParams.DiskType, string(infrav1.LocalSsdDiskType)) {
 			additionalDisk.Type = "SCRATCH" // Default is PERSISTENT.
@@ -270,9 +349,55 @@ func (m *MachineScope) InstanceAdditionalDiskSpec() []*compute.AttachedDisk {
 		additionalDisks = append(additionalDisks, additionalDisk)
 	}
 
+	if localSSDs := m.GCPMachine.Spec.LocalSSDs; localSSDs != nil {
+		for i := int32(0); i < localSSDs.Count; i++ {
+			additionalDisks = append(additionalDisks, &compute.AttachedDisk{
+				AutoDelete: true,
+				Type:       "SCRATCH",
+				Interface:  pointer.StringDeref(localSSDs.Interface, "NVME"),
+				InitializeParams: &compute.AttachedDiskInitializeParams{
+					DiskSizeGb: 375,
+					DiskType:   path.Join("zones", m.Zone(), "diskTypes", string(infrav1.LocalSsdDiskType)),
+				},
+			})
+		}
+	}
+
 	return additionalDisks
 }
 
+// localSSDStartupScript returns the startup script formatting and mounting the local SSDs of the instance.
+func localSSDStartupScript(localSSDs *infrav1.LocalSSDSpec) string {
+	devicePrefix := "/dev/disk/by-id/google-local-nvme-ssd-"
+	if pointer.StringDeref(localSSDs.Interface, "NVME") == "SCSI" {
+		devicePrefix = "/dev/disk/by-id/google-local-ssd-"
+	}
+
+	device := devicePrefix + "0"
+	assemble := ""
+	if localSSDs.Count > 1 {
+		device = "/dev/md/local-ssds"
+		assemble = fmt.Sprintf(`if [ ! -e %[1]s ]; then
+  mdadm --create %[1]s --level=0 --raid-devices=%[2]d --force --run %[3]s*
+fi
+`, device, localSSDs.Count, devicePrefix)
+	}
+
+	mountPath := localSSDs.Mount.Path
+	filesystem := pointer.StringDeref(localSSDs.Mount.Filesystem, "ext4")
+	return fmt.Sprintf(`#!/bin/bash
+set -euo pipefail
+if mountpoint -q %[1]s; then
+  exit 0
+fi
+%[2]sif ! blkid %[3]s; then
+  mkfs.%[4]s %[3]s
+fi
+mkdir -p %[1]s
+mount -o discard,defaults %[3]s %[1]s
+`, mountPath, assemble, device, filesystem)
+}
+
 // InstanceNetworkInterfaceSpec returns compute network interface spec.
 func (m *MachineScope) InstanceNetworkInterfaceSpec() *compute.NetworkInterface {
 	networkInterface := &compute.NetworkInterface{
@@ -292,6 +417,24 @@ func (m *MachineScope) InstanceNetworkInterfaceSpec() *compute.NetworkInterface
 		networkInterface.Subnetwork = path.Join("regions", m.ClusterGetter.Region(), "subnetworks", *m.GCPMachine.Spec.Subnet)
 	}
 
+	if m.GCPMachine.Spec.StackType != nil {
+		networkInterface.StackType = *m.GCPMachine.Spec.StackType
+	}
+
+	if m.GCPMachine.Spec.NicType != nil {
+		networkInterface.NicType = *m.GCPMachine.Spec.NicType
+	}
+
+	if pointer.StringDeref(m.GCPMachine.Spec.IPv6AccessType, "") == "EXTERNAL" {
+		networkInterface.Ipv6AccessConfigs = []*compute.AccessConfig{
+			{
+				Type:        "DIRECT_IPV6",
+				Name:        "External IPv6",
+				NetworkTier: "PREMIUM",
+			},
+		}
+	}
+
 	return networkInterface
 }
 
@@ -322,6 +465,42 @@ func (m *MachineScope) InstanceAdditionalMetadataSpec() *compute.Metadata {
 		})
 	}
 
+	if localSSDs := m.GCPMachine.Spec.LocalSSDs; localSSDs != nil && localSSDs.Mount != nil {
+		metadata.Items = append(metadata.Items, &compute.MetadataItems{
+			Key:   "startup-script",
+			Value: pointer.String(localSSDStartupScript(localSSDs)),
+		})
+	}
+
+	if osLogin := m.GCPMachine.Spec.OSLogin; osLogin != nil {
+		enableOSLogin := "FALSE"
+		if *osLogin == infrav1.OSLoginPolicyEnabled {
+			enableOSLogin = "TRUE"
+		}
+		metadata.Items = append(metadata.Items, &compute.MetadataItems{
+			Key:   "enable-oslogin",
+			Value: pointer.String(enableOSLogin),
+		})
+	}
+
+	if len(m.GCPMachine.Spec.SSHKeys) > 0 {
+		sshKeys := make([]string, 0, len(m.GCPMachine.Spec.SSHKeys))
+		for _, sshKey := range m.GCPMachine.Spec.SSHKeys {
+			sshKeys = append(sshKeys, fmt.Sprintf("%s:%s", sshKey.Username, strings.TrimSpace(sshKey.PublicKey)))
+		}
+		metadata.Items = append(metadata.Items, &compute.MetadataItems{
+			Key:   "ssh-keys",
+			Value: pointer.String(strings.Join(sshKeys, "\n")),
+		})
+	}
+
+	if pointer.BoolDeref(m.GCPMachine.Spec.BlockProjectSSHKeys, false) {
+		metadata.Items = append(metadata.Items, &compute.MetadataItems{
+			Key:   "block-project-ssh-keys",
+			Value: pointer.String("TRUE"),
+		})
+	}
+
 	return metadata
 }
 
@@ -359,6 +538,8 @@ func (m *MachineScope) InstanceSpec(log logr.Logger) *compute.Instance {
 			EnableSecureBoot:          false,
 			EnableVtpm:                true,
 			EnableIntegrityMonitoring: true,
+			// Disabled features are sent explicitly so that they do not fall back to the defaults of the image.
+			ForceSendFields: []string{"EnableSecureBoot", "EnableVtpm", "EnableIntegrityMonitoring"},
 		}
 		if m.GCPMachine.Spec.ShieldedInstanceConfig.SecureBoot == infrav1.SecureBootPolicyEnabled {
 			instance.ShieldedInstanceConfig.EnableSecureBoot = true
@@ -382,12 +563,53 @@ func (m *MachineScope) InstanceSpec(log logr.Logger) *compute.Instance {
 
 		instance.Scheduling.OnHostMaintenance = strings.ToUpper(string(*m.GCPMachine.Spec.OnHostMaintenance))
 	}
+	if m.GCPMachine.Spec.AutomaticRestart != nil {
+		instance.Scheduling.AutomaticRestart = m.GCPMachine.Spec.AutomaticRestart
+	}
+	if m.GCPMachine.Spec.ProvisioningModel != nil && *m.GCPMachine.Spec.ProvisioningModel == infrav1.ProvisioningModelSpot {
+		instance.Scheduling.ProvisioningModel = "SPOT"
+		// Spot instances cannot be live migrated nor restarted automatically.
+		instance.Scheduling.AutomaticRestart = pointer.Bool(false)
+		instance.Scheduling.OnHostMaintenance = "TERMINATE"
+	}
+	if m.GCPMachine.Spec.InstanceTerminationAction != nil {
+		instance.Scheduling.InstanceTerminationAction = strings.ToUpper(string(*m.GCPMachine.Spec.InstanceTerminationAction))
+	}
+	for _, affinity := range m.GCPMachine.Spec.NodeAffinities {
+		operator := "IN"
+		if affinity.Operator == infrav1.NodeAffinityOperatorNotIn {
+			operator = "NOT_IN"
+		}
+		instance.Scheduling.NodeAffinities = append(instance.Scheduling.NodeAffinities, &compute.SchedulingNodeAffinity{
+			Key:      affinity.Key,
+			Operator: operator,
+			Values:   affinity.Values,
+		})
+	}
 	if m.GCPMachine.Spec.ConfidentialCompute != nil {
 		enabled := *m.GCPMachine.Spec.ConfidentialCompute == infrav1.ConfidentialComputePolicyEnabled
 		instance.ConfidentialInstanceConfig = &compute.ConfidentialInstanceConfig{
 			EnableConfidentialCompute: enabled,
 		}
 	}
+	if config := m.GCPMachine.Spec.NetworkPerformanceConfig; config != nil {
+		instance.NetworkPerformanceConfig = &compute.NetworkPerformanceConfig{
+			TotalEgressBandwidthTier: config.TotalEgressBandwidthTier,
+		}
+	}
+	if features := m.GCPMachine.Spec.AdvancedMachineFeatures; features != nil {
+		instance.AdvancedMachineFeatures = &compute.AdvancedMachineFeatures{
+			EnableNestedVirtualization: pointer.BoolDeref(features.EnableNestedVirtualization, false),
+			ThreadsPerCore:             pointer.Int64Deref(features.ThreadsPerCore, 0),
+		}
+	}
+	instance.MinCpuPlatform = pointer.StringDeref(m.GCPMachine.Spec.MinCPUPlatform, "")
+	for _, accelerator := range m.GCPMachine.Spec.GuestAccelerators {
+		instance.GuestAccelerators = append(instance.GuestAccelerators, &compute.AcceleratorConfig{
+			AcceleratorType:  path.Join("projects", m.ClusterGetter.Project(), "zones", m.Zone(), "acceleratorTypes", accelerator.Type),
+			AcceleratorCount: accelerator.Count,
+		})
+	}
 
 	instance.Disks = append(instance.Disks, m.InstanceImageSpec())
 	instance.Disks = append(instance.Disks, m.InstanceAdditionalDiskSpec()...)
@@ -2,8 +2,10 @@ package scope
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -72,3 +74,211 @@ func TestMachineLocalSSDDiskType(t *testing.T) {
 	assert.Equal(t, "NVME", localSSDTest.Interface)
 	assert.Equal(t, int64(375), localSSDTest.InitializeParams.DiskSizeGb)
 }
+
+// This test verifies that the LocalSSDs of a GCPMachine are attached as scratch disks
+// and mounted by a startup script.
+func TestMachineLocalSSDs(t *testing.T) {
+	schema, err := infrav1.SchemeBuilder.Register(&infrav1.GCPMachine{}, &infrav1.GCPMachineList{}).Build()
+	assert.Nil(t, err)
+
+	testClient := fake.NewClientBuilder().WithScheme(schema).Build()
+
+	failureDomain := "us-central1-a"
+	testMachine := clusterv1.Machine{
+		Spec: clusterv1.MachineSpec{
+			FailureDomain: &failureDomain,
+		},
+	}
+
+	scsi := "SCSI"
+	testGCPMachine := infrav1.GCPMachine{
+		Spec: infrav1.GCPMachineSpec{
+			LocalSSDs: &infrav1.LocalSSDSpec{
+				Count:     2,
+				Interface: &scsi,
+				Mount: &infrav1.LocalSSDMount{
+					Path: "/mnt/disks/local-ssds",
+				},
+			},
+		},
+	}
+
+	testMachineScope, err := NewMachineScope(MachineScopeParams{
+		Client:     testClient,
+		Machine:    &testMachine,
+		GCPMachine: &testGCPMachine,
+	})
+	assert.Nil(t, err)
+
+	diskSpec := testMachineScope.InstanceAdditionalDiskSpec()
+	assert.Len(t, diskSpec, 2)
+	for _, disk := range diskSpec {
+		assert.Equal(t, "SCRATCH", disk.Type)
+		assert.Equal(t, "SCSI", disk.Interface)
+		assert.Equal(t, "zones/us-central1-a/diskTypes/local-ssd", disk.InitializeParams.DiskType)
+	}
+
+	metadata := testMachineScope.InstanceAdditionalMetadataSpec()
+	assert.Len(t, metadata.Items, 1)
+	assert.Equal(t, "startup-script", metadata.Items[0].Key)
+	assert.Contains(t, *metadata.Items[0].Value, "--raid-devices=2 --force --run /dev/disk/by-id/google-local-ssd-*")
+	assert.Contains(t, *metadata.Items[0].Value, "mkfs.ext4 /dev/md/local-ssds")
+	assert.Contains(t, *metadata.Items[0].Value, "mount -o discard,defaults /dev/md/local-ssds /mnt/disks/local-ssds")
+}
+
+func TestMachineAdditionalDiskEncryption(t *testing.T) {
+	schema, err := infrav1.SchemeBuilder.Register(&infrav1.GCPMachine{}, &infrav1.GCPMachineList{}).Build()
+	assert.Nil(t, err)
+
+	testClient := fake.NewClientBuilder().WithScheme(schema).Build()
+
+	failureDomain := "us-central1-a"
+	testMachine := clusterv1.Machine{
+		Spec: clusterv1.MachineSpec{
+			FailureDomain: &failureDomain,
+		},
+	}
+
+	pdSSD := infrav1.PdSsdDiskType
+	keyName := "projects/my-proj/locations/us-central1/keyRings/my-ring/cryptoKeys/my-key"
+	testGCPMachine := infrav1.GCPMachine{
+		Spec: infrav1.GCPMachineSpec{
+			AdditionalDisks: []infrav1.AttachedDiskSpec{
+				{
+					DeviceType: &pdSSD,
+					EncryptionKey: &infrav1.CustomerEncryptionKey{
+						KMSKeyName: keyName,
+					},
+				},
+				{
+					DeviceType: &pdSSD,
+				},
+			},
+		},
+	}
+
+	testMachineScope, err := NewMachineScope(MachineScopeParams{
+		Client:     testClient,
+		Machine:    &testMachine,
+		GCPMachine: &testGCPMachine,
+	})
+	assert.Nil(t, err)
+
+	diskSpec := testMachineScope.InstanceAdditionalDiskSpec()
+	assert.Len(t, diskSpec, 2)
+	assert.NotNil(t, diskSpec[0].DiskEncryptionKey)
+	assert.Equal(t, keyName, diskSpec[0].DiskEncryptionKey.KmsKeyName)
+	assert.Nil(t, diskSpec[1].DiskEncryptionKey)
+}
+
+func TestMachineAdditionalDisks(t *testing.T) {
+	schema, err := infrav1.SchemeBuilder.Register(&infrav1.GCPMachine{}, &infrav1.GCPMachineList{}).Build()
+	assert.Nil(t, err)
+
+	testClient := fake.NewClientBuilder().WithScheme(schema).Build()
+
+	failureDomain := "us-central1-a"
+	testMachine := clusterv1.Machine{
+		Spec: clusterv1.MachineSpec{
+			FailureDomain: &failureDomain,
+		},
+	}
+
+	pdSSD := infrav1.PdSsdDiskType
+	deviceName := "etcd"
+	autoDelete := false
+	testGCPMachine := infrav1.GCPMachine{
+		Spec: infrav1.GCPMachineSpec{
+			AdditionalDisks: []infrav1.AttachedDiskSpec{
+				{
+					DeviceType: &pdSSD,
+					DeviceName: &deviceName,
+					AutoDelete: &autoDelete,
+					Labels:     map[string]string{"purpose": "etcd"},
+				},
+				{
+					DeviceType: &pdSSD,
+				},
+			},
+		},
+	}
+
+	testMachineScope, err := NewMachineScope(MachineScopeParams{
+		Client:     testClient,
+		Machine:    &testMachine,
+		GCPMachine: &testGCPMachine,
+	})
+	assert.Nil(t, err)
+
+	diskSpec := testMachineScope.InstanceAdditionalDiskSpec()
+	assert.Len(t, diskSpec, 2)
+	assert.Equal(t, "etcd", diskSpec[0].DeviceName)
+	assert.False(t, diskSpec[0].AutoDelete)
+	assert.Equal(t, map[string]string{"purpose": "etcd"}, diskSpec[0].InitializeParams.Labels)
+	assert.Empty(t, diskSpec[1].DeviceName)
+	assert.True(t, diskSpec[1].AutoDelete)
+}
+
+func TestMachineSSHAccessMetadata(t *testing.T) {
+	schema, err := infrav1.SchemeBuilder.Register(&infrav1.GCPMachine{}, &infrav1.GCPMachineList{}).Build()
+	assert.Nil(t, err)
+
+	testClient := fake.NewClientBuilder().WithScheme(schema).Build()
+
+	osLoginDisabled := infrav1.OSLoginPolicyDisabled
+	blockProjectSSHKeys := true
+	testGCPMachine := infrav1.GCPMachine{
+		Spec: infrav1.GCPMachineSpec{
+			OSLogin: &osLoginDisabled,
+			SSHKeys: []infrav1.SSHKey{
+				{Username: "admin", PublicKey: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIA admin@example.com\n"},
+				{Username: "ops", PublicKey: "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB ops@example.com"},
+			},
+			BlockProjectSSHKeys: &blockProjectSSHKeys,
+		},
+	}
+
+	testMachineScope, err := NewMachineScope(MachineScopeParams{
+		Client:     testClient,
+		Machine:    &clusterv1.Machine{},
+		GCPMachine: &testGCPMachine,
+	})
+	assert.Nil(t, err)
+
+	metadata := testMachineScope.InstanceAdditionalMetadataSpec()
+	assert.Len(t, metadata.Items, 3)
+	assert.Equal(t, "enable-oslogin", metadata.Items[0].Key)
+	assert.Equal(t, "FALSE", *metadata.Items[0].Value)
+	assert.Equal(t, "ssh-keys", metadata.Items[1].Key)
+	assert.Equal(t, "admin:ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIA admin@example.com\nops:ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIB ops@example.com", *metadata.Items[1].Value)
+	assert.Equal(t, "block-project-ssh-keys", metadata.Items[2].Key)
+	assert.Equal(t, "TRUE", *metadata.Items[2].Value)
+}
+
+func TestMachineRunDeadline(t *testing.T) {
+	schema, err := infrav1.SchemeBuilder.Register(&infrav1.GCPMachine{}, &infrav1.GCPMachineList{}).Build()
+	assert.Nil(t, err)
+
+	testClient := fake.NewClientBuilder().WithScheme(schema).Build()
+
+	created := metav1.NewTime(time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC))
+	testGCPMachine := infrav1.GCPMachine{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created},
+	}
+
+	testMachineScope, err := NewMachineScope(MachineScopeParams{
+		Client:     testClient,
+		Machine:    &clusterv1.Machine{},
+		GCPMachine: &testGCPMachine,
+	})
+	assert.Nil(t, err)
+
+	assert.Nil(t, testMachineScope.RunDeadline())
+	assert.False(t, testMachineScope.IsEphemeral())
+
+	testGCPMachine.Spec.MaxRunDuration = &metav1.Duration{Duration: 3 * time.Hour}
+	deadline := testMachineScope.RunDeadline()
+	assert.NotNil(t, deadline)
+	assert.Equal(t, created.Add(3*time.Hour), *deadline)
+	assert.True(t, testMachineScope.IsEphemeral())
+}
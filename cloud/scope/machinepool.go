@@ -0,0 +1,388 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"path"
+	"sort"
+
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// MachinePoolScopeParams defines the input parameters used to create a new MachinePoolScope.
+type MachinePoolScopeParams struct {
+	Client         client.Client
+	ClusterGetter  cloud.ClusterGetter
+	MachinePool    *clusterv1exp.MachinePool
+	GCPMachinePool *infrav1exp.GCPMachinePool
+}
+
+// NewMachinePoolScope creates a new MachinePoolScope from the supplied parameters.
+// This is meant to be called for each reconcile iteration.
+func NewMachinePoolScope(params MachinePoolScopeParams) (*MachinePoolScope, error) {
+	if params.Client == nil {
+		return nil, errors.New("client is required when creating a MachinePoolScope")
+	}
+	if params.MachinePool == nil {
+		return nil, errors.New("machine pool is required when creating a MachinePoolScope")
+	}
+	if params.GCPMachinePool == nil {
+		return nil, errors.New("gcp machine pool is required when creating a MachinePoolScope")
+	}
+
+	helper, err := patch.NewHelper(params.GCPMachinePool, params.Client)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to init patch helper")
+	}
+
+	return &MachinePoolScope{
+		client:         params.Client,
+		MachinePool:    params.MachinePool,
+		GCPMachinePool: params.GCPMachinePool,
+		ClusterGetter:  params.ClusterGetter,
+		patchHelper:    helper,
+	}, nil
+}
+
+// MachinePoolScope defines a scope defined around a machine pool and its cluster.
+type MachinePoolScope struct {
+	client         client.Client
+	patchHelper    *patch.Helper
+	ClusterGetter  cloud.ClusterGetter
+	MachinePool    *clusterv1exp.MachinePool
+	GCPMachinePool *infrav1exp.GCPMachinePool
+}
+
+// ANCHOR: MachinePoolGetter
+
+// Cloud returns initialized cloud.
+func (m *MachinePoolScope) Cloud() cloud.Cloud {
+	return m.ClusterGetter.Cloud()
+}
+
+// ComputeService returns the compute service of the cluster, for the operations not covered by Cloud.
+func (m *MachinePoolScope) ComputeService() *compute.Service {
+	return m.ClusterGetter.ComputeService()
+}
+
+// Name returns the GCPMachinePool name.
+func (m *MachinePoolScope) Name() string {
+	return m.GCPMachinePool.Name
+}
+
+// Namespace returns the namespace name.
+func (m *MachinePoolScope) Namespace() string {
+	return m.GCPMachinePool.Namespace
+}
+
+// ClusterName returns the name of the cluster of the machine pool.
+func (m *MachinePoolScope) ClusterName() string {
+	return m.ClusterGetter.Name()
+}
+
+// Project return the project for the GCPMachinePool's cluster.
+func (m *MachinePoolScope) Project() string {
+	return m.ClusterGetter.Project()
+}
+
+// Zone returns the zone of the managed instance group, which is the first failure domain of the MachinePool
+// or of the cluster.
+func (m *MachinePoolScope) Zone() string {
+	if len(m.MachinePool.Spec.FailureDomains) > 0 {
+		return m.MachinePool.Spec.FailureDomains[0]
+	}
+
+	fd := m.ClusterGetter.FailureDomains()
+	if len(fd) == 0 {
+		return ""
+	}
+	zones := make([]string, 0, len(fd))
+	for zone := range fd {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+	return zones[0]
+}
+
+// Replicas returns the desired number of instances of the machine pool.
+func (m *MachinePoolScope) Replicas() int64 {
+	if m.MachinePool.Spec.Replicas == nil {
+		return 1
+	}
+	return int64(*m.MachinePool.Spec.Replicas)
+}
+
+// GetBootstrapData returns the bootstrap data from the secret in the MachinePool's bootstrap.dataSecretName.
+func (m *MachinePoolScope) GetBootstrapData() (string, error) {
+	if m.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName == nil {
+		return "", errors.New("error retrieving bootstrap data: linked MachinePool's bootstrap.dataSecretName is nil")
+	}
+
+	secret := &corev1.Secret{}
+	key := types.NamespacedName{Namespace: m.Namespace(), Name: *m.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName}
+	if err := m.client.Get(context.TODO(), key, secret); err != nil {
+		return "", errors.Wrapf(err, "failed to retrieve bootstrap data secret for GCPMachinePool %s/%s", m.Namespace(), m.Name())
+	}
+
+	value, ok := secret.Data["value"]
+	if !ok {
+		return "", errors.New("error retrieving bootstrap data: secret value key is missing")
+	}
+
+	return string(value), nil
+}
+
+// ANCHOR_END: MachinePoolGetter
+
+// ANCHOR: MachinePoolSetter
+
+// SetReady sets the GCPMachinePool Ready Status.
+func (m *MachinePoolScope) SetReady() {
+	m.GCPMachinePool.Status.Ready = true
+}
+
+// SetNotReady sets the GCPMachinePool Ready Status to false.
+func (m *MachinePoolScope) SetNotReady() {
+	m.GCPMachinePool.Status.Ready = false
+}
+
+// SetReplicas sets the observed number of replicas.
+func (m *MachinePoolScope) SetReplicas(replicas int32) {
+	m.GCPMachinePool.Status.Replicas = replicas
+}
+
+// SetProviderIDList sets the provider IDs of the instances of the managed instance group.
+func (m *MachinePoolScope) SetProviderIDList(providerIDs []string) {
+	m.GCPMachinePool.Spec.ProviderIDList = providerIDs
+}
+
+// SetInstanceTemplate sets the name of the instance template used by the managed instance group.
+func (m *MachinePoolScope) SetInstanceTemplate(name string) {
+	m.GCPMachinePool.Status.InstanceTemplate = name
+}
+
+// ANCHOR_END: MachinePoolSetter
+
+// ANCHOR: MachinePoolInstanceGroupSpec
+
+// machineScope returns a scope of a machine created from the template of the machine pool, so that the
+// instance templates are built the same way as the instances of GCPMachines.
+func (m *MachinePoolScope) machineScope() *MachineScope {
+	zone := m.Zone()
+	return &MachineScope{
+		ClusterGetter: m.ClusterGetter,
+		Machine: &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: m.MachinePool.Spec.Template.Labels,
+			},
+			Spec: clusterv1.MachineSpec{
+				Version:       m.MachinePool.Spec.Template.Spec.Version,
+				FailureDomain: &zone,
+			},
+		},
+		GCPMachine: &infrav1.GCPMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      m.Name(),
+				Namespace: m.Namespace(),
+			},
+			Spec: m.GCPMachinePool.Spec.Template,
+		},
+	}
+}
+
+// InstanceTemplateSpec returns the instance template spec of the machine pool. The name of the template
+// contains a hash of its properties followed by a hash of the bootstrap data, so that a new template is created
+// whenever either changes while a change of the bootstrap data alone can be told apart.
+func (m *MachinePoolScope) InstanceTemplateSpec(log logr.Logger, bootstrapData string) (*compute.InstanceTemplate, error) {
+	instance := m.machineScope().InstanceSpec(log)
+
+	// Instance templates reference machine, disk and accelerator types and resource policies by name instead
+	// of zonal or regional URLs.
+	for _, disk := range instance.Disks {
+		disk.InitializeParams.DiskType = path.Base(disk.InitializeParams.DiskType)
+		for i, resourcePolicy := range disk.InitializeParams.ResourcePolicies {
+			disk.InitializeParams.ResourcePolicies[i] = path.Base(resourcePolicy)
+		}
+	}
+	for _, accelerator := range instance.GuestAccelerators {
+		accelerator.AcceleratorType = path.Base(accelerator.AcceleratorType)
+	}
+
+	properties := &compute.InstanceProperties{
+		MachineType:                m.GCPMachinePool.Spec.Template.InstanceType,
+		Tags:                       instance.Tags,
+		Labels:                     instance.Labels,
+		Scheduling:                 instance.Scheduling,
+		CanIpForward:               instance.CanIpForward,
+		ShieldedInstanceConfig:     instance.ShieldedInstanceConfig,
+		ConfidentialInstanceConfig: instance.ConfidentialInstanceConfig,
+		Disks:                      instance.Disks,
+		Metadata:                   instance.Metadata,
+		ServiceAccounts:            instance.ServiceAccounts,
+		NetworkInterfaces:          instance.NetworkInterfaces,
+		GuestAccelerators:          instance.GuestAccelerators,
+		MinCpuPlatform:             instance.MinCpuPlatform,
+		AdvancedMachineFeatures:    instance.AdvancedMachineFeatures,
+		NetworkPerformanceConfig:   instance.NetworkPerformanceConfig,
+	}
+
+	// The bootstrap data is rotated regularly by the bootstrap provider, it is left out of the properties hash
+	// so that its rotation does not trigger a rollout of the instances.
+	data, err := json.Marshal(properties)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal instance template properties")
+	}
+	properties.Metadata.Items = append(properties.Metadata.Items, &compute.MetadataItems{
+		Key:   "user-data",
+		Value: &bootstrapData,
+	})
+
+	return &compute.InstanceTemplate{
+		Name:        fmt.Sprintf("%s-%s-%s", m.InstanceTemplatePrefix(), shortHash(data), shortHash([]byte(bootstrapData))),
+		Description: infrav1.ClusterTagKey(m.ClusterName()),
+		Properties:  properties,
+	}, nil
+}
+
+// InstanceTemplatePrefix returns the prefix of the names of the instance templates of the machine pool.
+func (m *MachinePoolScope) InstanceTemplatePrefix() string {
+	// Leave room for the hash suffixes within the 63 characters limit of resource names.
+	name := m.Name()
+	if len(name) > 45 {
+		name = name[:45]
+	}
+	return name
+}
+
+// InstanceGroupManagerName returns the name of the managed instance group of the machine pool, which is also the
+// base name of its instances. The name is truncated to the 58 characters limit of base instance names and suffixed
+// with a hash of the namespace, cluster and name of the machine pool, for machine pools of different clusters not
+// to collide.
+func (m *MachinePoolScope) InstanceGroupManagerName() string {
+	name := m.Name()
+	if len(name) > 49 {
+		name = name[:49]
+	}
+	return fmt.Sprintf("%s-%s", name, shortHash([]byte(fmt.Sprintf("%s/%s/%s", m.Namespace(), m.ClusterName(), m.Name()))))
+}
+
+// InstanceGroupManagerSpec returns the managed instance group spec of the machine pool.
+func (m *MachinePoolScope) InstanceGroupManagerSpec(instanceTemplate string) *compute.InstanceGroupManager {
+	maxSurge := intstr.FromInt(1)
+	maxUnavailable := intstr.FromInt(0)
+	if strategy := m.GCPMachinePool.Spec.Strategy; strategy != nil {
+		if strategy.MaxSurge != nil {
+			maxSurge = *strategy.MaxSurge
+		}
+		if strategy.MaxUnavailable != nil {
+			maxUnavailable = *strategy.MaxUnavailable
+		}
+	}
+
+	return &compute.InstanceGroupManager{
+		Name:             m.InstanceGroupManagerName(),
+		Description:      infrav1.ClusterTagKey(m.ClusterName()),
+		BaseInstanceName: m.InstanceGroupManagerName(),
+		InstanceTemplate: instanceTemplate,
+		TargetSize:       m.Replicas(),
+		UpdatePolicy:     m.updatePolicySpec(maxSurge, maxUnavailable),
+		ForceSendFields:  []string{"TargetSize"},
+	}
+}
+
+// ANCHOR_END: MachinePoolInstanceGroupSpec
+
+// updatePolicySpec returns the proactive update policy of the managed instance group. GCE only accepts
+// percentages for groups of at least 10 instances, smaller groups get the number of instances they amount to,
+// rounded up for the surge and down for the unavailable instances as for Deployments.
+func (m *MachinePoolScope) updatePolicySpec(maxSurge, maxUnavailable intstr.IntOrString) *compute.InstanceGroupManagerUpdatePolicy {
+	policy := &compute.InstanceGroupManagerUpdatePolicy{
+		Type:          "PROACTIVE",
+		MinimalAction: "REPLACE",
+	}
+
+	if m.Replicas() >= infrav1exp.MinInstancesForPercentUpdate {
+		policy.MaxSurge = fixedOrPercent(maxSurge)
+		policy.MaxUnavailable = fixedOrPercent(maxUnavailable)
+		return policy
+	}
+
+	surge, _ := intstr.GetScaledValueFromIntOrPercent(&maxSurge, int(m.Replicas()), true)
+	unavailable, _ := intstr.GetScaledValueFromIntOrPercent(&maxUnavailable, int(m.Replicas()), false)
+	if surge == 0 && unavailable == 0 {
+		// GCE cannot update a group without surging or taking down at least one instance.
+		surge = 1
+	}
+	policy.MaxSurge = fixedOrPercent(intstr.FromInt(surge))
+	policy.MaxUnavailable = fixedOrPercent(intstr.FromInt(unavailable))
+	return policy
+}
+
+// fixedOrPercent converts an int or percentage value to its google compute representation.
+func fixedOrPercent(value intstr.IntOrString) *compute.FixedOrPercent {
+	if value.Type == intstr.String {
+		percent, _ := intstr.GetScaledValueFromIntOrPercent(&value, 100, false)
+		return &compute.FixedOrPercent{Percent: int64(percent), ForceSendFields: []string{"Percent"}}
+	}
+
+	return &compute.FixedOrPercent{Fixed: int64(value.IntValue()), ForceSendFields: []string{"Fixed"}}
+}
+
+// shortHash returns a short hash of the given data.
+func shortHash(data []byte) string {
+	hasher := fnv.New32a()
+	_, _ = hasher.Write(data)
+	return fmt.Sprintf("%08x", hasher.Sum32())
+}
+
+// PatchObject persists the machine pool spec and status.
+func (m *MachinePoolScope) PatchObject() error {
+	return m.patchHelper.Patch(
+		context.TODO(),
+		m.GCPMachinePool,
+		patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			infrav1exp.GCPMachinePoolReadyCondition,
+		}})
+}
+
+// Close closes the current scope persisting the machine pool configuration and status.
+func (m *MachinePoolScope) Close() error {
+	return m.PatchObject()
+}
+
+// ConditionSetter return a condition setter (which is GCPMachinePool itself).
+func (m *MachinePoolScope) ConditionSetter() conditions.Setter {
+	return m.GCPMachinePool
+}
@@ -19,10 +19,11 @@ package scope
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"sort"
 
 	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/servicenetworking/v1"
 	"k8s.io/utils/pointer"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
@@ -51,8 +52,13 @@ func NewManagedClusterScope(ctx context.Context, params ManagedClusterScopeParam
 		return nil, errors.New("failed to generate new scope from nil GCPManagedCluster")
 	}
 
+	credsSource, err := getClusterCredentialsSource(ctx, params.Client, params.GCPManagedCluster.Namespace, params.GCPManagedCluster.Spec.CredentialsRef, params.GCPManagedCluster.Spec.IdentityRef, params.GCPManagedCluster.Spec.ImpersonateServiceAccount, params.GCPManagedCluster.Spec.QuotaProject)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get gcp credentials")
+	}
+
 	if params.GCPServices.Compute == nil {
-		computeSvc, err := newComputeService(ctx, params.GCPManagedCluster.Spec.CredentialsRef, params.Client)
+		computeSvc, err := newComputeService(ctx, credsSource, params.Client)
 		if err != nil {
 			return nil, errors.Errorf("failed to create gcp compute client: %v", err)
 		}
@@ -60,6 +66,15 @@ func NewManagedClusterScope(ctx context.Context, params ManagedClusterScopeParam
 		params.GCPServices.Compute = computeSvc
 	}
 
+	if params.GCPServices.ServiceNetworking == nil && params.GCPManagedCluster.Spec.PrivateServiceAccess != nil {
+		serviceNetworkingSvc, err := newServiceNetworkingService(ctx, credsSource, params.Client)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp service networking client: %v", err)
+		}
+
+		params.GCPServices.ServiceNetworking = serviceNetworkingSvc
+	}
+
 	helper, err := patch.NewHelper(params.GCPManagedCluster, params.Client)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to init patch helper")
@@ -93,6 +108,11 @@ func (s *ManagedClusterScope) Cloud() cloud.Cloud {
 	return newCloud(s.Project(), s.GCPServices)
 }
 
+// ComputeService returns the compute service, for the resources not covered by Cloud.
+func (s *ManagedClusterScope) ComputeService() *compute.Service {
+	return s.GCPServices.Compute
+}
+
 // Project returns the current project name.
 func (s *ManagedClusterScope) Project() string {
 	return s.GCPManagedCluster.Spec.Project
@@ -118,9 +138,24 @@ func (s *ManagedClusterScope) NetworkName() string {
 	return pointer.StringDeref(s.GCPManagedCluster.Spec.Network.Name, "default")
 }
 
+// NetworkProject returns the project of the cluster network, which is the host project for shared VPC networks.
+func (s *ManagedClusterScope) NetworkProject() string {
+	return pointer.StringDeref(s.GCPManagedCluster.Spec.Network.HostProject, s.Project())
+}
+
+// IsSharedVpc returns true if the cluster network is a shared VPC network of a host project.
+func (s *ManagedClusterScope) IsSharedVpc() bool {
+	return s.NetworkProject() != s.Project()
+}
+
+// IsExistingNetwork returns true if the cluster network and subnets already exist and are not managed by CAPG.
+func (s *ManagedClusterScope) IsExistingNetwork() bool {
+	return pointer.BoolDeref(s.GCPManagedCluster.Spec.Network.UseExisting, false)
+}
+
 // NetworkLink returns the partial URL for the network.
 func (s *ManagedClusterScope) NetworkLink() string {
-	return fmt.Sprintf("projects/%s/global/networks/%s", s.Project(), s.NetworkName())
+	return fmt.Sprintf("projects/%s/global/networks/%s", s.NetworkProject(), s.NetworkName())
 }
 
 // Network returns the cluster network object.
@@ -164,6 +199,21 @@ func (s *ManagedClusterScope) SetControlPlaneEndpoint(endpoint clusterv1.APIEndp
 	s.GCPManagedCluster.Spec.ControlPlaneEndpoint = endpoint
 }
 
+// SetSecondaryRanges sets the names of the secondary ranges of each subnet.
+func (s *ManagedClusterScope) SetSecondaryRanges(ranges map[string][]string) {
+	s.GCPManagedCluster.Status.SecondaryRanges = ranges
+}
+
+// SetNatIPs sets the external IP addresses of the Cloud NAT.
+func (s *ManagedClusterScope) SetNatIPs(ips []string) {
+	s.GCPManagedCluster.Status.NatIPs = ips
+}
+
+// SetPrivateServiceConnectEndpoint sets the IP address of the Private Service Connect endpoint.
+func (s *ManagedClusterScope) SetPrivateServiceConnectEndpoint(address *string) {
+	s.GCPManagedCluster.Status.PrivateServiceConnectEndpoint = address
+}
+
 // ANCHOR_END: ClusterSetter
 
 // ANCHOR: ClusterNetworkSpec
@@ -175,6 +225,7 @@ func (s *ManagedClusterScope) NetworkSpec() *compute.Network {
 		Name:                  s.NetworkName(),
 		Description:           infrav1.ClusterTagKey(s.Name()),
 		AutoCreateSubnetworks: createSubnet,
+		Mtu:                   pointer.Int64Deref(s.GCPManagedCluster.Spec.Network.MTU, 0),
 		ForceSendFields:       []string{"AutoCreateSubnetworks"},
 	}
 
@@ -184,93 +235,213 @@ func (s *ManagedClusterScope) NetworkSpec() *compute.Network {
 // NatRouterSpec returns google compute nat router spec.
 func (s *ManagedClusterScope) NatRouterSpec() *compute.Router {
 	networkSpec := s.NetworkSpec()
+	router := s.RouterSpec()
+	if router == nil {
+		router = &compute.Router{
+			Name: fmt.Sprintf("%s-%s", networkSpec.Name, "router"),
+		}
+	}
+	router.Nats = []*compute.RouterNat{
+		cloudNatSpec(fmt.Sprintf("%s-%s", networkSpec.Name, "nat"), s.Project(), s.Region(), s.GCPManagedCluster.Spec.Network.CloudNat),
+	}
+
+	return router
+}
+
+// CloudNatEnabled returns true if the Cloud NAT of the network is managed by CAPG.
+func (s *ManagedClusterScope) CloudNatEnabled() bool {
+	return cloudNatEnabled(s.GCPManagedCluster.Spec.Network)
+}
+
+// RouterSpec returns google compute router spec, or nil if no router is configured.
+func (s *ManagedClusterScope) RouterSpec() *compute.Router {
+	routerSpec := s.GCPManagedCluster.Spec.Network.Router
+	if routerSpec == nil {
+		return nil
+	}
+
 	return &compute.Router{
-		Name: fmt.Sprintf("%s-%s", networkSpec.Name, "router"),
-		Nats: []*compute.RouterNat{
-			{
-				Name:                          fmt.Sprintf("%s-%s", networkSpec.Name, "nat"),
-				NatIpAllocateOption:           "AUTO_ONLY",
-				SourceSubnetworkIpRangesToNat: "ALL_SUBNETWORKS_ALL_IP_RANGES",
-			},
-		},
+		Name:        pointer.StringDeref(routerSpec.Name, fmt.Sprintf("%s-%s", s.NetworkName(), "router")),
+		Description: infrav1.ClusterTagKey(s.Name()),
+		Network:     s.NetworkLink(),
+		Bgp:         routerBgpSpec(routerSpec),
 	}
 }
 
+// RouteSpecs returns google compute routes spec.
+func (s *ManagedClusterScope) RouteSpecs() []*compute.Route {
+	return routesSpec(s.GCPManagedCluster.Spec.Network.Routes, s.Project(), s.NetworkLink(), infrav1.ClusterTagKey(s.Name()))
+}
+
 // ANCHOR_END: ClusterNetworkSpec
 
 // SubnetSpecs returns google compute subnets spec.
 func (s *ManagedClusterScope) SubnetSpecs() []*compute.Subnetwork {
 	subnets := []*compute.Subnetwork{}
 	for _, subnetwork := range s.GCPManagedCluster.Spec.Network.Subnets {
-		secondaryIPRanges := []*compute.SubnetworkSecondaryRange{}
-		for _, secondaryCidrBlock := range subnetwork.SecondaryCidrBlocks {
-			secondaryIPRanges = append(secondaryIPRanges, &compute.SubnetworkSecondaryRange{IpCidrRange: secondaryCidrBlock})
+		secondaryIPRanges := secondaryIPRangesSpec(subnetwork.SecondaryCidrBlocks)
+		if policy := s.GCPManagedCluster.Spec.IPAllocationPolicy; policy != nil && policy.Subnetwork == subnetwork.Name {
+			secondaryIPRanges = appendSecondaryRange(secondaryIPRanges, policy.Pods)
+			secondaryIPRanges = appendSecondaryRange(secondaryIPRanges, policy.Services)
 		}
+		purpose := pointer.StringDeref(subnetwork.Purpose, "PRIVATE_RFC_1918")
 		subnets = append(subnets, &compute.Subnetwork{
 			Name:                  subnetwork.Name,
 			Region:                subnetwork.Region,
-			EnableFlowLogs:        pointer.BoolDeref(subnetwork.EnableFlowLogs, false),
-			PrivateIpGoogleAccess: pointer.BoolDeref(subnetwork.PrivateGoogleAccess, false),
+			EnableFlowLogs:        pointer.BoolDeref(subnetwork.EnableFlowLogs, false) || subnetwork.FlowLogs != nil,
+			LogConfig:             subnetLogConfigSpec(subnetwork.FlowLogs),
+			PrivateIpGoogleAccess: pointer.BoolDeref(subnetwork.PrivateGoogleAccess, !infrav1.IsProxyOnlySubnetPurpose(purpose)),
 			IpCidrRange:           subnetwork.CidrBlock,
 			SecondaryIpRanges:     secondaryIPRanges,
 			Description:           pointer.StringDeref(subnetwork.Description, infrav1.ClusterTagKey(s.Name())),
 			Network:               s.NetworkLink(),
-			Purpose:               pointer.StringDeref(subnetwork.Purpose, "PRIVATE_RFC_1918"),
-			Role:                  "ACTIVE",
+			Purpose:               purpose,
+			Role:                  subnetRole(purpose, subnetwork.Role),
+			StackType:             pointer.StringDeref(subnetwork.StackType, ""),
+			Ipv6AccessType:        pointer.StringDeref(subnetwork.IPv6AccessType, ""),
 		})
 	}
 
 	return subnets
 }
 
+// secondaryIPRangesSpec returns the google compute secondary ranges for the secondary CIDR blocks, named
+// after their keys so that GKE can refer to them, and sorted by name.
+func secondaryIPRangesSpec(secondaryCidrBlocks map[string]string) []*compute.SubnetworkSecondaryRange {
+	names := make([]string, 0, len(secondaryCidrBlocks))
+	for name := range secondaryCidrBlocks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	secondaryIPRanges := []*compute.SubnetworkSecondaryRange{}
+	for _, name := range names {
+		secondaryIPRanges = append(secondaryIPRanges, &compute.SubnetworkSecondaryRange{
+			RangeName:   name,
+			IpCidrRange: secondaryCidrBlocks[name],
+		})
+	}
+
+	return secondaryIPRanges
+}
+
+// appendSecondaryRange appends the secondary range of the IP allocation policy unless a range with the same
+// name is already defined or no CIDR block is set for it.
+func appendSecondaryRange(secondaryIPRanges []*compute.SubnetworkSecondaryRange, secondaryRange infrav1exp.SecondaryRange) []*compute.SubnetworkSecondaryRange {
+	if secondaryRange.CidrBlock == "" {
+		return secondaryIPRanges
+	}
+	for _, secondaryIPRange := range secondaryIPRanges {
+		if secondaryIPRange.RangeName == secondaryRange.Name {
+			return secondaryIPRanges
+		}
+	}
+
+	return append(secondaryIPRanges, &compute.SubnetworkSecondaryRange{
+		RangeName:   secondaryRange.Name,
+		IpCidrRange: secondaryRange.CidrBlock,
+	})
+}
+
+// PrivateServiceAccessAddressSpec returns google compute global address spec of the IP range reserved for private
+// services access, or nil if private services access is not configured.
+func (s *ManagedClusterScope) PrivateServiceAccessAddressSpec() *compute.Address {
+	psa := s.GCPManagedCluster.Spec.PrivateServiceAccess
+	if psa == nil {
+		return nil
+	}
+
+	return &compute.Address{
+		Name:         pointer.StringDeref(psa.AddressName, fmt.Sprintf("%s-%s", s.NetworkName(), "psa")),
+		Description:  infrav1.ClusterTagKey(s.Name()),
+		Address:      pointer.StringDeref(psa.Address, ""),
+		PrefixLength: psa.PrefixLength,
+		AddressType:  "INTERNAL",
+		Purpose:      "VPC_PEERING",
+		Network:      s.NetworkLink(),
+	}
+}
+
+// PrivateServiceConnectAddressSpec returns google compute address spec of the Private Service Connect endpoint
+// of the control plane, or nil if no endpoint is configured.
+func (s *ManagedClusterScope) PrivateServiceConnectAddressSpec() *compute.Address {
+	endpoint := s.GCPManagedCluster.Spec.PrivateServiceConnectEndpoint
+	if endpoint == nil {
+		return nil
+	}
+
+	return &compute.Address{
+		Name:        pointer.StringDeref(endpoint.Name, fmt.Sprintf("%s-%s", s.Name(), "psc")),
+		Description: infrav1.ClusterTagKey(s.Name()),
+		Address:     pointer.StringDeref(endpoint.Address, ""),
+		AddressType: "INTERNAL",
+		Region:      s.Region(),
+		Subnetwork:  fmt.Sprintf("projects/%s/regions/%s/subnetworks/%s", s.Project(), s.Region(), endpoint.Subnetwork),
+	}
+}
+
+// PrivateServiceConnectForwardingRuleSpec returns google compute forwarding rule spec of the Private Service
+// Connect endpoint of the control plane, or nil if no endpoint is configured.
+func (s *ManagedClusterScope) PrivateServiceConnectForwardingRuleSpec() *compute.ForwardingRule {
+	endpoint := s.GCPManagedCluster.Spec.PrivateServiceConnectEndpoint
+	if endpoint == nil {
+		return nil
+	}
+
+	return &compute.ForwardingRule{
+		Name:        pointer.StringDeref(endpoint.Name, fmt.Sprintf("%s-%s", s.Name(), "psc")),
+		Description: infrav1.ClusterTagKey(s.Name()),
+		Region:      s.Region(),
+		Network:     fmt.Sprintf("projects/%s/global/networks/%s", s.Project(), endpoint.Network),
+		Target:      endpoint.ServiceAttachment,
+	}
+}
+
+// ServiceNetworkingService returns the service networking service of the cluster.
+func (s *ManagedClusterScope) ServiceNetworkingService() *servicenetworking.APIService {
+	return s.GCPServices.ServiceNetworking
+}
+
 // ANCHOR: ClusterFirewallSpec
 
 // FirewallRulesSpec returns google compute firewall spec.
 func (s *ManagedClusterScope) FirewallRulesSpec() []*compute.Firewall {
-	firewallRules := []*compute.Firewall{
-		{
-			Name:    fmt.Sprintf("allow-%s-healthchecks", s.Name()),
-			Network: s.NetworkLink(),
-			Allowed: []*compute.FirewallAllowed{
-				{
-					IPProtocol: "TCP",
-					Ports: []string{
-						strconv.FormatInt(6443, 10),
-					},
-				},
-			},
-			Direction: "INGRESS",
-			SourceRanges: []string{
-				"35.191.0.0/16",
-				"130.211.0.0/22",
-			},
-			TargetTags: []string{
-				fmt.Sprintf("%s-control-plane", s.Name()),
-			},
-		},
-		{
-			Name:    fmt.Sprintf("allow-%s-cluster", s.Name()),
-			Network: s.NetworkLink(),
+	firewallRules := []*compute.Firewall{}
+	for _, rule := range s.GCPManagedCluster.Spec.FirewallRules {
+		protocol := rule.Protocol
+		if protocol == "" {
+			protocol = "tcp"
+		}
+		firewall := &compute.Firewall{
+			Name:        rule.Name,
+			Description: infrav1.ClusterTagKey(s.Name()),
+			Network:     s.NetworkLink(),
 			Allowed: []*compute.FirewallAllowed{
 				{
-					IPProtocol: "all",
+					IPProtocol: protocol,
+					Ports:      rule.Ports,
 				},
 			},
-			Direction: "INGRESS",
-			SourceTags: []string{
-				fmt.Sprintf("%s-control-plane", s.Name()),
-				fmt.Sprintf("%s-node", s.Name()),
-			},
-			TargetTags: []string{
-				fmt.Sprintf("%s-control-plane", s.Name()),
-				fmt.Sprintf("%s-node", s.Name()),
-			},
-		},
+			Direction:    "INGRESS",
+			SourceRanges: rule.SourceRanges,
+			TargetTags:   rule.TargetTags,
+		}
+		if rule.Direction == infrav1exp.FirewallRuleDirectionEgress {
+			firewall.Direction = "EGRESS"
+			firewall.DestinationRanges = rule.DestinationRanges
+		}
+		firewallRules = append(firewallRules, firewall)
 	}
 
 	return firewallRules
 }
 
+// DisabledFirewallRuleNames returns the names of the default firewall rules which are disabled. Managed clusters
+// have no default firewall rules.
+func (s *ManagedClusterScope) DisabledFirewallRuleNames() []string {
+	return nil
+}
+
 // ANCHOR_END: ClusterFirewallSpec
 
 // PatchObject persists the cluster configuration and status.
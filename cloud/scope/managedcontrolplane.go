@@ -19,14 +19,18 @@ package scope
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"sigs.k8s.io/cluster-api-provider-gcp/util/location"
 
 	"sigs.k8s.io/cluster-api/util/conditions"
 
 	container "cloud.google.com/go/container/apiv1"
+	"cloud.google.com/go/container/apiv1/containerpb"
 	credentials "cloud.google.com/go/iam/credentials/apiv1"
 	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
@@ -37,6 +41,10 @@ import (
 const (
 	// APIServerPort is the port of the GKE api server.
 	APIServerPort = 443
+
+	// DefaultKubeconfigRefreshWindow is the default time before the expiry of the token of the CAPI kubeconfig
+	// at which it is refreshed.
+	DefaultKubeconfigRefreshWindow = 10 * time.Minute
 )
 
 // ManagedControlPlaneScopeParams defines the input parameters used to create a new Scope.
@@ -47,6 +55,9 @@ type ManagedControlPlaneScopeParams struct {
 	Cluster                *clusterv1.Cluster
 	GCPManagedCluster      *infrav1exp.GCPManagedCluster
 	GCPManagedControlPlane *infrav1exp.GCPManagedControlPlane
+	// KubeconfigRefreshWindow is the time before the expiry of the token of the CAPI kubeconfig at which it is
+	// refreshed. It defaults to DefaultKubeconfigRefreshWindow.
+	KubeconfigRefreshWindow time.Duration
 }
 
 // NewManagedControlPlaneScope creates a new Scope from the supplied parameters.
@@ -62,13 +73,18 @@ func NewManagedControlPlaneScope(ctx context.Context, params ManagedControlPlane
 		return nil, errors.New("failed to generate new scope from nil GCPManagedControlPlane")
 	}
 
-	credential, err := getCredentials(ctx, params.GCPManagedCluster.Spec.CredentialsRef, params.Client)
+	credsSource, err := getManagedControlPlaneCredentialsSource(ctx, params.Client, params.GCPManagedCluster, params.GCPManagedControlPlane)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get gcp credentials")
+	}
+
+	credential, err := getCredentials(ctx, credsSource, params.Client)
 	if err != nil {
 		return nil, fmt.Errorf("getting gcp credentials: %w", err)
 	}
 
 	if params.ManagedClusterClient == nil {
-		managedClusterClient, err := newClusterManagerClient(ctx, params.GCPManagedCluster.Spec.CredentialsRef, params.Client)
+		managedClusterClient, err := newClusterManagerClient(ctx, credsSource, params.Client)
 		if err != nil {
 			return nil, errors.Errorf("failed to create gcp managed cluster client: %v", err)
 		}
@@ -76,7 +92,7 @@ func NewManagedControlPlaneScope(ctx context.Context, params ManagedControlPlane
 	}
 	if params.CredentialsClient == nil {
 		var credentialsClient *credentials.IamCredentialsClient
-		credentialsClient, err = newIamCredentialsClient(ctx, params.GCPManagedCluster.Spec.CredentialsRef, params.Client)
+		credentialsClient, err = newIamCredentialsClient(ctx, credsSource, params.Client)
 		if err != nil {
 			return nil, errors.Errorf("failed to create gcp credentials client: %v", err)
 		}
@@ -96,10 +112,24 @@ func NewManagedControlPlaneScope(ctx context.Context, params ManagedControlPlane
 		mcClient:               params.ManagedClusterClient,
 		credentialsClient:      params.CredentialsClient,
 		credential:             credential,
+		refreshWindow:          params.KubeconfigRefreshWindow,
 		patchHelper:            helper,
 	}, nil
 }
 
+// getManagedControlPlaneCredentialsSource returns the source of the credentials of the control plane, which
+// defaults to the credentials of the GCPManagedCluster.
+func getManagedControlPlaneCredentialsSource(ctx context.Context, crClient client.Client, managedCluster *infrav1exp.GCPManagedCluster, controlPlane *infrav1exp.GCPManagedControlPlane) (*credentialsSource, error) {
+	if controlPlane.Spec.CredentialsRef != nil {
+		return &credentialsSource{
+			secretRef:    controlPlane.Spec.CredentialsRef,
+			quotaProject: pointer.StringDeref(managedCluster.Spec.QuotaProject, ""),
+		}, nil
+	}
+
+	return getClusterCredentialsSource(ctx, crClient, managedCluster.Namespace, managedCluster.Spec.CredentialsRef, managedCluster.Spec.IdentityRef, managedCluster.Spec.ImpersonateServiceAccount, managedCluster.Spec.QuotaProject)
+}
+
 // ManagedControlPlaneScope defines the basic context for an actuator to operate upon.
 type ManagedControlPlaneScope struct {
 	client      client.Client
@@ -111,6 +141,7 @@ type ManagedControlPlaneScope struct {
 	mcClient               *container.ClusterManagerClient
 	credentialsClient      *credentials.IamCredentialsClient
 	credential             *Credential
+	refreshWindow          time.Duration
 
 	AllMachinePools        []clusterv1exp.MachinePool
 	AllManagedMachinePools []infrav1exp.GCPManagedMachinePool
@@ -126,6 +157,8 @@ func (s *ManagedControlPlaneScope) PatchObject() error {
 			infrav1exp.GKEControlPlaneCreatingCondition,
 			infrav1exp.GKEControlPlaneUpdatingCondition,
 			infrav1exp.GKEControlPlaneDeletingCondition,
+			infrav1exp.GKEControlPlaneKubeconfigRefreshedCondition,
+			infrav1exp.GKEControlPlaneDryRunCondition,
 		}})
 }
 
@@ -161,6 +194,16 @@ func (s *ManagedControlPlaneScope) GetCredential() *Credential {
 	return s.credential
 }
 
+// KubeconfigRefreshWindow returns the time before the expiry of the token of the CAPI kubeconfig at which it
+// is refreshed.
+func (s *ManagedControlPlaneScope) KubeconfigRefreshWindow() time.Duration {
+	if s.refreshWindow <= 0 {
+		return DefaultKubeconfigRefreshWindow
+	}
+
+	return s.refreshWindow
+}
+
 // GetAllNodePools gets all node pools for the control plane.
 func (s *ManagedControlPlaneScope) GetAllNodePools(ctx context.Context) ([]infrav1exp.GCPManagedMachinePool, []clusterv1exp.MachinePool, error) {
 	if s.AllManagedMachinePools == nil || len(s.AllManagedMachinePools) == 0 {
@@ -203,11 +246,54 @@ func (s *ManagedControlPlaneScope) ClusterFullName() string {
 	return fmt.Sprintf("%s/clusters/%s", s.ClusterLocation(), s.GCPManagedControlPlane.Spec.ClusterName)
 }
 
+// OperationFullName returns the full name of the GKE operation with the given name.
+func (s *ManagedControlPlaneScope) OperationFullName(name string) string {
+	return fmt.Sprintf("%s/operations/%s", s.ClusterLocation(), name)
+}
+
+// SetOperation records the GKE operation in progress on the cluster.
+func (s *ManagedControlPlaneScope) SetOperation(operation *containerpb.Operation) {
+	s.GCPManagedControlPlane.Status.Operation = &infrav1exp.GKEOperation{
+		Name: operation.GetName(),
+		Type: operation.GetOperationType().String(),
+	}
+}
+
 // ClusterName returns the name of the cluster.
 func (s *ManagedControlPlaneScope) ClusterName() string {
 	return s.GCPManagedControlPlane.Spec.ClusterName
 }
 
+// ClusterResourceLabels returns the GCP resource labels of the GKE cluster, which include the label marking it as
+// owned by the cluster.
+func (s *ManagedControlPlaneScope) ClusterResourceLabels() map[string]string {
+	labels := make(map[string]string, len(s.GCPManagedCluster.Spec.AdditionalLabels)+1)
+	for k, v := range s.GCPManagedCluster.Spec.AdditionalLabels {
+		labels[k] = v
+	}
+	labels[infrav1.ClusterTagKey(s.Cluster.Name)] = string(infrav1.ResourceLifecycleOwned)
+
+	return labels
+}
+
+// ClusterNetwork returns the network of the cluster, qualified with the host project for shared VPC networks.
+func (s *ManagedControlPlaneScope) ClusterNetwork() string {
+	network := pointer.StringDeref(s.GCPManagedCluster.Spec.Network.Name, "default")
+	if hostProject := s.GCPManagedCluster.Spec.Network.HostProject; hostProject != nil {
+		return fmt.Sprintf("projects/%s/global/networks/%s", *hostProject, network)
+	}
+	return network
+}
+
+// ClusterSubnetwork returns the given subnetwork of the cluster, qualified with the host project for shared VPC
+// networks.
+func (s *ManagedControlPlaneScope) ClusterSubnetwork(subnetwork string) string {
+	if hostProject := s.GCPManagedCluster.Spec.Network.HostProject; hostProject != nil {
+		return fmt.Sprintf("projects/%s/regions/%s/subnetworks/%s", *hostProject, s.Region(), subnetwork)
+	}
+	return subnetwork
+}
+
 // SetEndpoint sets the Endpoint of GCPManagedControlPlane.
 func (s *ManagedControlPlaneScope) SetEndpoint(host string) {
 	s.GCPManagedControlPlane.Spec.Endpoint = clusterv1.APIEndpoint{
@@ -29,11 +29,14 @@ import (
 	container "cloud.google.com/go/container/apiv1"
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/pkg/errors"
+	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/labels/format"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 )
 
@@ -41,6 +44,7 @@ import (
 type ManagedMachinePoolScopeParams struct {
 	ManagedClusterClient        *container.ClusterManagerClient
 	InstanceGroupManagersClient *compute.InstanceGroupManagersClient
+	MachineTypesClient          *compute.MachineTypesClient
 	Client                      client.Client
 	Cluster                     *clusterv1.Cluster
 	MachinePool                 *clusterv1exp.MachinePool
@@ -68,20 +72,32 @@ func NewManagedMachinePoolScope(ctx context.Context, params ManagedMachinePoolSc
 		return nil, errors.New("failed to generate new scope from nil GCPManagedMachinePool")
 	}
 
+	credsSource, err := getManagedMachinePoolCredentialsSource(ctx, params.Client, params.GCPManagedCluster, params.GCPManagedControlPlane, params.GCPManagedMachinePool)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get gcp credentials")
+	}
+
 	if params.ManagedClusterClient == nil {
-		managedClusterClient, err := newClusterManagerClient(ctx, params.GCPManagedCluster.Spec.CredentialsRef, params.Client)
+		managedClusterClient, err := newClusterManagerClient(ctx, credsSource, params.Client)
 		if err != nil {
 			return nil, errors.Errorf("failed to create gcp managed cluster client: %v", err)
 		}
 		params.ManagedClusterClient = managedClusterClient
 	}
 	if params.InstanceGroupManagersClient == nil {
-		instanceGroupManagersClient, err := newInstanceGroupManagerClient(ctx, params.GCPManagedCluster.Spec.CredentialsRef, params.Client)
+		instanceGroupManagersClient, err := newInstanceGroupManagerClient(ctx, credsSource, params.Client)
 		if err != nil {
 			return nil, errors.Errorf("failed to create gcp instance group manager client: %v", err)
 		}
 		params.InstanceGroupManagersClient = instanceGroupManagersClient
 	}
+	if params.MachineTypesClient == nil {
+		machineTypesClient, err := newMachineTypesClient(ctx, credsSource, params.Client)
+		if err != nil {
+			return nil, errors.Errorf("failed to create gcp machine types client: %v", err)
+		}
+		params.MachineTypesClient = machineTypesClient
+	}
 
 	helper, err := patch.NewHelper(params.GCPManagedMachinePool, params.Client)
 	if err != nil {
@@ -96,10 +112,24 @@ func NewManagedMachinePoolScope(ctx context.Context, params ManagedMachinePoolSc
 		GCPManagedMachinePool:  params.GCPManagedMachinePool,
 		mcClient:               params.ManagedClusterClient,
 		migClient:              params.InstanceGroupManagersClient,
+		mtClient:               params.MachineTypesClient,
 		patchHelper:            helper,
 	}, nil
 }
 
+// getManagedMachinePoolCredentialsSource returns the source of the credentials of the machine pool, which
+// defaults to the credentials of the control plane.
+func getManagedMachinePoolCredentialsSource(ctx context.Context, crClient client.Client, managedCluster *infrav1exp.GCPManagedCluster, controlPlane *infrav1exp.GCPManagedControlPlane, machinePool *infrav1exp.GCPManagedMachinePool) (*credentialsSource, error) {
+	if machinePool.Spec.CredentialsRef != nil {
+		return &credentialsSource{
+			secretRef:    machinePool.Spec.CredentialsRef,
+			quotaProject: pointer.StringDeref(managedCluster.Spec.QuotaProject, ""),
+		}, nil
+	}
+
+	return getManagedControlPlaneCredentialsSource(ctx, crClient, managedCluster, controlPlane)
+}
+
 // ManagedMachinePoolScope defines the basic context for an actuator to operate upon.
 type ManagedMachinePoolScope struct {
 	client      client.Client
@@ -112,6 +142,7 @@ type ManagedMachinePoolScope struct {
 	GCPManagedMachinePool  *infrav1exp.GCPManagedMachinePool
 	mcClient               *container.ClusterManagerClient
 	migClient              *compute.InstanceGroupManagersClient
+	mtClient               *compute.MachineTypesClient
 }
 
 // PatchObject persists the managed control plane configuration and status.
@@ -124,6 +155,9 @@ func (s *ManagedMachinePoolScope) PatchObject() error {
 			infrav1exp.GKEMachinePoolCreatingCondition,
 			infrav1exp.GKEMachinePoolUpdatingCondition,
 			infrav1exp.GKEMachinePoolDeletingCondition,
+			infrav1exp.GKEMachinePoolUpgradingCondition,
+			infrav1exp.GKEMachinePoolRollingBackCondition,
+			infrav1exp.GKEMachinePoolDryRunCondition,
 		}})
 }
 
@@ -131,6 +165,7 @@ func (s *ManagedMachinePoolScope) PatchObject() error {
 func (s *ManagedMachinePoolScope) Close() error {
 	s.mcClient.Close()
 	s.migClient.Close()
+	s.mtClient.Close()
 	return s.PatchObject()
 }
 
@@ -139,6 +174,11 @@ func (s *ManagedMachinePoolScope) ConditionSetter() conditions.Setter {
 	return s.GCPManagedMachinePool
 }
 
+// Client returns a k8s client.
+func (s *ManagedMachinePoolScope) Client() client.Client {
+	return s.client
+}
+
 // ManagedMachinePoolClient returns a client used to interact with GKE.
 func (s *ManagedMachinePoolScope) ManagedMachinePoolClient() *container.ClusterManagerClient {
 	return s.mcClient
@@ -149,40 +189,116 @@ func (s *ManagedMachinePoolScope) InstanceGroupManagersClient() *compute.Instanc
 	return s.migClient
 }
 
+// MachineTypesClient returns a client used to interact with GCP machine types.
+func (s *ManagedMachinePoolScope) MachineTypesClient() *compute.MachineTypesClient {
+	return s.mtClient
+}
+
+// ListClusterManagedMachinePools lists all the managed machine pools that belong to the same cluster.
+func (s *ManagedMachinePoolScope) ListClusterManagedMachinePools(ctx context.Context) ([]infrav1exp.GCPManagedMachinePool, error) {
+	managedMachinePoolList := &infrav1exp.GCPManagedMachinePoolList{}
+	if err := s.client.List(ctx, managedMachinePoolList,
+		client.InNamespace(s.GCPManagedMachinePool.Namespace),
+		client.MatchingLabels(map[string]string{clusterv1.ClusterNameLabel: s.Cluster.Name}),
+	); err != nil {
+		return nil, err
+	}
+
+	return managedMachinePoolList.Items, nil
+}
+
+// MachinePoolMachineLabels returns the labels identifying the GCPManagedMachinePoolMachines of the machine pool.
+func (s *ManagedMachinePoolScope) MachinePoolMachineLabels() map[string]string {
+	return map[string]string{
+		clusterv1.ClusterNameLabel:     s.Cluster.Name,
+		clusterv1.MachinePoolNameLabel: format.MustFormatValue(s.MachinePool.Name),
+	}
+}
+
+// ListMachinePoolMachines lists the GCPManagedMachinePoolMachines of the machine pool.
+func (s *ManagedMachinePoolScope) ListMachinePoolMachines(ctx context.Context) ([]infrav1exp.GCPManagedMachinePoolMachine, error) {
+	machineList := &infrav1exp.GCPManagedMachinePoolMachineList{}
+	if err := s.client.List(ctx, machineList,
+		client.InNamespace(s.GCPManagedMachinePool.Namespace),
+		client.MatchingLabels(s.MachinePoolMachineLabels()),
+	); err != nil {
+		return nil, err
+	}
+
+	return machineList.Items, nil
+}
+
 // NodePoolVersion returns the k8s version of the node pool.
 func (s *ManagedMachinePoolScope) NodePoolVersion() *string {
 	return infrav1exp.NormalizeMachineVersion(s.MachinePool.Spec.Template.Spec.Version)
 }
 
+// NodePoolResourceLabels returns the GCP resource labels of a node pool, which include the label marking it as
+// owned by the cluster.
+func NodePoolResourceLabels(nodePool infrav1exp.GCPManagedMachinePool, clusterName string) map[string]string {
+	labels := make(map[string]string, len(nodePool.Spec.ResourceLabels)+1)
+	for k, v := range nodePool.Spec.ResourceLabels {
+		labels[k] = v
+	}
+	labels[infrav1.ClusterTagKey(clusterName)] = string(infrav1.ResourceLifecycleOwned)
+
+	return labels
+}
+
 // ConvertToSdkNodePool converts a node pool to format that is used by GCP SDK.
 func ConvertToSdkNodePool(nodePool infrav1exp.GCPManagedMachinePool, machinePool clusterv1exp.MachinePool, regional bool) *containerpb.NodePool {
 	replicas := *machinePool.Spec.Replicas
-	if regional {
+	// TPU slices are placed in a single zone, so the replicas are not spread across the region.
+	if regional && !nodePool.IsMultiHostTPUSlice() {
 		replicas /= cloud.DefaultNumRegionsPerZone
 	}
-	nodePoolName := nodePool.Spec.NodePoolName
-	if len(nodePoolName) == 0 {
-		nodePoolName = nodePool.Name
-	}
-
 	sdkNodePool := containerpb.NodePool{
-		Name:             nodePoolName,
+		Name:             nodePool.NodePoolName(),
 		InitialNodeCount: replicas,
 		Autoscaling:      convertToSdkNodePoolAutoscaling(nodePool.Spec.Scaling),
 		Management:       convertToSdkNodeManagement(nodePool.Spec.Management),
 		Config: &containerpb.NodeConfig{
-			MachineType: nodePool.Spec.MachineType,
-			DiskSizeGb:  nodePool.Spec.DiskSizeGb,
-			DiskType:    nodePool.Spec.DiskType,
-			Labels:      nodePool.Spec.KubernetesLabels,
-			Taints:      infrav1exp.ConvertToSdkTaint(nodePool.Spec.KubernetesTaints),
-			Metadata:    nodePool.Spec.AdditionalLabels,
-			ImageType:   nodePool.Spec.ImageType,
-			Preemptible: nodePool.Spec.Preemptible != nil && *nodePool.Spec.Preemptible,
-			Spot:        nodePool.Spec.Spot != nil && *nodePool.Spec.Spot,
+			MachineType:    nodePool.Spec.MachineType,
+			DiskSizeGb:     nodePool.Spec.DiskSizeGb,
+			DiskType:       nodePool.Spec.DiskType,
+			Labels:         nodePool.Spec.KubernetesLabels,
+			Taints:         infrav1exp.ConvertToSdkTaint(nodePool.Spec.KubernetesTaints),
+			Metadata:       nodePool.Spec.AdditionalLabels,
+			ResourceLabels: NodePoolResourceLabels(nodePool, machinePool.Spec.ClusterName),
+			ImageType:      nodePool.Spec.ImageType,
+			Preemptible:    nodePool.Spec.Preemptible != nil && *nodePool.Spec.Preemptible,
+			Spot:           nodePool.Spec.Spot != nil && *nodePool.Spec.Spot,
 		},
 	}
 
+	if nodePool.Spec.Gvnic != nil {
+		sdkNodePool.Config.Gvnic = &containerpb.VirtualNIC{
+			Enabled: *nodePool.Spec.Gvnic,
+		}
+	}
+
+	if len(nodePool.Spec.Accelerators) > 0 {
+		sdkNodePool.Config.Accelerators = convertToSdkAccelerators(nodePool.Spec.Accelerators)
+	}
+
+	if nodePool.Spec.FastSocket != nil {
+		sdkNodePool.Config.FastSocket = &containerpb.FastSocket{
+			Enabled: *nodePool.Spec.FastSocket,
+		}
+	}
+
+	if nodePool.Spec.WorkloadMetadataMode != nil {
+		sdkNodePool.Config.WorkloadMetadataConfig = ConvertToSdkWorkloadMetadataConfig(*nodePool.Spec.WorkloadMetadataMode)
+	}
+
+	if nodePool.Spec.PlacementPolicy != nil {
+		sdkNodePool.PlacementPolicy = convertToSdkPlacementPolicy(nodePool.Spec.PlacementPolicy)
+	}
+
+	if nodePool.Spec.SoleTenantConfig != nil {
+		sdkNodePool.Config.SoleTenantConfig = convertToSdkSoleTenantConfig(nodePool.Spec.SoleTenantConfig)
+	}
+
 	if machinePool.Spec.Template.Spec.Version != nil {
 		sdkNodePool.Version = *infrav1exp.NormalizeMachineVersion(machinePool.Spec.Template.Spec.Version)
 	}
@@ -215,6 +331,113 @@ func convertToSdkNodePoolAutoscaling(scaling *infrav1exp.NodePoolAutoScaling) *c
 		result.MaxNodeCount = *scaling.MaxCount
 	}
 
+	if scaling.TotalMinCount != nil {
+		result.TotalMinNodeCount = *scaling.TotalMinCount
+	}
+
+	if scaling.TotalMaxCount != nil {
+		result.TotalMaxNodeCount = *scaling.TotalMaxCount
+	}
+
+	if scaling.LocationPolicy != nil {
+		result.LocationPolicy = convertToSdkLocationPolicy(*scaling.LocationPolicy)
+	}
+
+	return result
+}
+
+// convertToSdkLocationPolicy converts node pool autoscaling location policy to format that is used by GCP SDK.
+func convertToSdkLocationPolicy(locationPolicy infrav1exp.ManagedNodePoolLocationPolicy) containerpb.NodePoolAutoscaling_LocationPolicy {
+	switch locationPolicy {
+	case infrav1exp.ManagedNodePoolLocationPolicyBalanced:
+		return containerpb.NodePoolAutoscaling_BALANCED
+	case infrav1exp.ManagedNodePoolLocationPolicyAny:
+		return containerpb.NodePoolAutoscaling_ANY
+	}
+	return containerpb.NodePoolAutoscaling_LOCATION_POLICY_UNSPECIFIED
+}
+
+// ConvertToSdkWorkloadMetadataConfig converts workload metadata mode to format that is used by GCP SDK.
+func ConvertToSdkWorkloadMetadataConfig(mode infrav1exp.WorkloadMetadataMode) *containerpb.WorkloadMetadataConfig {
+	result := &containerpb.WorkloadMetadataConfig{}
+
+	switch mode {
+	case infrav1exp.WorkloadMetadataModeGCEMetadata:
+		result.Mode = containerpb.WorkloadMetadataConfig_GCE_METADATA
+	case infrav1exp.WorkloadMetadataModeGKEMetadata:
+		result.Mode = containerpb.WorkloadMetadataConfig_GKE_METADATA
+	}
+
+	return result
+}
+
+// convertToSdkAccelerators converts node pool accelerators to format that is used by GCP SDK.
+func convertToSdkAccelerators(accelerators []infrav1exp.AcceleratorConfig) []*containerpb.AcceleratorConfig {
+	result := make([]*containerpb.AcceleratorConfig, 0, len(accelerators))
+
+	for _, accelerator := range accelerators {
+		sdkAccelerator := &containerpb.AcceleratorConfig{
+			AcceleratorType:  accelerator.Type,
+			AcceleratorCount: accelerator.Count,
+			GpuPartitionSize: accelerator.GPUPartitionSize,
+		}
+		if accelerator.GPUDriverVersion != nil {
+			sdkAccelerator.GpuDriverInstallationConfig = &containerpb.GPUDriverInstallationConfig{
+				GpuDriverVersion: convertToSdkGPUDriverVersion(*accelerator.GPUDriverVersion).Enum(),
+			}
+		}
+		result = append(result, sdkAccelerator)
+	}
+
+	return result
+}
+
+// convertToSdkGPUDriverVersion converts GPU driver version to format that is used by GCP SDK.
+func convertToSdkGPUDriverVersion(version infrav1exp.GPUDriverVersion) containerpb.GPUDriverInstallationConfig_GPUDriverVersion {
+	switch version {
+	case infrav1exp.GPUDriverVersionInstallationDisabled:
+		return containerpb.GPUDriverInstallationConfig_INSTALLATION_DISABLED
+	case infrav1exp.GPUDriverVersionDefault:
+		return containerpb.GPUDriverInstallationConfig_DEFAULT
+	case infrav1exp.GPUDriverVersionLatest:
+		return containerpb.GPUDriverInstallationConfig_LATEST
+	}
+	return containerpb.GPUDriverInstallationConfig_GPU_DRIVER_VERSION_UNSPECIFIED
+}
+
+// convertToSdkSoleTenantConfig converts node pool sole tenant config to format that is used by GCP SDK.
+func convertToSdkSoleTenantConfig(soleTenantConfig *infrav1exp.SoleTenantConfig) *containerpb.SoleTenantConfig {
+	result := &containerpb.SoleTenantConfig{}
+
+	for _, affinity := range soleTenantConfig.NodeAffinities {
+		operator := containerpb.SoleTenantConfig_NodeAffinity_OPERATOR_UNSPECIFIED
+		switch affinity.Operator {
+		case infrav1exp.NodeAffinityOperatorIn:
+			operator = containerpb.SoleTenantConfig_NodeAffinity_IN
+		case infrav1exp.NodeAffinityOperatorNotIn:
+			operator = containerpb.SoleTenantConfig_NodeAffinity_NOT_IN
+		}
+		result.NodeAffinities = append(result.NodeAffinities, &containerpb.SoleTenantConfig_NodeAffinity{
+			Key:      affinity.Key,
+			Operator: operator,
+			Values:   affinity.Values,
+		})
+	}
+
+	return result
+}
+
+// convertToSdkPlacementPolicy converts node pool placement policy to format that is used by GCP SDK.
+func convertToSdkPlacementPolicy(placementPolicy *infrav1exp.NodePoolPlacementPolicy) *containerpb.NodePool_PlacementPolicy {
+	result := &containerpb.NodePool_PlacementPolicy{
+		TpuTopology: placementPolicy.TpuTopology,
+		PolicyName:  placementPolicy.PolicyName,
+	}
+
+	if placementPolicy.Type != nil && *placementPolicy.Type == infrav1exp.PlacementPolicyCompact {
+		result.Type = containerpb.NodePool_PlacementPolicy_COMPACT
+	}
+
 	return result
 }
 
@@ -242,12 +465,22 @@ func (s *ManagedMachinePoolScope) SetReplicas(replicas int32) {
 	s.GCPManagedMachinePool.Status.Replicas = replicas
 }
 
+// WorkloadMetadataMode returns the workload metadata mode of the node pool, defaulting to the GKE metadata
+// server when workload identity is enabled on the control plane.
+func (s *ManagedMachinePoolScope) WorkloadMetadataMode() *infrav1exp.WorkloadMetadataMode {
+	if s.GCPManagedMachinePool.Spec.WorkloadMetadataMode != nil {
+		return s.GCPManagedMachinePool.Spec.WorkloadMetadataMode
+	}
+	if s.GCPManagedControlPlane.Spec.EnableWorkloadIdentity {
+		mode := infrav1exp.WorkloadMetadataModeGKEMetadata
+		return &mode
+	}
+	return nil
+}
+
 // NodePoolName returns the node pool name.
 func (s *ManagedMachinePoolScope) NodePoolName() string {
-	if len(s.GCPManagedMachinePool.Spec.NodePoolName) > 0 {
-		return s.GCPManagedMachinePool.Spec.NodePoolName
-	}
-	return s.GCPManagedMachinePool.Name
+	return s.GCPManagedMachinePool.NodePoolName()
 }
 
 // Region returns the region of the GKE node pool.
@@ -256,6 +489,11 @@ func (s *ManagedMachinePoolScope) Region() string {
 	return loc.Region
 }
 
+// ClusterLocation returns the location of the cluster.
+func (s *ManagedMachinePoolScope) ClusterLocation() string {
+	return fmt.Sprintf("projects/%s/locations/%s", s.GCPManagedControlPlane.Spec.Project, s.Region())
+}
+
 // NodePoolLocation returns the location of the node pool.
 func (s *ManagedMachinePoolScope) NodePoolLocation() string {
 	return fmt.Sprintf("projects/%s/locations/%s/clusters/%s", s.GCPManagedControlPlane.Spec.Project, s.Region(), s.GCPManagedControlPlane.Spec.ClusterName)
@@ -263,5 +501,10 @@ func (s *ManagedMachinePoolScope) NodePoolLocation() string {
 
 // NodePoolFullName returns the full name of the node pool.
 func (s *ManagedMachinePoolScope) NodePoolFullName() string {
-	return fmt.Sprintf("%s/nodePools/%s", s.NodePoolLocation(), s.NodePoolName())
+	return s.NodePoolFullNameOf(s.NodePoolName())
+}
+
+// NodePoolFullNameOf returns the full name of the node pool with the given name in the cluster.
+func (s *ManagedMachinePoolScope) NodePoolFullNameOf(name string) string {
+	return fmt.Sprintf("%s/nodePools/%s", s.NodePoolLocation(), name)
 }
@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// APIRateLimit is a client-side rate limit of the calls to a GCP API.
+type APIRateLimit struct {
+	// QPS is the sustained number of calls per second, 0 disables the rate limit.
+	QPS float32
+	// Burst is the number of calls allowed at once on top of the QPS.
+	Burst int
+}
+
+var (
+	computeRateLimiter   flowcontrol.RateLimiter
+	containerRateLimiter flowcontrol.RateLimiter
+)
+
+// SetAPIRateLimits sets the rate limits of the calls to the compute and container APIs. The limits are shared by
+// the clients of all the reconcilers, so that the calls of many clusters stay within the quotas of the project.
+func SetAPIRateLimits(compute, container APIRateLimit) {
+	computeRateLimiter = newRateLimiter(compute)
+	containerRateLimiter = newRateLimiter(container)
+}
+
+func newRateLimiter(limit APIRateLimit) flowcontrol.RateLimiter {
+	if limit.QPS <= 0 {
+		return nil
+	}
+
+	burst := limit.Burst
+	if burst < 1 {
+		burst = 1
+	}
+
+	return flowcontrol.NewTokenBucketRateLimiter(limit.QPS, burst)
+}
+
+// rateLimitedTransport waits for the rate limiter before sending the requests of a REST client.
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter flowcontrol.RateLimiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// rateLimitInterceptor waits for the rate limiter before the calls of a gRPC client.
+func rateLimitInterceptor(limiter flowcontrol.RateLimiter) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := limiter.Wait(ctx); err != nil {
+			return err
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// This test verifies that the requests of the REST clients wait for the rate limiter of their API.
+func TestRateLimitedTransport(t *testing.T) {
+	assert.Nil(t, newRateLimiter(APIRateLimit{}))
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	limiter := newRateLimiter(APIRateLimit{QPS: 0.001, Burst: 1})
+	defer limiter.Stop()
+	transport := &rateLimitedTransport{base: http.DefaultTransport, limiter: limiter}
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, http.NoBody)
+	assert.Nil(t, err)
+	resp, err := transport.RoundTrip(req)
+	assert.Nil(t, err)
+	assert.Nil(t, resp.Body.Close())
+
+	// The burst is used up, the next request has to wait longer than its context allows.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = transport.RoundTrip(req.WithContext(ctx))
+	assert.Error(t, err)
+}
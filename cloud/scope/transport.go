@@ -0,0 +1,113 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scope
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+	"google.golang.org/grpc"
+	grpccredentials "google.golang.org/grpc/credentials"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// gcpTransport is the HTTP transport of the GCP clients when they trust additional CAs, or nil for the default
+// transport. Both use the proxy of the HTTPS_PROXY and NO_PROXY environment variables.
+var gcpTransport *http.Transport
+
+// SetCABundle makes the GCP clients trust the CA certificates of a PEM file in addition to the system CAs,
+// e.g. the CA of a TLS-intercepting proxy. An empty path only trusts the system CAs.
+func SetCABundle(path string) error {
+	if path == "" {
+		gcpTransport = nil
+		return nil
+	}
+
+	bundle, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading CA bundle %s: %w", path, err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(bundle) {
+		return fmt.Errorf("no CA certificates found in %s", path)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{
+		RootCAs:    pool,
+		MinVersion: tls.VersionTLS12,
+	}
+	gcpTransport = transport
+
+	return nil
+}
+
+// withHTTPTransport adds an authenticated HTTP client using the transport of the GCP clients to the options of
+// a REST client, which waits for the rate limiter of its API when there is one.
+func withHTTPTransport(ctx context.Context, opts []option.ClientOption, limiter flowcontrol.RateLimiter) ([]option.ClientOption, error) {
+	if gcpTransport == nil && limiter == nil {
+		return opts, nil
+	}
+
+	var base http.RoundTripper = http.DefaultTransport
+	if gcpTransport != nil {
+		base = gcpTransport
+	}
+	if limiter != nil {
+		base = &rateLimitedTransport{base: base, limiter: limiter}
+	}
+
+	transport, err := htransport.NewTransport(ctx, base, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating http transport: %w", err)
+	}
+
+	return append(opts, option.WithHTTPClient(&http.Client{Transport: transport})), nil
+}
+
+// withGRPCTransport adds the TLS configuration of the transport of the GCP clients to the options of a gRPC
+// client, and the rate limiter of its API when there is one.
+func withGRPCTransport(opts []option.ClientOption, limiter flowcontrol.RateLimiter) []option.ClientOption {
+	if limiter != nil {
+		opts = append(opts, option.WithGRPCDialOption(grpc.WithChainUnaryInterceptor(rateLimitInterceptor(limiter))))
+	}
+	if gcpTransport == nil {
+		return opts
+	}
+
+	creds := grpccredentials.NewTLS(gcpTransport.TLSClientConfig.Clone())
+	return append(opts, option.WithGRPCDialOption(grpc.WithTransportCredentials(creds)))
+}
+
+// withTokenTransport makes the OAuth token requests made with the context use the transport of the GCP clients.
+func withTokenTransport(ctx context.Context) context.Context {
+	if gcpTransport == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: gcpTransport})
+}
@@ -0,0 +1,52 @@
+package scope
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/api/option"
+)
+
+// This test verifies that the CA bundle is loaded into the transport used by the GCP clients.
+func TestSetCABundle(t *testing.T) {
+	defer func() { assert.Nil(t, SetCABundle("")) }()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "proxy-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+
+	dir := t.TempDir()
+	bundle := filepath.Join(dir, "ca.pem")
+	assert.Nil(t, os.WriteFile(bundle, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	invalid := filepath.Join(dir, "invalid.pem")
+	assert.Nil(t, os.WriteFile(invalid, []byte("not a certificate"), 0o600))
+
+	assert.Error(t, SetCABundle(filepath.Join(dir, "missing.pem")))
+	assert.Error(t, SetCABundle(invalid))
+	assert.Nil(t, gcpTransport)
+	assert.Empty(t, withGRPCTransport(nil, nil))
+
+	assert.Nil(t, SetCABundle(bundle))
+	assert.NotNil(t, gcpTransport)
+	assert.NotNil(t, gcpTransport.Proxy)
+	assert.Len(t, withGRPCTransport([]option.ClientOption{}, nil), 1)
+}
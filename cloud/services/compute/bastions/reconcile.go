@@ -0,0 +1,192 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bastions
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Reconcile reconciles the bastion host of the cluster, and removes it once it is no longer configured.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.BastionInstanceSpec()
+	if spec == nil {
+		if s.scope.Bastion() == nil {
+			return nil
+		}
+		return s.Delete(ctx)
+	}
+
+	log.Info("Reconciling bastion resources")
+	if err := s.reconcileFirewalls(ctx); err != nil {
+		return err
+	}
+
+	if spec.Zone == "" {
+		return errors.New("no zone available for the bastion host")
+	}
+
+	key := meta.ZonalKey(spec.Name, spec.Zone)
+	instance, err := s.instances.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for bastion instance", "name", spec.Name, "zone", spec.Zone)
+			return err
+		}
+
+		log.V(2).Info("Creating a bastion instance", "name", spec.Name, "zone", spec.Zone)
+		if err := s.instances.Insert(ctx, key, spec); err != nil {
+			log.Error(err, "Error creating a bastion instance", "name", spec.Name, "zone", spec.Zone)
+			return err
+		}
+
+		instance, err = s.instances.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	s.scope.SetBastion(bastionStatus(instance, spec.Zone))
+	return nil
+}
+
+// Delete deletes the bastion host of the cluster if it was created by capg.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	var key *meta.Key
+	if status := s.scope.Bastion(); status != nil {
+		key = meta.ZonalKey(status.Name, status.Zone)
+	} else if spec := s.scope.BastionInstanceSpec(); spec != nil && spec.Zone != "" {
+		key = meta.ZonalKey(spec.Name, spec.Zone)
+	}
+
+	if key != nil {
+		log.Info("Deleting bastion resources")
+		if err := s.deleteInstance(ctx, key); err != nil {
+			return err
+		}
+	}
+
+	if err := s.deleteFirewalls(ctx); err != nil {
+		return err
+	}
+
+	s.scope.SetBastion(nil)
+	return nil
+}
+
+func (s *Service) reconcileFirewalls(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	if s.scope.IsSharedVpc() {
+		log.V(2).Info("Shared VPC enabled, skipping bastion firewall reconciliation", "project", s.scope.NetworkProject())
+		return nil
+	}
+
+	for _, spec := range s.scope.BastionFirewallRulesSpec() {
+		firewallKey := meta.GlobalKey(spec.Name)
+		firewall, err := s.firewalls.Get(ctx, firewallKey)
+		if err != nil {
+			if !gcperrors.IsNotFound(err) {
+				return err
+			}
+
+			log.V(2).Info("Creating firewall", "name", spec.Name)
+			if err := s.firewalls.Insert(ctx, firewallKey, spec); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !sets.NewString(firewall.SourceRanges...).Equal(sets.NewString(spec.SourceRanges...)) {
+			log.V(2).Info("Updating firewall source ranges", "name", spec.Name)
+			if err := s.firewalls.Update(ctx, firewallKey, spec); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) deleteInstance(ctx context.Context, key *meta.Key) error {
+	log := log.FromContext(ctx)
+	instance, err := s.instances.Get(ctx, key)
+	if err != nil {
+		if gcperrors.IsNotFound(err) {
+			return nil
+		}
+		log.Error(err, "Error looking for bastion instance", "name", key.Name, "zone", key.Zone)
+		return err
+	}
+
+	if instance.Description != infrav1.ClusterTagKey(s.scope.Name()) {
+		return nil
+	}
+
+	log.V(2).Info("Deleting a bastion instance", "name", key.Name, "zone", key.Zone)
+	if err := s.instances.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting a bastion instance", "name", key.Name, "zone", key.Zone)
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) deleteFirewalls(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	if s.scope.IsSharedVpc() {
+		log.V(2).Info("Shared VPC enabled, skipping bastion firewall deletion", "project", s.scope.NetworkProject())
+		return nil
+	}
+
+	for _, spec := range s.scope.BastionFirewallRulesSpec() {
+		log.V(2).Info("Deleting firewall", "name", spec.Name)
+		if err := s.firewalls.Delete(ctx, meta.GlobalKey(spec.Name)); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting firewall", "name", spec.Name)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bastionStatus returns the status of the bastion host from its instance.
+func bastionStatus(instance *compute.Instance, zone string) *infrav1.BastionStatus {
+	status := &infrav1.BastionStatus{
+		Name: instance.Name,
+		Zone: zone,
+	}
+	if len(instance.NetworkInterfaces) > 0 {
+		iface := instance.NetworkInterfaces[0]
+		status.PrivateIP = iface.NetworkIP
+		for _, config := range iface.AccessConfigs {
+			if config.NatIP != "" {
+				status.PublicIP = config.NatIP
+			}
+		}
+	}
+
+	return status
+}
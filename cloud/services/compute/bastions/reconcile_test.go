@@ -0,0 +1,282 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package bastions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Bastion: &infrav1.BastionSpec{
+			Zone:              pointer.String("us-central1-a"),
+			AllowedCIDRBlocks: []string{"203.0.113.0/24"},
+		},
+	},
+}
+
+type testCase struct {
+	name          string
+	scope         func() *scope.ClusterScope
+	mockInstances *cloud.MockInstances
+	mockFirewalls *cloud.MockFirewalls
+	wantErr       bool
+	assert        func(ctx context.Context, t testCase) error
+}
+
+func newClusterScope(t *testing.T, gcpCluster *infrav1.GCPCluster) *scope.ClusterScope {
+	t.Helper()
+
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: gcpCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return clusterScope
+}
+
+func TestService_Reconcile(t *testing.T) {
+	instanceKey := meta.ZonalKey("my-cluster-bastion", "us-central1-a")
+	sshFirewallKey := meta.GlobalKey("allow-my-cluster-bastion-ssh")
+
+	removedGCPCluster := fakeGCPCluster.DeepCopy()
+	removedGCPCluster.Spec.Bastion = nil
+	removedGCPCluster.Status.Bastion = &infrav1.BastionStatus{
+		Name: "my-cluster-bastion",
+		Zone: "us-central1-a",
+	}
+
+	tests := []testCase{
+		{
+			name:  "bastion does not exist (should create instance and firewalls)",
+			scope: func() *scope.ClusterScope { return newClusterScope(t, fakeGCPCluster.DeepCopy()) },
+			mockInstances: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockFirewallsObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				instance, err := t.mockInstances.Get(ctx, instanceKey)
+				if err != nil {
+					return err
+				}
+				if len(instance.NetworkInterfaces) != 1 || len(instance.NetworkInterfaces[0].AccessConfigs) != 1 {
+					return errors.New("bastion instance was created without a public IP")
+				}
+
+				firewall, err := t.mockFirewalls.Get(ctx, sshFirewallKey)
+				if err != nil {
+					return err
+				}
+				if len(firewall.SourceRanges) != 1 || firewall.SourceRanges[0] != "203.0.113.0/24" {
+					return errors.New("bastion firewall was created with wrong source ranges")
+				}
+
+				return nil
+			},
+		},
+		{
+			name:  "error getting instance with non 404 error code (should return an error)",
+			scope: func() *scope.ClusterScope { return newClusterScope(t, fakeGCPCluster.DeepCopy()) },
+			mockInstances: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+				GetHook: func(ctx context.Context, key *meta.Key, m *cloud.MockInstances) (bool, *compute.Instance, error) {
+					return true, &compute.Instance{}, &googleapi.Error{Code: http.StatusBadRequest}
+				},
+			},
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockFirewallsObj{},
+			},
+			wantErr: true,
+		},
+		{
+			name:  "bastion removed from spec (should delete instance and firewalls)",
+			scope: func() *scope.ClusterScope { return newClusterScope(t, removedGCPCluster.DeepCopy()) },
+			mockInstances: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockInstancesObj{
+					*instanceKey: {Obj: &compute.Instance{
+						Name:        "my-cluster-bastion",
+						Description: infrav1.ClusterTagKey("my-cluster"),
+					}},
+				},
+			},
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*sshFirewallKey: {Obj: &compute.Firewall{Name: "allow-my-cluster-bastion-ssh"}},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockInstances.Get(ctx, instanceKey); err == nil {
+					return errors.New("bastion instance was not deleted")
+				}
+				if _, err := t.mockFirewalls.Get(ctx, sshFirewallKey); err == nil {
+					return errors.New("bastion firewall was not deleted")
+				}
+
+				return nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			clusterScope := tt.scope()
+			s := New(clusterScope)
+			s.instances = tt.mockInstances
+			s.firewalls = tt.mockFirewalls
+			err := s.Reconcile(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assert != nil {
+				err = tt.assert(ctx, tt)
+				if err != nil {
+					t.Errorf("bastion was not reconciled as expected: %v", err)
+					return
+				}
+			}
+			if !tt.wantErr && (clusterScope.Bastion() != nil) != (clusterScope.GCPCluster.Spec.Bastion != nil) {
+				t.Errorf("bastion status was not updated: %+v", clusterScope.Bastion())
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	instanceKey := meta.ZonalKey("my-cluster-bastion", "us-central1-a")
+
+	tests := []testCase{
+		{
+			name:  "bastion does not exist, should do nothing",
+			scope: func() *scope.ClusterScope { return newClusterScope(t, fakeGCPCluster.DeepCopy()) },
+			mockInstances: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockFirewallsObj{},
+			},
+		},
+		{
+			name:  "instance not created by capg, should not delete it",
+			scope: func() *scope.ClusterScope { return newClusterScope(t, fakeGCPCluster.DeepCopy()) },
+			mockInstances: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockInstancesObj{
+					*instanceKey: {Obj: &compute.Instance{Name: "my-cluster-bastion"}},
+				},
+			},
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockFirewallsObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockInstances.Get(ctx, instanceKey); err != nil {
+					return errors.New("unmanaged instance was deleted")
+				}
+				return nil
+			},
+		},
+		{
+			name:  "error deleting firewall, should return error",
+			scope: func() *scope.ClusterScope { return newClusterScope(t, fakeGCPCluster.DeepCopy()) },
+			mockInstances: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				DeleteError: map[meta.Key]error{
+					*meta.GlobalKey("allow-my-cluster-bastion-ssh"): &googleapi.Error{Code: http.StatusBadRequest},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(tt.scope())
+			s.instances = tt.mockInstances
+			s.firewalls = tt.mockFirewalls
+			err := s.Delete(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assert != nil {
+				if err := tt.assert(ctx, tt); err != nil {
+					t.Errorf("bastion was not deleted as expected: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -18,8 +18,11 @@ package firewalls
 
 import (
 	"context"
+	"strings"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -27,11 +30,17 @@ import (
 // Reconcile reconcile cluster firewall compoenents.
 func (s *Service) Reconcile(ctx context.Context) error {
 	log := log.FromContext(ctx)
+	if s.scope.IsSharedVpc() {
+		log.V(2).Info("Shared VPC enabled, skipping firewall reconciliation", "project", s.scope.NetworkProject())
+		return nil
+	}
+
 	log.Info("Reconciling firewall resources")
 	for _, spec := range s.scope.FirewallRulesSpec() {
 		log.V(2).Info("Looking firewall", "name", spec.Name)
 		firewallKey := meta.GlobalKey(spec.Name)
-		if _, err := s.firewalls.Get(ctx, firewallKey); err != nil {
+		firewall, err := s.firewalls.Get(ctx, firewallKey)
+		if err != nil {
 			if !gcperrors.IsNotFound(err) {
 				return err
 			}
@@ -40,15 +49,65 @@ func (s *Service) Reconcile(ctx context.Context) error {
 			if err := s.firewalls.Insert(ctx, firewallKey, spec); err != nil {
 				return err
 			}
+			continue
+		}
+
+		if firewallChanged(firewall, spec) {
+			log.V(2).Info("Updating firewall", "name", spec.Name)
+			if err := s.firewalls.Update(ctx, firewallKey, spec); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range s.scope.DisabledFirewallRuleNames() {
+		log.V(2).Info("Deleting disabled firewall", "name", name)
+		if err := s.firewalls.Delete(ctx, meta.GlobalKey(name)); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting firewall", "name", name)
+			return err
 		}
 	}
 
 	return nil
 }
 
+// firewallChanged returns true if the sources, destinations or allowed traffic of a firewall differ from the spec,
+// or if it misses the description marking it as owned by the cluster.
+func firewallChanged(firewall, spec *compute.Firewall) bool {
+	if firewall.Description != spec.Description ||
+		!sets.NewString(firewall.SourceRanges...).Equal(sets.NewString(spec.SourceRanges...)) ||
+		!sets.NewString(firewall.SourceTags...).Equal(sets.NewString(spec.SourceTags...)) ||
+		!sets.NewString(firewall.DestinationRanges...).Equal(sets.NewString(spec.DestinationRanges...)) {
+		return true
+	}
+
+	return !allowedSet(firewall.Allowed).Equal(allowedSet(spec.Allowed))
+}
+
+// allowedSet returns the protocol and port pairs allowed by a firewall, protocols being case-insensitive.
+func allowedSet(allowed []*compute.FirewallAllowed) sets.String {
+	set := sets.NewString()
+	for _, a := range allowed {
+		protocol := strings.ToLower(a.IPProtocol)
+		if len(a.Ports) == 0 {
+			set.Insert(protocol)
+		}
+		for _, port := range a.Ports {
+			set.Insert(protocol + ":" + port)
+		}
+	}
+
+	return set
+}
+
 // Delete delete cluster firewall compoenents.
 func (s *Service) Delete(ctx context.Context) error {
 	log := log.FromContext(ctx)
+	if s.scope.IsSharedVpc() {
+		log.V(2).Info("Shared VPC enabled, skipping firewall deletion", "project", s.scope.NetworkProject())
+		return nil
+	}
+
 	log.Info("Deleting firewall resources")
 	for _, spec := range s.scope.FirewallRulesSpec() {
 		log.V(2).Info("Deleting firewall", "name", spec.Name)
@@ -0,0 +1,244 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package firewalls
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+	},
+}
+
+type testCase struct {
+	name          string
+	scope         func() *scope.ClusterScope
+	mockFirewalls *cloud.MockFirewalls
+	wantErr       bool
+	assert        func(ctx context.Context, t testCase) error
+}
+
+func newClusterScope(t *testing.T, gcpCluster *infrav1.GCPCluster) *scope.ClusterScope {
+	t.Helper()
+
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: gcpCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return clusterScope
+}
+
+func TestService_Reconcile(t *testing.T) {
+	healthChecksKey := meta.GlobalKey("allow-my-cluster-healthchecks")
+	clusterKey := meta.GlobalKey("allow-my-cluster-cluster")
+
+	customizedGCPCluster := fakeGCPCluster.DeepCopy()
+	customizedGCPCluster.Spec.DefaultFirewallRules = &infrav1.DefaultFirewallRulesSpec{
+		HealthChecks: &infrav1.DefaultFirewallRuleSpec{
+			Disabled: true,
+		},
+		Cluster: &infrav1.DefaultFirewallRuleSpec{
+			SourceRanges: []string{"10.0.0.0/8"},
+			Protocol:     pointer.String("tcp"),
+			Ports:        []string{"6443", "10250"},
+		},
+	}
+
+	tests := []testCase{
+		{
+			name:  "firewalls do not exist (should create the default firewalls)",
+			scope: func() *scope.ClusterScope { return newClusterScope(t, fakeGCPCluster.DeepCopy()) },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockFirewallsObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockFirewalls.Get(ctx, healthChecksKey); err != nil {
+					return err
+				}
+				firewall, err := t.mockFirewalls.Get(ctx, clusterKey)
+				if err != nil {
+					return err
+				}
+				if len(firewall.SourceTags) != 2 || len(firewall.SourceRanges) != 0 {
+					return errors.New("cluster firewall was created with wrong sources")
+				}
+
+				return nil
+			},
+		},
+		{
+			name:  "default firewalls are customized (should delete the disabled firewall and update the other)",
+			scope: func() *scope.ClusterScope { return newClusterScope(t, customizedGCPCluster.DeepCopy()) },
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*healthChecksKey: {Obj: &compute.Firewall{
+						Name:         "allow-my-cluster-healthchecks",
+						Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"6443"}}},
+						SourceRanges: []string{"35.191.0.0/16", "130.211.0.0/22"},
+					}},
+					*clusterKey: {Obj: &compute.Firewall{
+						Name:       "allow-my-cluster-cluster",
+						Allowed:    []*compute.FirewallAllowed{{IPProtocol: "all"}},
+						SourceTags: []string{"my-cluster-control-plane", "my-cluster-node"},
+					}},
+				},
+				UpdateHook: func(_ context.Context, key *meta.Key, obj *compute.Firewall, m *cloud.MockFirewalls) error {
+					m.Objects[*key].Obj = obj
+					return nil
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockFirewalls.Get(ctx, healthChecksKey); !gcperrors.IsNotFound(err) {
+					return errors.New("disabled health checks firewall was not deleted")
+				}
+				firewall, err := t.mockFirewalls.Get(ctx, clusterKey)
+				if err != nil {
+					return err
+				}
+				if len(firewall.SourceTags) != 0 || len(firewall.SourceRanges) != 1 || firewall.SourceRanges[0] != "10.0.0.0/8" {
+					return errors.New("cluster firewall was updated with wrong sources")
+				}
+				if len(firewall.Allowed) != 1 || firewall.Allowed[0].IPProtocol != "tcp" || len(firewall.Allowed[0].Ports) != 2 {
+					return errors.New("cluster firewall was updated with wrong allowed traffic")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "firewalls are in sync (should not update firewalls)",
+			scope: func() *scope.ClusterScope {
+				return newClusterScope(t, fakeGCPCluster.DeepCopy())
+			},
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*healthChecksKey: {Obj: &compute.Firewall{
+						Name:         "allow-my-cluster-healthchecks",
+						Description:  "capg-cluster-my-cluster",
+						Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"6443"}}},
+						SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+					}},
+					*clusterKey: {Obj: &compute.Firewall{
+						Name:        "allow-my-cluster-cluster",
+						Description: "capg-cluster-my-cluster",
+						Allowed:     []*compute.FirewallAllowed{{IPProtocol: "all"}},
+						SourceTags:  []string{"my-cluster-node", "my-cluster-control-plane"},
+					}},
+				},
+				UpdateHook: func(_ context.Context, key *meta.Key, _ *compute.Firewall, _ *cloud.MockFirewalls) error {
+					return errors.New("unexpected update of firewall " + key.Name)
+				},
+			},
+		},
+		{
+			name: "firewalls created before being marked as owned (should update their description)",
+			scope: func() *scope.ClusterScope {
+				return newClusterScope(t, fakeGCPCluster.DeepCopy())
+			},
+			mockFirewalls: &cloud.MockFirewalls{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockFirewallsObj{
+					*healthChecksKey: {Obj: &compute.Firewall{
+						Name:         "allow-my-cluster-healthchecks",
+						Allowed:      []*compute.FirewallAllowed{{IPProtocol: "tcp", Ports: []string{"6443"}}},
+						SourceRanges: []string{"130.211.0.0/22", "35.191.0.0/16"},
+					}},
+				},
+				UpdateHook: func(_ context.Context, key *meta.Key, obj *compute.Firewall, m *cloud.MockFirewalls) error {
+					m.Objects[*key].Obj = obj
+					return nil
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				firewall, err := t.mockFirewalls.Get(ctx, healthChecksKey)
+				if err != nil {
+					return err
+				}
+				if firewall.Description != "capg-cluster-my-cluster" {
+					return errors.New("health checks firewall was not marked as owned by the cluster")
+				}
+
+				return nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(tt.scope())
+			s.firewalls = tt.mockFirewalls
+			err := s.Reconcile(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Service.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.assert != nil {
+				if err := tt.assert(ctx, tt); err != nil {
+					t.Errorf("Service.Reconcile() %v", err)
+				}
+			}
+		})
+	}
+}
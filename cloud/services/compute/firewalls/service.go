@@ -35,6 +35,7 @@ type firewallsInterface interface {
 type Scope interface {
 	cloud.ClusterGetter
 	FirewallRulesSpec() []*compute.Firewall
+	DisabledFirewallRuleNames() []string
 }
 
 // Service implements firewalls reconciler.
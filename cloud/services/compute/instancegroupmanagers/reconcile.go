@@ -0,0 +1,344 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanagers
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/providerid"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Reconcile reconciles the managed instance group of the machine pool.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling managed instance group resources")
+
+	log.V(2).Info("Getting bootstrap data for machine pool")
+	bootstrapData, err := s.scope.GetBootstrapData()
+	if err != nil {
+		log.Error(err, "Error getting bootstrap data for machine pool")
+		return errors.Wrap(err, "failed to retrieve bootstrap data")
+	}
+
+	templateSpec, err := s.scope.InstanceTemplateSpec(log, bootstrapData)
+	if err != nil {
+		return err
+	}
+
+	template, err := s.createOrGetInstanceTemplate(ctx, templateSpec)
+	if err != nil {
+		return err
+	}
+
+	instanceGroupManager, err := s.createOrGetInstanceGroupManager(ctx, template)
+	if err != nil {
+		return err
+	}
+
+	// The type of the update policy is kept until the instance template changes. Only new instances need the
+	// rotated bootstrap data, existing ones are left alone when nothing else changed.
+	policyType := updatePolicyType(instanceGroupManager)
+	if instanceGroupManager.InstanceTemplate != template.SelfLink || policyType == "" {
+		policyType = "PROACTIVE"
+		if s.bootstrapDataChangedOnly(instanceGroupManager, template) {
+			policyType = "OPPORTUNISTIC"
+		}
+	}
+	if err := s.reconcileUpdatePolicy(ctx, instanceGroupManager, template, policyType); err != nil {
+		return err
+	}
+
+	instanceGroupManagerKey := meta.ZonalKey(s.scope.InstanceGroupManagerName(), s.scope.Zone())
+	if instanceGroupManager.InstanceTemplate != template.SelfLink {
+		log.V(2).Info("Rolling out instance template", "name", s.scope.InstanceGroupManagerName(), "template", template.Name, "type", policyType)
+		req := &compute.InstanceGroupManagersSetInstanceTemplateRequest{InstanceTemplate: template.SelfLink}
+		if err := s.instancegroupmanagers.SetInstanceTemplate(ctx, instanceGroupManagerKey, req); err != nil {
+			log.Error(err, "Error setting instance template", "name", s.scope.InstanceGroupManagerName())
+			return err
+		}
+		instanceGroupManager.Status = nil
+	}
+	s.scope.SetInstanceTemplate(template.Name)
+
+	if instanceGroupManager.TargetSize != s.scope.Replicas() {
+		log.V(2).Info("Resizing managed instance group", "name", s.scope.InstanceGroupManagerName(), "from", instanceGroupManager.TargetSize, "to", s.scope.Replicas())
+		if err := s.instancegroupmanagers.Resize(ctx, instanceGroupManagerKey, s.scope.Replicas()); err != nil {
+			log.Error(err, "Error resizing managed instance group", "name", s.scope.InstanceGroupManagerName())
+			return err
+		}
+		instanceGroupManager.Status = nil
+	}
+
+	providerIDs, err := s.listProviderIDs(ctx)
+	if err != nil {
+		return err
+	}
+	s.scope.SetProviderIDList(providerIDs)
+	s.scope.SetReplicas(int32(len(providerIDs)))
+
+	// Opportunistic updates are never reached until every instance has been recreated for another reason.
+	if status := instanceGroupManager.Status; status != nil && status.IsStable &&
+		(status.VersionTarget == nil || status.VersionTarget.IsReached || updatePolicyType(instanceGroupManager) == "OPPORTUNISTIC") {
+		s.scope.SetReady()
+	} else {
+		s.scope.SetNotReady()
+	}
+
+	s.deleteUnusedInstanceTemplates(ctx, template.Name)
+	return nil
+}
+
+// Delete deletes the managed instance group of the machine pool and its instance templates.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	log.Info("Deleting managed instance group resources")
+
+	instanceGroupManagerKey := meta.ZonalKey(s.scope.InstanceGroupManagerName(), s.scope.Zone())
+	log.V(2).Info("Looking for managed instance group before deleting", "name", s.scope.InstanceGroupManagerName())
+	instanceGroupManager, err := s.instancegroupmanagers.Get(ctx, instanceGroupManagerKey)
+	if err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error looking for managed instance group", "name", s.scope.InstanceGroupManagerName())
+		return err
+	}
+
+	if instanceGroupManager != nil && instanceGroupManager.Description == infrav1.ClusterTagKey(s.scope.ClusterName()) {
+		log.V(2).Info("Deleting managed instance group", "name", s.scope.InstanceGroupManagerName())
+		if err := s.instancegroupmanagers.Delete(ctx, instanceGroupManagerKey); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting managed instance group", "name", s.scope.InstanceGroupManagerName())
+			return err
+		}
+	}
+
+	templates, err := s.listInstanceTemplates(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, template := range templates {
+		log.V(2).Info("Deleting instance template", "name", template.Name)
+		if err := s.instancetemplates.Delete(ctx, meta.GlobalKey(template.Name)); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting instance template", "name", template.Name)
+			return err
+		}
+	}
+
+	s.scope.SetProviderIDList(nil)
+	s.scope.SetReplicas(0)
+	return nil
+}
+
+// createOrGetInstanceTemplate creates the instance template if not exist otherwise return the existing one.
+func (s *Service) createOrGetInstanceTemplate(ctx context.Context, spec *compute.InstanceTemplate) (*compute.InstanceTemplate, error) {
+	log := log.FromContext(ctx)
+	log.V(2).Info("Looking for instance template", "name", spec.Name)
+	templateKey := meta.GlobalKey(spec.Name)
+	template, err := s.instancetemplates.Get(ctx, templateKey)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for instance template", "name", spec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating an instance template", "name", spec.Name)
+		if err := s.instancetemplates.Insert(ctx, templateKey, spec); err != nil {
+			log.Error(err, "Error creating an instance template", "name", spec.Name)
+			return nil, err
+		}
+
+		template, err = s.instancetemplates.Get(ctx, templateKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return template, nil
+}
+
+// createOrGetInstanceGroupManager creates the managed instance group if not exist otherwise return the existing one.
+// A managed instance group of the same name that was not created for the cluster is left alone.
+func (s *Service) createOrGetInstanceGroupManager(ctx context.Context, template *compute.InstanceTemplate) (*compute.InstanceGroupManager, error) {
+	log := log.FromContext(ctx)
+	log.V(2).Info("Looking for managed instance group", "name", s.scope.InstanceGroupManagerName())
+	instanceGroupManagerKey := meta.ZonalKey(s.scope.InstanceGroupManagerName(), s.scope.Zone())
+	instanceGroupManager, err := s.instancegroupmanagers.Get(ctx, instanceGroupManagerKey)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for managed instance group", "name", s.scope.InstanceGroupManagerName())
+			return nil, err
+		}
+
+		log.V(2).Info("Creating a managed instance group", "name", s.scope.InstanceGroupManagerName())
+		spec := s.scope.InstanceGroupManagerSpec(template.SelfLink)
+		if err := s.instancegroupmanagers.Insert(ctx, instanceGroupManagerKey, spec); err != nil {
+			log.Error(err, "Error creating a managed instance group", "name", s.scope.InstanceGroupManagerName())
+			return nil, err
+		}
+
+		instanceGroupManager, err = s.instancegroupmanagers.Get(ctx, instanceGroupManagerKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if instanceGroupManager.Description != infrav1.ClusterTagKey(s.scope.ClusterName()) {
+		return nil, errors.Errorf("managed instance group %s already exists and is not owned by cluster %s", instanceGroupManager.Name, s.scope.ClusterName())
+	}
+
+	return instanceGroupManager, nil
+}
+
+// listProviderIDs returns the provider IDs of the instances of the managed instance group, sorted to keep the
+// list stable. Instances that are being removed from the group are skipped.
+func (s *Service) listProviderIDs(ctx context.Context) ([]string, error) {
+	log := log.FromContext(ctx)
+	instances, err := s.managedinstances.List(ctx, meta.ZonalKey(s.scope.InstanceGroupManagerName(), s.scope.Zone()))
+	if err != nil {
+		log.Error(err, "Error listing instances of managed instance group", "name", s.scope.InstanceGroupManagerName())
+		return nil, err
+	}
+
+	providerIDs := make([]string, 0, len(instances))
+	for _, instance := range instances {
+		switch instance.CurrentAction {
+		case "DELETING", "ABANDONING":
+			continue
+		}
+		providerID, err := providerid.NewFromResourceURL(instance.Instance)
+		if err != nil {
+			return nil, err
+		}
+		providerIDs = append(providerIDs, providerID.String())
+	}
+	sort.Strings(providerIDs)
+
+	return providerIDs, nil
+}
+
+// listInstanceTemplates returns the instance templates of the machine pool created by capg.
+func (s *Service) listInstanceTemplates(ctx context.Context) ([]*compute.InstanceTemplate, error) {
+	log := log.FromContext(ctx)
+	fl := filter.Regexp("name", s.instanceTemplateNamePattern())
+	templates, err := s.instancetemplates.List(ctx, fl)
+	if err != nil {
+		log.Error(err, "Error listing instance templates", "prefix", s.scope.InstanceTemplatePrefix())
+		return nil, err
+	}
+
+	owned := make([]*compute.InstanceTemplate, 0, len(templates))
+	for _, template := range templates {
+		if template.Description == infrav1.ClusterTagKey(s.scope.ClusterName()) {
+			owned = append(owned, template)
+		}
+	}
+
+	return owned, nil
+}
+
+// instanceTemplateNamePattern returns the pattern of the names of the instance templates of the machine pool,
+// which capture the hash of their properties other than the bootstrap data.
+func (s *Service) instanceTemplateNamePattern() string {
+	return fmt.Sprintf("%s-([0-9a-f]{8})-[0-9a-f]{8}", regexp.QuoteMeta(s.scope.InstanceTemplatePrefix()))
+}
+
+// bootstrapDataChangedOnly returns whether the instance template of the managed instance group differs from the
+// given one by its bootstrap data only, while no rollout of another change is in progress.
+func (s *Service) bootstrapDataChangedOnly(instanceGroupManager *compute.InstanceGroupManager, template *compute.InstanceTemplate) bool {
+	if updatePolicyType(instanceGroupManager) != "OPPORTUNISTIC" {
+		status := instanceGroupManager.Status
+		if status == nil || (status.VersionTarget != nil && !status.VersionTarget.IsReached) {
+			return false
+		}
+	}
+
+	re := regexp.MustCompile("^" + s.instanceTemplateNamePattern() + "$")
+	current := re.FindStringSubmatch(path.Base(instanceGroupManager.InstanceTemplate))
+	desired := re.FindStringSubmatch(template.Name)
+	return current != nil && desired != nil && current[1] == desired[1]
+}
+
+// reconcileUpdatePolicy updates the update policy of the managed instance group when it differs from the strategy
+// of the machine pool or from the given type, which decides whether its instances are replaced once its instance
+// template is set.
+func (s *Service) reconcileUpdatePolicy(ctx context.Context, instanceGroupManager *compute.InstanceGroupManager, template *compute.InstanceTemplate, policyType string) error {
+	policy := s.scope.InstanceGroupManagerSpec(template.SelfLink).UpdatePolicy
+	policy.Type = policyType
+	if current := instanceGroupManager.UpdatePolicy; current != nil && current.Type == policy.Type &&
+		current.MinimalAction == policy.MinimalAction &&
+		fixedOrPercentEqual(current.MaxSurge, policy.MaxSurge) &&
+		fixedOrPercentEqual(current.MaxUnavailable, policy.MaxUnavailable) {
+		return nil
+	}
+
+	log := log.FromContext(ctx)
+	log.V(2).Info("Updating update policy of managed instance group", "name", s.scope.InstanceGroupManagerName(), "type", policyType)
+	if err := s.updatepolicies.Set(ctx, meta.ZonalKey(s.scope.InstanceGroupManagerName(), s.scope.Zone()), policy); err != nil {
+		log.Error(err, "Error updating update policy of managed instance group", "name", s.scope.InstanceGroupManagerName())
+		return err
+	}
+	instanceGroupManager.UpdatePolicy = policy
+
+	return nil
+}
+
+// updatePolicyType returns the type of the update policy of a managed instance group.
+func updatePolicyType(instanceGroupManager *compute.InstanceGroupManager) string {
+	if instanceGroupManager.UpdatePolicy == nil {
+		return ""
+	}
+	return instanceGroupManager.UpdatePolicy.Type
+}
+
+// fixedOrPercentEqual returns whether two fixed or percent values of an update policy are the same, ignoring the
+// number of instances calculated from them.
+func fixedOrPercentEqual(a, b *compute.FixedOrPercent) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Fixed == b.Fixed && a.Percent == b.Percent
+}
+
+// deleteUnusedInstanceTemplates deletes the previous instance templates of the machine pool. Templates still
+// referenced by instances being replaced cannot be deleted yet and are retried on the next reconciliation.
+func (s *Service) deleteUnusedInstanceTemplates(ctx context.Context, current string) {
+	log := log.FromContext(ctx)
+	templates, err := s.listInstanceTemplates(ctx)
+	if err != nil {
+		return
+	}
+
+	for _, template := range templates {
+		if template.Name == current {
+			continue
+		}
+
+		log.V(2).Info("Deleting unused instance template", "name", template.Name)
+		if err := s.instancetemplates.Delete(ctx, meta.GlobalKey(template.Name)); err != nil && !gcperrors.IsNotFound(err) {
+			log.V(2).Info("Unable to delete instance template, retrying later", "name", template.Name, "error", err.Error())
+		}
+	}
+}
@@ -0,0 +1,526 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanagers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/go-logr/logr"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+	_ = infrav1exp.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+	},
+}
+
+var fakeBootstrapSecret = &corev1.Secret{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-pool-bootstrap",
+		Namespace: "default",
+	},
+	Data: map[string][]byte{
+		"value": []byte("Zm9vCg=="),
+	},
+}
+
+var fakeMachinePool = &clusterv1exp.MachinePool{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-pool",
+		Namespace: "default",
+	},
+	Spec: clusterv1exp.MachinePoolSpec{
+		ClusterName:    "my-cluster",
+		Replicas:       pointer.Int32(2),
+		FailureDomains: []string{"us-central1-a"},
+		Template: clusterv1.MachineTemplateSpec{
+			Spec: clusterv1.MachineSpec{
+				ClusterName: "my-cluster",
+				Version:     pointer.String("v1.27.3"),
+				Bootstrap: clusterv1.Bootstrap{
+					DataSecretName: pointer.String("my-pool-bootstrap"),
+				},
+			},
+		},
+	},
+}
+
+var fakeGCPMachinePool = &infrav1exp.GCPMachinePool{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-pool",
+		Namespace: "default",
+	},
+	Spec: infrav1exp.GCPMachinePoolSpec{
+		Template: infrav1.GCPMachineSpec{
+			InstanceType:               "n1-standard-2",
+			RootDeviceResourcePolicies: []string{"daily-snapshots"},
+			MinCPUPlatform:             pointer.String("Intel Cascade Lake"),
+		},
+	},
+}
+
+var instanceGroupManagerKey = meta.ZonalKey("my-pool-f784094a", "us-central1-a")
+
+// fakeUpdatePolicies sets the update policies of the managed instance groups of a mock.
+type fakeUpdatePolicies struct {
+	m *cloud.MockInstanceGroupManagers
+}
+
+func (f *fakeUpdatePolicies) Set(_ context.Context, key *meta.Key, policy *compute.InstanceGroupManagerUpdatePolicy) error {
+	f.m.Objects[*key].Obj.(*compute.InstanceGroupManager).UpdatePolicy = policy
+	return nil
+}
+
+// fakeManagedInstances lists the same instances for every managed instance group.
+type fakeManagedInstances []*compute.ManagedInstance
+
+func (f fakeManagedInstances) List(_ context.Context, _ *meta.Key) ([]*compute.ManagedInstance, error) {
+	return f, nil
+}
+
+type testCase struct {
+	name                      string
+	mockInstanceGroupManagers *cloud.MockInstanceGroupManagers
+	mockInstanceTemplates     *cloud.MockInstanceTemplates
+	managedInstances          fakeManagedInstances
+	wantErr                   bool
+	assert                    func(ctx context.Context, t testCase, s *scope.MachinePoolScope) error
+}
+
+func newMachinePoolScope(t *testing.T) *scope.MachinePoolScope {
+	t.Helper()
+
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret.DeepCopy()).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machinePoolScope, err := scope.NewMachinePoolScope(scope.MachinePoolScopeParams{
+		Client:         fakec,
+		ClusterGetter:  clusterScope,
+		MachinePool:    fakeMachinePool.DeepCopy(),
+		GCPMachinePool: fakeGCPMachinePool.DeepCopy(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return machinePoolScope
+}
+
+func TestService_Reconcile(t *testing.T) {
+	machinePoolScope := newMachinePoolScope(t)
+	previousTemplate, err := machinePoolScope.InstanceTemplateSpec(logr.Discard(), "previous bootstrap data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bootstrapData, err := machinePoolScope.GetBootstrapData()
+	if err != nil {
+		t.Fatal(err)
+	}
+	currentTemplate, err := machinePoolScope.InstanceTemplateSpec(logr.Discard(), bootstrapData)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []testCase{
+		{
+			name: "managed instance group does not exist (should create instance template and managed instance group)",
+			mockInstanceGroupManagers: &cloud.MockInstanceGroupManagers{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstanceGroupManagersObj{},
+			},
+			mockInstanceTemplates: &cloud.MockInstanceTemplates{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstanceTemplatesObj{},
+			},
+			managedInstances: fakeManagedInstances{
+				{Instance: "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/my-pool-f784094a-wxyz", CurrentAction: "NONE"},
+				{Instance: "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/my-pool-f784094a-efgh", CurrentAction: "DELETING"},
+				{Instance: "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/my-pool-f784094a-abcd", CurrentAction: "CREATING"},
+			},
+			assert: func(ctx context.Context, t testCase, s *scope.MachinePoolScope) error {
+				instanceGroupManager, err := t.mockInstanceGroupManagers.Get(ctx, instanceGroupManagerKey)
+				if err != nil {
+					return err
+				}
+
+				if instanceGroupManager.TargetSize != 2 ||
+					instanceGroupManager.BaseInstanceName != "my-pool-f784094a" ||
+					!strings.Contains(instanceGroupManager.InstanceTemplate, s.GCPMachinePool.Status.InstanceTemplate) {
+					return errors.New("managed instance group was created but with wrong values")
+				}
+
+				template, err := t.mockInstanceTemplates.Get(ctx, meta.GlobalKey(s.GCPMachinePool.Status.InstanceTemplate))
+				if err != nil {
+					return err
+				}
+
+				if template.Properties.MachineType != "n1-standard-2" ||
+					template.Properties.MinCpuPlatform != "Intel Cascade Lake" {
+					return errors.New("instance template was created but with wrong values")
+				}
+
+				if len(s.GCPMachinePool.Spec.ProviderIDList) != 2 ||
+					s.GCPMachinePool.Spec.ProviderIDList[0] != "gce://my-proj/us-central1-a/my-pool-f784094a-abcd" ||
+					s.GCPMachinePool.Spec.ProviderIDList[1] != "gce://my-proj/us-central1-a/my-pool-f784094a-wxyz" {
+					return errors.New("provider IDs were not set")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "machine pool disks have resource policies (should reference them by name in the instance template)",
+			mockInstanceGroupManagers: &cloud.MockInstanceGroupManagers{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstanceGroupManagersObj{},
+			},
+			mockInstanceTemplates: &cloud.MockInstanceTemplates{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstanceTemplatesObj{},
+			},
+			assert: func(ctx context.Context, t testCase, s *scope.MachinePoolScope) error {
+				template, err := t.mockInstanceTemplates.Get(ctx, meta.GlobalKey(s.GCPMachinePool.Status.InstanceTemplate))
+				if err != nil {
+					return err
+				}
+
+				resourcePolicies := template.Properties.Disks[0].InitializeParams.ResourcePolicies
+				if len(resourcePolicies) != 1 || resourcePolicies[0] != "daily-snapshots" {
+					return errors.New("instance template references resource policies by URL")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "managed instance group uses an outdated template (should set the template and resize)",
+			mockInstanceGroupManagers: &cloud.MockInstanceGroupManagers{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockInstanceGroupManagersObj{
+					*instanceGroupManagerKey: {Obj: &compute.InstanceGroupManager{
+						Name:             "my-pool-f784094a",
+						Description:      infrav1.ClusterTagKey("my-cluster"),
+						InstanceTemplate: "global/instanceTemplates/my-pool-00000000-00000000",
+						TargetSize:       1,
+					}},
+				},
+				SetInstanceTemplateHook: func(ctx context.Context, key *meta.Key, req *compute.InstanceGroupManagersSetInstanceTemplateRequest, m *cloud.MockInstanceGroupManagers) error {
+					m.Objects[*key].Obj.(*compute.InstanceGroupManager).InstanceTemplate = req.InstanceTemplate
+					return nil
+				},
+				ResizeHook: func(ctx context.Context, key *meta.Key, size int64, m *cloud.MockInstanceGroupManagers) error {
+					m.Objects[*key].Obj.(*compute.InstanceGroupManager).TargetSize = size
+					return nil
+				},
+			},
+			mockInstanceTemplates: &cloud.MockInstanceTemplates{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstanceTemplatesObj{},
+			},
+			assert: func(ctx context.Context, t testCase, s *scope.MachinePoolScope) error {
+				instanceGroupManager, err := t.mockInstanceGroupManagers.Get(ctx, instanceGroupManagerKey)
+				if err != nil {
+					return err
+				}
+
+				if instanceGroupManager.TargetSize != 2 ||
+					!strings.Contains(instanceGroupManager.InstanceTemplate, s.GCPMachinePool.Status.InstanceTemplate) {
+					return errors.New("managed instance group was not updated")
+				}
+
+				if s.GCPMachinePool.Status.Ready {
+					return errors.New("machine pool is ready while the managed instance group is updating")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "managed instance group template differs by its bootstrap data only (should set the template opportunistically)",
+			mockInstanceGroupManagers: &cloud.MockInstanceGroupManagers{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockInstanceGroupManagersObj{
+					*instanceGroupManagerKey: {Obj: &compute.InstanceGroupManager{
+						Name:             "my-pool-f784094a",
+						Description:      infrav1.ClusterTagKey("my-cluster"),
+						InstanceTemplate: "global/instanceTemplates/" + previousTemplate.Name,
+						TargetSize:       2,
+						UpdatePolicy:     &compute.InstanceGroupManagerUpdatePolicy{Type: "PROACTIVE"},
+						Status: &compute.InstanceGroupManagerStatus{
+							IsStable:      true,
+							VersionTarget: &compute.InstanceGroupManagerStatusVersionTarget{IsReached: true},
+						},
+					}},
+				},
+				SetInstanceTemplateHook: func(ctx context.Context, key *meta.Key, req *compute.InstanceGroupManagersSetInstanceTemplateRequest, m *cloud.MockInstanceGroupManagers) error {
+					m.Objects[*key].Obj.(*compute.InstanceGroupManager).InstanceTemplate = req.InstanceTemplate
+					return nil
+				},
+			},
+			mockInstanceTemplates: &cloud.MockInstanceTemplates{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstanceTemplatesObj{},
+			},
+			assert: func(ctx context.Context, t testCase, s *scope.MachinePoolScope) error {
+				instanceGroupManager, err := t.mockInstanceGroupManagers.Get(ctx, instanceGroupManagerKey)
+				if err != nil {
+					return err
+				}
+
+				if s.GCPMachinePool.Status.InstanceTemplate == previousTemplate.Name ||
+					!strings.Contains(instanceGroupManager.InstanceTemplate, s.GCPMachinePool.Status.InstanceTemplate) {
+					return errors.New("managed instance group template was not updated")
+				}
+
+				if instanceGroupManager.UpdatePolicy.Type != "OPPORTUNISTIC" {
+					return errors.New("instances are replaced while only the bootstrap data changed")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "managed instance group update policy differs from the strategy (should update the policy only)",
+			mockInstanceGroupManagers: &cloud.MockInstanceGroupManagers{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockInstanceGroupManagersObj{
+					*instanceGroupManagerKey: {Obj: &compute.InstanceGroupManager{
+						Name:             "my-pool-f784094a",
+						Description:      infrav1.ClusterTagKey("my-cluster"),
+						InstanceTemplate: cloud.SelfLink(meta.VersionGA, "my-proj", "instanceTemplates", meta.GlobalKey(currentTemplate.Name)),
+						TargetSize:       2,
+						UpdatePolicy: &compute.InstanceGroupManagerUpdatePolicy{
+							Type:           "OPPORTUNISTIC",
+							MinimalAction:  "REPLACE",
+							MaxSurge:       &compute.FixedOrPercent{Fixed: 3, Calculated: 3},
+							MaxUnavailable: &compute.FixedOrPercent{Fixed: 0},
+						},
+					}},
+				},
+			},
+			mockInstanceTemplates: &cloud.MockInstanceTemplates{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstanceTemplatesObj{},
+			},
+			assert: func(ctx context.Context, t testCase, s *scope.MachinePoolScope) error {
+				instanceGroupManager, err := t.mockInstanceGroupManagers.Get(ctx, instanceGroupManagerKey)
+				if err != nil {
+					return err
+				}
+
+				policy := instanceGroupManager.UpdatePolicy
+				if policy.MaxSurge.Fixed != 1 || policy.MaxUnavailable.Fixed != 0 {
+					return errors.New("update policy was not updated")
+				}
+
+				if policy.Type != "OPPORTUNISTIC" {
+					return errors.New("update policy type was changed while the instance template did not change")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "managed instance group is not owned by the cluster (should return an error)",
+			mockInstanceGroupManagers: &cloud.MockInstanceGroupManagers{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockInstanceGroupManagersObj{
+					*instanceGroupManagerKey: {Obj: &compute.InstanceGroupManager{
+						Name:             "my-pool-f784094a",
+						Description:      infrav1.ClusterTagKey("other-cluster"),
+						InstanceTemplate: "global/instanceTemplates/other-template",
+						TargetSize:       1,
+					}},
+				},
+				SetInstanceTemplateHook: func(ctx context.Context, key *meta.Key, req *compute.InstanceGroupManagersSetInstanceTemplateRequest, m *cloud.MockInstanceGroupManagers) error {
+					return errors.New("instance template of a managed instance group of another cluster was set")
+				},
+			},
+			mockInstanceTemplates: &cloud.MockInstanceTemplates{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstanceTemplatesObj{},
+			},
+			wantErr: true,
+			assert: func(ctx context.Context, t testCase, s *scope.MachinePoolScope) error {
+				instanceGroupManager, err := t.mockInstanceGroupManagers.Get(ctx, instanceGroupManagerKey)
+				if err != nil {
+					return err
+				}
+
+				if instanceGroupManager.TargetSize != 1 || instanceGroupManager.InstanceTemplate != "global/instanceTemplates/other-template" {
+					return errors.New("managed instance group of another cluster was updated")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "instance template creation fails (should return an error)",
+			mockInstanceGroupManagers: &cloud.MockInstanceGroupManagers{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstanceGroupManagersObj{},
+			},
+			mockInstanceTemplates: &cloud.MockInstanceTemplates{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstanceTemplatesObj{},
+				InsertHook: func(ctx context.Context, key *meta.Key, obj *compute.InstanceTemplate, m *cloud.MockInstanceTemplates) (bool, error) {
+					return true, &googleapi.Error{Code: http.StatusBadRequest}
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			machinePoolScope := newMachinePoolScope(t)
+			s := New(machinePoolScope)
+			s.instancegroupmanagers = tt.mockInstanceGroupManagers
+			s.updatepolicies = &fakeUpdatePolicies{m: tt.mockInstanceGroupManagers}
+			s.instancetemplates = tt.mockInstanceTemplates
+			s.managedinstances = tt.managedInstances
+			err := s.Reconcile(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assert != nil {
+				err = tt.assert(ctx, tt, machinePoolScope)
+				if err != nil {
+					t.Errorf("managed instance group was not reconciled as expected: %v", err)
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	tests := []testCase{
+		{
+			name: "managed instance group does not exist, should do nothing",
+			mockInstanceGroupManagers: &cloud.MockInstanceGroupManagers{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstanceGroupManagersObj{},
+			},
+			mockInstanceTemplates: &cloud.MockInstanceTemplates{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstanceTemplatesObj{},
+			},
+		},
+		{
+			name: "error deleting managed instance group, should return error",
+			mockInstanceGroupManagers: &cloud.MockInstanceGroupManagers{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockInstanceGroupManagersObj{
+					*instanceGroupManagerKey: {Obj: &compute.InstanceGroupManager{Description: infrav1.ClusterTagKey("my-cluster")}},
+				},
+				DeleteError: map[meta.Key]error{
+					*instanceGroupManagerKey: &googleapi.Error{Code: http.StatusBadRequest},
+				},
+			},
+			mockInstanceTemplates: &cloud.MockInstanceTemplates{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockInstanceTemplatesObj{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(newMachinePoolScope(t))
+			s.instancegroupmanagers = tt.mockInstanceGroupManagers
+			s.instancetemplates = tt.mockInstanceTemplates
+			err := s.Delete(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
+
+func TestInstanceGroupManagerName(t *testing.T) {
+	machinePoolScope := newMachinePoolScope(t)
+	if got := machinePoolScope.InstanceGroupManagerName(); got != "my-pool-f784094a" {
+		t.Errorf("InstanceGroupManagerName() = %s, want my-pool-f784094a", got)
+	}
+
+	otherClusterScope := newMachinePoolScope(t)
+	otherClusterScope.ClusterGetter.(*scope.ClusterScope).Cluster = &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "other-cluster", Namespace: "default"}}
+	if otherClusterScope.InstanceGroupManagerName() == machinePoolScope.InstanceGroupManagerName() {
+		t.Error("InstanceGroupManagerName() is the same for the machine pools of different clusters")
+	}
+
+	longNameScope := newMachinePoolScope(t)
+	longNameScope.GCPMachinePool.Name = strings.Repeat("a", 63)
+	if got := longNameScope.InstanceGroupManagerName(); len(got) > 58 {
+		t.Errorf("InstanceGroupManagerName() = %s, longer than the 58 characters of base instance names", got)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instancegroupmanagers
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+)
+
+type instancegroupmanagersInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.InstanceGroupManager, error)
+	Insert(ctx context.Context, key *meta.Key, obj *compute.InstanceGroupManager) error
+	Delete(ctx context.Context, key *meta.Key) error
+	Resize(ctx context.Context, key *meta.Key, size int64) error
+	SetInstanceTemplate(ctx context.Context, key *meta.Key, req *compute.InstanceGroupManagersSetInstanceTemplateRequest) error
+}
+
+type updatepoliciesInterface interface {
+	Set(ctx context.Context, key *meta.Key, policy *compute.InstanceGroupManagerUpdatePolicy) error
+}
+
+type instancetemplatesInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.InstanceTemplate, error)
+	List(ctx context.Context, fl *filter.F) ([]*compute.InstanceTemplate, error)
+	Insert(ctx context.Context, key *meta.Key, obj *compute.InstanceTemplate) error
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type managedinstancesInterface interface {
+	List(ctx context.Context, key *meta.Key) ([]*compute.ManagedInstance, error)
+}
+
+// Scope is an interfaces that hold used methods.
+type Scope interface {
+	cloud.MachinePool
+	ComputeService() *compute.Service
+	InstanceTemplateSpec(log logr.Logger, bootstrapData string) (*compute.InstanceTemplate, error)
+	InstanceTemplatePrefix() string
+	InstanceGroupManagerName() string
+	InstanceGroupManagerSpec(instanceTemplate string) *compute.InstanceGroupManager
+}
+
+// Service implements managed instance groups reconciler.
+type Service struct {
+	scope                 Scope
+	instancegroupmanagers instancegroupmanagersInterface
+	updatepolicies        updatepoliciesInterface
+	instancetemplates     instancetemplatesInterface
+	managedinstances      managedinstancesInterface
+}
+
+var _ cloud.Reconciler = &Service{}
+
+// New returns Service from given scope.
+func New(scope Scope) *Service {
+	return &Service{
+		scope:                 scope,
+		instancegroupmanagers: scope.Cloud().InstanceGroupManagers(),
+		updatepolicies: &updatePolicies{
+			project: scope.Project(),
+			service: scope.ComputeService(),
+		},
+		instancetemplates: scope.Cloud().InstanceTemplates(),
+		managedinstances: &managedInstances{
+			project: scope.Project(),
+			service: scope.ComputeService(),
+		},
+	}
+}
+
+// updatePolicies implements updatepoliciesInterface on top of the compute API, as patching a managed instance
+// group is not covered by the cloud provider library.
+type updatePolicies struct {
+	project string
+	service *compute.Service
+}
+
+// Set sets the update policy of a zonal managed instance group and waits for the operation to complete.
+func (u *updatePolicies) Set(ctx context.Context, key *meta.Key, policy *compute.InstanceGroupManagerUpdatePolicy) error {
+	op, err := u.service.InstanceGroupManagers.Patch(u.project, key.Zone, key.Name, &compute.InstanceGroupManager{UpdatePolicy: policy}).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	err = wait.PollUntilContextTimeout(ctx, time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
+		if op.Status == "DONE" {
+			return true, nil
+		}
+
+		var err error
+		op, err = u.service.ZoneOperations.Wait(u.project, key.Zone, op.Name).Context(ctx).Do()
+		return false, err
+	})
+	if err != nil {
+		return err
+	}
+
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		return errors.Errorf("operation %s failed: %s", op.Name, op.Error.Errors[0].Message)
+	}
+
+	return nil
+}
+
+// managedInstances implements managedinstancesInterface on top of the compute API, as listing the instances of a
+// managed instance group is not covered by the cloud provider library.
+type managedInstances struct {
+	project string
+	service *compute.Service
+}
+
+// List lists the instances of a zonal managed instance group.
+func (m *managedInstances) List(ctx context.Context, key *meta.Key) ([]*compute.ManagedInstance, error) {
+	var instances []*compute.ManagedInstance
+	err := m.service.InstanceGroupManagers.ListManagedInstances(m.project, key.Zone, key.Name).Pages(ctx, func(page *compute.InstanceGroupManagersListManagedInstancesResponse) error {
+		instances = append(instances, page.ManagedInstances...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return instances, nil
+}
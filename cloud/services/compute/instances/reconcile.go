@@ -19,6 +19,7 @@ package instances
 import (
 	"context"
 	"fmt"
+	"path"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
@@ -41,6 +42,11 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		return err
 	}
 
+	if instance == nil {
+		s.scope.SetInstanceStatus(infrav1.InstanceStatusTerminated)
+		return nil
+	}
+
 	addresses := make([]corev1.NodeAddress, 0, len(instance.NetworkInterfaces))
 	for _, iface := range instance.NetworkInterfaces {
 		addresses = append(addresses, corev1.NodeAddress{
@@ -54,6 +60,23 @@ func (s *Service) Reconcile(ctx context.Context) error {
 				Address: ac.NatIP,
 			})
 		}
+
+		if iface.Ipv6Address != "" {
+			addresses = append(addresses, corev1.NodeAddress{
+				Type:    corev1.NodeInternalIP,
+				Address: iface.Ipv6Address,
+			})
+		}
+
+		for _, ac := range iface.Ipv6AccessConfigs {
+			if ac.ExternalIpv6 == "" {
+				continue
+			}
+			addresses = append(addresses, corev1.NodeAddress{
+				Type:    corev1.NodeExternalIP,
+				Address: ac.ExternalIpv6,
+			})
+		}
 	}
 
 	machineName := s.scope.Name()
@@ -81,8 +104,17 @@ func (s *Service) Reconcile(ctx context.Context) error {
 		Address: machineName,
 	})
 
-	s.scope.SetProviderID()
+	if err := s.reconcileLabelsAndTags(ctx, instance); err != nil {
+		return err
+	}
+
+	if err := s.reconcileRootDiskSize(ctx, instance); err != nil {
+		return err
+	}
+
+	s.scope.SetProviderID(instance.SelfLink)
 	s.scope.SetAddresses(addresses)
+	s.scope.SetDisks(attachedDisksStatus(instance.Disks))
 	s.scope.SetInstanceStatus(infrav1.InstanceStatus(instance.Status))
 
 	if s.scope.IsControlPlane() {
@@ -94,6 +126,123 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return nil
 }
 
+// reconcileLabelsAndTags updates the labels and network tags of an existing instance when they drifted from
+// the ones of the spec, e.g. after the additional labels or network tags of the GCPMachine were changed.
+func (s *Service) reconcileLabelsAndTags(ctx context.Context, instance *compute.Instance) error {
+	log := log.FromContext(ctx)
+	instanceSpec := s.scope.InstanceSpec(log)
+	instanceKey := meta.ZonalKey(instance.Name, s.scope.Zone())
+
+	if !labelsEqual(instance.Labels, instanceSpec.Labels) {
+		log.V(2).Info("Updating instance labels", "name", instance.Name, "zone", s.scope.Zone())
+		if err := s.instanceattributes.SetLabels(ctx, instanceKey, &compute.InstancesSetLabelsRequest{
+			Labels:           instanceSpec.Labels,
+			LabelFingerprint: instance.LabelFingerprint,
+		}); err != nil {
+			log.Error(err, "Error updating instance labels", "name", instance.Name)
+			return err
+		}
+		instance.Labels = instanceSpec.Labels
+	}
+
+	var currentTags []string
+	fingerprint := ""
+	if instance.Tags != nil {
+		currentTags = instance.Tags.Items
+		fingerprint = instance.Tags.Fingerprint
+	}
+	if !sets.NewString(currentTags...).Equal(sets.NewString(instanceSpec.Tags.Items...)) {
+		log.V(2).Info("Updating instance network tags", "name", instance.Name, "zone", s.scope.Zone())
+		if err := s.instanceattributes.SetTags(ctx, instanceKey, &compute.Tags{
+			Items:       sets.NewString(instanceSpec.Tags.Items...).List(),
+			Fingerprint: fingerprint,
+		}); err != nil {
+			log.Error(err, "Error updating instance network tags", "name", instance.Name)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reconcileRootDiskSize resizes the root disk of an existing instance when the size of the spec was increased.
+// Disks cannot be shrunk, a smaller size is only reported. The guest OS grows the root partition on the next
+// boot, or online with growpart.
+func (s *Service) reconcileRootDiskSize(ctx context.Context, instance *compute.Instance) error {
+	log := log.FromContext(ctx)
+	size := s.scope.InstanceImageSpec().InitializeParams.DiskSizeGb
+	for _, disk := range instance.Disks {
+		if !disk.Boot || size == 0 || size == disk.DiskSizeGb {
+			continue
+		}
+
+		name := path.Base(disk.Source)
+		if size < disk.DiskSizeGb {
+			log.Info("Root disk is larger than the requested size and cannot be shrunk", "name", name, "sizeGb", disk.DiskSizeGb, "requestedSizeGb", size)
+			continue
+		}
+
+		log.Info("Resizing root disk", "name", name, "sizeGb", disk.DiskSizeGb, "requestedSizeGb", size)
+		if err := s.disks.Resize(ctx, meta.ZonalKey(name, s.scope.Zone()), &compute.DisksResizeRequest{SizeGb: size}); err != nil {
+			log.Error(err, "Error resizing root disk", "name", name)
+			return err
+		}
+		disk.DiskSizeGb = size
+	}
+
+	return nil
+}
+
+// validateCustomMachineType returns an error if the given machine type is a custom machine type of a machine
+// series which is not offered in the zone of the instance. Custom machine types cannot be looked up by name, the
+// series is available if one of its predefined machine types is.
+func (s *Service) validateCustomMachineType(ctx context.Context, machineType string) error {
+	if !infrav1.IsCustomMachineType(machineType) {
+		return nil
+	}
+
+	series := infrav1.MachineSeries(machineType)
+	machineTypes, err := s.machinetypes.List(ctx, s.scope.Zone(), fmt.Sprintf("name eq %s-.*", series))
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the machine types of zone %s", s.scope.Zone())
+	}
+	if len(machineTypes) == 0 {
+		return errors.Errorf("custom machine type %s is not available in zone %s, which does not offer the %s series", machineType, s.scope.Zone(), series)
+	}
+
+	return nil
+}
+
+// validateGuestAccelerators returns an error if one of the accelerator types is not offered in the zone of the
+// instance, which the admission webhooks cannot check as the zone is only known once the machine is placed.
+func (s *Service) validateGuestAccelerators(ctx context.Context, accelerators []*compute.AcceleratorConfig) error {
+	for _, accelerator := range accelerators {
+		acceleratorType := path.Base(accelerator.AcceleratorType)
+		if _, err := s.acceleratortypes.Get(ctx, s.scope.Zone(), acceleratorType); err != nil {
+			if gcperrors.IsNotFound(err) {
+				return errors.Errorf("accelerator type %s is not available in zone %s", acceleratorType, s.scope.Zone())
+			}
+			return errors.Wrapf(err, "failed to get accelerator type %s of zone %s", acceleratorType, s.scope.Zone())
+		}
+	}
+
+	return nil
+}
+
+// labelsEqual returns true if both sets of labels hold the same keys and values.
+func labelsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, ok := b[k]; !ok || bv != v {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Delete delete machine instance.
 func (s *Service) Delete(ctx context.Context) error {
 	log := log.FromContext(ctx)
@@ -122,6 +271,8 @@ func (s *Service) Delete(ctx context.Context) error {
 	return gcperrors.IgnoreNotFound(s.instances.Delete(ctx, instanceKey))
 }
 
+// createOrGetInstance creates an instance if it does not exist, otherwise it returns the existing one. It returns
+// nil if a preemptible instance was deleted after it was created.
 func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, error) {
 	log := log.FromContext(ctx)
 	log.V(2).Info("Getting bootstrap data for machine")
@@ -147,6 +298,21 @@ func (s *Service) createOrGetInstance(ctx context.Context) (*compute.Instance, e
 			return nil, err
 		}
 
+		// An ephemeral instance which existed before was deleted, e.g. on preemption, it is replaced along
+		// with its Machine rather than recreated.
+		if s.scope.IsEphemeral() && s.scope.GetInstanceStatus() != nil {
+			log.Info("Ephemeral instance was deleted, skipping its creation", "name", instanceName, "zone", s.scope.Zone())
+			return nil, nil
+		}
+
+		if err := s.validateCustomMachineType(ctx, path.Base(instanceSpec.MachineType)); err != nil {
+			return nil, err
+		}
+
+		if err := s.validateGuestAccelerators(ctx, instanceSpec.GuestAccelerators); err != nil {
+			return nil, err
+		}
+
 		log.V(2).Info("Creating an instance", "name", instanceName, "zone", s.scope.Zone())
 		if err := s.instances.Insert(ctx, instanceKey, instanceSpec); err != nil {
 			log.Error(err, "Error creating an instance", "name", instanceName, "zone", s.scope.Zone())
@@ -230,3 +396,20 @@ func (s *Service) deregisterControlPlaneInstance(ctx context.Context, instance *
 
 	return nil
 }
+
+// attachedDisksStatus returns the status of the disks attached to an instance.
+func attachedDisksStatus(disks []*compute.AttachedDisk) []infrav1.AttachedDiskStatus {
+	status := make([]infrav1.AttachedDiskStatus, 0, len(disks))
+	for _, disk := range disks {
+		status = append(status, infrav1.AttachedDiskStatus{
+			DeviceName: disk.DeviceName,
+			Source:     disk.Source,
+			Type:       disk.Type,
+			Boot:       disk.Boot,
+			AutoDelete: disk.AutoDelete,
+			SizeGb:     disk.DiskSizeGb,
+		})
+	}
+
+	return status
+}
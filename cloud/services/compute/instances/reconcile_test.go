@@ -347,9 +347,14 @@ func TestService_createOrGetInstance(t *testing.T) {
 				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
 			},
 			want: &compute.Instance{
-				Name:                   "my-machine",
-				CanIpForward:           true,
-				ShieldedInstanceConfig: &compute.ShieldedInstanceConfig{EnableSecureBoot: true, EnableVtpm: true, EnableIntegrityMonitoring: true},
+				Name:         "my-machine",
+				CanIpForward: true,
+				ShieldedInstanceConfig: &compute.ShieldedInstanceConfig{
+					EnableSecureBoot:          true,
+					EnableVtpm:                true,
+					EnableIntegrityMonitoring: true,
+					ForceSendFields:           []string{"EnableSecureBoot", "EnableVtpm", "EnableIntegrityMonitoring"},
+				},
 				Disks: []*compute.AttachedDisk{
 					{
 						AutoDelete: true,
@@ -464,6 +469,151 @@ func TestService_createOrGetInstance(t *testing.T) {
 				Zone: "us-central1-c",
 			},
 		},
+		{
+			name: "instance does not exist (should create instance) on sole-tenant nodes",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.NodeAffinities = []infrav1.NodeAffinity{
+					{
+						Key:      "compute.googleapis.com/node-group-name",
+						Operator: infrav1.NodeAffinityOperatorIn,
+						Values:   []string{"my-node-group"},
+					},
+				}
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:    "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage: "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
+						},
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "user-data",
+							Value: pointer.String("Zm9vCg=="),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network: "projects/my-proj/global/networks/default",
+					},
+				},
+				SelfLink: "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Scheduling: &compute.Scheduling{
+					NodeAffinities: []*compute.SchedulingNodeAffinity{
+						{
+							Key:      "compute.googleapis.com/node-group-name",
+							Operator: "IN",
+							Values:   []string{"my-node-group"},
+						},
+					},
+				},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
+		{
+			name: "instance does not exist (should create instance) with external IPv6 on gVNIC",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.StackType = pointer.String("IPV4_IPV6")
+				machineScope.GCPMachine.Spec.IPv6AccessType = pointer.String("EXTERNAL")
+				machineScope.GCPMachine.Spec.NicType = pointer.String("GVNIC")
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			want: &compute.Instance{
+				Name:         "my-machine",
+				CanIpForward: true,
+				Disks: []*compute.AttachedDisk{
+					{
+						AutoDelete: true,
+						Boot:       true,
+						InitializeParams: &compute.AttachedDiskInitializeParams{
+							DiskType:    "zones/us-central1-c/diskTypes/pd-standard",
+							SourceImage: "projects/my-proj/global/images/family/capi-ubuntu-1804-k8s-v1-19",
+						},
+					},
+				},
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				MachineType: "zones/us-central1-c/machineTypes",
+				Metadata: &compute.Metadata{
+					Items: []*compute.MetadataItems{
+						{
+							Key:   "user-data",
+							Value: pointer.String("Zm9vCg=="),
+						},
+					},
+				},
+				NetworkInterfaces: []*compute.NetworkInterface{
+					{
+						Network:   "projects/my-proj/global/networks/default",
+						StackType: "IPV4_IPV6",
+						NicType:   "GVNIC",
+						Ipv6AccessConfigs: []*compute.AccessConfig{
+							{
+								Type:        "DIRECT_IPV6",
+								Name:        "External IPv6",
+								NetworkTier: "PREMIUM",
+							},
+						},
+					},
+				},
+				SelfLink:   "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/instances/my-machine",
+				Scheduling: &compute.Scheduling{},
+				ServiceAccounts: []*compute.ServiceAccount{
+					{
+						Email:  "default",
+						Scopes: []string{"https://www.googleapis.com/auth/cloud-platform"},
+					},
+				},
+				Tags: &compute.Tags{
+					Items: []string{
+						"my-cluster-node",
+						"my-cluster",
+					},
+				},
+				Zone: "us-central1-c",
+			},
+		},
 		{
 			name: "instance does not exist (should create instance) with MIGRATE OnHostMaintenance",
 			scope: func() Scope {
@@ -527,6 +677,20 @@ func TestService_createOrGetInstance(t *testing.T) {
 				Zone: "us-central1-c",
 			},
 		},
+		{
+			name: "preemptible instance was deleted (should not recreate instance)",
+			scope: func() Scope {
+				machineScope.GCPMachine = getFakeGCPMachine()
+				machineScope.GCPMachine.Spec.Preemptible = true
+				machineScope.SetInstanceStatus(infrav1.InstanceStatusRunning)
+				return machineScope
+			},
+			mockInstance: &cloud.MockInstances{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				Objects:       map[meta.Key]*cloud.MockInstancesObj{},
+			},
+			want: nil,
+		},
 		{
 			name:  "FailureDomain not given (should pick up a failure domain from the cluster)",
 			scope: func() Scope { return machineScopeWithoutFailureDomain },
@@ -602,3 +766,367 @@ func TestService_createOrGetInstance(t *testing.T) {
 		})
 	}
 }
+
+type fakeInstanceAttributes struct {
+	labels *compute.InstancesSetLabelsRequest
+	tags   *compute.Tags
+}
+
+func (f *fakeInstanceAttributes) SetLabels(_ context.Context, _ *meta.Key, req *compute.InstancesSetLabelsRequest) error {
+	f.labels = req
+	return nil
+}
+
+func (f *fakeInstanceAttributes) SetTags(_ context.Context, _ *meta.Key, tags *compute.Tags) error {
+	f.tags = tags
+	return nil
+}
+
+func TestService_reconcileLabelsAndTags(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    getFakeGCPMachine(),
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		instance   *compute.Instance
+		wantLabels *compute.InstancesSetLabelsRequest
+		wantTags   *compute.Tags
+	}{
+		{
+			name: "labels and network tags are in sync (should not update instance)",
+			instance: &compute.Instance{
+				Name: "my-machine",
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				Tags: &compute.Tags{
+					Items: []string{"my-cluster", "my-cluster-node"},
+				},
+			},
+		},
+		{
+			name: "labels drifted (should set labels of instance)",
+			instance: &compute.Instance{
+				Name: "my-machine",
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "baz",
+					"stale":                   "label",
+				},
+				LabelFingerprint: "label-fingerprint",
+				Tags: &compute.Tags{
+					Items: []string{"my-cluster-node", "my-cluster"},
+				},
+			},
+			wantLabels: &compute.InstancesSetLabelsRequest{
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				LabelFingerprint: "label-fingerprint",
+			},
+		},
+		{
+			name: "network tags drifted (should set network tags of instance)",
+			instance: &compute.Instance{
+				Name: "my-machine",
+				Labels: map[string]string{
+					"capg-role":               "node",
+					"capg-cluster-my-cluster": "owned",
+					"foo":                     "bar",
+				},
+				Tags: &compute.Tags{
+					Items:       []string{"my-cluster-node", "my-cluster", "stale-tag"},
+					Fingerprint: "tags-fingerprint",
+				},
+			},
+			wantTags: &compute.Tags{
+				Items:       []string{"my-cluster", "my-cluster-node"},
+				Fingerprint: "tags-fingerprint",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			attributes := &fakeInstanceAttributes{}
+			s := New(machineScope)
+			s.instanceattributes = attributes
+			if err := s.reconcileLabelsAndTags(ctx, tt.instance); err != nil {
+				t.Fatalf("Service.reconcileLabelsAndTags() error = %v", err)
+			}
+
+			if d := cmp.Diff(tt.wantLabels, attributes.labels); d != "" {
+				t.Errorf("Service.reconcileLabelsAndTags() labels mismatch (-want +got):\n%s", d)
+			}
+			if d := cmp.Diff(tt.wantTags, attributes.tags); d != "" {
+				t.Errorf("Service.reconcileLabelsAndTags() tags mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
+
+type fakeMachineTypes struct {
+	machineTypes []*compute.MachineType
+}
+
+func (f *fakeMachineTypes) List(_ context.Context, _, _ string) ([]*compute.MachineType, error) {
+	return f.machineTypes, nil
+}
+
+func TestService_validateCustomMachineType(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    getFakeGCPMachine(),
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		machineType  string
+		machineTypes []*compute.MachineType
+		wantErr      bool
+	}{
+		{
+			name:        "predefined machine type (should not look up machine types)",
+			machineType: "n2-standard-4",
+			wantErr:     false,
+		},
+		{
+			name:         "custom machine type of a series offered in the zone",
+			machineType:  "n2-custom-8-16384",
+			machineTypes: []*compute.MachineType{{Name: "n2-standard-2"}},
+			wantErr:      false,
+		},
+		{
+			name:        "custom machine type of a series not offered in the zone (should return an error)",
+			machineType: "n2d-custom-8-16384",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(machineScope)
+			s.machinetypes = &fakeMachineTypes{machineTypes: tt.machineTypes}
+			err := s.validateCustomMachineType(context.TODO(), tt.machineType)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.validateCustomMachineType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+type fakeAcceleratorTypes struct {
+	acceleratorTypes map[string]bool
+}
+
+func (f *fakeAcceleratorTypes) Get(_ context.Context, _, name string) (*compute.AcceleratorType, error) {
+	if !f.acceleratorTypes[name] {
+		return nil, &googleapi.Error{Code: http.StatusNotFound}
+	}
+	return &compute.AcceleratorType{Name: name}, nil
+}
+
+func TestService_validateGuestAccelerators(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+		Client:        fakec,
+		Machine:       fakeMachine,
+		GCPMachine:    getFakeGCPMachine(),
+		ClusterGetter: clusterScope,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		accelerators []*compute.AcceleratorConfig
+		wantErr      bool
+	}{
+		{
+			name:    "no accelerators",
+			wantErr: false,
+		},
+		{
+			name: "accelerator type offered in the zone",
+			accelerators: []*compute.AcceleratorConfig{
+				{AcceleratorType: "projects/my-proj/zones/us-central1-c/acceleratorTypes/nvidia-tesla-t4", AcceleratorCount: 1},
+			},
+			wantErr: false,
+		},
+		{
+			name: "accelerator type not offered in the zone (should return an error)",
+			accelerators: []*compute.AcceleratorConfig{
+				{AcceleratorType: "projects/my-proj/zones/us-central1-c/acceleratorTypes/nvidia-tesla-v100", AcceleratorCount: 1},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(machineScope)
+			s.acceleratortypes = &fakeAcceleratorTypes{acceleratorTypes: map[string]bool{"nvidia-tesla-t4": true}}
+			err := s.validateGuestAccelerators(context.TODO(), tt.accelerators)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.validateGuestAccelerators() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestService_reconcileRootDiskSize(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		WithObjects(fakeBootstrapSecret).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		rootDeviceSize int64
+		diskSizeGb     int64
+		wantResize     *compute.DisksResizeRequest
+	}{
+		{
+			name:           "root disk size is unchanged (should not resize the disk)",
+			rootDeviceSize: 50,
+			diskSizeGb:     50,
+		},
+		{
+			name:       "root disk size is not set (should not resize the disk)",
+			diskSizeGb: 50,
+		},
+		{
+			name:           "root disk size is smaller than the disk (should not resize the disk)",
+			rootDeviceSize: 30,
+			diskSizeGb:     50,
+		},
+		{
+			name:           "root disk size was increased (should resize the disk)",
+			rootDeviceSize: 100,
+			diskSizeGb:     50,
+			wantResize:     &compute.DisksResizeRequest{SizeGb: 100},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gcpMachine := getFakeGCPMachine()
+			gcpMachine.Spec.RootDeviceSize = tt.rootDeviceSize
+			machineScope, err := scope.NewMachineScope(scope.MachineScopeParams{
+				Client:        fakec,
+				Machine:       fakeMachine,
+				GCPMachine:    gcpMachine,
+				ClusterGetter: clusterScope,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var gotResize *compute.DisksResizeRequest
+			s := New(machineScope)
+			s.disks = &cloud.MockDisks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "proj-id"},
+				ResizeHook: func(_ context.Context, key *meta.Key, req *compute.DisksResizeRequest, _ *cloud.MockDisks) error {
+					if key.Name != "my-machine" {
+						t.Errorf("Service.reconcileRootDiskSize() resized disk %s", key.Name)
+					}
+					gotResize = req
+					return nil
+				},
+			}
+			instance := &compute.Instance{
+				Name: "my-machine",
+				Disks: []*compute.AttachedDisk{
+					{
+						Boot:       true,
+						DiskSizeGb: tt.diskSizeGb,
+						Source:     "https://www.googleapis.com/compute/v1/projects/proj-id/zones/us-central1-c/disks/my-machine",
+					},
+				},
+			}
+			if err := s.reconcileRootDiskSize(context.TODO(), instance); err != nil {
+				t.Fatalf("Service.reconcileRootDiskSize() error = %v", err)
+			}
+			if d := cmp.Diff(tt.wantResize, gotResize); d != "" {
+				t.Errorf("Service.reconcileRootDiskSize() resize mismatch (-want +got):\n%s", d)
+			}
+		})
+	}
+}
@@ -18,12 +18,15 @@ package instances
 
 import (
 	"context"
+	"time"
 
 	"github.com/go-logr/logr"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/pkg/errors"
 	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 )
 
@@ -33,6 +36,23 @@ type instancesInterface interface {
 	Delete(ctx context.Context, key *meta.Key) error
 }
 
+type disksInterface interface {
+	Resize(context.Context, *meta.Key, *compute.DisksResizeRequest) error
+}
+
+type instanceattributesInterface interface {
+	SetLabels(ctx context.Context, key *meta.Key, req *compute.InstancesSetLabelsRequest) error
+	SetTags(ctx context.Context, key *meta.Key, tags *compute.Tags) error
+}
+
+type machinetypesInterface interface {
+	List(ctx context.Context, zone, filter string) ([]*compute.MachineType, error)
+}
+
+type acceleratortypesInterface interface {
+	Get(ctx context.Context, zone, name string) (*compute.AcceleratorType, error)
+}
+
 type instancegroupsInterface interface {
 	AddInstances(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsAddInstancesRequest) error
 	ListInstances(ctx context.Context, key *meta.Key, req *compute.InstanceGroupsListInstancesRequest, fl *filter.F) ([]*compute.InstanceWithNamedPorts, error)
@@ -42,6 +62,8 @@ type instancegroupsInterface interface {
 // Scope is an interfaces that hold used methods.
 type Scope interface {
 	cloud.Machine
+	ComputeService() *compute.Service
+	IsEphemeral() bool
 	InstanceSpec(log logr.Logger) *compute.Instance
 	InstanceImageSpec() *compute.AttachedDisk
 	InstanceAdditionalDiskSpec() []*compute.AttachedDisk
@@ -49,9 +71,13 @@ type Scope interface {
 
 // Service implements instances reconciler.
 type Service struct {
-	scope          Scope
-	instances      instancesInterface
-	instancegroups instancegroupsInterface
+	scope              Scope
+	instances          instancesInterface
+	disks              disksInterface
+	instanceattributes instanceattributesInterface
+	machinetypes       machinetypesInterface
+	acceleratortypes   acceleratortypesInterface
+	instancegroups     instancegroupsInterface
 }
 
 var _ cloud.Reconciler = &Service{}
@@ -59,8 +85,98 @@ var _ cloud.Reconciler = &Service{}
 // New returns Service from given scope.
 func New(scope Scope) *Service {
 	return &Service{
-		scope:          scope,
-		instances:      scope.Cloud().Instances(),
+		scope:     scope,
+		instances: scope.Cloud().Instances(),
+		disks:     scope.Cloud().Disks(),
+		instanceattributes: &instanceAttributes{
+			project: scope.Project(),
+			service: scope.ComputeService(),
+		},
+		machinetypes: &machineTypes{
+			project: scope.Project(),
+			service: scope.ComputeService(),
+		},
+		acceleratortypes: &acceleratorTypes{
+			project: scope.Project(),
+			service: scope.ComputeService(),
+		},
 		instancegroups: scope.Cloud().InstanceGroups(),
 	}
 }
+
+// machineTypes implements machinetypesInterface on top of the compute API, as machine types are not covered by
+// the cloud provider library.
+type machineTypes struct {
+	project string
+	service *compute.Service
+}
+
+// List returns the machine types of a zone matching the given filter.
+func (m *machineTypes) List(ctx context.Context, zone, filter string) ([]*compute.MachineType, error) {
+	var machineTypes []*compute.MachineType
+	err := m.service.MachineTypes.List(m.project, zone).Filter(filter).Pages(ctx, func(list *compute.MachineTypeList) error {
+		machineTypes = append(machineTypes, list.Items...)
+		return nil
+	})
+	return machineTypes, err
+}
+
+// acceleratorTypes implements acceleratortypesInterface on top of the compute API, as accelerator types are not
+// covered by the cloud provider library either.
+type acceleratorTypes struct {
+	project string
+	service *compute.Service
+}
+
+// Get returns an accelerator type of a zone.
+func (a *acceleratorTypes) Get(ctx context.Context, zone, name string) (*compute.AcceleratorType, error) {
+	return a.service.AcceleratorTypes.Get(a.project, zone, name).Context(ctx).Do()
+}
+
+// instanceAttributes implements instanceattributesInterface on top of the compute API, as updating the labels
+// and network tags of an instance is not covered by the cloud provider library.
+type instanceAttributes struct {
+	project string
+	service *compute.Service
+}
+
+// SetLabels sets the labels of a zonal instance and waits for the operation to complete.
+func (i *instanceAttributes) SetLabels(ctx context.Context, key *meta.Key, req *compute.InstancesSetLabelsRequest) error {
+	op, err := i.service.Instances.SetLabels(i.project, key.Zone, key.Name, req).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	return i.waitForOperation(ctx, key.Zone, op)
+}
+
+// SetTags sets the network tags of a zonal instance and waits for the operation to complete.
+func (i *instanceAttributes) SetTags(ctx context.Context, key *meta.Key, tags *compute.Tags) error {
+	op, err := i.service.Instances.SetTags(i.project, key.Zone, key.Name, tags).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	return i.waitForOperation(ctx, key.Zone, op)
+}
+
+func (i *instanceAttributes) waitForOperation(ctx context.Context, zone string, op *compute.Operation) error {
+	err := wait.PollUntilContextTimeout(ctx, time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
+		if op.Status == "DONE" {
+			return true, nil
+		}
+
+		var err error
+		op, err = i.service.ZoneOperations.Wait(i.project, zone, op.Name).Context(ctx).Do()
+		return false, err
+	})
+	if err != nil {
+		return err
+	}
+
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		return errors.Errorf("operation %s failed: %s", op.Name, op.Error.Errors[0].Message)
+	}
+
+	return nil
+}
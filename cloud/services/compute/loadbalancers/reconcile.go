@@ -18,10 +18,13 @@ package loadbalancers
 
 import (
 	"context"
+	"path"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -29,12 +32,173 @@ import (
 // Reconcile reconcile cluster control-plane loadbalancer compoenents.
 func (s *Service) Reconcile(ctx context.Context) error {
 	log := log.FromContext(ctx)
+	if s.scope.IsExistingInfrastructure() {
+		log.V(2).Info("Existing infrastructure is not managed, looking up loadbalancer resources")
+		return s.lookupLoadBalancer(ctx)
+	}
+
 	log.Info("Reconciling loadbalancer resources")
 	instancegroups, err := s.createOrGetInstanceGroups(ctx)
 	if err != nil {
 		return err
 	}
 
+	lbType := s.scope.LoadBalancerType()
+	if lbType != infrav1.LoadBalancerTypeInternal {
+		if err := s.reconcileExternalLoadBalancer(ctx, instancegroups); err != nil {
+			return err
+		}
+	}
+
+	if lbType != infrav1.LoadBalancerTypeExternal {
+		return s.reconcileInternalLoadBalancer(ctx, instancegroups)
+	}
+
+	return nil
+}
+
+// Delete delete cluster control-plane loadbalancer compoenents.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	if s.scope.IsExistingInfrastructure() {
+		log.V(2).Info("Existing infrastructure is not managed, skipping loadbalancer deletion")
+		return nil
+	}
+
+	log.Info("Deleting loadbalancer resources")
+	if err := s.deleteInternalLoadBalancer(ctx); err != nil {
+		return err
+	}
+
+	if err := s.deleteForwardingRule(ctx); err != nil {
+		return err
+	}
+
+	if err := s.deleteAddress(ctx); err != nil {
+		return err
+	}
+
+	if err := s.deleteTargetTCPProxy(ctx); err != nil {
+		return err
+	}
+
+	if err := s.deleteBackendService(ctx); err != nil {
+		return err
+	}
+
+	if err := s.deleteHealthCheck(ctx); err != nil {
+		return err
+	}
+
+	return s.deleteInstanceGroups(ctx)
+}
+
+// lookupLoadBalancer looks up the existing loadbalancer resources to populate the status. Only the address of
+// the control plane endpoint is required, the other resources are recorded when they are found.
+func (s *Service) lookupLoadBalancer(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	network := s.scope.Network()
+	groups := make(map[string]string)
+	for zone := range s.scope.FailureDomains() {
+		spec := s.scope.InstanceGroupSpec(zone)
+		group, err := s.instancegroups.Get(ctx, meta.ZonalKey(spec.Name, zone))
+		if err != nil {
+			if !gcperrors.IsNotFound(err) {
+				return err
+			}
+			continue
+		}
+		groups[zone] = group.SelfLink
+	}
+	network.APIServerInstanceGroups = groups
+
+	lbType := s.scope.LoadBalancerType()
+	if lbType != infrav1.LoadBalancerTypeInternal {
+		addrSpec := s.scope.AddressSpec()
+		addr, err := s.addresses.Get(ctx, meta.GlobalKey(addrSpec.Name))
+		if err != nil {
+			log.Error(err, "Error looking for existing address", "name", addrSpec.Name)
+			return err
+		}
+
+		network.APIServerAddress = pointer.String(addr.SelfLink)
+		endpoint := s.scope.ControlPlaneEndpoint()
+		endpoint.Host = addr.Address
+		s.scope.SetControlPlaneEndpoint(endpoint)
+
+		if healthcheck, err := s.healthchecks.Get(ctx, meta.GlobalKey(s.scope.HealthCheckSpec().Name)); err == nil {
+			network.APIServerHealthCheck = pointer.String(healthcheck.SelfLink)
+		} else if !gcperrors.IsNotFound(err) {
+			return err
+		}
+
+		if backendsvc, err := s.backendservices.Get(ctx, meta.GlobalKey(s.scope.BackendServiceSpec().Name)); err == nil {
+			network.APIServerBackendService = pointer.String(backendsvc.SelfLink)
+		} else if !gcperrors.IsNotFound(err) {
+			return err
+		}
+
+		if target, err := s.targettcpproxies.Get(ctx, meta.GlobalKey(s.scope.TargetTCPProxySpec().Name)); err == nil {
+			network.APIServerTargetProxy = pointer.String(target.SelfLink)
+		} else if !gcperrors.IsNotFound(err) {
+			return err
+		}
+
+		if forwarding, err := s.forwardingrules.Get(ctx, meta.GlobalKey(s.scope.ForwardingRuleSpec().Name)); err == nil {
+			network.APIServerForwardingRule = pointer.String(forwarding.SelfLink)
+		} else if !gcperrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if lbType == infrav1.LoadBalancerTypeExternal {
+		return nil
+	}
+
+	addrSpec := s.scope.InternalAddressSpec()
+	addr, err := s.internaladdresses.Get(ctx, meta.RegionalKey(addrSpec.Name, s.scope.Region()))
+	if err != nil {
+		log.Error(err, "Error looking for existing internal address", "name", addrSpec.Name)
+		return err
+	}
+
+	network.APIInternalAddress = pointer.String(addr.SelfLink)
+	if lbType == infrav1.LoadBalancerTypeInternal {
+		endpoint := s.scope.ControlPlaneEndpoint()
+		endpoint.Host = addr.Address
+		s.scope.SetControlPlaneEndpoint(endpoint)
+	}
+
+	if healthcheck, err := s.internalhealthchecks.Get(ctx, meta.RegionalKey(s.scope.InternalHealthCheckSpec().Name, s.scope.Region())); err == nil {
+		network.APIInternalHealthCheck = pointer.String(healthcheck.SelfLink)
+	} else if !gcperrors.IsNotFound(err) {
+		return err
+	}
+
+	if backendsvc, err := s.internalbackendservices.Get(ctx, meta.RegionalKey(s.scope.InternalBackendServiceSpec().Name, s.scope.Region())); err == nil {
+		network.APIInternalBackendService = pointer.String(backendsvc.SelfLink)
+	} else if !gcperrors.IsNotFound(err) {
+		return err
+	}
+
+	if forwarding, err := s.internalforwardingrules.Get(ctx, meta.RegionalKey(s.scope.InternalForwardingRuleSpec().Name, s.scope.Region())); err == nil {
+		network.APIInternalForwardingRule = pointer.String(forwarding.SelfLink)
+	} else if !gcperrors.IsNotFound(err) {
+		return err
+	}
+
+	if spec := s.scope.ServiceAttachmentSpec(); spec != nil {
+		if attachment, err := s.serviceattachments.Get(ctx, meta.RegionalKey(spec.Name, s.scope.Region())); err == nil {
+			network.APIInternalServiceAttachment = pointer.String(attachment.SelfLink)
+		} else if !gcperrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) reconcileExternalLoadBalancer(ctx context.Context, instancegroups []*compute.InstanceGroup) error {
 	healthcheck, err := s.createOrGetHealthCheck(ctx)
 	if err != nil {
 		return err
@@ -58,31 +222,48 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	return s.createForwardingRule(ctx, target, addr)
 }
 
-// Delete delete cluster control-plane loadbalancer compoenents.
-func (s *Service) Delete(ctx context.Context) error {
-	log := log.FromContext(ctx)
-	log.Info("Deleting loadbalancer resources")
-	if err := s.deleteForwardingRule(ctx); err != nil {
+func (s *Service) reconcileInternalLoadBalancer(ctx context.Context, instancegroups []*compute.InstanceGroup) error {
+	healthcheck, err := s.createOrGetInternalHealthCheck(ctx)
+	if err != nil {
 		return err
 	}
 
-	if err := s.deleteAddress(ctx); err != nil {
+	backendsvc, err := s.createOrGetInternalBackendService(ctx, instancegroups, healthcheck)
+	if err != nil {
 		return err
 	}
 
-	if err := s.deleteTargetTCPProxy(ctx); err != nil {
+	addr, err := s.createOrGetInternalAddress(ctx)
+	if err != nil {
 		return err
 	}
 
-	if err := s.deleteBackendService(ctx); err != nil {
+	forwarding, err := s.createInternalForwardingRule(ctx, backendsvc, addr)
+	if err != nil {
 		return err
 	}
 
-	if err := s.deleteHealthCheck(ctx); err != nil {
+	return s.reconcileServiceAttachment(ctx, forwarding)
+}
+
+func (s *Service) deleteInternalLoadBalancer(ctx context.Context) error {
+	if err := s.deleteServiceAttachment(ctx); err != nil {
 		return err
 	}
 
-	return s.deleteInstanceGroups(ctx)
+	if err := s.deleteInternalForwardingRule(ctx); err != nil {
+		return err
+	}
+
+	if err := s.deleteInternalAddress(ctx); err != nil {
+		return err
+	}
+
+	if err := s.deleteInternalBackendService(ctx); err != nil {
+		return err
+	}
+
+	return s.deleteInternalHealthCheck(ctx)
 }
 
 func (s *Service) createOrGetInstanceGroups(ctx context.Context) ([]*compute.InstanceGroup, error) {
@@ -152,6 +333,14 @@ func (s *Service) createOrGetHealthCheck(ctx context.Context) (*compute.HealthCh
 		}
 	}
 
+	if healthCheckNeedsUpdate(healthcheck, healthcheckSpec) {
+		log.V(2).Info("Updating a healthcheck", "name", healthcheckSpec.Name)
+		if err := s.healthchecks.Update(ctx, meta.GlobalKey(healthcheckSpec.Name), healthcheckSpec); err != nil {
+			log.Error(err, "Error updating a healthcheck", "name", healthcheckSpec.Name)
+			return nil, err
+		}
+	}
+
 	s.scope.Network().APIServerHealthCheck = pointer.String(healthcheck.SelfLink)
 	return healthcheck, nil
 }
@@ -188,9 +377,9 @@ func (s *Service) createOrGetBackendService(ctx context.Context, instancegroups
 		}
 	}
 
-	if len(backendsvc.Backends) != len(backendsvcSpec.Backends) {
+	if backendServiceNeedsUpdate(backendsvc, backendsvcSpec) {
 		log.V(2).Info("Updating a backendservice", "name", backendsvcSpec.Name)
-		backendsvc.Backends = backendsvcSpec.Backends
+		updateBackendService(backendsvc, backendsvcSpec)
 		if err := s.backendservices.Update(ctx, meta.GlobalKey(backendsvcSpec.Name), backendsvc); err != nil {
 			log.Error(err, "Error updating a backendservice", "name", backendsvcSpec.Name)
 			return nil, err
@@ -201,6 +390,104 @@ func (s *Service) createOrGetBackendService(ctx context.Context, instancegroups
 	return backendsvc, nil
 }
 
+// healthCheckNeedsUpdate returns true if the settings of the health check differ from its spec.
+func healthCheckNeedsUpdate(healthcheck, spec *compute.HealthCheck) bool {
+	if healthcheck.Type != spec.Type ||
+		healthcheck.CheckIntervalSec != spec.CheckIntervalSec ||
+		healthcheck.TimeoutSec != spec.TimeoutSec ||
+		healthcheck.HealthyThreshold != spec.HealthyThreshold ||
+		healthcheck.UnhealthyThreshold != spec.UnhealthyThreshold {
+		return true
+	}
+
+	port, requestPath, proxyHeader := healthCheckProbe(healthcheck)
+	specPort, specRequestPath, specProxyHeader := healthCheckProbe(spec)
+	return port != specPort || requestPath != specRequestPath || proxyHeader != specProxyHeader
+}
+
+// healthCheckProbe returns the port, request path and proxy header probed by the health check.
+func healthCheckProbe(healthcheck *compute.HealthCheck) (int64, string, string) {
+	switch {
+	case healthcheck.TcpHealthCheck != nil:
+		return healthcheck.TcpHealthCheck.Port, "", healthcheck.TcpHealthCheck.ProxyHeader
+	case healthcheck.HttpHealthCheck != nil:
+		return healthcheck.HttpHealthCheck.Port, healthcheck.HttpHealthCheck.RequestPath, healthcheck.HttpHealthCheck.ProxyHeader
+	case healthcheck.HttpsHealthCheck != nil:
+		return healthcheck.HttpsHealthCheck.Port, healthcheck.HttpsHealthCheck.RequestPath, healthcheck.HttpsHealthCheck.ProxyHeader
+	}
+
+	return 0, "", ""
+}
+
+// backendServiceNeedsUpdate returns true if the backends or the configured options of the backend service
+// differ from its spec. Options which are not configured are left to the value set in GCP.
+func backendServiceNeedsUpdate(backendsvc, spec *compute.BackendService) bool {
+	if len(backendsvc.Backends) != len(spec.Backends) {
+		return true
+	}
+
+	if spec.ConnectionDraining != nil &&
+		(backendsvc.ConnectionDraining == nil || backendsvc.ConnectionDraining.DrainingTimeoutSec != spec.ConnectionDraining.DrainingTimeoutSec) {
+		return true
+	}
+
+	if spec.LogConfig != nil &&
+		(backendsvc.LogConfig == nil || !backendsvc.LogConfig.Enable || backendsvc.LogConfig.SampleRate != spec.LogConfig.SampleRate) {
+		return true
+	}
+
+	if spec.SessionAffinity != "" && backendsvc.SessionAffinity != spec.SessionAffinity {
+		return true
+	}
+
+	if spec.TimeoutSec != 0 && backendsvc.TimeoutSec != spec.TimeoutSec {
+		return true
+	}
+
+	if spec.ConnectionTrackingPolicy != nil &&
+		(backendsvc.ConnectionTrackingPolicy == nil || backendsvc.ConnectionTrackingPolicy.IdleTimeoutSec != spec.ConnectionTrackingPolicy.IdleTimeoutSec) {
+		return true
+	}
+
+	if spec.FailoverPolicy != nil {
+		current := backendsvc.FailoverPolicy
+		if current == nil ||
+			current.DisableConnectionDrainOnFailover != spec.FailoverPolicy.DisableConnectionDrainOnFailover ||
+			current.DropTrafficIfUnhealthy != spec.FailoverPolicy.DropTrafficIfUnhealthy ||
+			current.FailoverRatio != spec.FailoverPolicy.FailoverRatio {
+			return true
+		}
+	}
+
+	return false
+}
+
+// updateBackendService sets the backends and the configured options of the spec on the backend service.
+func updateBackendService(backendsvc, spec *compute.BackendService) {
+	backendsvc.Backends = spec.Backends
+	if spec.ConnectionDraining != nil {
+		backendsvc.ConnectionDraining = spec.ConnectionDraining
+	}
+	if spec.LogConfig != nil {
+		backendsvc.LogConfig = spec.LogConfig
+	}
+	if spec.FailoverPolicy != nil {
+		backendsvc.FailoverPolicy = spec.FailoverPolicy
+	}
+	if spec.SessionAffinity != "" {
+		backendsvc.SessionAffinity = spec.SessionAffinity
+	}
+	if spec.TimeoutSec != 0 {
+		backendsvc.TimeoutSec = spec.TimeoutSec
+	}
+	if spec.ConnectionTrackingPolicy != nil {
+		if backendsvc.ConnectionTrackingPolicy == nil {
+			backendsvc.ConnectionTrackingPolicy = &compute.BackendServiceConnectionTrackingPolicy{}
+		}
+		backendsvc.ConnectionTrackingPolicy.IdleTimeoutSec = spec.ConnectionTrackingPolicy.IdleTimeoutSec
+	}
+}
+
 func (s *Service) createOrGetTargetTCPProxy(ctx context.Context, service *compute.BackendService) (*compute.TargetTcpProxy, error) {
 	log := log.FromContext(ctx)
 	targetSpec := s.scope.TargetTCPProxySpec()
@@ -375,3 +662,304 @@ func (s *Service) deleteInstanceGroups(ctx context.Context) error {
 
 	return nil
 }
+
+func (s *Service) createOrGetInternalHealthCheck(ctx context.Context) (*compute.HealthCheck, error) {
+	log := log.FromContext(ctx)
+	healthcheckSpec := s.scope.InternalHealthCheckSpec()
+	key := meta.RegionalKey(healthcheckSpec.Name, s.scope.Region())
+	log.V(2).Info("Looking for internal healthcheck", "name", healthcheckSpec.Name)
+	healthcheck, err := s.internalhealthchecks.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for internal healthcheck", "name", healthcheckSpec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating an internal healthcheck", "name", healthcheckSpec.Name)
+		if err := s.internalhealthchecks.Insert(ctx, key, healthcheckSpec); err != nil {
+			log.Error(err, "Error creating an internal healthcheck", "name", healthcheckSpec.Name)
+			return nil, err
+		}
+
+		healthcheck, err = s.internalhealthchecks.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if healthCheckNeedsUpdate(healthcheck, healthcheckSpec) {
+		log.V(2).Info("Updating an internal healthcheck", "name", healthcheckSpec.Name)
+		if err := s.internalhealthchecks.Update(ctx, key, healthcheckSpec); err != nil {
+			log.Error(err, "Error updating an internal healthcheck", "name", healthcheckSpec.Name)
+			return nil, err
+		}
+	}
+
+	s.scope.Network().APIInternalHealthCheck = pointer.String(healthcheck.SelfLink)
+	return healthcheck, nil
+}
+
+func (s *Service) createOrGetInternalBackendService(ctx context.Context, instancegroups []*compute.InstanceGroup, healthcheck *compute.HealthCheck) (*compute.BackendService, error) {
+	log := log.FromContext(ctx)
+	backends := make([]*compute.Backend, 0, len(instancegroups))
+	for _, group := range instancegroups {
+		backends = append(backends, &compute.Backend{
+			BalancingMode: "CONNECTION",
+			Group:         group.SelfLink,
+		})
+	}
+
+	backendsvcSpec := s.scope.InternalBackendServiceSpec()
+	backendsvcSpec.Backends = backends
+	backendsvcSpec.HealthChecks = []string{healthcheck.SelfLink}
+	key := meta.RegionalKey(backendsvcSpec.Name, s.scope.Region())
+	backendsvc, err := s.internalbackendservices.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for internal backendservice", "name", backendsvcSpec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating an internal backendservice", "name", backendsvcSpec.Name)
+		if err := s.internalbackendservices.Insert(ctx, key, backendsvcSpec); err != nil {
+			log.Error(err, "Error creating an internal backendservice", "name", backendsvcSpec.Name)
+			return nil, err
+		}
+
+		backendsvc, err = s.internalbackendservices.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if backendServiceNeedsUpdate(backendsvc, backendsvcSpec) {
+		log.V(2).Info("Updating an internal backendservice", "name", backendsvcSpec.Name)
+		updateBackendService(backendsvc, backendsvcSpec)
+		if err := s.internalbackendservices.Update(ctx, key, backendsvc); err != nil {
+			log.Error(err, "Error updating an internal backendservice", "name", backendsvcSpec.Name)
+			return nil, err
+		}
+	}
+
+	s.scope.Network().APIInternalBackendService = pointer.String(backendsvc.SelfLink)
+	return backendsvc, nil
+}
+
+func (s *Service) createOrGetInternalAddress(ctx context.Context) (*compute.Address, error) {
+	log := log.FromContext(ctx)
+	addrSpec := s.scope.InternalAddressSpec()
+	key := meta.RegionalKey(addrSpec.Name, s.scope.Region())
+	log.V(2).Info("Looking for internal address", "name", addrSpec.Name)
+	addr, err := s.internaladdresses.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for internal address", "name", addrSpec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating an internal address", "name", addrSpec.Name)
+		if err := s.internaladdresses.Insert(ctx, key, addrSpec); err != nil {
+			log.Error(err, "Error creating an internal address", "name", addrSpec.Name)
+			return nil, err
+		}
+
+		addr, err = s.internaladdresses.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.scope.Network().APIInternalAddress = pointer.String(addr.SelfLink)
+	if s.scope.LoadBalancerType() == infrav1.LoadBalancerTypeInternal {
+		endpoint := s.scope.ControlPlaneEndpoint()
+		endpoint.Host = addr.Address
+		s.scope.SetControlPlaneEndpoint(endpoint)
+	}
+	return addr, nil
+}
+
+func (s *Service) createInternalForwardingRule(ctx context.Context, backendsvc *compute.BackendService, addr *compute.Address) (*compute.ForwardingRule, error) {
+	log := log.FromContext(ctx)
+	spec := s.scope.InternalForwardingRuleSpec()
+	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	spec.IPAddress = addr.SelfLink
+	spec.BackendService = backendsvc.SelfLink
+	log.V(2).Info("Looking for internal forwardingrule", "name", spec.Name)
+	forwarding, err := s.internalforwardingrules.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for internal forwardingrule", "name", spec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating an internal forwardingrule", "name", spec.Name)
+		if err := s.internalforwardingrules.Insert(ctx, key, spec); err != nil {
+			log.Error(err, "Error creating an internal forwardingrule", "name", spec.Name)
+			return nil, err
+		}
+
+		forwarding, err = s.internalforwardingrules.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	s.scope.Network().APIInternalForwardingRule = pointer.String(forwarding.SelfLink)
+	return forwarding, nil
+}
+
+// reconcileServiceAttachment publishes the internal forwarding rule with a Private Service Connect service
+// attachment, updates it when its configuration drifted and removes it once it is no longer requested.
+func (s *Service) reconcileServiceAttachment(ctx context.Context, forwarding *compute.ForwardingRule) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.ServiceAttachmentSpec()
+	if spec == nil {
+		return s.deleteServiceAttachment(ctx)
+	}
+
+	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	spec.TargetService = forwarding.SelfLink
+	log.V(2).Info("Looking for serviceattachment", "name", spec.Name)
+	attachment, err := s.serviceattachments.Get(ctx, key)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for serviceattachment", "name", spec.Name)
+			return err
+		}
+
+		log.V(2).Info("Creating a serviceattachment", "name", spec.Name)
+		if err := s.serviceattachments.Insert(ctx, key, spec); err != nil {
+			log.Error(err, "Error creating a serviceattachment", "name", spec.Name)
+			return err
+		}
+
+		attachment, err = s.serviceattachments.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+	} else if serviceAttachmentChanged(attachment, spec) {
+		log.V(2).Info("Updating a serviceattachment", "name", spec.Name)
+		spec.Fingerprint = attachment.Fingerprint
+		if err := s.serviceattachments.Patch(ctx, key, spec); err != nil {
+			log.Error(err, "Error updating a serviceattachment", "name", spec.Name)
+			return err
+		}
+	}
+
+	s.scope.Network().APIInternalServiceAttachment = pointer.String(attachment.SelfLink)
+	return nil
+}
+
+// serviceAttachmentChanged returns true if the mutable fields of the service attachment differ from the spec.
+// NAT subnets are compared by name since GCP returns them as full URLs.
+func serviceAttachmentChanged(attachment, spec *compute.ServiceAttachment) bool {
+	if attachment.ConnectionPreference != spec.ConnectionPreference {
+		return true
+	}
+
+	subnetNames := func(links []string) sets.Set[string] {
+		names := sets.New[string]()
+		for _, link := range links {
+			names.Insert(path.Base(link))
+		}
+		return names
+	}
+	if !subnetNames(attachment.NatSubnets).Equal(subnetNames(spec.NatSubnets)) {
+		return true
+	}
+
+	if !sets.New(attachment.ConsumerRejectLists...).Equal(sets.New(spec.ConsumerRejectLists...)) {
+		return true
+	}
+
+	if len(attachment.ConsumerAcceptLists) != len(spec.ConsumerAcceptLists) {
+		return true
+	}
+	limits := make(map[string]int64, len(attachment.ConsumerAcceptLists))
+	for _, consumer := range attachment.ConsumerAcceptLists {
+		limits[consumer.ProjectIdOrNum] = consumer.ConnectionLimit
+	}
+	for _, consumer := range spec.ConsumerAcceptLists {
+		if limit, ok := limits[consumer.ProjectIdOrNum]; !ok || limit != consumer.ConnectionLimit {
+			return true
+		}
+	}
+
+	return false
+}
+
+// deleteServiceAttachment deletes the service attachment recorded in the status, which is kept even when the
+// attachment was removed from the spec.
+func (s *Service) deleteServiceAttachment(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	link := s.scope.Network().APIInternalServiceAttachment
+	if link == nil {
+		return nil
+	}
+
+	name := path.Base(*link)
+	log.V(2).Info("Deleting a serviceattachment", "name", name)
+	if err := s.serviceattachments.Delete(ctx, meta.RegionalKey(name, s.scope.Region())); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting a serviceattachment", "name", name)
+		return err
+	}
+
+	s.scope.Network().APIInternalServiceAttachment = nil
+	return nil
+}
+
+func (s *Service) deleteInternalForwardingRule(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.InternalForwardingRuleSpec()
+	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	log.V(2).Info("Deleting an internal forwardingrule", "name", spec.Name)
+	if err := s.internalforwardingrules.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting an internal forwardingrule", "name", spec.Name)
+		return err
+	}
+
+	s.scope.Network().APIInternalForwardingRule = nil
+	return nil
+}
+
+func (s *Service) deleteInternalAddress(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.InternalAddressSpec()
+	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	log.V(2).Info("Deleting an internal address", "name", spec.Name)
+	if err := s.internaladdresses.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting an internal address", "name", spec.Name)
+		return err
+	}
+
+	s.scope.Network().APIInternalAddress = nil
+	return nil
+}
+
+func (s *Service) deleteInternalBackendService(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.InternalBackendServiceSpec()
+	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	log.V(2).Info("Deleting an internal backendservice", "name", spec.Name)
+	if err := s.internalbackendservices.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting an internal backendservice", "name", spec.Name)
+		return err
+	}
+
+	s.scope.Network().APIInternalBackendService = nil
+	return nil
+}
+
+func (s *Service) deleteInternalHealthCheck(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.InternalHealthCheckSpec()
+	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	log.V(2).Info("Deleting an internal healthcheck", "name", spec.Name)
+	if err := s.internalhealthchecks.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting an internal healthcheck", "name", spec.Name)
+		return err
+	}
+
+	s.scope.Network().APIInternalHealthCheck = nil
+	return nil
+}
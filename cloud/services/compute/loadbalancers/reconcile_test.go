@@ -0,0 +1,531 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package loadbalancers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Subnets: infrav1.Subnets{
+				infrav1.SubnetSpec{
+					Name:      "control-plane",
+					CidrBlock: "10.0.0.0/24",
+					Region:    "us-central1",
+				},
+			},
+		},
+		LoadBalancer: infrav1.LoadBalancerSpec{
+			LoadBalancerType: func() *infrav1.LoadBalancerType {
+				lbType := infrav1.LoadBalancerTypeInternal
+				return &lbType
+			}(),
+			InternalLoadBalancer: &infrav1.InternalLoadBalancerSpec{
+				IPAddress: pointer.String("10.0.0.10"),
+			},
+		},
+	},
+	Status: infrav1.GCPClusterStatus{
+		FailureDomains: clusterv1.FailureDomains{
+			"us-central1-a": clusterv1.FailureDomainSpec{ControlPlane: true},
+		},
+	},
+}
+
+var internalLoadBalancerKey = meta.RegionalKey("my-cluster-apiserver-internal", "us-central1")
+
+type testCase struct {
+	name                      string
+	existingInfrastructure    bool
+	healthCheck               *infrav1.LoadBalancerHealthCheck
+	backendService            *infrav1.BackendServiceSpec
+	serviceAttachment         *infrav1.ServiceAttachmentSpec
+	mockAddresses             *cloud.MockAddresses
+	mockForwardingRules       *cloud.MockForwardingRules
+	mockRegionHealthChecks    *cloud.MockRegionHealthChecks
+	mockRegionBackendServices *cloud.MockRegionBackendServices
+	mockServiceAttachments    *cloud.MockServiceAttachments
+	wantErr                   bool
+	assert                    func(ctx context.Context, t testCase, s *scope.ClusterScope) error
+}
+
+func newClusterScope(t *testing.T, tt testCase) *scope.ClusterScope {
+	t.Helper()
+
+	gcpCluster := fakeGCPCluster.DeepCopy()
+	gcpCluster.Spec.LoadBalancer.BackendService = tt.backendService
+	gcpCluster.Spec.LoadBalancer.HealthCheck = tt.healthCheck
+	gcpCluster.Spec.LoadBalancer.InternalLoadBalancer.ServiceAttachment = tt.serviceAttachment
+	gcpCluster.Spec.UseExistingInfrastructure = pointer.Bool(tt.existingInfrastructure)
+
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: gcpCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return clusterScope
+}
+
+func newService(clusterScope *scope.ClusterScope, tt testCase) *Service {
+	s := New(clusterScope)
+	s.instancegroups = &cloud.MockInstanceGroups{
+		ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+		Objects:       map[meta.Key]*cloud.MockInstanceGroupsObj{},
+	}
+	s.internaladdresses = tt.mockAddresses
+	s.internalforwardingrules = tt.mockForwardingRules
+	s.internalhealthchecks = tt.mockRegionHealthChecks
+	s.internalbackendservices = tt.mockRegionBackendServices
+	s.serviceattachments = tt.mockServiceAttachments
+	return s
+}
+
+func TestService_Reconcile(t *testing.T) {
+	tests := []testCase{
+		{
+			name: "internal load balancer does not exist (should create it)",
+			mockAddresses: &cloud.MockAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+			},
+			mockForwardingRules: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+			},
+			mockRegionHealthChecks: &cloud.MockRegionHealthChecks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRegionHealthChecksObj{},
+			},
+			mockRegionBackendServices: &cloud.MockRegionBackendServices{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRegionBackendServicesObj{},
+			},
+			assert: func(ctx context.Context, t testCase, s *scope.ClusterScope) error {
+				address, err := t.mockAddresses.Get(ctx, internalLoadBalancerKey)
+				if err != nil {
+					return err
+				}
+
+				if address.AddressType != "INTERNAL" ||
+					address.Subnetwork != "projects/my-proj/regions/us-central1/subnetworks/control-plane" {
+					return errors.New("internal address was created but with wrong values")
+				}
+
+				forwardingRule, err := t.mockForwardingRules.Get(ctx, internalLoadBalancerKey)
+				if err != nil {
+					return err
+				}
+
+				if forwardingRule.LoadBalancingScheme != "INTERNAL" ||
+					forwardingRule.BackendService == "" ||
+					len(forwardingRule.Ports) != 1 || forwardingRule.Ports[0] != "6443" {
+					return errors.New("internal forwarding rule was created but with wrong values")
+				}
+
+				endpoint := s.ControlPlaneEndpoint()
+				if endpoint.Host != "10.0.0.10" || endpoint.Port != 6443 {
+					return errors.New("control plane endpoint is not the internal address")
+				}
+
+				if s.Network().APIServerForwardingRule != nil {
+					return errors.New("external load balancer was created")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "internal backend service options differ (should update the backend service)",
+			backendService: &infrav1.BackendServiceSpec{
+				ConnectionDrainingTimeoutSec: pointer.Int64(30),
+				SessionAffinity:              pointer.String("CLIENT_IP"),
+				IdleTimeoutSec:               pointer.Int64(3600),
+				FailoverPolicy: &infrav1.BackendServiceFailoverPolicy{
+					DropTrafficIfUnhealthy: pointer.Bool(true),
+					FailoverRatio:          pointer.String("0.5"),
+				},
+				Logging: &infrav1.BackendServiceLogging{},
+			},
+			mockAddresses: &cloud.MockAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+			},
+			mockForwardingRules: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+			},
+			mockRegionHealthChecks: &cloud.MockRegionHealthChecks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRegionHealthChecksObj{},
+			},
+			mockRegionBackendServices: &cloud.MockRegionBackendServices{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRegionBackendServicesObj{
+					*internalLoadBalancerKey: {Obj: &compute.BackendService{
+						Name: "my-cluster-apiserver-internal",
+						Backends: []*compute.Backend{
+							{Group: "zones/us-central1-a/instanceGroups/my-cluster-apiserver-us-central1-a"},
+						},
+					}},
+				},
+				UpdateHook: func(ctx context.Context, key *meta.Key, obj *compute.BackendService, m *cloud.MockRegionBackendServices) error {
+					m.Objects[*key] = &cloud.MockRegionBackendServicesObj{Obj: obj}
+					return nil
+				},
+			},
+			assert: func(ctx context.Context, t testCase, s *scope.ClusterScope) error {
+				backendsvc, err := t.mockRegionBackendServices.Get(ctx, internalLoadBalancerKey)
+				if err != nil {
+					return err
+				}
+
+				if backendsvc.ConnectionDraining == nil || backendsvc.ConnectionDraining.DrainingTimeoutSec != 30 ||
+					backendsvc.FailoverPolicy == nil || !backendsvc.FailoverPolicy.DropTrafficIfUnhealthy || backendsvc.FailoverPolicy.FailoverRatio != 0.5 ||
+					backendsvc.LogConfig == nil || !backendsvc.LogConfig.Enable || backendsvc.LogConfig.SampleRate != 1 ||
+					backendsvc.SessionAffinity != "CLIENT_IP" ||
+					backendsvc.ConnectionTrackingPolicy == nil || backendsvc.ConnectionTrackingPolicy.IdleTimeoutSec != 3600 {
+					return errors.New("internal backend service was not updated")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "internal health check settings differ (should update the health check)",
+			healthCheck: &infrav1.LoadBalancerHealthCheck{
+				Type:             pointer.String("TCP"),
+				Port:             pointer.Int64(8443),
+				CheckIntervalSec: pointer.Int64(5),
+				TimeoutSec:       pointer.Int64(2),
+				ProxyHeader:      pointer.String("PROXY_V1"),
+			},
+			mockAddresses: &cloud.MockAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+			},
+			mockForwardingRules: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+			},
+			mockRegionHealthChecks: &cloud.MockRegionHealthChecks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRegionHealthChecksObj{
+					*internalLoadBalancerKey: {Obj: &compute.HealthCheck{
+						Name: "my-cluster-apiserver-internal",
+						Type: "HTTPS",
+						HttpsHealthCheck: &compute.HTTPSHealthCheck{
+							Port:        6443,
+							RequestPath: "/readyz",
+						},
+					}},
+				},
+				UpdateHook: func(ctx context.Context, key *meta.Key, obj *compute.HealthCheck, m *cloud.MockRegionHealthChecks) error {
+					m.Objects[*key] = &cloud.MockRegionHealthChecksObj{Obj: obj}
+					return nil
+				},
+			},
+			mockRegionBackendServices: &cloud.MockRegionBackendServices{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRegionBackendServicesObj{},
+			},
+			assert: func(ctx context.Context, t testCase, s *scope.ClusterScope) error {
+				healthcheck, err := t.mockRegionHealthChecks.Get(ctx, internalLoadBalancerKey)
+				if err != nil {
+					return err
+				}
+
+				if healthcheck.Type != "TCP" || healthcheck.CheckIntervalSec != 5 || healthcheck.TimeoutSec != 2 ||
+					healthcheck.TcpHealthCheck == nil || healthcheck.TcpHealthCheck.Port != 8443 ||
+					healthcheck.TcpHealthCheck.ProxyHeader != "PROXY_V1" {
+					return errors.New("internal health check was not updated")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "service attachment does not exist (should publish the internal forwarding rule)",
+			serviceAttachment: &infrav1.ServiceAttachmentSpec{
+				NatSubnets:           []string{"psc-nat"},
+				ConnectionPreference: pointer.String("ACCEPT_MANUAL"),
+				ConsumerAcceptList: []infrav1.ServiceAttachmentConsumer{
+					{Project: "management-proj", ConnectionLimit: 2},
+				},
+			},
+			mockAddresses: &cloud.MockAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+			},
+			mockForwardingRules: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+			},
+			mockRegionHealthChecks: &cloud.MockRegionHealthChecks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRegionHealthChecksObj{},
+			},
+			mockRegionBackendServices: &cloud.MockRegionBackendServices{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRegionBackendServicesObj{},
+			},
+			mockServiceAttachments: &cloud.MockServiceAttachments{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockServiceAttachmentsObj{},
+			},
+			assert: func(ctx context.Context, t testCase, s *scope.ClusterScope) error {
+				forwardingRule, err := t.mockForwardingRules.Get(ctx, internalLoadBalancerKey)
+				if err != nil {
+					return err
+				}
+
+				attachment, err := t.mockServiceAttachments.Get(ctx, internalLoadBalancerKey)
+				if err != nil {
+					return err
+				}
+
+				if attachment.TargetService != forwardingRule.SelfLink ||
+					attachment.ConnectionPreference != "ACCEPT_MANUAL" ||
+					len(attachment.NatSubnets) != 1 || attachment.NatSubnets[0] != "projects/my-proj/regions/us-central1/subnetworks/psc-nat" ||
+					len(attachment.ConsumerAcceptLists) != 1 || attachment.ConsumerAcceptLists[0].ProjectIdOrNum != "management-proj" {
+					return errors.New("service attachment was created but with wrong values")
+				}
+
+				if s.Network().APIInternalServiceAttachment == nil || *s.Network().APIInternalServiceAttachment != attachment.SelfLink {
+					return errors.New("service attachment was not recorded in the status")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "service attachment consumers differ (should update the service attachment)",
+			serviceAttachment: &infrav1.ServiceAttachmentSpec{
+				NatSubnets:         []string{"psc-nat"},
+				ConsumerRejectList: []string{"untrusted-proj"},
+			},
+			mockAddresses: &cloud.MockAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+			},
+			mockForwardingRules: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+			},
+			mockRegionHealthChecks: &cloud.MockRegionHealthChecks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRegionHealthChecksObj{},
+			},
+			mockRegionBackendServices: &cloud.MockRegionBackendServices{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRegionBackendServicesObj{},
+			},
+			mockServiceAttachments: &cloud.MockServiceAttachments{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockServiceAttachmentsObj{
+					*internalLoadBalancerKey: {Obj: &compute.ServiceAttachment{
+						Name:                 "my-cluster-apiserver-internal",
+						ConnectionPreference: "ACCEPT_AUTOMATIC",
+						NatSubnets:           []string{"https://www.googleapis.com/compute/v1/projects/my-proj/regions/us-central1/subnetworks/psc-nat"},
+						Fingerprint:          "abc",
+					}},
+				},
+				PatchHook: func(ctx context.Context, key *meta.Key, obj *compute.ServiceAttachment, m *cloud.MockServiceAttachments) error {
+					if obj.Fingerprint != "abc" {
+						return errors.New("fingerprint was not preserved")
+					}
+					m.Objects[*key] = &cloud.MockServiceAttachmentsObj{Obj: obj}
+					return nil
+				},
+			},
+			assert: func(ctx context.Context, t testCase, s *scope.ClusterScope) error {
+				attachment, err := t.mockServiceAttachments.Get(ctx, internalLoadBalancerKey)
+				if err != nil {
+					return err
+				}
+
+				if len(attachment.ConsumerRejectLists) != 1 || attachment.ConsumerRejectLists[0] != "untrusted-proj" {
+					return errors.New("service attachment was not updated")
+				}
+
+				return nil
+			},
+		},
+		{
+			name:                   "existing internal load balancer (should populate the status without creating resources)",
+			existingInfrastructure: true,
+			mockAddresses: &cloud.MockAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockAddressesObj{
+					*internalLoadBalancerKey: {Obj: &compute.Address{
+						Name:     "my-cluster-apiserver-internal",
+						Address:  "10.0.0.20",
+						SelfLink: "https://www.googleapis.com/compute/v1/projects/my-proj/regions/us-central1/addresses/my-cluster-apiserver-internal",
+					}},
+				},
+			},
+			mockForwardingRules: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+			},
+			mockRegionHealthChecks: &cloud.MockRegionHealthChecks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRegionHealthChecksObj{},
+			},
+			mockRegionBackendServices: &cloud.MockRegionBackendServices{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRegionBackendServicesObj{
+					*internalLoadBalancerKey: {Obj: &compute.BackendService{
+						Name:     "my-cluster-apiserver-internal",
+						SelfLink: "https://www.googleapis.com/compute/v1/projects/my-proj/regions/us-central1/backendServices/my-cluster-apiserver-internal",
+					}},
+				},
+			},
+			assert: func(ctx context.Context, t testCase, s *scope.ClusterScope) error {
+				if _, err := t.mockForwardingRules.Get(ctx, internalLoadBalancerKey); err == nil {
+					return errors.New("internal forwarding rule was created")
+				}
+
+				if s.ControlPlaneEndpoint().Host != "10.0.0.20" {
+					return errors.New("control plane endpoint is not the existing internal address")
+				}
+
+				if s.Network().APIInternalBackendService == nil || s.Network().APIInternalForwardingRule != nil {
+					return errors.New("status was not populated from the existing resources")
+				}
+
+				return nil
+			},
+		},
+		{
+			name:                   "existing internal address not found (should return an error)",
+			existingInfrastructure: true,
+			mockAddresses: &cloud.MockAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+			},
+			mockForwardingRules: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+			},
+			mockRegionHealthChecks: &cloud.MockRegionHealthChecks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRegionHealthChecksObj{},
+			},
+			mockRegionBackendServices: &cloud.MockRegionBackendServices{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRegionBackendServicesObj{},
+			},
+			wantErr: true,
+			assert: func(ctx context.Context, t testCase, s *scope.ClusterScope) error {
+				if _, err := t.mockAddresses.Get(ctx, internalLoadBalancerKey); err == nil {
+					return errors.New("internal address was created")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "internal backend service creation fails (should return an error)",
+			mockAddresses: &cloud.MockAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+			},
+			mockForwardingRules: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+			},
+			mockRegionHealthChecks: &cloud.MockRegionHealthChecks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRegionHealthChecksObj{},
+			},
+			mockRegionBackendServices: &cloud.MockRegionBackendServices{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRegionBackendServicesObj{},
+				InsertError: map[meta.Key]error{
+					*internalLoadBalancerKey: &googleapi.Error{Code: http.StatusBadRequest},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			clusterScope := newClusterScope(t, tt)
+			s := newService(clusterScope, tt)
+			err := s.Reconcile(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assert != nil {
+				err = tt.assert(ctx, tt, clusterScope)
+				if err != nil {
+					t.Errorf("load balancer was not reconciled as expected: %v", err)
+					return
+				}
+			}
+		})
+	}
+}
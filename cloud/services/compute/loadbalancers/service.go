@@ -22,6 +22,7 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 )
 
@@ -47,6 +48,7 @@ type forwardingrulesInterface interface {
 type healthchecksInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.HealthCheck, error)
 	Insert(ctx context.Context, key *meta.Key, obj *compute.HealthCheck) error
+	Update(context.Context, *meta.Key, *compute.HealthCheck) error
 	Delete(ctx context.Context, key *meta.Key) error
 }
 
@@ -57,6 +59,13 @@ type instancegroupsInterface interface {
 	Delete(ctx context.Context, key *meta.Key) error
 }
 
+type serviceattachmentsInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.ServiceAttachment, error)
+	Insert(ctx context.Context, key *meta.Key, obj *compute.ServiceAttachment) error
+	Patch(context.Context, *meta.Key, *compute.ServiceAttachment) error
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
 type targettcpproxiesInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.TargetTcpProxy, error)
 	Insert(ctx context.Context, key *meta.Key, obj *compute.TargetTcpProxy) error
@@ -72,6 +81,13 @@ type Scope interface {
 	HealthCheckSpec() *compute.HealthCheck
 	InstanceGroupSpec(zone string) *compute.InstanceGroup
 	TargetTCPProxySpec() *compute.TargetTcpProxy
+	LoadBalancerType() infrav1.LoadBalancerType
+	IsExistingInfrastructure() bool
+	InternalAddressSpec() *compute.Address
+	InternalBackendServiceSpec() *compute.BackendService
+	InternalForwardingRuleSpec() *compute.ForwardingRule
+	InternalHealthCheckSpec() *compute.HealthCheck
+	ServiceAttachmentSpec() *compute.ServiceAttachment
 }
 
 // Service implements loadbalancers reconciler.
@@ -83,6 +99,12 @@ type Service struct {
 	healthchecks     healthchecksInterface
 	instancegroups   instancegroupsInterface
 	targettcpproxies targettcpproxiesInterface
+
+	internaladdresses       addressesInterface
+	internalbackendservices backendservicesInterface
+	internalforwardingrules forwardingrulesInterface
+	internalhealthchecks    healthchecksInterface
+	serviceattachments      serviceattachmentsInterface
 }
 
 var _ cloud.Reconciler = &Service{}
@@ -97,5 +119,11 @@ func New(scope Scope) *Service {
 		healthchecks:     scope.Cloud().HealthChecks(),
 		instancegroups:   scope.Cloud().InstanceGroups(),
 		targettcpproxies: scope.Cloud().TargetTcpProxies(),
+
+		internaladdresses:       scope.Cloud().Addresses(),
+		internalbackendservices: scope.Cloud().RegionBackendServices(),
+		internalforwardingrules: scope.Cloud().ForwardingRules(),
+		internalhealthchecks:    scope.Cloud().RegionHealthChecks(),
+		serviceattachments:      scope.Cloud().ServiceAttachments(),
 	}
 }
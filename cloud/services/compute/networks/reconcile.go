@@ -18,6 +18,7 @@ package networks
 
 import (
 	"context"
+	"path"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"google.golang.org/api/compute/v1"
@@ -30,6 +31,30 @@ import (
 // Reconcile reconcile cluster network components.
 func (s *Service) Reconcile(ctx context.Context) error {
 	log := log.FromContext(ctx)
+	if s.scope.IsSharedVpc() {
+		log.V(2).Info("Shared VPC enabled, skipping network reconciliation", "project", s.scope.NetworkProject())
+		s.scope.Network().SelfLink = pointer.String(s.scope.NetworkLink())
+		return nil
+	}
+
+	if s.scope.IsExistingNetwork() {
+		log.V(2).Info("Looking for existing network", "name", s.scope.NetworkName())
+		network, err := s.networks.Get(ctx, meta.GlobalKey(s.scope.NetworkName()))
+		if err != nil {
+			log.Error(err, "Error looking for existing network", "name", s.scope.NetworkName())
+			return err
+		}
+
+		if s.scope.CloudNatEnabled() {
+			if err := s.reconcileNatRouter(ctx, network); err != nil {
+				return err
+			}
+		}
+
+		s.scope.Network().SelfLink = pointer.String(network.SelfLink)
+		return nil
+	}
+
 	log.Info("Reconciling network resources")
 	network, err := s.createOrGetNetwork(ctx)
 	if err != nil {
@@ -37,12 +62,9 @@ func (s *Service) Reconcile(ctx context.Context) error {
 	}
 
 	if network.Description == infrav1.ClusterTagKey(s.scope.Name()) {
-		router, err := s.createOrGetRouter(ctx, network)
-		if err != nil {
+		if err := s.reconcileNatRouter(ctx, network); err != nil {
 			return err
 		}
-
-		s.scope.Network().Router = pointer.String(router.SelfLink)
 	}
 
 	s.scope.Network().SelfLink = pointer.String(network.SelfLink)
@@ -52,6 +74,25 @@ func (s *Service) Reconcile(ctx context.Context) error {
 // Delete delete cluster network components.
 func (s *Service) Delete(ctx context.Context) error {
 	log := log.FromContext(ctx)
+	if s.scope.IsSharedVpc() {
+		log.V(2).Info("Shared VPC enabled, skipping network deletion", "project", s.scope.NetworkProject())
+		s.scope.Network().SelfLink = nil
+		return nil
+	}
+
+	if s.scope.IsExistingNetwork() {
+		if s.scope.CloudNatEnabled() {
+			if err := s.deleteNatRouter(ctx); err != nil {
+				return err
+			}
+			s.scope.Network().Router = nil
+		}
+
+		log.V(2).Info("Existing network is not managed, skipping network deletion", "name", s.scope.NetworkName())
+		s.scope.Network().SelfLink = nil
+		return nil
+	}
+
 	log.Info("Deleting network resources")
 	networkKey := meta.GlobalKey(s.scope.NetworkName())
 	log.V(2).Info("Looking for network before deleting", "name", networkKey)
@@ -66,20 +107,10 @@ func (s *Service) Delete(ctx context.Context) error {
 
 	log.V(2).Info("Found network created by capg", "name", s.scope.NetworkName())
 
-	routerSpec := s.scope.NatRouterSpec()
-	routerKey := meta.RegionalKey(routerSpec.Name, s.scope.Region())
-	log.V(2).Info("Looking for cloudnat router before deleting", "name", routerSpec.Name)
-	router, err := s.routers.Get(ctx, routerKey)
-	if err != nil && !gcperrors.IsNotFound(err) {
+	if err := s.deleteNatRouter(ctx); err != nil {
 		return err
 	}
 
-	if router != nil && router.Description == infrav1.ClusterTagKey(s.scope.Name()) {
-		if err := s.routers.Delete(ctx, routerKey); err != nil && !gcperrors.IsNotFound(err) {
-			return err
-		}
-	}
-
 	if err := s.networks.Delete(ctx, networkKey); err != nil {
 		log.Error(err, "Error deleting a network", "name", s.scope.NetworkName())
 		return err
@@ -117,6 +148,65 @@ func (s *Service) createOrGetNetwork(ctx context.Context) (*compute.Network, err
 	return network, nil
 }
 
+// reconcileNatRouter reconciles the cloudnat router of the network. Once the Cloud NAT is disabled, it is
+// removed from the router if the router was created by capg.
+func (s *Service) reconcileNatRouter(ctx context.Context, network *compute.Network) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.NatRouterSpec()
+	routerKey := meta.RegionalKey(spec.Name, s.scope.Region())
+	if !s.scope.CloudNatEnabled() {
+		router, err := s.routers.Get(ctx, routerKey)
+		if err != nil {
+			return gcperrors.IgnoreNotFound(err)
+		}
+
+		if router.Description == infrav1.ClusterTagKey(s.scope.Name()) && len(router.Nats) > 0 {
+			log.V(2).Info("Removing cloudnat from router", "name", spec.Name)
+			if err := s.routers.Patch(ctx, routerKey, &compute.Router{Nats: []*compute.RouterNat{}, ForceSendFields: []string{"Nats"}}); err != nil {
+				log.Error(err, "Error removing cloudnat from router", "name", spec.Name)
+				return err
+			}
+		}
+		return nil
+	}
+
+	router, err := s.createOrGetRouter(ctx, network)
+	if err != nil {
+		return err
+	}
+
+	if router.Description == infrav1.ClusterTagKey(s.scope.Name()) && !natsEqual(router.Nats, spec.Nats) {
+		log.V(2).Info("Updating cloudnat configuration", "name", spec.Name)
+		if err := s.routers.Patch(ctx, routerKey, &compute.Router{Nats: spec.Nats}); err != nil {
+			log.Error(err, "Error updating cloudnat configuration", "name", spec.Name)
+			return err
+		}
+	}
+
+	s.scope.Network().Router = pointer.String(router.SelfLink)
+	return nil
+}
+
+// deleteNatRouter deletes the cloudnat router of the network if it was created by capg.
+func (s *Service) deleteNatRouter(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	routerSpec := s.scope.NatRouterSpec()
+	routerKey := meta.RegionalKey(routerSpec.Name, s.scope.Region())
+	log.V(2).Info("Looking for cloudnat router before deleting", "name", routerSpec.Name)
+	router, err := s.routers.Get(ctx, routerKey)
+	if err != nil {
+		return gcperrors.IgnoreNotFound(err)
+	}
+
+	if router.Description == infrav1.ClusterTagKey(s.scope.Name()) {
+		if err := s.routers.Delete(ctx, routerKey); err != nil && !gcperrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // createOrGetRouter creates a cloudnat router if not exist otherwise return the existing.
 func (s *Service) createOrGetRouter(ctx context.Context, network *compute.Network) (*compute.Router, error) {
 	log := log.FromContext(ctx)
@@ -146,3 +236,32 @@ func (s *Service) createOrGetRouter(ctx context.Context, network *compute.Networ
 
 	return router, nil
 }
+
+// natsEqual returns true if the existing Cloud NAT configuration of the router matches the desired one.
+func natsEqual(existing, desired []*compute.RouterNat) bool {
+	if len(existing) != len(desired) {
+		return false
+	}
+
+	for i := range desired {
+		e, d := existing[i], desired[i]
+		if e.Name != d.Name || e.NatIpAllocateOption != d.NatIpAllocateOption || len(e.NatIps) != len(d.NatIps) {
+			return false
+		}
+		for j := range d.NatIps {
+			if path.Base(e.NatIps[j]) != path.Base(d.NatIps[j]) {
+				return false
+			}
+		}
+		// The minimum number of ports is defaulted by the API when unset.
+		if d.MinPortsPerVm != 0 && e.MinPortsPerVm != d.MinPortsPerVm {
+			return false
+		}
+		logEnabled := d.LogConfig != nil && d.LogConfig.Enable
+		if logEnabled != (e.LogConfig != nil && e.LogConfig.Enable) || (logEnabled && e.LogConfig.Filter != d.LogConfig.Filter) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,271 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Name: pointer.String("my-network"),
+		},
+	},
+}
+
+type testCase struct {
+	name         string
+	gcpCluster   func() *infrav1.GCPCluster
+	mockNetworks *cloud.MockNetworks
+	mockRouters  *cloud.MockRouters
+	wantErr      bool
+	assert       func(ctx context.Context, t testCase) error
+}
+
+func TestService_Reconcile(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	networkKey := meta.GlobalKey("my-network")
+	routerKey := meta.RegionalKey("my-network-router", "us-central1")
+	ownedNetwork := &compute.Network{
+		Name:        "my-network",
+		Description: infrav1.ClusterTagKey("my-cluster"),
+	}
+
+	tests := []testCase{
+		{
+			name:       "network does not exist (should create network and cloudnat router)",
+			gcpCluster: fakeGCPCluster.DeepCopy,
+			mockNetworks: &cloud.MockNetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockNetworksObj{},
+			},
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutersObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				router, err := t.mockRouters.Get(ctx, routerKey)
+				if err != nil {
+					return err
+				}
+				if len(router.Nats) != 1 || router.Nats[0].NatIpAllocateOption != "AUTO_ONLY" {
+					return errors.New("cloudnat router was created with wrong values")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "cloudnat with reserved addresses (should create cloudnat with manual addresses)",
+			gcpCluster: func() *infrav1.GCPCluster {
+				gcpCluster := fakeGCPCluster.DeepCopy()
+				gcpCluster.Spec.Network.CloudNat = &infrav1.CloudNatSpec{
+					NatIPs:        []string{"my-address"},
+					MinPortsPerVM: pointer.Int64(128),
+					LogFilter:     pointer.String("ERRORS_ONLY"),
+				}
+				return gcpCluster
+			},
+			mockNetworks: &cloud.MockNetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockNetworksObj{},
+			},
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutersObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				router, err := t.mockRouters.Get(ctx, routerKey)
+				if err != nil {
+					return err
+				}
+				nat := router.Nats[0]
+				if nat.NatIpAllocateOption != "MANUAL_ONLY" ||
+					len(nat.NatIps) != 1 || nat.NatIps[0] != "projects/my-proj/regions/us-central1/addresses/my-address" ||
+					nat.MinPortsPerVm != 128 ||
+					nat.LogConfig == nil || nat.LogConfig.Filter != "ERRORS_ONLY" {
+					return errors.New("cloudnat was created with wrong values")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "cloudnat disabled on existing router (should remove cloudnat)",
+			gcpCluster: func() *infrav1.GCPCluster {
+				gcpCluster := fakeGCPCluster.DeepCopy()
+				gcpCluster.Spec.Network.CloudNat = &infrav1.CloudNatSpec{Enabled: pointer.Bool(false)}
+				return gcpCluster
+			},
+			mockNetworks: &cloud.MockNetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockNetworksObj{
+					*networkKey: {Obj: ownedNetwork},
+				},
+			},
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutersObj{
+					*routerKey: {Obj: &compute.Router{
+						Name:        "my-network-router",
+						Description: infrav1.ClusterTagKey("my-cluster"),
+						Nats:        []*compute.RouterNat{{Name: "my-network-nat"}},
+					}},
+				},
+				PatchHook: func(ctx context.Context, key *meta.Key, obj *compute.Router, m *cloud.MockRouters) error {
+					router := m.Objects[*key].Obj.(*compute.Router)
+					router.Nats = obj.Nats
+					return nil
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				router, err := t.mockRouters.Get(ctx, routerKey)
+				if err != nil {
+					return err
+				}
+				if len(router.Nats) != 0 {
+					return errors.New("cloudnat was not removed")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "cloudnat enabled on existing network (should create cloudnat router)",
+			gcpCluster: func() *infrav1.GCPCluster {
+				gcpCluster := fakeGCPCluster.DeepCopy()
+				gcpCluster.Spec.Network.UseExisting = pointer.Bool(true)
+				gcpCluster.Spec.Network.CloudNat = &infrav1.CloudNatSpec{Enabled: pointer.Bool(true)}
+				return gcpCluster
+			},
+			mockNetworks: &cloud.MockNetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockNetworksObj{
+					*networkKey: {Obj: &compute.Network{Name: "my-network"}},
+				},
+			},
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutersObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				router, err := t.mockRouters.Get(ctx, routerKey)
+				if err != nil {
+					return err
+				}
+				if router.Description != infrav1.ClusterTagKey("my-cluster") || len(router.Nats) != 1 {
+					return errors.New("cloudnat router was created with wrong values")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "existing network (should not create cloudnat router by default)",
+			gcpCluster: func() *infrav1.GCPCluster {
+				gcpCluster := fakeGCPCluster.DeepCopy()
+				gcpCluster.Spec.Network.UseExisting = pointer.Bool(true)
+				return gcpCluster
+			},
+			mockNetworks: &cloud.MockNetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockNetworksObj{
+					*networkKey: {Obj: &compute.Network{Name: "my-network"}},
+				},
+			},
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutersObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockRouters.Get(ctx, routerKey); err == nil {
+					return errors.New("cloudnat router was created in an existing network")
+				}
+
+				return nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
+				Client:     fakec,
+				Cluster:    fakeCluster,
+				GCPCluster: tt.gcpCluster(),
+				GCPServices: scope.GCPServices{
+					Compute: &compute.Service{},
+				},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := New(clusterScope)
+			s.networks = tt.mockNetworks
+			s.routers = tt.mockRouters
+			err = s.Reconcile(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assert != nil {
+				err = tt.assert(ctx, tt)
+				if err != nil {
+					t.Errorf("network was not reconciled as expected: %v", err)
+					return
+				}
+			}
+		})
+	}
+}
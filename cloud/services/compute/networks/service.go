@@ -33,14 +33,17 @@ type networksInterface interface {
 type routersInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.Router, error)
 	Insert(ctx context.Context, key *meta.Key, obj *compute.Router) error
+	Patch(ctx context.Context, key *meta.Key, obj *compute.Router) error
 	Delete(ctx context.Context, key *meta.Key) error
 }
 
 // Scope is an interfaces that hold used methods.
 type Scope interface {
 	cloud.Cluster
+	NetworkLink() string
 	NetworkSpec() *compute.Network
 	NatRouterSpec() *compute.Router
+	CloudNatEnabled() bool
 }
 
 // Service implements networks reconciler.
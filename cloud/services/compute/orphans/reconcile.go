@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Reconcile does nothing, orphaned resources are only collected once the cluster is deleted. The resources
+// removed from the spec of the cluster are deleted by the services managing them.
+func (s *Service) Reconcile(_ context.Context) error {
+	return nil
+}
+
+// Delete deletes the firewalls, routers and addresses whose description marks them as owned by the cluster
+// and that were not deleted with the resources of the cluster, such as resources left behind by an earlier
+// failed deletion.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	if s.scope.IsSharedVpc() {
+		return nil
+	}
+
+	log.Info("Deleting orphaned resources")
+	fl := filter.Regexp("description", fmt.Sprintf("^%s$", regexp.QuoteMeta(infrav1.ClusterTagKey(s.scope.Name()))))
+
+	firewalls, err := s.firewalls.List(ctx, fl)
+	if err != nil {
+		log.Error(err, "Error listing firewalls")
+		return err
+	}
+	for _, firewall := range firewalls {
+		if err := s.deleteOrphan(ctx, "firewall", meta.GlobalKey(firewall.Name), s.firewalls.Delete); err != nil {
+			return err
+		}
+	}
+
+	addresses, err := s.addresses.List(ctx, s.scope.Region(), fl)
+	if err != nil {
+		log.Error(err, "Error listing addresses")
+		return err
+	}
+	for _, address := range addresses {
+		if err := s.deleteOrphan(ctx, "address", meta.RegionalKey(address.Name, s.scope.Region()), s.addresses.Delete); err != nil {
+			return err
+		}
+	}
+
+	globalAddresses, err := s.globalAddresses.List(ctx, fl)
+	if err != nil {
+		log.Error(err, "Error listing global addresses")
+		return err
+	}
+	for _, address := range globalAddresses {
+		if err := s.deleteOrphan(ctx, "global address", meta.GlobalKey(address.Name), s.globalAddresses.Delete); err != nil {
+			return err
+		}
+	}
+
+	routers, err := s.routers.List(ctx, s.scope.Region(), fl)
+	if err != nil {
+		log.Error(err, "Error listing routers")
+		return err
+	}
+	for _, router := range routers {
+		if err := s.deleteOrphan(ctx, "router", meta.RegionalKey(router.Name, s.scope.Region()), s.routers.Delete); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) deleteOrphan(ctx context.Context, kind string, key *meta.Key, deleteFunc func(context.Context, *meta.Key) error) error {
+	log := log.FromContext(ctx)
+	log.V(2).Info("Deleting an orphaned resource", "kind", kind, "name", key.Name)
+	if err := deleteFunc(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting an orphaned resource", "kind", kind, "name", key.Name)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Name: pointer.String("my-network"),
+		},
+	},
+}
+
+var ownerDescription = infrav1.ClusterTagKey(fakeCluster.Name)
+
+type testCase struct {
+	name                string
+	mockFirewalls       *cloud.MockFirewalls
+	mockRouters         *cloud.MockRouters
+	mockAddresses       *cloud.MockAddresses
+	mockGlobalAddresses *cloud.MockGlobalAddresses
+	wantErr             bool
+	wantDeleted         []meta.Key
+	wantKept            []meta.Key
+}
+
+func newMocks() (*cloud.MockFirewalls, *cloud.MockRouters, *cloud.MockAddresses, *cloud.MockGlobalAddresses) {
+	projectRouter := &cloud.SingleProjectRouter{ID: "my-proj"}
+	return &cloud.MockFirewalls{ProjectRouter: projectRouter, Objects: map[meta.Key]*cloud.MockFirewallsObj{}},
+		&cloud.MockRouters{ProjectRouter: projectRouter, Objects: map[meta.Key]*cloud.MockRoutersObj{}},
+		&cloud.MockAddresses{ProjectRouter: projectRouter, Objects: map[meta.Key]*cloud.MockAddressesObj{}},
+		&cloud.MockGlobalAddresses{ProjectRouter: projectRouter, Objects: map[meta.Key]*cloud.MockGlobalAddressesObj{}}
+}
+
+func TestService_Delete(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firewallKey := *meta.GlobalKey("allow-my-cluster-cluster")
+	foreignFirewallKey := *meta.GlobalKey("allow-ssh")
+	routerKey := *meta.RegionalKey("my-network-router", "us-central1")
+	addressKey := *meta.RegionalKey("my-cluster-apiserver-internal", "us-central1")
+	globalAddressKey := *meta.GlobalKey("my-cluster-apiserver")
+	foreignGlobalAddressKey := *meta.GlobalKey("reserved")
+
+	tests := []testCase{
+		func() testCase {
+			firewalls, routers, addresses, globalAddresses := newMocks()
+			return testCase{
+				name:                "no resources, should do nothing",
+				mockFirewalls:       firewalls,
+				mockRouters:         routers,
+				mockAddresses:       addresses,
+				mockGlobalAddresses: globalAddresses,
+			}
+		}(),
+		func() testCase {
+			firewalls, routers, addresses, globalAddresses := newMocks()
+			firewalls.Objects[firewallKey] = &cloud.MockFirewallsObj{Obj: &compute.Firewall{Name: firewallKey.Name, Description: ownerDescription}}
+			firewalls.Objects[foreignFirewallKey] = &cloud.MockFirewallsObj{Obj: &compute.Firewall{Name: foreignFirewallKey.Name}}
+			routers.Objects[routerKey] = &cloud.MockRoutersObj{Obj: &compute.Router{Name: routerKey.Name, Description: ownerDescription}}
+			addresses.Objects[addressKey] = &cloud.MockAddressesObj{Obj: &compute.Address{Name: addressKey.Name, Description: ownerDescription}}
+			globalAddresses.Objects[globalAddressKey] = &cloud.MockGlobalAddressesObj{Obj: &compute.Address{Name: globalAddressKey.Name, Description: ownerDescription}}
+			globalAddresses.Objects[foreignGlobalAddressKey] = &cloud.MockGlobalAddressesObj{Obj: &compute.Address{Name: foreignGlobalAddressKey.Name, Description: infrav1.ClusterTagKey("other-cluster")}}
+			return testCase{
+				name:                "owned resources exist, should delete them and keep the others",
+				mockFirewalls:       firewalls,
+				mockRouters:         routers,
+				mockAddresses:       addresses,
+				mockGlobalAddresses: globalAddresses,
+				wantDeleted:         []meta.Key{firewallKey, routerKey, addressKey, globalAddressKey},
+				wantKept:            []meta.Key{foreignFirewallKey, foreignGlobalAddressKey},
+			}
+		}(),
+		func() testCase {
+			firewalls, routers, addresses, globalAddresses := newMocks()
+			firewalls.Objects[firewallKey] = &cloud.MockFirewallsObj{Obj: &compute.Firewall{Name: firewallKey.Name, Description: ownerDescription}}
+			firewalls.DeleteError = map[meta.Key]error{firewallKey: &googleapi.Error{Code: http.StatusBadRequest}}
+			return testCase{
+				name:                "error deleting a firewall, should return error",
+				mockFirewalls:       firewalls,
+				mockRouters:         routers,
+				mockAddresses:       addresses,
+				mockGlobalAddresses: globalAddresses,
+				wantErr:             true,
+			}
+		}(),
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(clusterScope)
+			s.firewalls = tt.mockFirewalls
+			s.routers = tt.mockRouters
+			s.addresses = tt.mockAddresses
+			s.globalAddresses = tt.mockGlobalAddresses
+			err := s.Delete(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for _, key := range tt.wantDeleted {
+				if exists(ctx, tt, key) {
+					t.Errorf("resource %s was not deleted", key.Name)
+				}
+			}
+			for _, key := range tt.wantKept {
+				if !exists(ctx, tt, key) {
+					t.Errorf("resource %s was deleted", key.Name)
+				}
+			}
+		})
+	}
+}
+
+// exists returns true if the resource with the given key is found in one of the mocks.
+func exists(ctx context.Context, tt testCase, key meta.Key) bool {
+	if _, err := tt.mockFirewalls.Get(ctx, &key); err == nil {
+		return true
+	}
+	if _, err := tt.mockRouters.Get(ctx, &key); err == nil {
+		return true
+	}
+	if _, err := tt.mockAddresses.Get(ctx, &key); err == nil {
+		return true
+	}
+	_, err := tt.mockGlobalAddresses.Get(ctx, &key)
+	return err == nil
+}
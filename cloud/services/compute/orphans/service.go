@@ -0,0 +1,73 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package orphans
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+)
+
+type firewallsInterface interface {
+	List(ctx context.Context, fl *filter.F) ([]*compute.Firewall, error)
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type routersInterface interface {
+	List(ctx context.Context, region string, fl *filter.F) ([]*compute.Router, error)
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type addressesInterface interface {
+	List(ctx context.Context, region string, fl *filter.F) ([]*compute.Address, error)
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+type globalAddressesInterface interface {
+	List(ctx context.Context, fl *filter.F) ([]*compute.Address, error)
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+// Scope is an interfaces that hold used methods.
+type Scope interface {
+	cloud.ClusterGetter
+}
+
+// Service implements the garbage collection of orphaned resources.
+type Service struct {
+	scope           Scope
+	firewalls       firewallsInterface
+	routers         routersInterface
+	addresses       addressesInterface
+	globalAddresses globalAddressesInterface
+}
+
+var _ cloud.Reconciler = &Service{}
+
+// New returns Service from given scope.
+func New(scope Scope) *Service {
+	return &Service{
+		scope:           scope,
+		firewalls:       scope.Cloud().Firewalls(),
+		routers:         scope.Cloud().Routers(),
+		addresses:       scope.Cloud().Addresses(),
+		globalAddresses: scope.Cloud().GlobalAddresses(),
+	}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pscendpoints
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Reconcile reconciles the Private Service Connect endpoint of the control plane.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	addressSpec := s.scope.PrivateServiceConnectAddressSpec()
+	if addressSpec == nil {
+		return nil
+	}
+
+	log.Info("Reconciling private service connect endpoint resources")
+	address, err := s.createOrGetAddress(ctx, addressSpec)
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.createOrGetForwardingRule(ctx, address); err != nil {
+		return err
+	}
+
+	s.scope.SetPrivateServiceConnectEndpoint(pointer.String(address.Address))
+	return nil
+}
+
+// Delete deletes the Private Service Connect endpoint of the control plane if it was created by capg.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	forwardingRuleSpec := s.scope.PrivateServiceConnectForwardingRuleSpec()
+	if forwardingRuleSpec == nil {
+		return nil
+	}
+
+	log.Info("Deleting private service connect endpoint resources")
+	forwardingRuleKey := meta.RegionalKey(forwardingRuleSpec.Name, s.scope.Region())
+	forwardingRule, err := s.forwardingrules.Get(ctx, forwardingRuleKey)
+	if err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error looking for forwarding rule", "name", forwardingRuleSpec.Name)
+		return err
+	}
+
+	if forwardingRule != nil && forwardingRule.Description == infrav1.ClusterTagKey(s.scope.Name()) {
+		log.V(2).Info("Deleting a forwarding rule", "name", forwardingRuleSpec.Name)
+		if err := s.forwardingrules.Delete(ctx, forwardingRuleKey); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting a forwarding rule", "name", forwardingRuleSpec.Name)
+			return err
+		}
+	}
+
+	addressSpec := s.scope.PrivateServiceConnectAddressSpec()
+	addressKey := meta.RegionalKey(addressSpec.Name, s.scope.Region())
+	address, err := s.addresses.Get(ctx, addressKey)
+	if err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error looking for address", "name", addressSpec.Name)
+		return err
+	}
+
+	if address != nil && address.Description == infrav1.ClusterTagKey(s.scope.Name()) {
+		log.V(2).Info("Deleting an address", "name", addressSpec.Name)
+		if err := s.addresses.Delete(ctx, addressKey); err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting an address", "name", addressSpec.Name)
+			return err
+		}
+	}
+
+	s.scope.SetPrivateServiceConnectEndpoint(nil)
+	return nil
+}
+
+// createOrGetAddress creates the endpoint address if not exist otherwise return the existing one.
+func (s *Service) createOrGetAddress(ctx context.Context, spec *compute.Address) (*compute.Address, error) {
+	log := log.FromContext(ctx)
+	log.V(2).Info("Looking for address", "name", spec.Name)
+	addressKey := meta.RegionalKey(spec.Name, s.scope.Region())
+	address, err := s.addresses.Get(ctx, addressKey)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for address", "name", spec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating an address", "name", spec.Name)
+		if err := s.addresses.Insert(ctx, addressKey, spec); err != nil {
+			log.Error(err, "Error creating an address", "name", spec.Name)
+			return nil, err
+		}
+
+		address, err = s.addresses.Get(ctx, addressKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return address, nil
+}
+
+// createOrGetForwardingRule creates the endpoint forwarding rule targeting the service attachment if not exist
+// otherwise return the existing one.
+func (s *Service) createOrGetForwardingRule(ctx context.Context, address *compute.Address) (*compute.ForwardingRule, error) {
+	log := log.FromContext(ctx)
+	spec := s.scope.PrivateServiceConnectForwardingRuleSpec()
+	log.V(2).Info("Looking for forwarding rule", "name", spec.Name)
+	forwardingRuleKey := meta.RegionalKey(spec.Name, s.scope.Region())
+	forwardingRule, err := s.forwardingrules.Get(ctx, forwardingRuleKey)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for forwarding rule", "name", spec.Name)
+			return nil, err
+		}
+
+		spec.IPAddress = address.SelfLink
+		log.V(2).Info("Creating a forwarding rule", "name", spec.Name)
+		if err := s.forwardingrules.Insert(ctx, forwardingRuleKey, spec); err != nil {
+			log.Error(err, "Error creating a forwarding rule", "name", spec.Name)
+			return nil, err
+		}
+
+		forwardingRule, err = s.forwardingrules.Get(ctx, forwardingRuleKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return forwardingRule, nil
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pscendpoints
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1exp.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPManagedCluster = &infrav1exp.GCPManagedCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1exp.GCPManagedClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		PrivateServiceConnectEndpoint: &infrav1exp.PrivateServiceConnectEndpoint{
+			Network:           "management",
+			Subnetwork:        "management-nodes",
+			ServiceAttachment: "projects/tenant-proj/regions/us-central1/serviceAttachments/my-cluster-cp",
+		},
+	},
+}
+
+var (
+	addressKey        = meta.RegionalKey("my-cluster-psc", "us-central1")
+	forwardingRuleKey = meta.RegionalKey("my-cluster-psc", "us-central1")
+)
+
+type testCase struct {
+	name                string
+	mockAddresses       *cloud.MockAddresses
+	mockForwardingRules *cloud.MockForwardingRules
+	wantErr             bool
+	assert              func(ctx context.Context, t testCase) error
+}
+
+func newManagedClusterScope(t *testing.T) *scope.ManagedClusterScope {
+	t.Helper()
+
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewManagedClusterScope(context.TODO(), scope.ManagedClusterScopeParams{
+		Client:            fakec,
+		Cluster:           fakeCluster,
+		GCPManagedCluster: fakeGCPManagedCluster.DeepCopy(),
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return clusterScope
+}
+
+func TestService_Reconcile(t *testing.T) {
+	tests := []testCase{
+		{
+			name: "endpoint does not exist (should create address and forwarding rule)",
+			mockAddresses: &cloud.MockAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+			},
+			mockForwardingRules: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				address, err := t.mockAddresses.Get(ctx, addressKey)
+				if err != nil {
+					return err
+				}
+
+				if address.AddressType != "INTERNAL" ||
+					address.Subnetwork != "projects/my-proj/regions/us-central1/subnetworks/management-nodes" {
+					return errors.New("address was created but with wrong values")
+				}
+
+				forwardingRule, err := t.mockForwardingRules.Get(ctx, forwardingRuleKey)
+				if err != nil {
+					return err
+				}
+
+				if forwardingRule.Target != fakeGCPManagedCluster.Spec.PrivateServiceConnectEndpoint.ServiceAttachment ||
+					forwardingRule.Network != "projects/my-proj/global/networks/management" ||
+					forwardingRule.IPAddress != address.SelfLink {
+					return errors.New("forwarding rule was created but with wrong values")
+				}
+
+				return nil
+			},
+		},
+		{
+			name: "address creation fails (should return an error)",
+			mockAddresses: &cloud.MockAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+				InsertError: map[meta.Key]error{
+					*addressKey: &googleapi.Error{Code: http.StatusBadRequest},
+				},
+			},
+			mockForwardingRules: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			clusterScope := newManagedClusterScope(t)
+			s := New(clusterScope)
+			s.addresses = tt.mockAddresses
+			s.forwardingrules = tt.mockForwardingRules
+			err := s.Reconcile(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assert != nil {
+				err = tt.assert(ctx, tt)
+				if err != nil {
+					t.Errorf("endpoint was not created as expected: %v", err)
+					return
+				}
+			}
+			if !tt.wantErr && clusterScope.GCPManagedCluster.Status.PrivateServiceConnectEndpoint == nil {
+				t.Errorf("endpoint address was not recorded in the status")
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	tests := []testCase{
+		{
+			name: "endpoint does not exist, should do nothing",
+			mockAddresses: &cloud.MockAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+			},
+			mockForwardingRules: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockForwardingRulesObj{},
+			},
+		},
+		{
+			name: "endpoint not created by capg, should not delete it",
+			mockAddresses: &cloud.MockAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockAddressesObj{
+					*addressKey: {},
+				},
+				DeleteError: map[meta.Key]error{
+					*addressKey: errors.New("address should not be deleted"),
+				},
+			},
+			mockForwardingRules: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockForwardingRulesObj{
+					*forwardingRuleKey: {},
+				},
+				DeleteError: map[meta.Key]error{
+					*forwardingRuleKey: errors.New("forwarding rule should not be deleted"),
+				},
+			},
+		},
+		{
+			name: "error deleting forwarding rule, should return error",
+			mockAddresses: &cloud.MockAddresses{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockAddressesObj{},
+			},
+			mockForwardingRules: &cloud.MockForwardingRules{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockForwardingRulesObj{
+					*forwardingRuleKey: {Obj: &compute.ForwardingRule{Description: infrav1.ClusterTagKey("my-cluster")}},
+				},
+				DeleteError: map[meta.Key]error{
+					*forwardingRuleKey: &googleapi.Error{Code: http.StatusBadRequest},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(newManagedClusterScope(t))
+			s.addresses = tt.mockAddresses
+			s.forwardingrules = tt.mockForwardingRules
+			err := s.Delete(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
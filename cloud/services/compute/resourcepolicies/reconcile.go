@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcepolicies
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Reconcile reconciles the snapshot schedule resource policy of the cluster.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.SnapshotScheduleSpec()
+	if spec == nil {
+		return nil
+	}
+
+	log.Info("Reconciling snapshot schedule resources")
+	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	if _, err := s.resourcepolicies.Get(ctx, key); err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for resource policy", "name", spec.Name)
+			return err
+		}
+
+		log.V(2).Info("Creating a resource policy", "name", spec.Name)
+		if err := s.resourcepolicies.Insert(ctx, key, spec); err != nil {
+			log.Error(err, "Error creating a resource policy", "name", spec.Name)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes the snapshot schedule resource policy of the cluster if it was created by capg.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.SnapshotScheduleSpec()
+	if spec == nil {
+		return nil
+	}
+
+	log.Info("Deleting snapshot schedule resources")
+	key := meta.RegionalKey(spec.Name, s.scope.Region())
+	resourcePolicy, err := s.resourcepolicies.Get(ctx, key)
+	if err != nil {
+		if gcperrors.IsNotFound(err) {
+			return nil
+		}
+		log.Error(err, "Error looking for resource policy", "name", spec.Name)
+		return err
+	}
+
+	if resourcePolicy.Description != infrav1.ClusterTagKey(s.scope.Name()) {
+		return nil
+	}
+
+	log.V(2).Info("Deleting a resource policy", "name", spec.Name)
+	if err := s.resourcepolicies.Delete(ctx, key); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting a resource policy", "name", spec.Name)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcepolicies
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		SnapshotSchedule: &infrav1.SnapshotScheduleSpec{
+			HoursInCycle:  pointer.Int64(4),
+			RetentionDays: 7,
+		},
+	},
+}
+
+// fakeResourcePolicies stores resource policies in memory.
+type fakeResourcePolicies struct {
+	objects     map[meta.Key]*compute.ResourcePolicy
+	insertError error
+}
+
+func (f *fakeResourcePolicies) Get(_ context.Context, key *meta.Key) (*compute.ResourcePolicy, error) {
+	if obj, ok := f.objects[*key]; ok {
+		return obj, nil
+	}
+	return nil, &googleapi.Error{Code: http.StatusNotFound}
+}
+
+func (f *fakeResourcePolicies) Insert(_ context.Context, key *meta.Key, obj *compute.ResourcePolicy) error {
+	if f.insertError != nil {
+		return f.insertError
+	}
+	f.objects[*key] = obj
+	return nil
+}
+
+func (f *fakeResourcePolicies) Delete(_ context.Context, key *meta.Key) error {
+	if _, ok := f.objects[*key]; !ok {
+		return &googleapi.Error{Code: http.StatusNotFound}
+	}
+	delete(f.objects, *key)
+	return nil
+}
+
+func TestService_Reconcile(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := meta.RegionalKey("my-cluster-snapshot-schedule", "us-central1")
+	tests := []struct {
+		name             string
+		resourcepolicies *fakeResourcePolicies
+		wantErr          bool
+	}{
+		{
+			name: "resource policy already exist (should do nothing)",
+			resourcepolicies: &fakeResourcePolicies{
+				objects: map[meta.Key]*compute.ResourcePolicy{
+					*key: {Name: "my-cluster-snapshot-schedule"},
+				},
+			},
+		},
+		{
+			name: "resource policy does not exist (should create resource policy)",
+			resourcepolicies: &fakeResourcePolicies{
+				objects: map[meta.Key]*compute.ResourcePolicy{},
+			},
+		},
+		{
+			name: "resource policy creation fails (should return an error)",
+			resourcepolicies: &fakeResourcePolicies{
+				objects:     map[meta.Key]*compute.ResourcePolicy{},
+				insertError: &googleapi.Error{Code: http.StatusBadRequest},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(clusterScope)
+			s.resourcepolicies = tt.resourcepolicies
+			err := s.Reconcile(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			policy, ok := tt.resourcepolicies.objects[*key]
+			if !ok {
+				t.Errorf("resource policy was not created")
+				return
+			}
+			if policy.SnapshotSchedulePolicy != nil {
+				if got := policy.SnapshotSchedulePolicy.Schedule.HourlySchedule.HoursInCycle; got != 4 {
+					t.Errorf("resource policy hours in cycle = %d, want 4", got)
+				}
+				if got := policy.SnapshotSchedulePolicy.RetentionPolicy.MaxRetentionDays; got != 7 {
+					t.Errorf("resource policy retention days = %d, want 7", got)
+				}
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key := meta.RegionalKey("my-cluster-snapshot-schedule", "us-central1")
+	tests := []struct {
+		name             string
+		resourcepolicies *fakeResourcePolicies
+		wantDeleted      bool
+	}{
+		{
+			name: "resource policy does not exist (should do nothing)",
+			resourcepolicies: &fakeResourcePolicies{
+				objects: map[meta.Key]*compute.ResourcePolicy{},
+			},
+			wantDeleted: true,
+		},
+		{
+			name: "resource policy created by capg (should delete resource policy)",
+			resourcepolicies: &fakeResourcePolicies{
+				objects: map[meta.Key]*compute.ResourcePolicy{
+					*key: {Name: "my-cluster-snapshot-schedule", Description: infrav1.ClusterTagKey("my-cluster")},
+				},
+			},
+			wantDeleted: true,
+		},
+		{
+			name: "resource policy not created by capg (should keep resource policy)",
+			resourcepolicies: &fakeResourcePolicies{
+				objects: map[meta.Key]*compute.ResourcePolicy{
+					*key: {Name: "my-cluster-snapshot-schedule"},
+				},
+			},
+			wantDeleted: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(clusterScope)
+			s.resourcepolicies = tt.resourcepolicies
+			if err := s.Delete(ctx); err != nil {
+				t.Errorf("Service.Delete() error = %v", err)
+				return
+			}
+			if _, ok := tt.resourcepolicies.objects[*key]; ok == tt.wantDeleted {
+				t.Errorf("resource policy deleted = %v, want %v", !ok, tt.wantDeleted)
+			}
+		})
+	}
+}
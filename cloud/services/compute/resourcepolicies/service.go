@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resourcepolicies
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
+)
+
+type resourcepoliciesInterface interface {
+	Get(ctx context.Context, key *meta.Key) (*compute.ResourcePolicy, error)
+	Insert(ctx context.Context, key *meta.Key, obj *compute.ResourcePolicy) error
+	Delete(ctx context.Context, key *meta.Key) error
+}
+
+// Scope is an interfaces that hold used methods.
+type Scope interface {
+	cloud.Cluster
+	ComputeService() *compute.Service
+	SnapshotScheduleSpec() *compute.ResourcePolicy
+}
+
+// Service implements resource policies reconciler.
+type Service struct {
+	scope            Scope
+	resourcepolicies resourcepoliciesInterface
+}
+
+var _ cloud.Reconciler = &Service{}
+
+// New returns Service from given scope.
+func New(scope Scope) *Service {
+	return &Service{
+		scope: scope,
+		resourcepolicies: &resourcePolicies{
+			project: scope.Project(),
+			service: scope.ComputeService(),
+		},
+	}
+}
+
+// resourcePolicies implements resourcepoliciesInterface on top of the compute API, as resource policies
+// are not covered by the cloud provider library.
+type resourcePolicies struct {
+	project string
+	service *compute.Service
+}
+
+// Get returns the regional resource policy of the given key.
+func (r *resourcePolicies) Get(ctx context.Context, key *meta.Key) (*compute.ResourcePolicy, error) {
+	return r.service.ResourcePolicies.Get(r.project, key.Region, key.Name).Context(ctx).Do()
+}
+
+// Insert creates a regional resource policy and waits for the operation to complete.
+func (r *resourcePolicies) Insert(ctx context.Context, key *meta.Key, obj *compute.ResourcePolicy) error {
+	obj.Name = key.Name
+	op, err := r.service.ResourcePolicies.Insert(r.project, key.Region, obj).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	return r.waitForOperation(ctx, key.Region, op)
+}
+
+// Delete deletes a regional resource policy and waits for the operation to complete.
+func (r *resourcePolicies) Delete(ctx context.Context, key *meta.Key) error {
+	op, err := r.service.ResourcePolicies.Delete(r.project, key.Region, key.Name).Context(ctx).Do()
+	if err != nil {
+		return err
+	}
+
+	return r.waitForOperation(ctx, key.Region, op)
+}
+
+func (r *resourcePolicies) waitForOperation(ctx context.Context, region string, op *compute.Operation) error {
+	err := wait.PollUntilContextTimeout(ctx, time.Second, 5*time.Minute, true, func(ctx context.Context) (bool, error) {
+		if op.Status == "DONE" {
+			return true, nil
+		}
+
+		var err error
+		op, err = r.service.RegionOperations.Wait(r.project, region, op.Name).Context(ctx).Do()
+		return false, err
+	})
+	if err != nil {
+		return err
+	}
+
+	if op.Error != nil && len(op.Error.Errors) > 0 {
+		return errors.Errorf("operation %s failed: %s", op.Name, op.Error.Errors[0].Message)
+	}
+
+	return nil
+}
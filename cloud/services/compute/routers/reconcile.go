@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routers
+
+import (
+	"context"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Reconcile reconciles the cloud router of the cluster network.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.RouterSpec()
+	if spec == nil || s.scope.IsSharedVpc() {
+		return nil
+	}
+
+	log.Info("Reconciling router resources")
+	router, err := s.createOrGetRouter(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	if router.Description == infrav1.ClusterTagKey(s.scope.Name()) && spec.Bgp != nil && !bgpEqual(router.Bgp, spec.Bgp) {
+		log.V(2).Info("Updating router BGP configuration", "name", spec.Name)
+		routerKey := meta.RegionalKey(spec.Name, s.scope.Region())
+		if err := s.routers.Patch(ctx, routerKey, &compute.Router{Bgp: spec.Bgp}); err != nil {
+			log.Error(err, "Error updating router", "name", spec.Name)
+			return err
+		}
+	}
+
+	s.scope.Network().Router = pointer.String(router.SelfLink)
+	return nil
+}
+
+// Delete deletes the cloud router of the cluster network if it was created by capg.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.RouterSpec()
+	if spec == nil || s.scope.IsSharedVpc() {
+		return nil
+	}
+
+	log.Info("Deleting router resources")
+	routerKey := meta.RegionalKey(spec.Name, s.scope.Region())
+	log.V(2).Info("Looking for router before deleting", "name", spec.Name)
+	router, err := s.routers.Get(ctx, routerKey)
+	if err != nil {
+		return gcperrors.IgnoreNotFound(err)
+	}
+
+	if router.Description != infrav1.ClusterTagKey(s.scope.Name()) {
+		return nil
+	}
+
+	log.V(2).Info("Deleting a router", "name", spec.Name)
+	if err := s.routers.Delete(ctx, routerKey); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting a router", "name", spec.Name)
+		return err
+	}
+
+	s.scope.Network().Router = nil
+	return nil
+}
+
+// createOrGetRouter creates a router if not exist otherwise return the existing one.
+func (s *Service) createOrGetRouter(ctx context.Context, spec *compute.Router) (*compute.Router, error) {
+	log := log.FromContext(ctx)
+	log.V(2).Info("Looking for router", "name", spec.Name)
+	routerKey := meta.RegionalKey(spec.Name, s.scope.Region())
+	router, err := s.routers.Get(ctx, routerKey)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for router", "name", spec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating a router", "name", spec.Name)
+		if err := s.routers.Insert(ctx, routerKey, spec); err != nil {
+			log.Error(err, "Error creating a router", "name", spec.Name)
+			return nil, err
+		}
+
+		router, err = s.routers.Get(ctx, routerKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return router, nil
+}
+
+// bgpEqual returns true if the existing BGP configuration of the router matches the desired one.
+func bgpEqual(existing, desired *compute.RouterBgp) bool {
+	if existing == nil {
+		return false
+	}
+
+	return existing.Asn == desired.Asn &&
+		existing.AdvertiseMode == desired.AdvertiseMode &&
+		cmp.Equal(existing.AdvertisedGroups, desired.AdvertisedGroups, cmpopts.EquateEmpty()) &&
+		cmp.Equal(existing.AdvertisedIpRanges, desired.AdvertisedIpRanges, cmpopts.EquateEmpty())
+}
@@ -0,0 +1,232 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Name: pointer.String("my-network"),
+			Router: &infrav1.RouterSpec{
+				ASN: pointer.Int64(64514),
+				AdvertisedIPRanges: []infrav1.RouterAdvertisedIPRange{
+					{Range: "10.100.0.0/16"},
+				},
+			},
+		},
+	},
+}
+
+var routerKey = meta.RegionalKey("my-network-router", "us-central1")
+
+type testCase struct {
+	name        string
+	scope       func() Scope
+	mockRouters *cloud.MockRouters
+	wantErr     bool
+	assert      func(ctx context.Context, t testCase) error
+}
+
+func TestService_Reconcile(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []testCase{
+		{
+			name:  "router already exist (should return existing router)",
+			scope: func() Scope { return clusterScope },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutersObj{
+					*routerKey: {},
+				},
+			},
+		},
+		{
+			name:  "error getting router with non 404 error code (should return an error)",
+			scope: func() Scope { return clusterScope },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutersObj{},
+				GetHook: func(ctx context.Context, key *meta.Key, m *cloud.MockRouters) (bool, *compute.Router, error) {
+					return true, &compute.Router{}, &googleapi.Error{Code: http.StatusBadRequest}
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name:  "router does not exist (should create router)",
+			scope: func() Scope { return clusterScope },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutersObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				router, err := t.mockRouters.Get(ctx, routerKey)
+				if err != nil {
+					return err
+				}
+
+				if router.Bgp == nil ||
+					router.Bgp.Asn != 64514 ||
+					router.Bgp.AdvertiseMode != "CUSTOM" ||
+					len(router.Bgp.AdvertisedIpRanges) != 1 ||
+					router.Bgp.AdvertisedIpRanges[0].Range != "10.100.0.0/16" {
+					return errors.New("router was created but with wrong values")
+				}
+
+				return nil
+			},
+		},
+		{
+			name:  "router creation fails (should return an error)",
+			scope: func() Scope { return clusterScope },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutersObj{},
+				InsertError: map[meta.Key]error{
+					*routerKey: &googleapi.Error{Code: http.StatusBadRequest},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(tt.scope())
+			s.routers = tt.mockRouters
+			err := s.Reconcile(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assert != nil {
+				err = tt.assert(ctx, tt)
+				if err != nil {
+					t.Errorf("router was not created as expected: %v", err)
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []testCase{
+		{
+			name:  "router does not exist, should do nothing",
+			scope: func() Scope { return clusterScope },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutersObj{},
+			},
+		},
+		{
+			name:  "error deleting router, should return error",
+			scope: func() Scope { return clusterScope },
+			mockRouters: &cloud.MockRouters{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutersObj{
+					*routerKey: {Obj: &compute.Router{Description: infrav1.ClusterTagKey(fakeCluster.Name)}},
+				},
+				DeleteError: map[meta.Key]error{
+					*routerKey: &googleapi.Error{Code: http.StatusBadRequest},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(tt.scope())
+			s.routers = tt.mockRouters
+			err := s.Delete(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+		})
+	}
+}
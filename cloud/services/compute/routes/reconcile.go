@@ -0,0 +1,155 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routes
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"google.golang.org/api/compute/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Reconcile reconciles the custom routes of the cluster network. Routes cannot be updated, so changed routes
+// are recreated, and the routes created by capg that are no longer configured are deleted.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	if s.scope.IsSharedVpc() {
+		return nil
+	}
+
+	log.Info("Reconciling route resources")
+	existing, err := s.listRoutes(ctx)
+	if err != nil {
+		return err
+	}
+
+	desired := make(map[string]bool)
+	for _, spec := range s.scope.RouteSpecs() {
+		desired[spec.Name] = true
+		route, ok := existing[spec.Name]
+		if ok && !routeChanged(route, spec) {
+			continue
+		}
+
+		if ok {
+			log.V(2).Info("Recreating a changed route", "name", spec.Name)
+			if err := s.deleteRoute(ctx, spec.Name); err != nil {
+				return err
+			}
+		}
+
+		log.V(2).Info("Creating a route", "name", spec.Name)
+		if err := s.routes.Insert(ctx, meta.GlobalKey(spec.Name), spec); err != nil {
+			log.Error(err, "Error creating a route", "name", spec.Name)
+			return err
+		}
+	}
+
+	for name := range existing {
+		if desired[name] {
+			continue
+		}
+
+		if err := s.deleteRoute(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete deletes the routes of the cluster network created by capg.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	if s.scope.IsSharedVpc() {
+		return nil
+	}
+
+	log.Info("Deleting route resources")
+	existing, err := s.listRoutes(ctx)
+	if err != nil {
+		return err
+	}
+
+	for name := range existing {
+		if err := s.deleteRoute(ctx, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// listRoutes returns the routes created by capg for the cluster, by name.
+func (s *Service) listRoutes(ctx context.Context) (map[string]*compute.Route, error) {
+	log := log.FromContext(ctx)
+	fl := filter.Regexp("description", fmt.Sprintf("^%s$", regexp.QuoteMeta(infrav1.ClusterTagKey(s.scope.Name()))))
+	routes, err := s.routes.List(ctx, fl)
+	if err != nil {
+		log.Error(err, "Error listing routes")
+		return nil, err
+	}
+
+	res := make(map[string]*compute.Route, len(routes))
+	for _, route := range routes {
+		res[route.Name] = route
+	}
+
+	return res, nil
+}
+
+func (s *Service) deleteRoute(ctx context.Context, name string) error {
+	log := log.FromContext(ctx)
+	log.V(2).Info("Deleting a route", "name", name)
+	if err := s.routes.Delete(ctx, meta.GlobalKey(name)); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting a route", "name", name)
+		return err
+	}
+
+	return nil
+}
+
+// routeChanged returns true if the existing route does not match the desired one. The next hops of existing
+// routes are full URLs, so they are compared by suffix, and the next hop IP is only compared when it is set,
+// as GCP may fill it in for other next hop types.
+func routeChanged(existing, desired *compute.Route) bool {
+	return existing.DestRange != desired.DestRange ||
+		existing.Priority != desired.Priority ||
+		!cmp.Equal(existing.Tags, desired.Tags, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b })) ||
+		!sameResource(existing.NextHopInstance, desired.NextHopInstance) ||
+		!sameResource(existing.NextHopIlb, desired.NextHopIlb) ||
+		!sameResource(existing.NextHopGateway, desired.NextHopGateway) ||
+		(desired.NextHopIp != "" && existing.NextHopIp != desired.NextHopIp)
+}
+
+// sameResource returns true if the partial or full URLs reference the same resource.
+func sameResource(existing, desired string) bool {
+	if existing == "" || desired == "" {
+		return existing == desired
+	}
+
+	return strings.HasSuffix(existing, desired) || strings.HasSuffix(desired, existing)
+}
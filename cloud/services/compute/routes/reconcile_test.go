@@ -0,0 +1,306 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routes
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud"
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/googleapi"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func init() {
+	_ = clusterv1.AddToScheme(scheme.Scheme)
+	_ = infrav1.AddToScheme(scheme.Scheme)
+}
+
+var fakeCluster = &clusterv1.Cluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: clusterv1.ClusterSpec{},
+}
+
+var fakeGCPCluster = &infrav1.GCPCluster{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "my-cluster",
+		Namespace: "default",
+	},
+	Spec: infrav1.GCPClusterSpec{
+		Project: "my-proj",
+		Region:  "us-central1",
+		Network: infrav1.NetworkSpec{
+			Name: pointer.String("my-network"),
+			Routes: infrav1.Routes{
+				{
+					Name:            "egress",
+					DestRange:       "0.0.0.0/0",
+					Priority:        pointer.Int64(800),
+					Tags:            []string{"nat-egress"},
+					NextHopInstance: pointer.String("zones/us-central1-a/instances/nat-appliance"),
+				},
+			},
+		},
+	},
+}
+
+var ownerDescription = infrav1.ClusterTagKey(fakeCluster.Name)
+
+var egressRoute = &compute.Route{
+	Name:            "egress",
+	Description:     ownerDescription,
+	DestRange:       "0.0.0.0/0",
+	Priority:        800,
+	Tags:            []string{"nat-egress"},
+	NextHopInstance: "https://www.googleapis.com/compute/v1/projects/my-proj/zones/us-central1-a/instances/nat-appliance",
+}
+
+type testCase struct {
+	name       string
+	scope      func() Scope
+	mockRoutes *cloud.MockRoutes
+	wantErr    bool
+	assert     func(ctx context.Context, t testCase) error
+}
+
+func TestService_Reconcile(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []testCase{
+		{
+			name:  "route does not exist (should create route)",
+			scope: func() Scope { return clusterScope },
+			mockRoutes: &cloud.MockRoutes{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutesObj{},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				route, err := t.mockRoutes.Get(ctx, meta.GlobalKey("egress"))
+				if err != nil {
+					return err
+				}
+
+				if route.Description != ownerDescription ||
+					route.Network != "projects/my-proj/global/networks/my-network" ||
+					route.Priority != 800 ||
+					route.NextHopInstance != "projects/my-proj/zones/us-central1-a/instances/nat-appliance" {
+					return fmt.Errorf("route was created with wrong values: %+v", route)
+				}
+
+				return nil
+			},
+		},
+		{
+			name:  "route is up to date (should not recreate route)",
+			scope: func() Scope { return clusterScope },
+			mockRoutes: &cloud.MockRoutes{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutesObj{
+					*meta.GlobalKey("egress"): {Obj: egressRoute},
+				},
+				DeleteError: map[meta.Key]error{
+					*meta.GlobalKey("egress"): &googleapi.Error{Code: http.StatusBadRequest},
+				},
+			},
+		},
+		{
+			name:  "route changed (should recreate route)",
+			scope: func() Scope { return clusterScope },
+			mockRoutes: &cloud.MockRoutes{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutesObj{
+					*meta.GlobalKey("egress"): {Obj: &compute.Route{
+						Name:           "egress",
+						Description:    ownerDescription,
+						DestRange:      "0.0.0.0/0",
+						Priority:       1000,
+						NextHopGateway: "https://www.googleapis.com/compute/v1/projects/my-proj/global/gateways/default-internet-gateway",
+					}},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				route, err := t.mockRoutes.Get(ctx, meta.GlobalKey("egress"))
+				if err != nil {
+					return err
+				}
+
+				if route.Priority != 800 || route.NextHopGateway != "" || route.NextHopInstance == "" {
+					return fmt.Errorf("route was not recreated: %+v", route)
+				}
+
+				return nil
+			},
+		},
+		{
+			name:  "route is no longer configured (should delete owned routes only)",
+			scope: func() Scope { return clusterScope },
+			mockRoutes: &cloud.MockRoutes{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutesObj{
+					*meta.GlobalKey("egress"):  {Obj: egressRoute},
+					*meta.GlobalKey("removed"): {Obj: &compute.Route{Name: "removed", Description: ownerDescription}},
+					*meta.GlobalKey("foreign"): {Obj: &compute.Route{Name: "foreign", Description: "created by someone else"}},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockRoutes.Get(ctx, meta.GlobalKey("removed")); err == nil {
+					return fmt.Errorf("route removed from the spec was not deleted")
+				}
+
+				if _, err := t.mockRoutes.Get(ctx, meta.GlobalKey("foreign")); err != nil {
+					return fmt.Errorf("route not created by capg was deleted: %w", err)
+				}
+
+				return nil
+			},
+		},
+		{
+			name:  "route creation fails (should return an error)",
+			scope: func() Scope { return clusterScope },
+			mockRoutes: &cloud.MockRoutes{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutesObj{},
+				InsertError: map[meta.Key]error{
+					*meta.GlobalKey("egress"): &googleapi.Error{Code: http.StatusBadRequest},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(tt.scope())
+			s.routes = tt.mockRoutes
+			err := s.Reconcile(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Reconcile() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assert != nil {
+				err = tt.assert(ctx, tt)
+				if err != nil {
+					t.Errorf("routes were not reconciled as expected: %v", err)
+					return
+				}
+			}
+		})
+	}
+}
+
+func TestService_Delete(t *testing.T) {
+	fakec := fake.NewClientBuilder().
+		WithScheme(scheme.Scheme).
+		Build()
+
+	clusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: fakeGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []testCase{
+		{
+			name:  "routes do not exist, should do nothing",
+			scope: func() Scope { return clusterScope },
+			mockRoutes: &cloud.MockRoutes{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockRoutesObj{},
+			},
+		},
+		{
+			name:  "owned route exists, should delete it",
+			scope: func() Scope { return clusterScope },
+			mockRoutes: &cloud.MockRoutes{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutesObj{
+					*meta.GlobalKey("egress"): {Obj: egressRoute},
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				if _, err := t.mockRoutes.Get(ctx, meta.GlobalKey("egress")); err == nil {
+					return fmt.Errorf("route was not deleted")
+				}
+				return nil
+			},
+		},
+		{
+			name:  "error deleting route, should return error",
+			scope: func() Scope { return clusterScope },
+			mockRoutes: &cloud.MockRoutes{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockRoutesObj{
+					*meta.GlobalKey("egress"): {Obj: egressRoute},
+				},
+				DeleteError: map[meta.Key]error{
+					*meta.GlobalKey("egress"): &googleapi.Error{Code: http.StatusBadRequest},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.TODO()
+			s := New(tt.scope())
+			s.routes = tt.mockRoutes
+			err := s.Delete(ctx)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Service.Delete() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.assert != nil {
+				if err := tt.assert(ctx, tt); err != nil {
+					t.Errorf("routes were not deleted as expected: %v", err)
+				}
+			}
+		})
+	}
+}
@@ -28,19 +28,42 @@ import (
 // Reconcile reconcile cluster network components.
 func (s *Service) Reconcile(ctx context.Context) error {
 	logger := log.FromContext(ctx)
+	if s.scope.IsSharedVpc() {
+		logger.V(2).Info("Shared VPC enabled, skipping subnetwork reconciliation", "project", s.scope.NetworkProject())
+		return nil
+	}
+
 	logger.Info("Reconciling subnetwork resources")
 
 	// reconcile subnets
-	if _, err := s.createOrGetSubnets(ctx); err != nil {
+	subnets, err := s.createOrGetSubnets(ctx)
+	if err != nil {
 		return err
 	}
 
+	subnetLinks := make(map[string]string, len(subnets))
+	for _, subnet := range subnets {
+		subnetLinks[subnet.Name] = subnet.SelfLink
+	}
+	s.scope.Network().Subnets = subnetLinks
+
 	return nil
 }
 
 // Delete deletes cluster subnetwork components.
 func (s *Service) Delete(ctx context.Context) error {
 	logger := log.FromContext(ctx)
+	if s.scope.IsSharedVpc() {
+		logger.V(2).Info("Shared VPC enabled, skipping subnetwork deletion", "project", s.scope.NetworkProject())
+		return nil
+	}
+
+	if s.scope.IsExistingNetwork() {
+		logger.V(2).Info("Existing subnetworks are not managed, skipping subnetwork deletion")
+		s.scope.Network().Subnets = nil
+		return nil
+	}
+
 	for _, subnetSpec := range s.scope.SubnetSpecs() {
 		logger.V(2).Info("Deleting a subnet", "name", subnetSpec.Name)
 		subnetKey := meta.RegionalKey(subnetSpec.Name, s.scope.Region())
@@ -51,6 +74,7 @@ func (s *Service) Delete(ctx context.Context) error {
 		}
 	}
 
+	s.scope.Network().Subnets = nil
 	return nil
 }
 
@@ -68,6 +92,11 @@ func (s *Service) createOrGetSubnets(ctx context.Context) ([]*compute.Subnetwork
 				return subnets, err
 			}
 
+			if s.scope.IsExistingNetwork() {
+				logger.Error(err, "Existing subnet not found", "name", subnetSpec.Name)
+				return subnets, err
+			}
+
 			// Subnet was not found, let's create it
 			logger.V(2).Info("Creating a subnet", "name", subnetSpec.Name)
 			if err := s.subnets.Insert(ctx, subnetKey, subnetSpec); err != nil {
@@ -81,6 +110,21 @@ func (s *Service) createOrGetSubnets(ctx context.Context) ([]*compute.Subnetwork
 				return subnets, err
 			}
 		}
+
+		// Private Google access is only ever enabled on existing subnets, so that access configured out of band
+		// is not revoked.
+		if !s.scope.IsExistingNetwork() && subnetSpec.PrivateIpGoogleAccess && !subnet.PrivateIpGoogleAccess {
+			logger.V(2).Info("Enabling private Google access on subnet", "name", subnetSpec.Name)
+			patch := &compute.Subnetwork{
+				PrivateIpGoogleAccess: true,
+				Fingerprint:           subnet.Fingerprint,
+			}
+			if err := s.subnets.Patch(ctx, subnetKey, patch); err != nil {
+				logger.Error(err, "Error enabling private Google access on subnet", "name", subnetSpec.Name)
+				return subnets, err
+			}
+			subnet.PrivateIpGoogleAccess = true
+		}
 		subnets = append(subnets, subnet)
 	}
 
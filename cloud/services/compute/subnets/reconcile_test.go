@@ -94,6 +94,35 @@ func TestService_Reconcile(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	existingNetworkGCPCluster := fakeGCPCluster.DeepCopy()
+	existingNetworkGCPCluster.Spec.Network.UseExisting = pointer.Bool(true)
+	existingNetworkClusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: existingNetworkGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateAccessGCPCluster := fakeGCPCluster.DeepCopy()
+	privateAccessGCPCluster.Spec.Network.Subnets[0].Purpose = nil
+	privateAccessGCPCluster.Spec.Network.Subnets[0].PrivateGoogleAccess = pointer.Bool(true)
+	privateAccessClusterScope, err := scope.NewClusterScope(context.TODO(), scope.ClusterScopeParams{
+		Client:     fakec,
+		Cluster:    fakeCluster,
+		GCPCluster: privateAccessGCPCluster,
+		GCPServices: scope.GCPServices{
+			Compute: &compute.Service{},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	tests := []testCase{
 		{
 			name:  "subnet already exist (should return existing subnet)",
@@ -140,6 +169,53 @@ func TestService_Reconcile(t *testing.T) {
 				return nil
 			},
 		},
+		{
+			name:  "subnet exists without private Google access (should enable it)",
+			scope: func() Scope { return privateAccessClusterScope },
+			mockSubnetworks: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects: map[meta.Key]*cloud.MockSubnetworksObj{
+					*meta.RegionalKey(fakeGCPCluster.Spec.Network.Subnets[0].Name, fakeGCPCluster.Spec.Region): {},
+				},
+				PatchHook: func(ctx context.Context, key *meta.Key, obj *compute.Subnetwork, m *cloud.MockSubnetworks) error {
+					if !obj.PrivateIpGoogleAccess {
+						return errors.New("private Google access was not enabled")
+					}
+					m.Objects[*key] = &cloud.MockSubnetworksObj{Obj: obj}
+					return nil
+				},
+			},
+			assert: func(ctx context.Context, t testCase) error {
+				key := meta.RegionalKey(fakeGCPCluster.Spec.Network.Subnets[0].Name, fakeGCPCluster.Spec.Region)
+				subnet, err := t.mockSubnetworks.Get(ctx, key)
+				if err != nil {
+					return err
+				}
+
+				if !subnet.PrivateIpGoogleAccess {
+					return errors.New("subnet was not patched")
+				}
+
+				return nil
+			},
+		},
+		{
+			name:  "subnet does not exist in existing network (should return an error without creating it)",
+			scope: func() Scope { return existingNetworkClusterScope },
+			mockSubnetworks: &cloud.MockSubnetworks{
+				ProjectRouter: &cloud.SingleProjectRouter{ID: "my-proj"},
+				Objects:       map[meta.Key]*cloud.MockSubnetworksObj{},
+			},
+			wantErr: true,
+			assert: func(ctx context.Context, t testCase) error {
+				key := meta.RegionalKey(fakeGCPCluster.Spec.Network.Subnets[0].Name, fakeGCPCluster.Spec.Region)
+				if _, err := t.mockSubnetworks.Get(ctx, key); err == nil {
+					return errors.New("subnet was created in an existing network")
+				}
+
+				return nil
+			},
+		},
 		{
 			name:  "subnet creation fails (should return an error)",
 			scope: func() Scope { return clusterScope },
@@ -27,6 +27,7 @@ import (
 type subnetsInterface interface {
 	Get(ctx context.Context, key *meta.Key) (*compute.Subnetwork, error)
 	Insert(ctx context.Context, key *meta.Key, obj *compute.Subnetwork) error
+	Patch(ctx context.Context, key *meta.Key, obj *compute.Subnetwork) error
 	Delete(ctx context.Context, key *meta.Key) error
 }
 
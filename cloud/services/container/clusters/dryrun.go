@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"strings"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+)
+
+// isDryRun returns true if the changes to the GKE cluster are only reported.
+func (s *Service) isDryRun() bool {
+	_, ok := s.scope.GCPManagedControlPlane.Annotations[infrav1exp.DryRunClusterAnnotation]
+	return ok
+}
+
+// reconcileDryRun reports the calls creating or updating the GKE cluster that reconciling it would make.
+func (s *Service) reconcileDryRun(ctx context.Context, cluster *containerpb.Cluster, log *logr.Logger) (ctrl.Result, error) {
+	var mutations []string
+	if cluster == nil {
+		nodePools, machinePools, err := s.scope.GetAllNodePools(ctx)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+
+		// Generated node pool names are not persisted in dry-run mode, they change until the cluster is created.
+		plannedNodePools := make([]infrav1exp.GCPManagedMachinePool, len(nodePools))
+		for i := range nodePools {
+			nodePools[i].DeepCopyInto(&plannedNodePools[i])
+			plannedNodePools[i].EnsureNodePoolName()
+		}
+
+		createClusterRequest, err := s.newCreateClusterRequest(plannedNodePools, machinePools, log)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		mutations = append(mutations, shared.FormatMutation("CreateCluster", createClusterRequest))
+	} else {
+		if !v1beta1.Labels(cluster.ResourceLabels).HasOwned(s.scope.Cluster.Name) {
+			mutations = append(mutations, shared.FormatMutation("SetLabels", s.newSetLabelsRequest(cluster)))
+		}
+		if needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(cluster, log); needUpdate {
+			mutations = append(mutations, shared.FormatMutation("UpdateCluster", updateClusterRequest))
+		}
+	}
+
+	return s.reportDryRun(log, mutations)
+}
+
+// reportDryRun reports the calls to GCP skipped in dry-run mode in the GKEControlPlaneDryRun condition and in events.
+func (s *Service) reportDryRun(log *logr.Logger, mutations []string) (ctrl.Result, error) {
+	result := ctrl.Result{RequeueAfter: reconciler.ResyncInterval(s.scope.GCPManagedControlPlane)}
+
+	if len(mutations) == 0 {
+		log.Info("Dry run, GKE cluster is up to date")
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneDryRunCondition)
+		return result, nil
+	}
+
+	for _, mutation := range mutations {
+		log.Info("Dry run, skipping call to GCP", "call", mutation)
+		record.Eventf(s.scope.GCPManagedControlPlane, "GKEClusterDryRun", "Skipped %s", mutation)
+	}
+	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneDryRunCondition, infrav1exp.GKEControlPlaneChangesPlannedReason, clusterv1.ConditionSeverityInfo,
+		"%s", strings.Join(mutations, "; "))
+
+	return result, nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/internal/gketest"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestReconcileDryRun(t *testing.T) {
+	runningCluster := func(labels map[string]string) *containerpb.Cluster {
+		return &containerpb.Cluster{
+			Name:           "my-gke-cluster",
+			Status:         containerpb.Cluster_RUNNING,
+			ResourceLabels: labels,
+			ReleaseChannel: &containerpb.ReleaseChannel{},
+			MasterAuthorizedNetworksConfig: &containerpb.MasterAuthorizedNetworksConfig{
+				GcpPublicCidrsAccessEnabled: new(bool),
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		cluster   *containerpb.Cluster
+		wantCalls []string
+	}{
+		{
+			name:      "cluster to create",
+			wantCalls: []string{"CreateCluster"},
+		},
+		{
+			name:      "cluster to label",
+			cluster:   runningCluster(map[string]string{"team": "a"}),
+			wantCalls: []string{"SetLabels"},
+		},
+		{
+			name:    "cluster up to date",
+			cluster: runningCluster(map[string]string{"capg-cluster-my-cluster": "owned"}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, gkeClient := gketest.NewServer(t)
+			controlPlane := &infrav1exp.GCPManagedControlPlane{}
+			controlPlane.Annotations = map[string]string{infrav1exp.DryRunClusterAnnotation: ""}
+			s := gketest.NewManagedControlPlaneScope(t, gkeClient, controlPlane)
+			log := logr.Discard()
+
+			if _, err := New(s).reconcileDryRun(context.Background(), tt.cluster, &log); err != nil {
+				t.Fatalf("reconcileDryRun() error = %v", err)
+			}
+
+			if requests := server.Requests(); len(requests) != 0 {
+				t.Errorf("calls made in dry-run mode: %v", requests)
+			}
+			dryRun := conditions.Get(s.GCPManagedControlPlane, infrav1exp.GKEControlPlaneDryRunCondition)
+			if dryRun == nil {
+				t.Fatal("dry run not reported")
+			}
+			var calls []string
+			for _, mutation := range strings.Split(dryRun.Message, "; ") {
+				if call, _, ok := strings.Cut(mutation, " "); ok {
+					calls = append(calls, call)
+				}
+			}
+			if strings.Join(calls, ",") != strings.Join(tt.wantCalls, ",") {
+				t.Errorf("reported calls = %v, want %v (%s)", calls, tt.wantCalls, dryRun.Message)
+			}
+		})
+	}
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package clusters
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
+	"strings"
+	"time"
 
 	"cloud.google.com/go/container/apiv1/containerpb"
 	"github.com/go-logr/logr"
@@ -31,6 +34,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	"sigs.k8s.io/cluster-api/util/kubeconfig"
 	"sigs.k8s.io/cluster-api/util/secret"
 )
@@ -40,7 +44,8 @@ const (
 	GkeScope = "https://www.googleapis.com/auth/cloud-platform"
 )
 
-func (s *Service) reconcileKubeconfig(ctx context.Context, cluster *containerpb.Cluster, log *logr.Logger) error {
+// reconcileKubeconfig creates or refreshes the CAPI kubeconfig and returns the expiry of its token.
+func (s *Service) reconcileKubeconfig(ctx context.Context, cluster *containerpb.Cluster, log *logr.Logger) (time.Time, error) {
 	log.Info("Reconciling kubeconfig")
 	clusterRef := types.NamespacedName{
 		Name:      s.scope.Cluster.Name,
@@ -51,23 +56,44 @@ func (s *Service) reconcileKubeconfig(ctx context.Context, cluster *containerpb.
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			log.Error(err, "getting kubeconfig secret", "name", clusterRef)
-			return fmt.Errorf("getting kubeconfig secret %s: %w", clusterRef, err)
+			return time.Time{}, fmt.Errorf("getting kubeconfig secret %s: %w", clusterRef, err)
 		}
 		log.Info("kubeconfig secret not found, creating")
 
-		if createErr := s.createCAPIKubeconfigSecret(
+		expiry, createErr := s.createCAPIKubeconfigSecret(
 			ctx,
 			cluster,
 			&clusterRef,
 			log,
-		); createErr != nil {
-			return fmt.Errorf("creating kubeconfig secret: %w", createErr)
+		)
+		if createErr != nil {
+			return time.Time{}, fmt.Errorf("creating kubeconfig secret: %w", createErr)
 		}
-	} else if updateErr := s.updateCAPIKubeconfigSecret(ctx, configSecret); updateErr != nil {
-		return fmt.Errorf("updating kubeconfig secret: %w", err)
+		return expiry, nil
 	}
 
-	return nil
+	expiry, updateErr := s.updateCAPIKubeconfigSecret(ctx, cluster, configSecret)
+	if updateErr != nil {
+		return time.Time{}, fmt.Errorf("updating kubeconfig secret: %w", updateErr)
+	}
+
+	return expiry, nil
+}
+
+// tokenRefreshAfter returns the time after which the token of the CAPI kubeconfig has to be refreshed, the
+// refresh window before its expiry, or zero if the token does not expire. A token that is already within the
+// refresh window is retried after the default retry time rather than in a hot loop.
+func tokenRefreshAfter(expiry, now time.Time, window time.Duration) time.Duration {
+	if expiry.IsZero() {
+		return 0
+	}
+
+	refreshAfter := expiry.Sub(now) - window
+	if refreshAfter < reconciler.DefaultRetryTime {
+		return reconciler.DefaultRetryTime
+	}
+
+	return refreshAfter
 }
 
 func (s *Service) reconcileAdditionalKubeconfigs(ctx context.Context, cluster *containerpb.Cluster, log *logr.Logger) error {
@@ -77,8 +103,9 @@ func (s *Service) reconcileAdditionalKubeconfigs(ctx context.Context, cluster *c
 		Namespace: s.scope.Cluster.Namespace,
 	}
 
-	// Create the additional kubeconfig for users. This doesn't need updating on every sync
-	_, err := secret.GetFromNamespacedName(ctx, s.scope.Client(), clusterRef, secret.Kubeconfig)
+	// Create the additional kubeconfig for users. It authenticates with the exec plugin rather than a token, so
+	// it only needs updating when the endpoint or the CA of the cluster changes.
+	configSecret, err := secret.GetFromNamespacedName(ctx, s.scope.Client(), clusterRef, secret.Kubeconfig)
 	if err != nil {
 		if !apierrors.IsNotFound(err) {
 			return fmt.Errorf("getting kubeconfig (user) secret %s: %w", clusterRef, err)
@@ -90,8 +117,10 @@ func (s *Service) reconcileAdditionalKubeconfigs(ctx context.Context, cluster *c
 			&clusterRef,
 		)
 		if createErr != nil {
-			return fmt.Errorf("creating additional kubeconfig secret: %w", err)
+			return fmt.Errorf("creating additional kubeconfig secret: %w", createErr)
 		}
+	} else if updateErr := s.updateUserKubeconfigSecret(ctx, cluster, configSecret, log); updateErr != nil {
+		return fmt.Errorf("updating additional kubeconfig secret: %w", updateErr)
 	}
 
 	return nil
@@ -100,11 +129,45 @@ func (s *Service) reconcileAdditionalKubeconfigs(ctx context.Context, cluster *c
 func (s *Service) createUserKubeconfigSecret(ctx context.Context, cluster *containerpb.Cluster, clusterRef *types.NamespacedName) error {
 	controllerOwnerRef := *metav1.NewControllerRef(s.scope.GCPManagedControlPlane, infrav1exp.GroupVersion.WithKind("GCPManagedControlPlane"))
 
+	out, err := s.createUserKubeconfig(cluster)
+	if err != nil {
+		return err
+	}
+
+	kubeconfigSecret := kubeconfig.GenerateSecretWithOwner(*clusterRef, out, controllerOwnerRef)
+	if err := s.scope.Client().Create(ctx, kubeconfigSecret); err != nil {
+		return fmt.Errorf("creating secret: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) updateUserKubeconfigSecret(ctx context.Context, cluster *containerpb.Cluster, configSecret *corev1.Secret, log *logr.Logger) error {
+	out, err := s.createUserKubeconfig(cluster)
+	if err != nil {
+		return err
+	}
+	if bytes.Equal(configSecret.Data[secret.KubeconfigDataName], out) {
+		return nil
+	}
+
+	log.Info("Updating user kubeconfig secret")
+	configSecret.Data[secret.KubeconfigDataName] = out
+	if err := s.scope.Client().Update(ctx, configSecret); err != nil {
+		return fmt.Errorf("updating kubeconfig secret: %w", err)
+	}
+
+	return nil
+}
+
+// createUserKubeconfig returns a kubeconfig that authenticates users with gke-gcloud-auth-plugin and their own
+// gcloud credentials, so that it does not expire.
+func (s *Service) createUserKubeconfig(cluster *containerpb.Cluster) ([]byte, error) {
 	contextName := s.getKubeConfigContextName(false)
 
 	cfg, err := s.createBaseKubeConfig(contextName, cluster)
 	if err != nil {
-		return fmt.Errorf("creating base kubeconfig: %w", err)
+		return nil, fmt.Errorf("creating base kubeconfig: %w", err)
 	}
 
 	execConfig := &api.ExecConfig{
@@ -121,18 +184,13 @@ func (s *Service) createUserKubeconfigSecret(ctx context.Context, cluster *conta
 
 	out, err := clientcmd.Write(*cfg)
 	if err != nil {
-		return fmt.Errorf("serialize kubeconfig to yaml: %w", err)
+		return nil, fmt.Errorf("serialize kubeconfig to yaml: %w", err)
 	}
 
-	kubeconfigSecret := kubeconfig.GenerateSecretWithOwner(*clusterRef, out, controllerOwnerRef)
-	if err := s.scope.Client().Create(ctx, kubeconfigSecret); err != nil {
-		return fmt.Errorf("creating secret: %w", err)
-	}
-
-	return nil
+	return out, nil
 }
 
-func (s *Service) createCAPIKubeconfigSecret(ctx context.Context, cluster *containerpb.Cluster, clusterRef *types.NamespacedName, log *logr.Logger) error {
+func (s *Service) createCAPIKubeconfigSecret(ctx context.Context, cluster *containerpb.Cluster, clusterRef *types.NamespacedName, log *logr.Logger) (time.Time, error) {
 	controllerOwnerRef := *metav1.NewControllerRef(s.scope.GCPManagedControlPlane, infrav1exp.GroupVersion.WithKind("GCPManagedControlPlane"))
 
 	contextName := s.getKubeConfigContextName(false)
@@ -140,13 +198,16 @@ func (s *Service) createCAPIKubeconfigSecret(ctx context.Context, cluster *conta
 	cfg, err := s.createBaseKubeConfig(contextName, cluster)
 	if err != nil {
 		log.Error(err, "failed creating base config")
-		return fmt.Errorf("creating base kubeconfig: %w", err)
+		return time.Time{}, fmt.Errorf("creating base kubeconfig: %w", err)
+	}
+	if err := s.setConnectGatewayServer(cfg, contextName, cluster); err != nil {
+		return time.Time{}, err
 	}
 
-	token, err := s.scope.GetCredential().GetToken(ctx)
+	token, expiry, err := s.scope.GetCredential().GetTokenWithExpiry(ctx)
 	if err != nil {
 		log.Error(err, "failed generating token")
-		return err
+		return time.Time{}, err
 	}
 	cfg.AuthInfos = map[string]*api.AuthInfo{
 		contextName: {
@@ -157,52 +218,98 @@ func (s *Service) createCAPIKubeconfigSecret(ctx context.Context, cluster *conta
 	out, err := clientcmd.Write(*cfg)
 	if err != nil {
 		log.Error(err, "failed serializing kubeconfig to yaml")
-		return fmt.Errorf("serialize kubeconfig to yaml: %w", err)
+		return time.Time{}, fmt.Errorf("serialize kubeconfig to yaml: %w", err)
 	}
 
 	kubeconfigSecret := kubeconfig.GenerateSecretWithOwner(*clusterRef, out, controllerOwnerRef)
 	if err := s.scope.Client().Create(ctx, kubeconfigSecret); err != nil {
 		log.Error(err, "failed creating secret")
-		return fmt.Errorf("creating secret: %w", err)
+		return time.Time{}, fmt.Errorf("creating secret: %w", err)
 	}
 
-	return nil
+	return expiry, nil
 }
 
-func (s *Service) updateCAPIKubeconfigSecret(ctx context.Context, configSecret *corev1.Secret) error {
+func (s *Service) updateCAPIKubeconfigSecret(ctx context.Context, cluster *containerpb.Cluster, configSecret *corev1.Secret) (time.Time, error) {
 	data, ok := configSecret.Data[secret.KubeconfigDataName]
 	if !ok {
-		return errors.Errorf("missing key %q in secret data", secret.KubeconfigDataName)
+		return time.Time{}, errors.Errorf("missing key %q in secret data", secret.KubeconfigDataName)
 	}
 
 	config, err := clientcmd.Load(data)
 	if err != nil {
-		return errors.Wrap(err, "failed to convert kubeconfig Secret into a clientcmdapi.Config")
+		return time.Time{}, errors.Wrap(err, "failed to convert kubeconfig Secret into a clientcmdapi.Config")
 	}
 
-	token, err := s.scope.GetCredential().GetToken(ctx)
+	token, expiry, err := s.scope.GetCredential().GetTokenWithExpiry(ctx)
 	if err != nil {
-		return err
+		return time.Time{}, err
 	}
 
 	contextName := s.getKubeConfigContextName(false)
 	config.AuthInfos[contextName].Token = token
 
+	// The server is refreshed as well, since the Connect Gateway can be enabled after the kubeconfig creation.
+	base, err := s.createBaseKubeConfig(contextName, cluster)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("creating base kubeconfig: %w", err)
+	}
+	config.Clusters[contextName] = base.Clusters[contextName]
+	if err := s.setConnectGatewayServer(config, contextName, cluster); err != nil {
+		return time.Time{}, err
+	}
+
 	out, err := clientcmd.Write(*config)
 	if err != nil {
-		return errors.Wrap(err, "failed to serialize config to yaml")
+		return time.Time{}, errors.Wrap(err, "failed to serialize config to yaml")
 	}
 
 	configSecret.Data[secret.KubeconfigDataName] = out
 
 	err = s.scope.Client().Update(ctx, configSecret)
 	if err != nil {
-		return fmt.Errorf("updating kubeconfig secret: %w", err)
+		return time.Time{}, fmt.Errorf("updating kubeconfig secret: %w", err)
+	}
+
+	return expiry, nil
+}
+
+// setConnectGatewayServer makes the kubeconfig point at the Connect Gateway endpoint of the fleet membership of
+// the cluster when it is enabled. The endpoint of the control plane is kept until the cluster is registered.
+func (s *Service) setConnectGatewayServer(cfg *api.Config, contextName string, cluster *containerpb.Cluster) error {
+	fleet := s.scope.GCPManagedControlPlane.Spec.Fleet
+	membership := cluster.GetFleet().GetMembership()
+	if fleet == nil || !fleet.ConnectGateway || membership == "" {
+		return nil
+	}
+
+	server, err := connectGatewayServer(membership)
+	if err != nil {
+		return err
 	}
+	// The Connect Gateway serves a publicly trusted certificate rather than the cluster CA.
+	cfg.Clusters[contextName] = &api.Cluster{Server: server}
 
 	return nil
 }
 
+// connectGatewayServer returns the Connect Gateway endpoint of a fleet membership, whose full resource name is
+// in the format //gkehub.googleapis.com/projects/*/locations/*/memberships/*.
+func connectGatewayServer(membership string) (string, error) {
+	parts := strings.Split(strings.TrimPrefix(membership, "//gkehub.googleapis.com/"), "/")
+	if len(parts) != 6 || parts[0] != "projects" || parts[2] != "locations" || parts[4] != "memberships" {
+		return "", errors.Errorf("unexpected fleet membership %q", membership)
+	}
+
+	// Regional memberships are served by regional gateway endpoints.
+	host := "connectgateway.googleapis.com"
+	if location := parts[3]; location != "global" {
+		host = fmt.Sprintf("%s-%s", location, host)
+	}
+
+	return fmt.Sprintf("https://%s/v1/projects/%s/locations/%s/gkeMemberships/%s", host, parts[1], parts[3], parts[5]), nil
+}
+
 func (s *Service) getKubeConfigContextName(isUser bool) string {
 	contextName := fmt.Sprintf("gke_%s_%s_%s", s.scope.GCPManagedControlPlane.Spec.Project, s.scope.GCPManagedControlPlane.Spec.Location, s.scope.ClusterName())
 	if isUser {
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"testing"
+	"time"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+)
+
+func TestConnectGatewayServer(t *testing.T) {
+	tests := []struct {
+		name       string
+		membership string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "global membership",
+			membership: "//gkehub.googleapis.com/projects/123456/locations/global/memberships/my-cluster",
+			want:       "https://connectgateway.googleapis.com/v1/projects/123456/locations/global/gkeMemberships/my-cluster",
+		},
+		{
+			name:       "regional membership",
+			membership: "//gkehub.googleapis.com/projects/123456/locations/us-central1/memberships/my-cluster",
+			want:       "https://us-central1-connectgateway.googleapis.com/v1/projects/123456/locations/us-central1/gkeMemberships/my-cluster",
+		},
+		{
+			name:       "malformed membership",
+			membership: "projects/123456/memberships/my-cluster",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := connectGatewayServer(tt.membership)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("connectGatewayServer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("connectGatewayServer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenRefreshAfter(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		expiry time.Time
+		want   time.Duration
+	}{
+		{name: "no expiry", want: 0},
+		{name: "fresh token", expiry: now.Add(time.Hour), want: 50 * time.Minute},
+		{name: "token within the window", expiry: now.Add(5 * time.Minute), want: reconciler.DefaultRetryTime},
+		{name: "expired token", expiry: now.Add(-time.Minute), want: reconciler.DefaultRetryTime},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenRefreshAfter(tt.expiry, now, 10*time.Minute); got != tt.want {
+				t.Errorf("tokenRefreshAfter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
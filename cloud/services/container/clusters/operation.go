@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"time"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"github.com/googleapis/gax-go/v2/apierror"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
+
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+)
+
+// reconcileOperation polls the GKE operation in progress on the cluster. It returns the time to wait before polling
+// it again while the operation is running, or before retrying when it has just failed, in which case its error is
+// reported in the condition of its type.
+func (s *Service) reconcileOperation(ctx context.Context, log *logr.Logger) (time.Duration, error) {
+	operation := s.scope.GCPManagedControlPlane.Status.Operation
+	if operation == nil {
+		return 0, nil
+	}
+
+	op, err := s.scope.ManagedControlPlaneClient().GetOperation(ctx, &containerpb.GetOperationRequest{
+		Name: s.scope.OperationFullName(operation.Name),
+	})
+	if err != nil {
+		var e *apierror.APIError
+		if ok := errors.As(err, &e); ok && e.GRPCStatus().Code() == codes.NotFound {
+			log.Info("GKE operation not found", "operation", operation.Name)
+			s.scope.GCPManagedControlPlane.Status.Operation = nil
+			return 0, nil
+		}
+		log.Error(err, "Error getting GKE operation", "operation", operation.Name)
+		return 0, err
+	}
+
+	if op.Status != containerpb.Operation_DONE {
+		log.Info("GKE operation in progress", "operation", op.Name, "type", op.OperationType.String(), "status", op.Status.String())
+		return reconciler.PollInterval(s.scope.GCPManagedControlPlane), nil
+	}
+	s.scope.GCPManagedControlPlane.Status.Operation = nil
+
+	msg := op.GetError().GetMessage()
+	if msg == "" && op.GetError().GetCode() == int32(codes.OK) {
+		log.Info("GKE operation done", "operation", op.Name, "type", op.OperationType.String())
+		record.Eventf(s.scope.GCPManagedControlPlane, "GKEOperationDone", "Operation %s of type %s is done", op.Name, op.OperationType.String())
+		return 0, nil
+	}
+
+	log.Error(errors.New(msg), "GKE operation failed", "operation", op.Name, "type", op.OperationType.String())
+	record.Warnf(s.scope.GCPManagedControlPlane, "GKEOperationFailed", "Operation %s of type %s failed: %s", op.Name, op.OperationType.String(), msg)
+	conditionType := operationConditionType(op.OperationType)
+	conditions.MarkFalse(s.scope.ConditionSetter(), conditionType, infrav1exp.GKEControlPlaneOperationFailedReason, clusterv1.ConditionSeverityError,
+		"operation %s of type %s failed: %s", op.Name, op.OperationType.String(), msg)
+	if conditionType == infrav1exp.GKEControlPlaneCreatingCondition {
+		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneOperationFailedReason, clusterv1.ConditionSeverityError, msg)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneReadyCondition, infrav1exp.GKEControlPlaneOperationFailedReason, clusterv1.ConditionSeverityError, msg)
+	}
+
+	return reconciler.ErrorBackoff(s.scope.GCPManagedControlPlane), nil
+}
+
+// operationConditionType returns the condition reporting on the GKE operations of the given type.
+func operationConditionType(operationType containerpb.Operation_Type) clusterv1.ConditionType {
+	switch operationType {
+	case containerpb.Operation_CREATE_CLUSTER:
+		return infrav1exp.GKEControlPlaneCreatingCondition
+	case containerpb.Operation_DELETE_CLUSTER:
+		return infrav1exp.GKEControlPlaneDeletingCondition
+	default:
+		return infrav1exp.GKEControlPlaneUpdatingCondition
+	}
+}
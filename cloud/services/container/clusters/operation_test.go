@@ -0,0 +1,131 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/internal/gketest"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+)
+
+func TestReconcileOperation(t *testing.T) {
+	intervals := &infrav1exp.GCPManagedControlPlane{}
+
+	tests := []struct {
+		name           string
+		operation      *containerpb.Operation
+		want           time.Duration
+		wantInProgress bool
+		wantFailed     clusterv1.ConditionType
+		wantReady      bool
+	}{
+		{
+			name:           "operation in progress",
+			operation:      &containerpb.Operation{Name: "operation-1", OperationType: containerpb.Operation_UPDATE_CLUSTER, Status: containerpb.Operation_RUNNING},
+			want:           reconciler.PollInterval(intervals),
+			wantInProgress: true,
+		},
+		{
+			name:      "operation done",
+			operation: &containerpb.Operation{Name: "operation-1", OperationType: containerpb.Operation_UPDATE_CLUSTER, Status: containerpb.Operation_DONE},
+		},
+		{
+			name: "operation not found",
+		},
+		{
+			name: "update operation failed",
+			operation: &containerpb.Operation{
+				Name: "operation-1", OperationType: containerpb.Operation_SET_LABELS, Status: containerpb.Operation_DONE,
+				Error: &status.Status{Code: int32(codes.Internal), Message: "internal error"},
+			},
+			want:       reconciler.ErrorBackoff(intervals),
+			wantFailed: infrav1exp.GKEControlPlaneUpdatingCondition,
+		},
+		{
+			name: "create operation failed",
+			operation: &containerpb.Operation{
+				Name: "operation-1", OperationType: containerpb.Operation_CREATE_CLUSTER, Status: containerpb.Operation_DONE,
+				Error: &status.Status{Code: int32(codes.ResourceExhausted), Message: "quota exceeded"},
+			},
+			want:       reconciler.ErrorBackoff(intervals),
+			wantFailed: infrav1exp.GKEControlPlaneCreatingCondition,
+			wantReady:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, gkeClient := gketest.NewServer(t)
+			if tt.operation != nil {
+				server.SetOperation(tt.operation)
+			}
+			controlPlane := &infrav1exp.GCPManagedControlPlane{}
+			controlPlane.Status.Operation = &infrav1exp.GKEOperation{Name: "operation-1"}
+			s := gketest.NewManagedControlPlaneScope(t, gkeClient, controlPlane)
+			log := logr.Discard()
+
+			got, err := New(s).reconcileOperation(context.Background(), &log)
+			if err != nil {
+				t.Fatalf("reconcileOperation() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("reconcileOperation() = %v, want %v", got, tt.want)
+			}
+			if inProgress := s.GCPManagedControlPlane.Status.Operation != nil; inProgress != tt.wantInProgress {
+				t.Errorf("operation in progress = %v, want %v", inProgress, tt.wantInProgress)
+			}
+			for _, conditionType := range []clusterv1.ConditionType{infrav1exp.GKEControlPlaneCreatingCondition, infrav1exp.GKEControlPlaneUpdatingCondition} {
+				failed := conditions.GetReason(s.GCPManagedControlPlane, conditionType) == infrav1exp.GKEControlPlaneOperationFailedReason
+				if failed != (conditionType == tt.wantFailed) {
+					t.Errorf("%s reports the failure = %v, want %v", conditionType, failed, conditionType == tt.wantFailed)
+				}
+			}
+			if ready := conditions.GetReason(s.GCPManagedControlPlane, clusterv1.ReadyCondition) == infrav1exp.GKEControlPlaneOperationFailedReason; ready != tt.wantReady {
+				t.Errorf("ready reports the failure = %v, want %v", ready, tt.wantReady)
+			}
+		})
+	}
+}
+
+func TestOperationConditionType(t *testing.T) {
+	tests := []struct {
+		operationType containerpb.Operation_Type
+		want          clusterv1.ConditionType
+	}{
+		{operationType: containerpb.Operation_CREATE_CLUSTER, want: infrav1exp.GKEControlPlaneCreatingCondition},
+		{operationType: containerpb.Operation_DELETE_CLUSTER, want: infrav1exp.GKEControlPlaneDeletingCondition},
+		{operationType: containerpb.Operation_UPGRADE_MASTER, want: infrav1exp.GKEControlPlaneUpdatingCondition},
+		{operationType: containerpb.Operation_SET_LABELS, want: infrav1exp.GKEControlPlaneUpdatingCondition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.operationType.String(), func(t *testing.T) {
+			if got := operationConditionType(tt.operationType); got != tt.want {
+				t.Errorf("operationConditionType() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"fmt"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/cluster-api/util/record"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+// reconcileOrphanedNodePools deletes the node pools labeled as owned by the cluster that no GCPManagedMachinePool
+// refers to anymore, such as the node pool of a GCPManagedMachinePool whose finalizer was removed before the
+// node pool was deleted. Failures are reported but do not fail the reconciliation of the cluster.
+func (s *Service) reconcileOrphanedNodePools(ctx context.Context, log *logr.Logger) {
+	managedMachinePools, _, err := s.scope.GetAllNodePools(ctx)
+	if err != nil {
+		log.Error(err, "Failed to list managed machine pools, skipping orphaned node pools")
+		return
+	}
+
+	resp, err := s.scope.ManagedControlPlaneClient().ListNodePools(ctx, &containerpb.ListNodePoolsRequest{
+		Parent: s.scope.ClusterFullName(),
+	})
+	if err != nil {
+		log.Error(err, "Failed to list node pools, skipping orphaned node pools")
+		return
+	}
+
+	for _, nodePool := range orphanedNodePools(resp.GetNodePools(), managedMachinePools, s.scope.Cluster.Name) {
+		log.Info("Deleting orphaned node pool", "nodepool", nodePool.Name)
+		fullName := fmt.Sprintf("%s/nodePools/%s", s.scope.ClusterFullName(), nodePool.Name)
+		if _, err := s.scope.ManagedControlPlaneClient().DeleteNodePool(ctx, &containerpb.DeleteNodePoolRequest{Name: fullName}); err != nil {
+			log.Error(err, "Failed to delete orphaned node pool", "nodepool", nodePool.Name)
+			record.Warnf(s.scope.GCPManagedControlPlane, "GKENodePoolOrphanDeleteFailed", "Failed to delete orphaned GKE node pool %s: %v", nodePool.Name, err)
+			// GKE runs a single operation per cluster at a time, the remaining node pools are retried later.
+			return
+		}
+		record.Eventf(s.scope.GCPManagedControlPlane, "GKENodePoolOrphanDelete", "Deleting orphaned GKE node pool %s", nodePool.Name)
+	}
+}
+
+// orphanedNodePools returns the node pools owned by the cluster that are neither the current nor the previous
+// node pool of a GCPManagedMachinePool. Node pools that are not running or in error are skipped, so that a node
+// pool being created for a GCPManagedMachinePool that is not in the cache yet is never deleted.
+func orphanedNodePools(nodePools []*containerpb.NodePool, managedMachinePools []infrav1exp.GCPManagedMachinePool, clusterName string) []*containerpb.NodePool {
+	known := make(map[string]bool, len(managedMachinePools))
+	for i := range managedMachinePools {
+		known[managedMachinePools[i].NodePoolName()] = true
+		if previous := managedMachinePools[i].Status.PreviousNodePoolName; previous != "" {
+			known[previous] = true
+		}
+	}
+
+	var orphans []*containerpb.NodePool
+	for _, nodePool := range nodePools {
+		if known[nodePool.Name] || !infrav1.Labels(nodePool.GetConfig().GetResourceLabels()).HasOwned(clusterName) {
+			continue
+		}
+		if nodePool.Status != containerpb.NodePool_RUNNING && nodePool.Status != containerpb.NodePool_ERROR {
+			continue
+		}
+		orphans = append(orphans, nodePool)
+	}
+
+	return orphans
+}
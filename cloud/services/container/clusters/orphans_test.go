@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/internal/gketest"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/nodepools"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestOrphanedNodePools(t *testing.T) {
+	owned := map[string]string{"capg-cluster-my-cluster": "owned"}
+	nodePool := func(name string, labels map[string]string, status containerpb.NodePool_Status) *containerpb.NodePool {
+		return &containerpb.NodePool{Name: name, Config: &containerpb.NodeConfig{ResourceLabels: labels}, Status: status}
+	}
+	managedMachinePools := []infrav1exp.GCPManagedMachinePool{
+		{ObjectMeta: metav1.ObjectMeta{Name: "pool-0"}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "pool-1"},
+			Status:     infrav1exp.GCPManagedMachinePoolStatus{NodePoolName: "pool-1-abcde", PreviousNodePoolName: "pool-1"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		nodePool *containerpb.NodePool
+		want     bool
+	}{
+		{
+			name:     "node pool of a managed machine pool",
+			nodePool: nodePool("pool-0", owned, containerpb.NodePool_RUNNING),
+		},
+		{
+			name:     "replacement node pool",
+			nodePool: nodePool("pool-1-abcde", owned, containerpb.NodePool_RUNNING),
+		},
+		{
+			name:     "node pool being replaced",
+			nodePool: nodePool("pool-1", owned, containerpb.NodePool_RUNNING),
+		},
+		{
+			name:     "node pool without the ownership label",
+			nodePool: nodePool("default-pool", map[string]string{"team": "a"}, containerpb.NodePool_RUNNING),
+		},
+		{
+			name:     "node pool owned by another cluster",
+			nodePool: nodePool("pool-2", map[string]string{"capg-cluster-other": "owned"}, containerpb.NodePool_RUNNING),
+		},
+		{
+			name:     "node pool being provisioned",
+			nodePool: nodePool("pool-2", owned, containerpb.NodePool_PROVISIONING),
+		},
+		{
+			name:     "orphaned node pool",
+			nodePool: nodePool("pool-2", owned, containerpb.NodePool_RUNNING),
+			want:     true,
+		},
+		{
+			name:     "orphaned node pool in error",
+			nodePool: nodePool("pool-2", owned, containerpb.NodePool_ERROR),
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := orphanedNodePools([]*containerpb.NodePool{tt.nodePool}, managedMachinePools, "my-cluster")
+			if (len(got) == 1) != tt.want {
+				t.Errorf("orphanedNodePools() = %v, want orphan %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrphanedNodePoolsAfterRetain(t *testing.T) {
+	ctx := context.Background()
+	server, gkeClient := gketest.NewServer(t)
+	for _, name := range []string{"pool-0", "pool-1"} {
+		server.SetNodePool(gketest.NodePoolFullName(name), &containerpb.NodePool{
+			Name:   name,
+			Status: containerpb.NodePool_RUNNING,
+			Config: &containerpb.NodeConfig{ResourceLabels: map[string]string{"capg-cluster-my-cluster": "owned"}},
+		})
+	}
+
+	// Delete the GCPManagedMachinePool of pool-0, which retains its node pool.
+	retained := &infrav1exp.GCPManagedMachinePool{
+		Spec: infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pool-0", DeletionPolicy: infrav1exp.NodePoolDeletionPolicyRetain},
+	}
+	svc := nodepools.New(gketest.NewManagedMachinePoolScope(t, gkeClient, retained))
+	for i := 0; ; i++ {
+		result, err := svc.Delete(ctx)
+		if err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if result.IsZero() {
+			break
+		}
+		if i == 3 {
+			t.Fatal("Delete() did not complete")
+		}
+	}
+
+	resp, err := gkeClient.ListNodePools(ctx, &containerpb.ListNodePoolsRequest{Parent: gketest.ClusterFullName})
+	if err != nil {
+		t.Fatalf("ListNodePools() error = %v", err)
+	}
+	if len(resp.NodePools) != 2 {
+		t.Fatalf("ListNodePools() returned %d node pools, want 2", len(resp.NodePools))
+	}
+	managedMachinePools := []infrav1exp.GCPManagedMachinePool{{ObjectMeta: metav1.ObjectMeta{Name: "pool-1"}}}
+	if got := orphanedNodePools(resp.NodePools, managedMachinePools, gketest.ClusterName); len(got) != 0 {
+		t.Errorf("orphanedNodePools() = %v, want the retained node pool to be left alone", got)
+	}
+}
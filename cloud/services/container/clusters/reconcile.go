@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
@@ -31,9 +32,13 @@ import (
 	"github.com/googleapis/gax-go/v2/apierror"
 	"github.com/pkg/errors"
 	"google.golang.org/grpc/codes"
+	"k8s.io/utils/pointer"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
@@ -45,6 +50,10 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 	log := log.FromContext(ctx).WithValues("service", "container.clusters")
 	log.Info("Reconciling cluster resources")
 
+	if requeueAfter, err := s.reconcileOperation(ctx, &log); err != nil || requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, err
+	}
+
 	cluster, err := s.describeCluster(ctx, &log)
 	if err != nil {
 		s.scope.GCPManagedControlPlane.Status.Initialized = false
@@ -52,6 +61,16 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return ctrl.Result{}, err
 	}
+
+	// A cluster that is not labeled as owned by the cluster is pre-existing, and only managed once adopted.
+	if cluster != nil && !s.isOwned(cluster) && !s.adoptCluster(cluster, &log) {
+		return ctrl.Result{}, nil
+	}
+
+	if s.isDryRun() {
+		return s.reconcileDryRun(ctx, cluster, &log)
+	}
+	conditions.Delete(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneDryRunCondition)
 	if cluster == nil {
 		log.Info("Cluster not found, creating")
 		s.scope.GCPManagedControlPlane.Status.Initialized = false
@@ -78,7 +97,7 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 				conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneRequiresAtLeastOneNodePoolReason, clusterv1.ConditionSeverityInfo, "")
 				conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneReadyCondition, infrav1exp.GKEControlPlaneRequiresAtLeastOneNodePoolReason, clusterv1.ConditionSeverityInfo, "")
 				conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneCreatingCondition, infrav1exp.GKEControlPlaneRequiresAtLeastOneNodePoolReason, clusterv1.ConditionSeverityInfo, "")
-				return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+				return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedControlPlane)}, nil
 			}
 		}
 
@@ -93,11 +112,12 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneCreatingReason, clusterv1.ConditionSeverityInfo, "")
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneReadyCondition, infrav1exp.GKEControlPlaneCreatingReason, clusterv1.ConditionSeverityInfo, "")
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneCreatingCondition)
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedControlPlane)}, nil
 	}
 
 	log.V(2).Info("gke cluster found", "status", cluster.Status)
 	s.scope.GCPManagedControlPlane.Status.CurrentVersion = cluster.CurrentMasterVersion
+	s.scope.GCPManagedControlPlane.Status.FleetMembership = cluster.GetFleet().GetMembership()
 
 	switch cluster.Status {
 	case containerpb.Cluster_PROVISIONING:
@@ -107,13 +127,13 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneCreatingCondition)
 		s.scope.GCPManagedControlPlane.Status.Initialized = false
 		s.scope.GCPManagedControlPlane.Status.Ready = false
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedControlPlane)}, nil
 	case containerpb.Cluster_RECONCILING:
 		log.Info("Cluster reconciling in progress")
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition)
 		s.scope.GCPManagedControlPlane.Status.Initialized = true
 		s.scope.GCPManagedControlPlane.Status.Ready = true
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedControlPlane)}, nil
 	case containerpb.Cluster_STOPPING:
 		log.Info("Cluster stopping in progress")
 		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneDeletingReason, clusterv1.ConditionSeverityInfo, "")
@@ -121,13 +141,14 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneDeletingCondition)
 		s.scope.GCPManagedControlPlane.Status.Initialized = false
 		s.scope.GCPManagedControlPlane.Status.Ready = false
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedControlPlane)}, nil
 	case containerpb.Cluster_ERROR, containerpb.Cluster_DEGRADED:
 		var msg string
 		if len(cluster.Conditions) > 0 {
 			msg = cluster.Conditions[0].GetMessage()
 		}
 		log.Error(errors.New("Cluster in error/degraded state"), msg, "name", s.scope.ClusterName())
+		record.Warnf(s.scope.GCPManagedControlPlane, "GKEClusterError", "GKE cluster %s is in %s state: %s", s.scope.ClusterName(), cluster.Status.String(), msg)
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneReadyCondition, infrav1exp.GKEControlPlaneErrorReason, clusterv1.ConditionSeverityError, "")
 		s.scope.GCPManagedControlPlane.Status.Ready = false
 		s.scope.GCPManagedControlPlane.Status.Initialized = false
@@ -140,6 +161,15 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		return ctrl.Result{}, statusErr
 	}
 
+	if !v1beta1.Labels(cluster.ResourceLabels).HasOwned(s.scope.Cluster.Name) {
+		log.Info("Labeling cluster as owned")
+		if err := s.setClusterLabels(ctx, cluster, &log); err != nil {
+			return ctrl.Result{}, err
+		}
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition)
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedControlPlane)}, nil
+	}
+
 	needUpdate, updateClusterRequest := s.checkDiffAndPrepareUpdate(cluster, &log)
 	if needUpdate {
 		log.Info("Update required")
@@ -151,16 +181,18 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition)
 		s.scope.GCPManagedControlPlane.Status.Initialized = true
 		s.scope.GCPManagedControlPlane.Status.Ready = true
-		return ctrl.Result{}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedControlPlane)}, nil
 	}
 	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneUpdatingCondition, infrav1exp.GKEControlPlaneUpdatedReason, clusterv1.ConditionSeverityInfo, "")
 
 	// Reconcile kubeconfig
-	err = s.reconcileKubeconfig(ctx, cluster, &log)
+	tokenExpiry, err := s.reconcileKubeconfig(ctx, cluster, &log)
 	if err != nil {
 		log.Error(err, "Failed to reconcile CAPI kubeconfig")
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneKubeconfigRefreshedCondition, infrav1exp.GKEControlPlaneKubeconfigRefreshFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 		return ctrl.Result{}, err
 	}
+	conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneKubeconfigRefreshedCondition)
 	err = s.reconcileAdditionalKubeconfigs(ctx, cluster, &log)
 	if err != nil {
 		log.Error(err, "Failed to reconcile additional kubeconfig")
@@ -176,7 +208,17 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 
 	log.Info("Cluster reconciled")
 
-	return ctrl.Result{}, nil
+	s.reconcileOrphanedNodePools(ctx, &log)
+
+	// Refresh the token of the kubeconfig ahead of its expiry rather than relying on the sync period.
+	refreshAfter := tokenRefreshAfter(tokenExpiry, time.Now(), s.scope.KubeconfigRefreshWindow())
+	log.V(2).Info("Scheduling kubeconfig token refresh", "expiry", tokenExpiry, "after", refreshAfter)
+
+	if resync := reconciler.ResyncInterval(s.scope.GCPManagedControlPlane); resync > 0 && resync < refreshAfter {
+		refreshAfter = resync
+	}
+
+	return ctrl.Result{RequeueAfter: refreshAfter}, nil
 }
 
 // Delete delete GKE cluster.
@@ -184,6 +226,10 @@ func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 	log := log.FromContext(ctx).WithValues("service", "container.clusters")
 	log.Info("Deleting cluster resources")
 
+	if requeueAfter, err := s.reconcileOperation(ctx, &log); err != nil || requeueAfter > 0 {
+		return ctrl.Result{RequeueAfter: requeueAfter}, err
+	}
+
 	cluster, err := s.describeCluster(ctx, &log)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -193,6 +239,12 @@ func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneDeletingCondition, infrav1exp.GKEControlPlaneDeletedReason, clusterv1.ConditionSeverityInfo, "")
 		return ctrl.Result{}, nil
 	}
+	if s.leaveNotAdopted(cluster, &log) {
+		return ctrl.Result{}, nil
+	}
+	if s.isDryRun() {
+		return s.reportDryRun(&log, []string{shared.FormatMutation("DeleteCluster", &containerpb.DeleteClusterRequest{Name: s.scope.ClusterFullName()})})
+	}
 
 	switch cluster.Status {
 	case containerpb.Cluster_PROVISIONING:
@@ -224,6 +276,48 @@ func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 	return ctrl.Result{}, nil
 }
 
+// isOwned returns true if the cluster has the label marking it as owned by the cluster. Clusters created or adopted
+// by the GCPManagedControlPlane before the label was introduced are owned too, and get the label once running.
+func (s *Service) isOwned(cluster *containerpb.Cluster) bool {
+	return v1beta1.Labels(cluster.ResourceLabels).HasOwned(s.scope.Cluster.Name) ||
+		conditions.Get(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneCreatingCondition) != nil
+}
+
+// adoptCluster returns true if the pre-existing cluster is adopted by the GCPManagedControlPlane, and otherwise
+// reports that it already exists.
+func (s *Service) adoptCluster(cluster *containerpb.Cluster, log *logr.Logger) bool {
+	if _, ok := s.scope.GCPManagedControlPlane.Annotations[infrav1exp.AdoptClusterAnnotation]; !ok {
+		log.Info("Cluster already exists and is not adopted", "name", cluster.Name)
+		s.scope.GCPManagedControlPlane.Status.Initialized = false
+		s.scope.GCPManagedControlPlane.Status.Ready = false
+		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEControlPlaneAlreadyExistsReason, clusterv1.ConditionSeverityError,
+			"cluster %s already exists, set the %s annotation to adopt it", cluster.Name, infrav1exp.AdoptClusterAnnotation)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneReadyCondition, infrav1exp.GKEControlPlaneAlreadyExistsReason, clusterv1.ConditionSeverityError,
+			"cluster %s already exists, set the %s annotation to adopt it", cluster.Name, infrav1exp.AdoptClusterAnnotation)
+		return false
+	}
+
+	// The adoption is only recorded once the changes to the cluster are applied, not while they are reviewed.
+	if !s.isDryRun() {
+		log.Info("Adopting existing cluster", "name", cluster.Name)
+		record.Eventf(s.scope.GCPManagedControlPlane, "GKEClusterAdopt", "Adopted existing GKE cluster %s", cluster.Name)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneCreatingCondition, infrav1exp.GKEControlPlaneAdoptedReason, clusterv1.ConditionSeverityInfo, "")
+	}
+	return true
+}
+
+// leaveNotAdopted returns true if the cluster is not owned by the GCPManagedControlPlane, in which case it is left
+// behind when the GCPManagedControlPlane is deleted.
+func (s *Service) leaveNotAdopted(cluster *containerpb.Cluster, log *logr.Logger) bool {
+	if s.isOwned(cluster) {
+		return false
+	}
+
+	log.Info("Leaving cluster that has not been adopted", "name", cluster.Name)
+	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEControlPlaneDeletingCondition, infrav1exp.GKEControlPlaneDeletedReason, clusterv1.ConditionSeverityInfo, "")
+	return true
+}
+
 func (s *Service) describeCluster(ctx context.Context, log *logr.Logger) (*containerpb.Cluster, error) {
 	getClusterRequest := &containerpb.GetClusterRequest{
 		Name: s.scope.ClusterFullName(),
@@ -246,16 +340,47 @@ func (s *Service) describeCluster(ctx context.Context, log *logr.Logger) (*conta
 func (s *Service) createCluster(ctx context.Context, log *logr.Logger) error {
 	nodePools, machinePools, _ := s.scope.GetAllNodePools(ctx)
 
+	// The node pools are created along with the cluster, so their generated names have to be persisted first.
+	// Marking them as creating also tells the node pool reconciler that they are not pre-existing node pools.
+	for i := range nodePools {
+		original := nodePools[i].DeepCopy()
+		nodePools[i].EnsureNodePoolName()
+		conditions.MarkTrue(&nodePools[i], infrav1exp.GKEMachinePoolCreatingCondition)
+		if err := s.scope.Client().Status().Patch(ctx, &nodePools[i], client.MergeFrom(original)); err != nil {
+			return fmt.Errorf("persisting status of node pool %s: %w", nodePools[i].Name, err)
+		}
+	}
+
+	createClusterRequest, err := s.newCreateClusterRequest(nodePools, machinePools, log)
+	if err != nil {
+		return err
+	}
+
+	log.V(2).Info("Creating GKE cluster")
+	op, err := s.scope.ManagedControlPlaneClient().CreateCluster(ctx, createClusterRequest)
+	if err != nil {
+		log.Error(err, "Error creating GKE cluster", "name", s.scope.ClusterName())
+		record.Warnf(s.scope.GCPManagedControlPlane, "GKEClusterCreateFailed", "Failed to create GKE cluster %s: %v", s.scope.ClusterName(), err)
+		return err
+	}
+	s.scope.SetOperation(op)
+	record.Eventf(s.scope.GCPManagedControlPlane, "GKEClusterCreate", "Creating GKE cluster %s", s.scope.ClusterName())
+
+	return nil
+}
+
+// newCreateClusterRequest returns the request creating the GKE cluster along with the given node pools.
+func (s *Service) newCreateClusterRequest(nodePools []infrav1exp.GCPManagedMachinePool, machinePools []clusterv1exp.MachinePool, log *logr.Logger) (*containerpb.CreateClusterRequest, error) {
 	log.V(2).Info("Running pre-flight checks on machine pools before cluster creation")
 	if err := shared.ManagedMachinePoolsPreflightCheck(nodePools, machinePools, s.scope.Region()); err != nil {
-		return fmt.Errorf("preflight checks on machine pools before cluster create: %w", err)
+		return nil, fmt.Errorf("preflight checks on machine pools before cluster create: %w", err)
 	}
 
 	isRegional := shared.IsRegional(s.scope.Region())
 
 	cluster := &containerpb.Cluster{
 		Name:    s.scope.ClusterName(),
-		Network: *s.scope.GCPManagedCluster.Spec.Network.Name,
+		Network: s.scope.ClusterNetwork(),
 		Autopilot: &containerpb.Autopilot{
 			Enabled: s.scope.GCPManagedControlPlane.Spec.EnableAutopilot,
 		},
@@ -265,10 +390,19 @@ func (s *Service) createCluster(ctx context.Context, log *logr.Logger) error {
 		WorkloadIdentityConfig:         s.createWorkloadIdentityConfig(),
 		NetworkConfig:                  s.createNetworkConfig(),
 		AddonsConfig:                   s.createAddonsConfig(),
-		ResourceLabels:                 s.scope.GCPManagedCluster.Spec.AdditionalLabels,
+		ResourceLabels:                 s.scope.ClusterResourceLabels(),
 		MasterAuthorizedNetworksConfig: convertToSdkMasterAuthorizedNetworksConfig(s.scope.GCPManagedControlPlane.Spec.MasterAuthorizedNetworksConfig),
 	}
 
+	if policy := s.scope.GCPManagedCluster.Spec.IPAllocationPolicy; policy != nil {
+		cluster.Subnetwork = s.scope.ClusterSubnetwork(policy.Subnetwork)
+		cluster.IpAllocationPolicy = convertToSdkIPAllocationPolicy(policy)
+	}
+
+	if fleet := s.scope.GCPManagedControlPlane.Spec.Fleet; fleet != nil {
+		cluster.Fleet = &containerpb.Fleet{Project: fleet.Project}
+	}
+
 	if s.scope.GCPManagedControlPlane.Spec.ControlPlaneVersion != nil {
 		cluster.InitialClusterVersion = *s.scope.GCPManagedControlPlane.Spec.ControlPlaneVersion
 	}
@@ -277,27 +411,53 @@ func (s *Service) createCluster(ctx context.Context, log *logr.Logger) error {
 		cluster.NodePools = scope.ConvertToSdkNodePools(nodePools, machinePools, isRegional)
 	}
 
-	createClusterRequest := &containerpb.CreateClusterRequest{
+	return &containerpb.CreateClusterRequest{
 		Cluster: cluster,
 		Parent:  s.scope.ClusterLocation(),
-	}
+	}, nil
+}
 
-	log.V(2).Info("Creating GKE cluster")
-	_, err := s.scope.ManagedControlPlaneClient().CreateCluster(ctx, createClusterRequest)
+func (s *Service) updateCluster(ctx context.Context, updateClusterRequest *containerpb.UpdateClusterRequest, log *logr.Logger) error {
+	op, err := s.scope.ManagedControlPlaneClient().UpdateCluster(ctx, updateClusterRequest)
 	if err != nil {
-		log.Error(err, "Error creating GKE cluster", "name", s.scope.ClusterName())
+		log.Error(err, "Error updating GKE cluster", "name", s.scope.ClusterName())
+		record.Warnf(s.scope.GCPManagedControlPlane, "GKEClusterUpdateFailed", "Failed to update GKE cluster %s: %v", s.scope.ClusterName(), err)
 		return err
 	}
+	s.scope.SetOperation(op)
+	if version := updateClusterRequest.GetUpdate().GetDesiredMasterVersion(); version != "" {
+		record.Eventf(s.scope.GCPManagedControlPlane, "GKEClusterUpgrade", "Upgrading GKE cluster %s to version %s", s.scope.ClusterName(), version)
+	} else {
+		record.Eventf(s.scope.GCPManagedControlPlane, "GKEClusterUpdate", "Updating GKE cluster %s", s.scope.ClusterName())
+	}
 
 	return nil
 }
 
-func (s *Service) updateCluster(ctx context.Context, updateClusterRequest *containerpb.UpdateClusterRequest, log *logr.Logger) error {
-	_, err := s.scope.ManagedControlPlaneClient().UpdateCluster(ctx, updateClusterRequest)
+// newSetLabelsRequest returns the request adding the label marking the cluster as owned to its resource labels.
+func (s *Service) newSetLabelsRequest(cluster *containerpb.Cluster) *containerpb.SetLabelsRequest {
+	labels := make(map[string]string, len(cluster.ResourceLabels)+1)
+	for k, v := range cluster.ResourceLabels {
+		labels[k] = v
+	}
+	labels[v1beta1.ClusterTagKey(s.scope.Cluster.Name)] = string(v1beta1.ResourceLifecycleOwned)
+
+	return &containerpb.SetLabelsRequest{
+		Name:             s.scope.ClusterFullName(),
+		ResourceLabels:   labels,
+		LabelFingerprint: cluster.LabelFingerprint,
+	}
+}
+
+func (s *Service) setClusterLabels(ctx context.Context, cluster *containerpb.Cluster, log *logr.Logger) error {
+	op, err := s.scope.ManagedControlPlaneClient().SetLabels(ctx, s.newSetLabelsRequest(cluster))
 	if err != nil {
-		log.Error(err, "Error updating GKE cluster", "name", s.scope.ClusterName())
+		log.Error(err, "Error setting labels of GKE cluster", "name", s.scope.ClusterName())
+		record.Warnf(s.scope.GCPManagedControlPlane, "GKEClusterUpdateFailed", "Failed to set labels of GKE cluster %s: %v", s.scope.ClusterName(), err)
 		return err
 	}
+	s.scope.SetOperation(op)
+	record.Eventf(s.scope.GCPManagedControlPlane, "GKEClusterUpdate", "Labeling GKE cluster %s as owned", s.scope.ClusterName())
 
 	return nil
 }
@@ -306,11 +466,14 @@ func (s *Service) deleteCluster(ctx context.Context, log *logr.Logger) error {
 	deleteClusterRequest := &containerpb.DeleteClusterRequest{
 		Name: s.scope.ClusterFullName(),
 	}
-	_, err := s.scope.ManagedControlPlaneClient().DeleteCluster(ctx, deleteClusterRequest)
+	op, err := s.scope.ManagedControlPlaneClient().DeleteCluster(ctx, deleteClusterRequest)
 	if err != nil {
 		log.Error(err, "Error deleting GKE cluster", "name", s.scope.ClusterName())
+		record.Warnf(s.scope.GCPManagedControlPlane, "GKEClusterDeleteFailed", "Failed to delete GKE cluster %s: %v", s.scope.ClusterName(), err)
 		return err
 	}
+	s.scope.SetOperation(op)
+	record.Eventf(s.scope.GCPManagedControlPlane, "GKEClusterDelete", "Deleting GKE cluster %s", s.scope.ClusterName())
 
 	return nil
 }
@@ -359,6 +522,16 @@ func (s *Service) createNetworkConfig() *containerpb.NetworkConfig {
 	}
 }
 
+// convertToSdkIPAllocationPolicy converts the IPAllocationPolicy defined in CRs to the SDK version.
+func convertToSdkIPAllocationPolicy(policy *infrav1exp.IPAllocationPolicy) *containerpb.IPAllocationPolicy {
+	return &containerpb.IPAllocationPolicy{
+		UseIpAliases:               true,
+		ClusterSecondaryRangeName:  policy.Pods.Name,
+		ServicesSecondaryRangeName: policy.Services.Name,
+		StackType:                  containerpb.StackType(containerpb.StackType_value[pointer.StringDeref(policy.StackType, "IPV4")]),
+	}
+}
+
 func convertToSdkDatapathProvider(datapath *v1beta1.DatapathProvider) containerpb.DatapathProvider {
 	if datapath == nil {
 		return containerpb.DatapathProvider_DATAPATH_PROVIDER_UNSPECIFIED
@@ -464,6 +637,13 @@ func (s *Service) checkDiffAndPrepareUpdate(existingCluster *containerpb.Cluster
 		log.V(4).Info("Master authorized networks config update check", "desired", desiredMasterAuthorizedNetworksConfig)
 	}
 
+	// Fleet registration
+	if fleet := s.scope.GCPManagedControlPlane.Spec.Fleet; fleet != nil && existingCluster.GetFleet().GetProject() == "" {
+		log.V(2).Info("Fleet registration required", "project", fleet.Project)
+		needUpdate = true
+		clusterUpdate.DesiredFleet = &containerpb.Fleet{Project: fleet.Project}
+	}
+
 	updateClusterRequest := containerpb.UpdateClusterRequest{
 		Name:   s.scope.ClusterFullName(),
 		Update: &clusterUpdate,
@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusters
+
+import (
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func newTestService(annotations map[string]string, creating *clusterv1.Condition) *Service {
+	controlPlane := &infrav1exp.GCPManagedControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster", Annotations: annotations}}
+	if creating != nil {
+		conditions.Set(controlPlane, creating)
+	}
+	return New(&scope.ManagedControlPlaneScope{
+		Cluster:                &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "my-cluster"}},
+		GCPManagedCluster:      &infrav1exp.GCPManagedCluster{},
+		GCPManagedControlPlane: controlPlane,
+	})
+}
+
+func TestIsOwned(t *testing.T) {
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		creating *clusterv1.Condition
+		want     bool
+	}{
+		{
+			name:   "cluster labeled as owned by the cluster",
+			labels: map[string]string{"capg-cluster-my-cluster": "owned"},
+			want:   true,
+		},
+		{
+			name:   "cluster labeled as owned by another cluster",
+			labels: map[string]string{"capg-cluster-other-cluster": "owned"},
+		},
+		{
+			name: "cluster without labels",
+		},
+		{
+			name:     "unlabeled cluster created before the label was introduced",
+			creating: conditions.FalseCondition(infrav1exp.GKEControlPlaneCreatingCondition, infrav1exp.GKEControlPlaneCreatedReason, clusterv1.ConditionSeverityInfo, ""),
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(nil, tt.creating)
+			cluster := &containerpb.Cluster{Name: "my-cluster", ResourceLabels: tt.labels}
+			if got := s.isOwned(cluster); got != tt.want {
+				t.Errorf("isOwned() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdoptCluster(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		want         bool
+		wantCreating bool
+	}{
+		{
+			name: "cluster not adopted",
+		},
+		{
+			name:         "cluster adopted",
+			annotations:  map[string]string{infrav1exp.AdoptClusterAnnotation: ""},
+			want:         true,
+			wantCreating: true,
+		},
+		{
+			name:        "cluster adopted in dry-run mode",
+			annotations: map[string]string{infrav1exp.AdoptClusterAnnotation: "", infrav1exp.DryRunClusterAnnotation: ""},
+			want:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(tt.annotations, nil)
+			log := logr.Discard()
+			if got := s.adoptCluster(&containerpb.Cluster{Name: "my-cluster"}, &log); got != tt.want {
+				t.Errorf("adoptCluster() = %v, want %v", got, tt.want)
+			}
+
+			controlPlane := s.scope.GCPManagedControlPlane
+			if creating := conditions.Get(controlPlane, infrav1exp.GKEControlPlaneCreatingCondition); (creating != nil) != tt.wantCreating {
+				t.Errorf("adoption recorded = %v, want %v", creating != nil, tt.wantCreating)
+			}
+			if alreadyExists := conditions.GetReason(controlPlane, clusterv1.ReadyCondition) == infrav1exp.GKEControlPlaneAlreadyExistsReason; alreadyExists == tt.want {
+				t.Errorf("already exists reported = %v, want %v", alreadyExists, !tt.want)
+			}
+		})
+	}
+}
+
+func TestLeaveNotAdopted(t *testing.T) {
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   bool
+	}{
+		{
+			name:   "cluster owned by the cluster",
+			labels: map[string]string{"capg-cluster-my-cluster": "owned"},
+		},
+		{
+			name:   "cluster not adopted",
+			labels: map[string]string{"team": "a"},
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newTestService(nil, nil)
+			log := logr.Discard()
+			if got := s.leaveNotAdopted(&containerpb.Cluster{Name: "my-cluster", ResourceLabels: tt.labels}, &log); got != tt.want {
+				t.Errorf("leaveNotAdopted() = %v, want %v", got, tt.want)
+			}
+
+			deleted := conditions.GetReason(s.scope.GCPManagedControlPlane, infrav1exp.GKEControlPlaneDeletingCondition) == infrav1exp.GKEControlPlaneDeletedReason
+			if deleted != tt.want {
+				t.Errorf("cluster reported deleted = %v, want %v", deleted, tt.want)
+			}
+		})
+	}
+}
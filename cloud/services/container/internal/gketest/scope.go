@@ -0,0 +1,186 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gketest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	compute "cloud.google.com/go/compute/apiv1"
+	container "cloud.google.com/go/container/apiv1"
+	credentials "cloud.google.com/go/iam/credentials/apiv1"
+	"google.golang.org/api/option"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+const (
+	// ClusterName is the name of the Cluster of the test scopes.
+	ClusterName = "my-cluster"
+	// ClusterFullName is the full name of the GKE cluster of the test scopes.
+	ClusterFullName = "projects/my-project/locations/us-central1/clusters/my-gke-cluster"
+)
+
+// NodePoolFullName returns the full name of the node pool with the given name in the GKE cluster of the test scopes.
+func NodePoolFullName(name string) string {
+	return fmt.Sprintf("%s/nodePools/%s", ClusterFullName, name)
+}
+
+// NewManagedMachinePoolScope returns the scope of the GCPManagedMachinePool, which is named after its node pool,
+// in the GKE cluster of the test scopes. The GCPManagedMachinePool and the other objects are stored in a fake
+// client. A GCPManagedControlPlane among the objects is the control plane of the scope, its GKE cluster is
+// defaulted to the one of the test scopes.
+func NewManagedMachinePoolScope(t *testing.T, gkeClient *container.ClusterManagerClient, managedMachinePool *infrav1exp.GCPManagedMachinePool, objs ...client.Object) *scope.ManagedMachinePoolScope {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+	_ = clusterv1exp.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	var controlPlane *infrav1exp.GCPManagedControlPlane
+	for _, obj := range objs {
+		if cp, ok := obj.(*infrav1exp.GCPManagedControlPlane); ok {
+			controlPlane = cp
+		}
+	}
+	if controlPlane == nil {
+		controlPlane = &infrav1exp.GCPManagedControlPlane{ObjectMeta: metav1.ObjectMeta{Name: ClusterName + "-control-plane", Namespace: metav1.NamespaceDefault}}
+		objs = append(objs, controlPlane)
+	}
+	if controlPlane.Spec.Project == "" {
+		controlPlane.Spec.Project = "my-project"
+		controlPlane.Spec.Location = "us-central1"
+		controlPlane.Spec.ClusterName = "my-gke-cluster"
+	}
+
+	managedMachinePool.Name = managedMachinePool.NodePoolName()
+	managedMachinePool.Namespace = metav1.NamespaceDefault
+	if managedMachinePool.Labels == nil {
+		managedMachinePool.Labels = map[string]string{}
+	}
+	managedMachinePool.Labels[clusterv1.ClusterNameLabel] = ClusterName
+	managedMachinePool.Spec.CredentialsRef = &infrav1.ObjectReference{Name: "gcp-credentials", Namespace: metav1.NamespaceDefault}
+	objs = append(objs, managedMachinePool)
+	crClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithStatusSubresource(objs...).Build()
+
+	// Read the objects of the scope back for them to have a resource version.
+	ctx := context.Background()
+	for _, obj := range []client.Object{controlPlane, managedMachinePool} {
+		if err := crClient.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			t.Fatalf("getting %s: %v", obj.GetName(), err)
+		}
+	}
+
+	migClient, err := compute.NewInstanceGroupManagersRESTClient(ctx, option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("creating instance group managers client: %v", err)
+	}
+	machineTypesClient, err := compute.NewMachineTypesRESTClient(ctx, option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("creating machine types client: %v", err)
+	}
+
+	s, err := scope.NewManagedMachinePoolScope(ctx, scope.ManagedMachinePoolScopeParams{
+		ManagedClusterClient:        gkeClient,
+		InstanceGroupManagersClient: migClient,
+		MachineTypesClient:          machineTypesClient,
+		Client:                      crClient,
+		Cluster:                     &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: ClusterName, Namespace: metav1.NamespaceDefault}},
+		MachinePool: &clusterv1exp.MachinePool{
+			ObjectMeta: metav1.ObjectMeta{Name: managedMachinePool.Name, Namespace: metav1.NamespaceDefault},
+			Spec: clusterv1exp.MachinePoolSpec{
+				ClusterName: ClusterName,
+				Replicas:    pointer.Int32(3),
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{InfrastructureRef: corev1.ObjectReference{Name: managedMachinePool.Name}},
+				},
+			},
+		},
+		GCPManagedCluster:      &infrav1exp.GCPManagedCluster{},
+		GCPManagedControlPlane: controlPlane,
+		GCPManagedMachinePool:  managedMachinePool,
+	})
+	if err != nil {
+		t.Fatalf("creating managed machine pool scope: %v", err)
+	}
+
+	return s
+}
+
+// NewManagedControlPlaneScope returns the scope of the GCPManagedControlPlane, whose GKE cluster is defaulted to
+// the one of the test scopes. The GCPManagedControlPlane is stored in a fake client, along with a Secret holding
+// placeholder credentials, which are never used to call GCP.
+func NewManagedControlPlaneScope(t *testing.T, gkeClient *container.ClusterManagerClient, controlPlane *infrav1exp.GCPManagedControlPlane) *scope.ManagedControlPlaneScope {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+	_ = clusterv1exp.AddToScheme(scheme)
+	_ = infrav1exp.AddToScheme(scheme)
+
+	controlPlane.Name = ClusterName + "-control-plane"
+	controlPlane.Namespace = metav1.NamespaceDefault
+	if controlPlane.Spec.Project == "" {
+		controlPlane.Spec.Project = "my-project"
+		controlPlane.Spec.Location = "us-central1"
+		controlPlane.Spec.ClusterName = "my-gke-cluster"
+	}
+	controlPlane.Spec.CredentialsRef = &infrav1.ObjectReference{Name: "gcp-credentials", Namespace: metav1.NamespaceDefault}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "gcp-credentials", Namespace: metav1.NamespaceDefault},
+		Data:       map[string][]byte{"credentials": []byte(`{"type": "service_account", "project_id": "my-project", "client_email": "capg@my-project.iam.gserviceaccount.com"}`)},
+	}
+	crClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(controlPlane, secret).WithStatusSubresource(controlPlane).Build()
+
+	ctx := context.Background()
+	if err := crClient.Get(ctx, client.ObjectKeyFromObject(controlPlane), controlPlane); err != nil {
+		t.Fatalf("getting %s: %v", controlPlane.Name, err)
+	}
+
+	credentialsClient, err := credentials.NewIamCredentialsClient(ctx, option.WithoutAuthentication())
+	if err != nil {
+		t.Fatalf("creating credentials client: %v", err)
+	}
+	t.Cleanup(func() { _ = credentialsClient.Close() })
+
+	s, err := scope.NewManagedControlPlaneScope(ctx, scope.ManagedControlPlaneScopeParams{
+		CredentialsClient:      credentialsClient,
+		ManagedClusterClient:   gkeClient,
+		Client:                 crClient,
+		Cluster:                &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: ClusterName, Namespace: metav1.NamespaceDefault}},
+		GCPManagedCluster:      &infrav1exp.GCPManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: ClusterName, Namespace: metav1.NamespaceDefault}},
+		GCPManagedControlPlane: controlPlane,
+	})
+	if err != nil {
+		t.Fatalf("creating managed control plane scope: %v", err)
+	}
+
+	return s
+}
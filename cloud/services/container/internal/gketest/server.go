@@ -0,0 +1,250 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gketest implements an in-memory GKE API server for the tests of the GKE services.
+package gketest
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+
+	container "cloud.google.com/go/container/apiv1"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"google.golang.org/api/option"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+)
+
+// Server is a GKE API server keeping clusters, node pools and operations in memory. Operations that change a
+// node pool are applied at once and reported as done.
+type Server struct {
+	containerpb.UnimplementedClusterManagerServer
+
+	mu         sync.Mutex
+	clusters   map[string]*containerpb.Cluster
+	nodePools  map[string]*containerpb.NodePool
+	operations map[string]*containerpb.Operation
+	requests   []proto.Message
+}
+
+// NewServer starts a server for the duration of the test and returns it along with a client connected to it.
+func NewServer(t *testing.T) (*Server, *container.ClusterManagerClient) {
+	t.Helper()
+
+	s := &Server{
+		clusters:   map[string]*containerpb.Cluster{},
+		nodePools:  map[string]*containerpb.NodePool{},
+		operations: map[string]*containerpb.Operation{},
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	containerpb.RegisterClusterManagerServer(server, s)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dialing gke test server: %v", err)
+	}
+	client, err := container.NewClusterManagerClient(context.Background(), option.WithGRPCConn(conn))
+	if err != nil {
+		t.Fatalf("creating gke test client: %v", err)
+	}
+	t.Cleanup(func() { _ = client.Close() })
+
+	return s, client
+}
+
+// SetCluster stores the cluster under its full name.
+func (s *Server) SetCluster(fullName string, cluster *containerpb.Cluster) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clusters[fullName] = proto.Clone(cluster).(*containerpb.Cluster)
+}
+
+// Cluster returns the cluster with the given full name, or nil if there is none.
+func (s *Server) Cluster(fullName string) *containerpb.Cluster {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if cluster, ok := s.clusters[fullName]; ok {
+		return proto.Clone(cluster).(*containerpb.Cluster)
+	}
+	return nil
+}
+
+// SetNodePool stores the node pool under its full name.
+func (s *Server) SetNodePool(fullName string, nodePool *containerpb.NodePool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nodePools[fullName] = proto.Clone(nodePool).(*containerpb.NodePool)
+}
+
+// NodePool returns the node pool with the given full name, or nil if there is none.
+func (s *Server) NodePool(fullName string) *containerpb.NodePool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if nodePool, ok := s.nodePools[fullName]; ok {
+		return proto.Clone(nodePool).(*containerpb.NodePool)
+	}
+	return nil
+}
+
+// SetOperation stores the operation under its name.
+func (s *Server) SetOperation(operation *containerpb.Operation) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.operations[operation.Name] = proto.Clone(operation).(*containerpb.Operation)
+}
+
+// Requests returns the requests that changed resources, in the order they were received.
+func (s *Server) Requests() []proto.Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]proto.Message(nil), s.requests...)
+}
+
+// GetCluster implements containerpb.ClusterManagerServer.
+func (s *Server) GetCluster(_ context.Context, req *containerpb.GetClusterRequest) (*containerpb.Cluster, error) {
+	if cluster := s.Cluster(req.Name); cluster != nil {
+		return cluster, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "cluster %s not found", req.Name)
+}
+
+// SetLabels implements containerpb.ClusterManagerServer.
+func (s *Server) SetLabels(_ context.Context, req *containerpb.SetLabelsRequest) (*containerpb.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	cluster, ok := s.clusters[req.Name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "cluster %s not found", req.Name)
+	}
+	cluster.ResourceLabels = req.ResourceLabels
+	return s.done(req, containerpb.Operation_SET_LABELS, req.Name), nil
+}
+
+// ListNodePools implements containerpb.ClusterManagerServer.
+func (s *Server) ListNodePools(_ context.Context, req *containerpb.ListNodePoolsRequest) (*containerpb.ListNodePoolsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &containerpb.ListNodePoolsResponse{}
+	for fullName, nodePool := range s.nodePools {
+		if strings.HasPrefix(fullName, req.Parent+"/nodePools/") {
+			resp.NodePools = append(resp.NodePools, proto.Clone(nodePool).(*containerpb.NodePool))
+		}
+	}
+	return resp, nil
+}
+
+// GetNodePool implements containerpb.ClusterManagerServer.
+func (s *Server) GetNodePool(_ context.Context, req *containerpb.GetNodePoolRequest) (*containerpb.NodePool, error) {
+	if nodePool := s.NodePool(req.Name); nodePool != nil {
+		return nodePool, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "node pool %s not found", req.Name)
+}
+
+// CreateNodePool implements containerpb.ClusterManagerServer.
+func (s *Server) CreateNodePool(_ context.Context, req *containerpb.CreateNodePoolRequest) (*containerpb.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fullName := fmt.Sprintf("%s/nodePools/%s", req.Parent, req.NodePool.GetName())
+	if _, ok := s.nodePools[fullName]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "node pool %s already exists", fullName)
+	}
+	nodePool := proto.Clone(req.NodePool).(*containerpb.NodePool)
+	nodePool.Status = containerpb.NodePool_PROVISIONING
+	s.nodePools[fullName] = nodePool
+	return s.done(req, containerpb.Operation_CREATE_NODE_POOL, fullName), nil
+}
+
+// UpdateNodePool implements containerpb.ClusterManagerServer. Only the resource labels are applied.
+func (s *Server) UpdateNodePool(_ context.Context, req *containerpb.UpdateNodePoolRequest) (*containerpb.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	nodePool, ok := s.nodePools[req.Name]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "node pool %s not found", req.Name)
+	}
+	if req.ResourceLabels != nil {
+		if nodePool.Config == nil {
+			nodePool.Config = &containerpb.NodeConfig{}
+		}
+		nodePool.Config.ResourceLabels = req.ResourceLabels.Labels
+	}
+	return s.done(req, containerpb.Operation_UPGRADE_NODES, req.Name), nil
+}
+
+// DeleteNodePool implements containerpb.ClusterManagerServer.
+func (s *Server) DeleteNodePool(_ context.Context, req *containerpb.DeleteNodePoolRequest) (*containerpb.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.nodePools[req.Name]; !ok {
+		return nil, status.Errorf(codes.NotFound, "node pool %s not found", req.Name)
+	}
+	delete(s.nodePools, req.Name)
+	return s.done(req, containerpb.Operation_DELETE_NODE_POOL, req.Name), nil
+}
+
+// GetOperation implements containerpb.ClusterManagerServer. Operations are looked up by the last segment of
+// their full name.
+func (s *Server) GetOperation(_ context.Context, req *containerpb.GetOperationRequest) (*containerpb.Operation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if operation, ok := s.operations[path.Base(req.Name)]; ok {
+		return proto.Clone(operation).(*containerpb.Operation), nil
+	}
+	return nil, status.Errorf(codes.NotFound, "operation %s not found", req.Name)
+}
+
+// ListOperations implements containerpb.ClusterManagerServer. All the operations are listed, whatever their
+// location.
+func (s *Server) ListOperations(_ context.Context, _ *containerpb.ListOperationsRequest) (*containerpb.ListOperationsResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	resp := &containerpb.ListOperationsResponse{}
+	for _, operation := range s.operations {
+		resp.Operations = append(resp.Operations, proto.Clone(operation).(*containerpb.Operation))
+	}
+	return resp, nil
+}
+
+// done records the request along with the operation that applied it.
+func (s *Server) done(req proto.Message, operationType containerpb.Operation_Type, targetLink string) *containerpb.Operation {
+	s.requests = append(s.requests, proto.Clone(req))
+	operation := &containerpb.Operation{
+		Name:          fmt.Sprintf("operation-%d", len(s.operations)+1),
+		OperationType: operationType,
+		Status:        containerpb.Operation_DONE,
+		TargetLink:    targetLink,
+	}
+	s.operations[operation.Name] = operation
+	return proto.Clone(operation).(*containerpb.Operation)
+}
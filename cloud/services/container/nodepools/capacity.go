@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/cluster-api/util/record"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/util/resourceurl"
+)
+
+const (
+	// gpuResourceName is the extended resource name GKE exposes NVIDIA GPUs under.
+	gpuResourceName corev1.ResourceName = "nvidia.com/gpu"
+)
+
+// reconcileCapacity derives the capacity published in the status from the machine type of the node pool,
+// unless it was already derived from the same machine type. The capacity is only a hint for the cluster
+// autoscaler, so failing to get it is reported without failing the reconciliation of the node pool.
+func (s *Service) reconcileCapacity(ctx context.Context, nodePool *containerpb.NodePool, log *logr.Logger) {
+	status := &s.scope.GCPManagedMachinePool.Status
+	machineType := nodePool.GetConfig().GetMachineType()
+	if status.Capacity != nil && status.CapacityMachineType == machineType {
+		return
+	}
+
+	capacity, err := s.getCapacity(ctx, nodePool)
+	if err != nil {
+		log.Error(err, "Failed to get node pool capacity", "machineType", machineType)
+		record.Warnf(s.scope.GCPManagedMachinePool, "GKENodePoolCapacityFailed", "Failed to get the capacity of GKE node pool %s: %v", s.scope.NodePoolName(), err)
+		return
+	}
+	status.Capacity = capacity
+	status.CapacityMachineType = machineType
+}
+
+// getCapacity returns the resource capacity of a single node of the node pool, as published for the
+// cluster autoscaler to scale the node pool from zero. The machine type is looked up in the project and
+// zone of the instance groups of the node pool.
+func (s *Service) getCapacity(ctx context.Context, nodePool *containerpb.NodePool) (corev1.ResourceList, error) {
+	if len(nodePool.InstanceGroupUrls) == 0 {
+		return nil, errors.New("node pool has no instance groups")
+	}
+	instanceGroupURL, err := resourceurl.Parse(nodePool.InstanceGroupUrls[0])
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing instance group url")
+	}
+
+	getMachineTypeRequest := &computepb.GetMachineTypeRequest{
+		MachineType: nodePool.GetConfig().GetMachineType(),
+		Project:     instanceGroupURL.Project,
+		Zone:        instanceGroupURL.Location,
+	}
+	machineType, err := s.scope.MachineTypesClient().Get(ctx, getMachineTypeRequest)
+	if err != nil {
+		return nil, errors.Wrapf(err, "getting machine type %s", getMachineTypeRequest.MachineType)
+	}
+
+	capacity := corev1.ResourceList{
+		corev1.ResourceCPU:    *resource.NewQuantity(int64(machineType.GetGuestCpus()), resource.DecimalSI),
+		corev1.ResourceMemory: *resource.NewQuantity(int64(machineType.GetMemoryMb())*1024*1024, resource.BinarySI),
+	}
+
+	// GPUs are either bundled with the machine type (e.g. A2 and G2) or attached to the node pool.
+	gpus := int64(0)
+	for _, accelerator := range machineType.GetAccelerators() {
+		gpus += int64(accelerator.GetGuestAcceleratorCount())
+	}
+	for _, accelerator := range nodePool.GetConfig().GetAccelerators() {
+		gpus += accelerator.GetAcceleratorCount()
+	}
+	if gpus > 0 {
+		capacity[gpuResourceName] = *resource.NewQuantity(gpus, resource.DecimalSI)
+	}
+
+	return capacity, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+	"strings"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+)
+
+// isDryRun returns true if the changes to the GKE node pool are only reported.
+func (s *Service) isDryRun() bool {
+	_, ok := s.scope.GCPManagedMachinePool.Annotations[infrav1exp.DryRunNodePoolAnnotation]
+	return ok
+}
+
+// reconcileDryRun reports the calls creating, replacing or updating the GKE node pool that reconciling it would
+// make. Unlike the reconciliation, which makes them one at a time, all the pending changes are reported at once.
+func (s *Service) reconcileDryRun(nodePool *containerpb.NodePool, log *logr.Logger) (ctrl.Result, error) {
+	managedMachinePool := s.scope.GCPManagedMachinePool
+	_, rollback := managedMachinePool.Annotations[infrav1exp.RollbackNodePoolUpgradeAnnotation]
+
+	var mutations []string
+	switch {
+	case nodePool == nil:
+		createNodePoolRequest, err := s.newCreateNodePoolRequest(log)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		mutations = append(mutations, shared.FormatMutation("CreateNodePool", createNodePoolRequest))
+	case rollback:
+		if shouldStartRollback(conditions.Get(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolRollingBackCondition)) {
+			mutations = append(mutations, shared.FormatMutation("RollbackNodePoolUpgrade", &containerpb.RollbackNodePoolUpgradeRequest{
+				Name:       s.scope.NodePoolFullName(),
+				RespectPdb: true,
+			}))
+		}
+	default:
+		if previous := managedMachinePool.Status.PreviousNodePoolName; previous != "" {
+			mutations = append(mutations, shared.FormatMutation("DeleteNodePool", &containerpb.DeleteNodePoolRequest{
+				Name: s.scope.NodePoolFullNameOf(previous),
+			}))
+		}
+		if s.needsReplacement(nodePool) {
+			createNodePoolRequest, err := s.newCreateNodePoolRequest(log)
+			if err != nil {
+				return ctrl.Result{}, err
+			}
+			createNodePoolRequest.NodePool.Name = managedMachinePool.GenerateReplacementNodePoolName()
+			mutations = append(mutations,
+				shared.FormatMutation("CreateNodePool", createNodePoolRequest),
+				shared.FormatMutation("DeleteNodePool", &containerpb.DeleteNodePoolRequest{Name: s.scope.NodePoolFullName()}))
+			break
+		}
+		if needUpdate, updateNodePoolRequest := s.checkDiffAndPrepareUpdateVersionOrImage(nodePool); needUpdate {
+			mutations = append(mutations, shared.FormatMutation("UpdateNodePool", updateNodePoolRequest))
+		}
+		if needUpdate, setNodePoolAutoscalingRequest := s.checkDiffAndPrepareUpdateAutoscaling(nodePool); needUpdate {
+			mutations = append(mutations, shared.FormatMutation("SetNodePoolAutoscaling", setNodePoolAutoscalingRequest))
+		}
+		if needUpdate, setNodePoolSizeRequest := s.checkDiffAndPrepareUpdateSize(nodePool); needUpdate {
+			mutations = append(mutations, shared.FormatMutation("SetNodePoolSize", setNodePoolSizeRequest))
+		}
+	}
+
+	return s.reportDryRun(log, mutations)
+}
+
+// reconcileDryRunDelete reports the calls deleting the GKE node pool that deleting the GCPManagedMachinePool would
+// make. The GCPManagedMachinePoolMachines and the Machines of the node pool are left in place.
+func (s *Service) reconcileDryRunDelete(ctx context.Context, log *logr.Logger) (ctrl.Result, error) {
+	managedMachinePool := s.scope.GCPManagedMachinePool
+	if managedMachinePool.Spec.DeletionPolicy == infrav1exp.NodePoolDeletionPolicyRetain {
+		return s.reportDryRun(log, nil)
+	}
+
+	var mutations []string
+	if previous := managedMachinePool.Status.PreviousNodePoolName; previous != "" {
+		mutations = append(mutations, shared.FormatMutation("DeleteNodePool", &containerpb.DeleteNodePoolRequest{
+			Name: s.scope.NodePoolFullNameOf(previous),
+		}))
+	}
+
+	nodePool, err := s.describeNodePool(ctx, log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if nodePool != nil && s.isOwned(nodePool) {
+		mutations = append(mutations, shared.FormatMutation("DeleteNodePool", &containerpb.DeleteNodePoolRequest{
+			Name: s.scope.NodePoolFullName(),
+		}))
+	}
+
+	return s.reportDryRun(log, mutations)
+}
+
+// reportDryRun reports the calls to GCP skipped in dry-run mode in the GKEMachinePoolDryRun condition and in events.
+func (s *Service) reportDryRun(log *logr.Logger, mutations []string) (ctrl.Result, error) {
+	result := ctrl.Result{RequeueAfter: reconciler.ResyncInterval(s.scope.GCPManagedMachinePool)}
+
+	if len(mutations) == 0 {
+		log.Info("Dry run, GKE node pool is up to date")
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolDryRunCondition)
+		return result, nil
+	}
+
+	for _, mutation := range mutations {
+		log.Info("Dry run, skipping call to GCP", "call", mutation)
+		record.Eventf(s.scope.GCPManagedMachinePool, "GKENodePoolDryRun", "Skipped %s", mutation)
+	}
+	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolDryRunCondition, infrav1exp.GKEMachinePoolChangesPlannedReason, clusterv1.ConditionSeverityInfo,
+		"%s", strings.Join(mutations, "; "))
+
+	return result, nil
+}
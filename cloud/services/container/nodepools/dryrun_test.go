@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/internal/gketest"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestReconcileDryRun(t *testing.T) {
+	ownedLabels := map[string]string{"capg-cluster-my-cluster": "owned"}
+
+	tests := []struct {
+		name      string
+		nodePool  *containerpb.NodePool
+		spec      infrav1exp.GCPManagedMachinePoolSpec
+		delete    bool
+		wantCalls []string
+	}{
+		{
+			name:      "node pool to create",
+			spec:      infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pool-0"},
+			wantCalls: []string{"CreateNodePool"},
+		},
+		{
+			name: "node pool to replace",
+			nodePool: &containerpb.NodePool{
+				Name:   "pool-0",
+				Status: containerpb.NodePool_RUNNING,
+				Config: &containerpb.NodeConfig{MachineType: "e2-medium", ResourceLabels: ownedLabels},
+			},
+			spec: infrav1exp.GCPManagedMachinePoolSpec{
+				NodePoolName:              "pool-0",
+				MachineType:               "e2-standard-4",
+				MachineTypeUpdateStrategy: infrav1exp.MachineTypeUpdateStrategyReplace,
+			},
+			wantCalls: []string{"CreateNodePool", "DeleteNodePool"},
+		},
+		{
+			name: "node pool to delete",
+			nodePool: &containerpb.NodePool{
+				Name:   "pool-0",
+				Status: containerpb.NodePool_RUNNING,
+				Config: &containerpb.NodeConfig{ResourceLabels: ownedLabels},
+			},
+			spec:      infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pool-0"},
+			delete:    true,
+			wantCalls: []string{"DeleteNodePool"},
+		},
+		{
+			name: "node pool to retain",
+			nodePool: &containerpb.NodePool{
+				Name:   "pool-0",
+				Status: containerpb.NodePool_RUNNING,
+				Config: &containerpb.NodeConfig{ResourceLabels: ownedLabels},
+			},
+			spec:   infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pool-0", DeletionPolicy: infrav1exp.NodePoolDeletionPolicyRetain},
+			delete: true,
+		},
+		{
+			name: "node pool not adopted left in place",
+			nodePool: &containerpb.NodePool{
+				Name:   "pool-0",
+				Status: containerpb.NodePool_RUNNING,
+				Config: &containerpb.NodeConfig{ResourceLabels: map[string]string{"team": "a"}},
+			},
+			spec:   infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pool-0"},
+			delete: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, gkeClient := gketest.NewServer(t)
+			if tt.nodePool != nil {
+				server.SetNodePool(gketest.NodePoolFullName("pool-0"), tt.nodePool)
+			}
+			managedMachinePool := &infrav1exp.GCPManagedMachinePool{Spec: tt.spec}
+			managedMachinePool.Annotations = map[string]string{infrav1exp.DryRunNodePoolAnnotation: ""}
+			s := gketest.NewManagedMachinePoolScope(t, gkeClient, managedMachinePool)
+
+			svc := New(s)
+			reconcile := svc.Reconcile
+			if tt.delete {
+				reconcile = svc.Delete
+			}
+			if _, err := reconcile(context.Background()); err != nil {
+				t.Fatalf("reconcile error = %v", err)
+			}
+
+			if requests := server.Requests(); len(requests) != 0 {
+				t.Errorf("calls made in dry-run mode: %v", requests)
+			}
+			dryRun := conditions.Get(s.GCPManagedMachinePool, infrav1exp.GKEMachinePoolDryRunCondition)
+			if dryRun == nil {
+				t.Fatal("dry run not reported")
+			}
+			var calls []string
+			for _, mutation := range strings.Split(dryRun.Message, "; ") {
+				if call, _, ok := strings.Cut(mutation, " "); ok {
+					calls = append(calls, call)
+				}
+			}
+			if strings.Join(calls, ",") != strings.Join(tt.wantCalls, ",") {
+				t.Errorf("reported calls = %v, want %v", calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/providerid"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/resourceurl"
+)
+
+// reconcileMachines aligns the GCPManagedMachinePoolMachines of the machine pool with the instances of the node
+// pool, for Cluster API to create a Machine for each of them. The instance of a GCPManagedMachinePoolMachine
+// that is deleted, e.g. by a MachineHealthCheck remediation, is recreated once its node has been drained.
+func (s *Service) reconcileMachines(ctx context.Context, nodePool *containerpb.NodePool, instances []*computepb.ManagedInstance) error {
+	log := log.FromContext(ctx)
+
+	// Instances that are being removed from the instance group will not back a node anymore.
+	activeInstances := map[string]*computepb.ManagedInstance{}
+	for _, instance := range instances {
+		switch instance.GetCurrentAction() {
+		case computepb.ManagedInstance_DELETING.String(), computepb.ManagedInstance_ABANDONING.String():
+			continue
+		}
+		providerID, err := providerid.NewFromResourceURL(instance.GetInstance())
+		if err != nil {
+			return errors.Wrapf(err, "parsing instance url %s", instance.GetInstance())
+		}
+		activeInstances[providerID.String()] = instance
+	}
+
+	machines, err := s.scope.ListMachinePoolMachines(ctx)
+	if err != nil {
+		return errors.Wrap(err, "listing machine pool machines")
+	}
+
+	existing := map[string]bool{}
+	for i := range machines {
+		machine := &machines[i]
+		existing[machine.Spec.ProviderID] = true
+		instance, active := activeInstances[machine.Spec.ProviderID]
+
+		if !machine.DeletionTimestamp.IsZero() {
+			if active && instance.GetCurrentAction() == computepb.ManagedInstance_NONE.String() {
+				log.Info("Recreating node pool instance", "machine", machine.Name, "providerID", machine.Spec.ProviderID)
+				if err := s.recreateInstance(ctx, nodePool, machine.Spec.ProviderID, instance.GetInstance()); err != nil {
+					return err
+				}
+			}
+			if err := s.releaseMachine(ctx, machine); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !active {
+			log.Info("Node pool instance is gone, deleting its machine", "machine", machine.Name, "providerID", machine.Spec.ProviderID)
+			if err := s.deleteMachine(ctx, machine); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := s.patchMachineStatus(ctx, machine, instance); err != nil {
+			return err
+		}
+	}
+
+	for providerID, instance := range activeInstances {
+		if existing[providerID] {
+			continue
+		}
+		if err := s.createMachine(ctx, providerID, instance); err != nil {
+			return err
+		}
+	}
+
+	s.scope.GCPManagedMachinePool.Status.InfrastructureMachineKind = infrav1exp.GCPManagedMachinePoolMachineKind
+	return nil
+}
+
+// deleteMachines deletes the GCPManagedMachinePoolMachines of the machine pool without recreating their
+// instances, which are removed along with the node pool.
+func (s *Service) deleteMachines(ctx context.Context) error {
+	machines, err := s.scope.ListMachinePoolMachines(ctx)
+	if err != nil {
+		return errors.Wrap(err, "listing machine pool machines")
+	}
+
+	for i := range machines {
+		machine := &machines[i]
+		if err := s.releaseMachine(ctx, machine); err != nil {
+			return err
+		}
+		if machine.DeletionTimestamp.IsZero() {
+			if err := s.scope.Client().Delete(ctx, machine); err != nil && !apierrors.IsNotFound(err) {
+				return errors.Wrapf(err, "deleting machine pool machine %s", machine.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) createMachine(ctx context.Context, providerID string, instance *computepb.ManagedInstance) error {
+	log := log.FromContext(ctx)
+
+	managedMachinePool := s.scope.GCPManagedMachinePool
+	resourceURL, err := resourceurl.Parse(instance.GetInstance())
+	if err != nil {
+		return errors.Wrap(err, "error parsing instance url")
+	}
+
+	machine := &infrav1exp.GCPManagedMachinePoolMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      resourceURL.Name,
+			Namespace: managedMachinePool.Namespace,
+			Labels:    s.scope.MachinePoolMachineLabels(),
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: infrav1exp.GroupVersion.String(),
+				Kind:       "GCPManagedMachinePool",
+				Name:       managedMachinePool.Name,
+				UID:        managedMachinePool.UID,
+			}},
+			Finalizers: []string{infrav1exp.ManagedMachinePoolMachineFinalizer},
+		},
+		Spec: infrav1exp.GCPManagedMachinePoolMachineSpec{
+			ProviderID: providerID,
+		},
+	}
+
+	log.Info("Creating machine for node pool instance", "machine", machine.Name, "providerID", providerID)
+	if err := s.scope.Client().Create(ctx, machine); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "creating machine pool machine %s", machine.Name)
+	}
+
+	return s.patchMachineStatus(ctx, machine, instance)
+}
+
+func (s *Service) patchMachineStatus(ctx context.Context, machine *infrav1exp.GCPManagedMachinePoolMachine, instance *computepb.ManagedInstance) error {
+	status := infrav1exp.GCPManagedMachinePoolMachineStatus{
+		Ready: instance.GetInstanceStatus() == computepb.ManagedInstance_RUNNING.String() &&
+			instance.GetCurrentAction() == computepb.ManagedInstance_NONE.String(),
+		InstanceStatus: instance.GetInstanceStatus(),
+		CurrentAction:  instance.GetCurrentAction(),
+	}
+	if machine.Status == status {
+		return nil
+	}
+
+	patchHelper, err := patch.NewHelper(machine, s.scope.Client())
+	if err != nil {
+		return err
+	}
+	machine.Status = status
+
+	return patchHelper.Patch(ctx, machine)
+}
+
+// deleteMachine deletes the Machine owning the GCPManagedMachinePoolMachine, for its node to be drained
+// before the GCPManagedMachinePoolMachine is deleted.
+func (s *Service) deleteMachine(ctx context.Context, machine *infrav1exp.GCPManagedMachinePoolMachine) error {
+	owner, err := util.GetOwnerMachine(ctx, s.scope.Client(), machine.ObjectMeta)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "getting owner machine of %s", machine.Name)
+	}
+
+	var obj client.Object = machine
+	if owner != nil {
+		obj = owner
+	}
+	if err := s.scope.Client().Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "deleting machine %s", obj.GetName())
+	}
+
+	return nil
+}
+
+// releaseMachine removes the finalizer of the GCPManagedMachinePoolMachine.
+func (s *Service) releaseMachine(ctx context.Context, machine *infrav1exp.GCPManagedMachinePoolMachine) error {
+	if !controllerutil.ContainsFinalizer(machine, infrav1exp.ManagedMachinePoolMachineFinalizer) {
+		return nil
+	}
+
+	patchHelper, err := patch.NewHelper(machine, s.scope.Client())
+	if err != nil {
+		return err
+	}
+	controllerutil.RemoveFinalizer(machine, infrav1exp.ManagedMachinePoolMachineFinalizer)
+
+	return patchHelper.Patch(ctx, machine)
+}
+
+// recreateInstance recreates the instance in the instance group of its zone, which keeps the size of the node pool.
+func (s *Service) recreateInstance(ctx context.Context, nodePool *containerpb.NodePool, id, instanceURL string) error {
+	providerID, err := providerid.Parse(id)
+	if err != nil {
+		return errors.Wrapf(err, "parsing provider id %s", id)
+	}
+
+	for _, url := range nodePool.InstanceGroupUrls {
+		resourceURL, err := resourceurl.Parse(url)
+		if err != nil {
+			return errors.Wrap(err, "error parsing instance group url")
+		}
+		if resourceURL.Location != providerID.Location() {
+			continue
+		}
+
+		_, err = s.scope.InstanceGroupManagersClient().RecreateInstances(ctx, &computepb.RecreateInstancesInstanceGroupManagerRequest{
+			InstanceGroupManager: resourceURL.Name,
+			Project:              resourceURL.Project,
+			Zone:                 resourceURL.Location,
+			InstanceGroupManagersRecreateInstancesRequestResource: &computepb.InstanceGroupManagersRecreateInstancesRequest{
+				Instances: []string{instanceURL},
+			},
+		})
+		return err
+	}
+
+	return errors.Errorf("no instance group found for instance %s", instanceURL)
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/compute/apiv1/computepb"
+	"cloud.google.com/go/container/apiv1/containerpb"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/internal/gketest"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+// newMachine returns a GCPManagedMachinePoolMachine of the node pool "pool-0" for the instance with the given name.
+func newMachine(name string, deleting bool) *infrav1exp.GCPManagedMachinePoolMachine {
+	machine := &infrav1exp.GCPManagedMachinePoolMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			Namespace:  metav1.NamespaceDefault,
+			Labels:     map[string]string{clusterv1.ClusterNameLabel: gketest.ClusterName, clusterv1.MachinePoolNameLabel: "pool-0"},
+			Finalizers: []string{infrav1exp.ManagedMachinePoolMachineFinalizer},
+		},
+		Spec: infrav1exp.GCPManagedMachinePoolMachineSpec{ProviderID: "gce://my-project/us-central1-a/" + name},
+	}
+	if deleting {
+		now := metav1.Now()
+		machine.DeletionTimestamp = &now
+	}
+	return machine
+}
+
+// newInstance returns the managed instance with the given name.
+func newInstance(name string, status computepb.ManagedInstance_InstanceStatus, action computepb.ManagedInstance_CurrentAction) *computepb.ManagedInstance {
+	return &computepb.ManagedInstance{
+		Instance:       pointer.String("https://www.googleapis.com/compute/v1/projects/my-project/zones/us-central1-a/instances/" + name),
+		InstanceStatus: pointer.String(status.String()),
+		CurrentAction:  pointer.String(action.String()),
+	}
+}
+
+func TestReconcileMachines(t *testing.T) {
+	ctx := context.Background()
+	_, gkeClient := gketest.NewServer(t)
+	s := gketest.NewManagedMachinePoolScope(t, gkeClient, &infrav1exp.GCPManagedMachinePool{
+		Spec: infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pool-0"},
+	},
+		newMachine("gke-running", false),
+		newMachine("gke-gone", false),
+		newMachine("gke-recreated", true),
+	)
+	instances := []*computepb.ManagedInstance{
+		newInstance("gke-running", computepb.ManagedInstance_RUNNING, computepb.ManagedInstance_NONE),
+		newInstance("gke-recreated", computepb.ManagedInstance_STOPPING, computepb.ManagedInstance_RECREATING),
+		newInstance("gke-new", computepb.ManagedInstance_PROVISIONING, computepb.ManagedInstance_CREATING),
+		newInstance("gke-deleting", computepb.ManagedInstance_STOPPING, computepb.ManagedInstance_DELETING),
+	}
+
+	if err := New(s).reconcileMachines(ctx, &containerpb.NodePool{Name: "pool-0"}, instances); err != nil {
+		t.Fatalf("reconcileMachines() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		wantExists bool
+		wantStatus infrav1exp.GCPManagedMachinePoolMachineStatus
+	}{
+		{
+			name:       "gke-running",
+			wantExists: true,
+			wantStatus: infrav1exp.GCPManagedMachinePoolMachineStatus{Ready: true, InstanceStatus: "RUNNING", CurrentAction: "NONE"},
+		},
+		{
+			name:       "gke-new",
+			wantExists: true,
+			wantStatus: infrav1exp.GCPManagedMachinePoolMachineStatus{InstanceStatus: "PROVISIONING", CurrentAction: "CREATING"},
+		},
+		{
+			name: "gke-gone",
+		},
+		{
+			name: "gke-recreated",
+		},
+		{
+			name: "gke-deleting",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			machine := &infrav1exp.GCPManagedMachinePoolMachine{}
+			err := s.Client().Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: tt.name}, machine)
+			if apierrors.IsNotFound(err) || err == nil && !machine.DeletionTimestamp.IsZero() {
+				if tt.wantExists {
+					t.Fatal("machine deleted")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !tt.wantExists {
+				t.Fatal("machine not deleted")
+			}
+			if machine.Spec.ProviderID != "gce://my-project/us-central1-a/"+tt.name {
+				t.Errorf("provider id = %q", machine.Spec.ProviderID)
+			}
+			if machine.Status != tt.wantStatus {
+				t.Errorf("status = %+v, want %+v", machine.Status, tt.wantStatus)
+			}
+		})
+	}
+
+	if got := s.GCPManagedMachinePool.Status.InfrastructureMachineKind; got != infrav1exp.GCPManagedMachinePoolMachineKind {
+		t.Errorf("infrastructure machine kind = %q, want %q", got, infrav1exp.GCPManagedMachinePoolMachineKind)
+	}
+}
+
+func TestDeleteMachines(t *testing.T) {
+	ctx := context.Background()
+	_, gkeClient := gketest.NewServer(t)
+	s := gketest.NewManagedMachinePoolScope(t, gkeClient, &infrav1exp.GCPManagedMachinePool{
+		Spec: infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pool-0"},
+	},
+		newMachine("gke-running", false),
+		newMachine("gke-deleting", true),
+	)
+
+	if err := New(s).deleteMachines(ctx); err != nil {
+		t.Fatalf("deleteMachines() error = %v", err)
+	}
+
+	machines, err := s.ListMachinePoolMachines(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(machines) != 0 {
+		t.Errorf("machines = %v, want none", machines)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"reflect"
+	"sort"
 	"strings"
 
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
@@ -35,9 +36,11 @@ import (
 	"github.com/pkg/errors"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/providerid"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/shared"
@@ -50,12 +53,28 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling node pool resources")
 
+	// Persist a generated node pool name before creating the node pool to not lose track of it.
+	if s.scope.GCPManagedMachinePool.EnsureNodePoolName() {
+		log.Info("Generated node pool name", "name", s.scope.NodePoolName())
+		if err := s.scope.PatchObject(); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
 	nodePool, err := s.describeNodePool(ctx, &log)
 	if err != nil {
 		s.scope.GCPManagedMachinePool.Status.Ready = false
 		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
 		return ctrl.Result{}, err
 	}
+	// A node pool that is not labeled as owned by the cluster is pre-existing, and only managed once adopted.
+	if nodePool != nil && !s.isOwned(nodePool) && !s.adoptNodePool(nodePool, &log) {
+		return ctrl.Result{}, nil
+	}
+	if s.isDryRun() {
+		return s.reconcileDryRun(nodePool, &log)
+	}
+	conditions.Delete(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolDryRunCondition)
 	if nodePool == nil {
 		log.Info("Node pool not found, creating", "cluster", s.scope.Cluster.Name)
 		s.scope.GCPManagedMachinePool.Status.Ready = false
@@ -69,7 +88,7 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEMachinePoolCreatingReason, clusterv1.ConditionSeverityInfo, "")
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolCreatingReason, clusterv1.ConditionSeverityInfo, "")
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolCreatingCondition)
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
 	}
 	log.V(2).Info("Node pool found", "cluster", s.scope.Cluster.Name, "nodepool", nodePool.Name)
 
@@ -81,6 +100,12 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 	}
 	providerIDList := []string{}
 	for _, instance := range instances {
+		// Instances that are being removed from the instance group will not back a node anymore.
+		switch instance.GetCurrentAction() {
+		case computepb.ManagedInstance_DELETING.String(), computepb.ManagedInstance_ABANDONING.String():
+			log.V(4).Info("skipping instance being removed", "url", instance.GetInstance(), "action", instance.GetCurrentAction())
+			continue
+		}
 		log.V(4).Info("parsing gce instance url", "url", *instance.Instance)
 		providerID, err := providerid.NewFromResourceURL(*instance.Instance)
 		if err != nil {
@@ -91,7 +116,19 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		}
 		providerIDList = append(providerIDList, providerID.String())
 	}
+	// Instances are listed per instance group, keep the list stable to avoid needless updates.
+	sort.Strings(providerIDList)
 	s.scope.GCPManagedMachinePool.Spec.ProviderIDList = providerIDList
+	s.scope.SetReplicas(int32(len(providerIDList)))
+
+	if err := s.reconcileMachines(ctx, nodePool, instances); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if _, ok := s.scope.GCPManagedMachinePool.Annotations[infrav1exp.RollbackNodePoolUpgradeAnnotation]; ok {
+		return s.reconcileRollback(ctx, nodePool)
+	}
+	conditions.Delete(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolRollingBackCondition)
 
 	switch nodePool.Status {
 	case containerpb.NodePool_PROVISIONING:
@@ -100,12 +137,20 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		conditions.MarkFalse(s.scope.ConditionSetter(), clusterv1.ReadyCondition, infrav1exp.GKEMachinePoolCreatingReason, clusterv1.ConditionSeverityInfo, "")
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolCreatingReason, clusterv1.ConditionSeverityInfo, "")
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolCreatingCondition)
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
 	case containerpb.NodePool_RECONCILING:
 		log.Info("Node pool reconciling in progress")
+		if err := s.reconcileCompleteUpgrade(ctx, nodePool); err != nil {
+			return ctrl.Result{}, err
+		}
+		if conditions.IsTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpgradingCondition) {
+			if err := s.reconcileUpgradeProgress(ctx, nodePool); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
 		s.scope.GCPManagedMachinePool.Status.Ready = true
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition)
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
 	case containerpb.NodePool_STOPPING:
 		log.Info("Node pool stopping in progress")
 		s.scope.GCPManagedMachinePool.Status.Ready = false
@@ -119,6 +164,7 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 			msg = nodePool.Conditions[0].GetMessage()
 		}
 		log.Error(errors.New("Node pool in error/degraded state"), msg, "name", s.scope.GCPManagedMachinePool.Name)
+		record.Warnf(s.scope.GCPManagedMachinePool, "GKENodePoolError", "GKE node pool %s is in %s state: %s", s.scope.NodePoolName(), nodePool.Status.String(), msg)
 		s.scope.GCPManagedMachinePool.Status.Ready = false
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolErrorReason, clusterv1.ConditionSeverityError, "")
 		return ctrl.Result{}, nil
@@ -129,6 +175,38 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		return ctrl.Result{}, nil
 	}
 
+	if s.scope.GCPManagedMachinePool.Status.PreviousNodePoolName != "" {
+		deleted, err := s.reconcilePreviousNodePool(ctx, &log)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !deleted {
+			conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition)
+			return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
+		}
+	}
+
+	if s.needsReplacement(nodePool) {
+		if err := s.startReplacement(nodePool, &log); err != nil {
+			return ctrl.Result{}, err
+		}
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition)
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
+	}
+
+	upgradeVersion := !s.hasDesiredVersion(s.scope.NodePoolVersion(), nodePool.Version)
+	if upgradeVersion {
+		reason, message, err := s.checkUpgradeAllowed(ctx)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if reason != "" {
+			log.Info("Node pool version upgrade is waiting", "reason", reason, "message", message)
+			conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpgradingCondition, reason, clusterv1.ConditionSeverityInfo, message)
+			return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
+		}
+	}
+
 	needUpdateVersionOrImage, nodePoolUpdateVersionOrImage := s.checkDiffAndPrepareUpdateVersionOrImage(nodePool)
 	if needUpdateVersionOrImage {
 		log.Info("Version/image update required")
@@ -139,7 +217,10 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		log.Info("Node pool version/image updating in progress")
 		s.scope.GCPManagedMachinePool.Status.Ready = true
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition)
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		if upgradeVersion {
+			conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpgradingCondition)
+		}
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
 	}
 
 	needUpdateAutoscaling, setNodePoolAutoscalingRequest := s.checkDiffAndPrepareUpdateAutoscaling(nodePool)
@@ -152,7 +233,7 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		log.Info("Node pool auto scaling updating in progress")
 		s.scope.GCPManagedMachinePool.Status.Ready = true
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition)
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
 	}
 
 	needUpdateSize, setNodePoolSizeRequest := s.checkDiffAndPrepareUpdateSize(nodePool)
@@ -165,19 +246,29 @@ func (s *Service) Reconcile(ctx context.Context) (ctrl.Result, error) {
 		log.Info("Node pool size updating in progress")
 		s.scope.GCPManagedMachinePool.Status.Ready = true
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition)
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
+	}
+
+	if msg := s.checkProvisioningModelDrift(nodePool); msg != "" {
+		log.Info("Node pool provisioning model cannot be updated", "message", msg)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition, infrav1exp.GKEMachinePoolProvisioningModelChangedReason, clusterv1.ConditionSeverityWarning, msg)
+	} else {
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition, infrav1exp.GKEMachinePoolUpdatedReason, clusterv1.ConditionSeverityInfo, "")
+	}
+	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpgradingCondition, infrav1exp.GKEMachinePoolUpgradedReason, clusterv1.ConditionSeverityInfo, "")
+	if err := s.releaseUpgradeSlot(ctx); err != nil {
+		return ctrl.Result{}, err
 	}
 
-	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpdatingCondition, infrav1exp.GKEMachinePoolUpdatedReason, clusterv1.ConditionSeverityInfo, "")
+	s.reconcileCapacity(ctx, nodePool, &log)
 
-	s.scope.SetReplicas(int32(len(s.scope.GCPManagedMachinePool.Spec.ProviderIDList)))
 	log.Info("Node pool reconciled")
 	s.scope.GCPManagedMachinePool.Status.Ready = true
 	conditions.MarkTrue(s.scope.ConditionSetter(), clusterv1.ReadyCondition)
 	conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition)
 	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolCreatingCondition, infrav1exp.GKEMachinePoolCreatedReason, clusterv1.ConditionSeverityInfo, "")
 
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: reconciler.ResyncInterval(s.scope.GCPManagedMachinePool)}, nil
 }
 
 // Delete delete GKE node pool.
@@ -185,6 +276,28 @@ func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 	log := log.FromContext(ctx)
 	log.Info("Deleting node pool resources")
 
+	if s.isDryRun() {
+		return s.reconcileDryRunDelete(ctx, &log)
+	}
+
+	if err := s.deleteMachines(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if s.scope.GCPManagedMachinePool.Spec.DeletionPolicy == infrav1exp.NodePoolDeletionPolicyRetain {
+		return s.retainNodePool(ctx, &log)
+	}
+
+	if s.scope.GCPManagedMachinePool.Status.PreviousNodePoolName != "" {
+		deleted, err := s.reconcilePreviousNodePool(ctx, &log)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !deleted {
+			return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
+		}
+	}
+
 	nodePool, err := s.describeNodePool(ctx, &log)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -194,19 +307,24 @@ func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolDeletingCondition, infrav1exp.GKEMachinePoolDeletedReason, clusterv1.ConditionSeverityInfo, "")
 		return ctrl.Result{}, err
 	}
+	if !s.isOwned(nodePool) {
+		log.Info("Leaving node pool that has not been adopted", "nodepool", nodePool.Name)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolDeletingCondition, infrav1exp.GKEMachinePoolDeletedReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{}, nil
+	}
 
 	switch nodePool.Status {
 	case containerpb.NodePool_PROVISIONING:
 		log.Info("Node pool provisioning in progress")
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
 	case containerpb.NodePool_RECONCILING:
 		log.Info("Node pool reconciling in progress")
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
 	case containerpb.NodePool_STOPPING:
 		log.Info("Node pool stopping in progress")
 		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolDeletingReason, clusterv1.ConditionSeverityInfo, "")
 		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolDeletingCondition)
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
 	default:
 		break
 	}
@@ -224,6 +342,74 @@ func (s *Service) Delete(ctx context.Context) (ctrl.Result, error) {
 	return ctrl.Result{}, nil
 }
 
+// retainNodePool leaves the node pool behind according to the deletion policy of the GCPManagedMachinePool. The
+// label marking the node pool as owned by the cluster is removed first, for the node pool not to be deleted as
+// an orphan once the GCPManagedMachinePool is gone.
+func (s *Service) retainNodePool(ctx context.Context, log *logr.Logger) (ctrl.Result, error) {
+	nodePool, err := s.describeNodePool(ctx, log)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	clusterName := s.scope.MachinePool.Spec.ClusterName
+	if nodePool != nil && infrav1.Labels(nodePool.GetConfig().GetResourceLabels()).HasOwned(clusterName) {
+		switch nodePool.Status {
+		case containerpb.NodePool_PROVISIONING, containerpb.NodePool_RECONCILING, containerpb.NodePool_STOPPING:
+			log.Info("Waiting for node pool operation before retaining it", "status", nodePool.Status.String())
+			return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
+		}
+
+		labels := make(map[string]string, len(nodePool.Config.ResourceLabels))
+		for k, v := range nodePool.Config.ResourceLabels {
+			labels[k] = v
+		}
+		delete(labels, infrav1.ClusterTagKey(clusterName))
+
+		log.Info("Removing owned label from retained node pool", "nodepool", nodePool.Name)
+		if _, err := s.scope.ManagedMachinePoolClient().UpdateNodePool(ctx, &containerpb.UpdateNodePoolRequest{
+			Name:           s.scope.NodePoolFullName(),
+			ResourceLabels: &containerpb.ResourceLabels{Labels: labels},
+		}); err != nil {
+			record.Warnf(s.scope.GCPManagedMachinePool, "GKENodePoolUpdateFailed", "Failed to remove the owned label from GKE node pool %s: %v", s.scope.NodePoolName(), err)
+			return ctrl.Result{}, err
+		}
+		// The node pool is only released once the label is gone.
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
+	}
+
+	log.Info("Retaining node pool according to its deletion policy")
+	s.scope.GCPManagedMachinePool.Status.Ready = false
+	conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolDeletingCondition, infrav1exp.GKEMachinePoolRetainedReason, clusterv1.ConditionSeverityInfo, "")
+	return ctrl.Result{}, nil
+}
+
+// isOwned returns true if the node pool has the label marking it as owned by the cluster. Node pools created or
+// adopted by the GCPManagedMachinePool before the label was introduced are owned too, and get the label with
+// their next update.
+func (s *Service) isOwned(nodePool *containerpb.NodePool) bool {
+	return infrav1.Labels(nodePool.GetConfig().GetResourceLabels()).HasOwned(s.scope.MachinePool.Spec.ClusterName) ||
+		conditions.Get(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolCreatingCondition) != nil
+}
+
+// adoptNodePool returns true if the pre-existing node pool is adopted by the GCPManagedMachinePool, and otherwise
+// reports that it already exists.
+func (s *Service) adoptNodePool(nodePool *containerpb.NodePool, log *logr.Logger) bool {
+	if _, ok := s.scope.GCPManagedMachinePool.Annotations[infrav1exp.AdoptNodePoolAnnotation]; !ok {
+		log.Info("Node pool already exists and is not adopted", "nodepool", nodePool.Name)
+		s.scope.GCPManagedMachinePool.Status.Ready = false
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolReadyCondition, infrav1exp.GKEMachinePoolAlreadyExistsReason, clusterv1.ConditionSeverityError,
+			"node pool %s already exists, set the %s annotation to adopt it", nodePool.Name, infrav1exp.AdoptNodePoolAnnotation)
+		return false
+	}
+
+	// The adoption is only recorded once the changes to the node pool are applied, not while they are reviewed.
+	if !s.isDryRun() {
+		log.Info("Adopting existing node pool", "nodepool", nodePool.Name)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolCreatingCondition, infrav1exp.GKEMachinePoolAdoptedReason, clusterv1.ConditionSeverityInfo, "")
+	}
+	return true
+}
+
 func (s *Service) describeNodePool(ctx context.Context, log *logr.Logger) (*containerpb.NodePool, error) {
 	getNodePoolRequest := &containerpb.GetNodePoolRequest{
 		Name: s.scope.NodePoolFullName(),
@@ -273,30 +459,51 @@ func (s *Service) getInstances(ctx context.Context, nodePool *containerpb.NodePo
 }
 
 func (s *Service) createNodePool(ctx context.Context, log *logr.Logger) error {
+	createNodePoolRequest, err := s.newCreateNodePoolRequest(log)
+	if err != nil {
+		return err
+	}
+	_, err = s.scope.ManagedMachinePoolClient().CreateNodePool(ctx, createNodePoolRequest)
+	if err != nil {
+		record.Warnf(s.scope.GCPManagedMachinePool, "GKENodePoolCreateFailed", "Failed to create GKE node pool %s: %v", s.scope.NodePoolName(), err)
+		return err
+	}
+	record.Eventf(s.scope.GCPManagedMachinePool, "GKENodePoolCreate", "Creating GKE node pool %s", s.scope.NodePoolName())
+
+	return nil
+}
+
+// newCreateNodePoolRequest returns the request creating the GKE node pool.
+func (s *Service) newCreateNodePoolRequest(log *logr.Logger) (*containerpb.CreateNodePoolRequest, error) {
 	log.V(2).Info("Running pre-flight checks on machine pool before creation")
 	if err := shared.ManagedMachinePoolPreflightCheck(s.scope.GCPManagedMachinePool, s.scope.MachinePool, s.scope.Region()); err != nil {
-		return fmt.Errorf("preflight checks on machine pool before creating: %w", err)
+		return nil, fmt.Errorf("preflight checks on machine pool before creating: %w", err)
 	}
 
 	isRegional := shared.IsRegional(s.scope.Region())
 
-	createNodePoolRequest := &containerpb.CreateNodePoolRequest{
-		NodePool: scope.ConvertToSdkNodePool(*s.scope.GCPManagedMachinePool, *s.scope.MachinePool, isRegional),
-		Parent:   s.scope.NodePoolLocation(),
-	}
-	_, err := s.scope.ManagedMachinePoolClient().CreateNodePool(ctx, createNodePoolRequest)
-	if err != nil {
-		return err
+	nodePool := scope.ConvertToSdkNodePool(*s.scope.GCPManagedMachinePool, *s.scope.MachinePool, isRegional)
+	if mode := s.scope.WorkloadMetadataMode(); mode != nil {
+		nodePool.Config.WorkloadMetadataConfig = scope.ConvertToSdkWorkloadMetadataConfig(*mode)
 	}
 
-	return nil
+	return &containerpb.CreateNodePoolRequest{
+		NodePool: nodePool,
+		Parent:   s.scope.NodePoolLocation(),
+	}, nil
 }
 
 func (s *Service) updateNodePoolVersionOrImage(ctx context.Context, updateNodePoolRequest *containerpb.UpdateNodePoolRequest) error {
 	_, err := s.scope.ManagedMachinePoolClient().UpdateNodePool(ctx, updateNodePoolRequest)
 	if err != nil {
+		record.Warnf(s.scope.GCPManagedMachinePool, "GKENodePoolUpdateFailed", "Failed to update GKE node pool %s: %v", s.scope.NodePoolName(), err)
 		return err
 	}
+	if updateNodePoolRequest.NodeVersion != "" {
+		record.Eventf(s.scope.GCPManagedMachinePool, "GKENodePoolUpgrade", "Upgrading GKE node pool %s to version %s", s.scope.NodePoolName(), updateNodePoolRequest.NodeVersion)
+	} else {
+		record.Eventf(s.scope.GCPManagedMachinePool, "GKENodePoolUpdate", "Updating GKE node pool %s", s.scope.NodePoolName())
+	}
 
 	return nil
 }
@@ -304,8 +511,10 @@ func (s *Service) updateNodePoolVersionOrImage(ctx context.Context, updateNodePo
 func (s *Service) updateNodePoolAutoscaling(ctx context.Context, setNodePoolAutoscalingRequest *containerpb.SetNodePoolAutoscalingRequest) error {
 	_, err := s.scope.ManagedMachinePoolClient().SetNodePoolAutoscaling(ctx, setNodePoolAutoscalingRequest)
 	if err != nil {
+		record.Warnf(s.scope.GCPManagedMachinePool, "GKENodePoolUpdateFailed", "Failed to update the autoscaling of GKE node pool %s: %v", s.scope.NodePoolName(), err)
 		return err
 	}
+	record.Eventf(s.scope.GCPManagedMachinePool, "GKENodePoolUpdate", "Updating the autoscaling of GKE node pool %s", s.scope.NodePoolName())
 
 	return nil
 }
@@ -313,8 +522,32 @@ func (s *Service) updateNodePoolAutoscaling(ctx context.Context, setNodePoolAuto
 func (s *Service) updateNodePoolSize(ctx context.Context, setNodePoolSizeRequest *containerpb.SetNodePoolSizeRequest) error {
 	_, err := s.scope.ManagedMachinePoolClient().SetNodePoolSize(ctx, setNodePoolSizeRequest)
 	if err != nil {
+		record.Warnf(s.scope.GCPManagedMachinePool, "GKENodePoolUpdateFailed", "Failed to resize GKE node pool %s: %v", s.scope.NodePoolName(), err)
 		return err
 	}
+	record.Eventf(s.scope.GCPManagedMachinePool, "GKENodePoolUpdate", "Resizing GKE node pool %s to %d nodes", s.scope.NodePoolName(), setNodePoolSizeRequest.NodeCount)
+
+	return nil
+}
+
+func (s *Service) completeNodePoolUpgrade(ctx context.Context) error {
+	completeNodePoolUpgradeRequest := &containerpb.CompleteNodePoolUpgradeRequest{
+		Name: s.scope.NodePoolFullName(),
+	}
+	return s.scope.ManagedMachinePoolClient().CompleteNodePoolUpgrade(ctx, completeNodePoolUpgradeRequest)
+}
+
+func (s *Service) rollbackNodePoolUpgrade(ctx context.Context) error {
+	rollbackNodePoolUpgradeRequest := &containerpb.RollbackNodePoolUpgradeRequest{
+		Name:       s.scope.NodePoolFullName(),
+		RespectPdb: true,
+	}
+	_, err := s.scope.ManagedMachinePoolClient().RollbackNodePoolUpgrade(ctx, rollbackNodePoolUpgradeRequest)
+	if err != nil {
+		record.Warnf(s.scope.GCPManagedMachinePool, "GKENodePoolRollbackFailed", "Failed to roll back the upgrade of GKE node pool %s: %v", s.scope.NodePoolName(), err)
+		return err
+	}
+	record.Eventf(s.scope.GCPManagedMachinePool, "GKENodePoolRollback", "Rolling back the upgrade of GKE node pool %s", s.scope.NodePoolName())
 
 	return nil
 }
@@ -325,8 +558,10 @@ func (s *Service) deleteNodePool(ctx context.Context) error {
 	}
 	_, err := s.scope.ManagedMachinePoolClient().DeleteNodePool(ctx, deleteNodePoolRequest)
 	if err != nil {
+		record.Warnf(s.scope.GCPManagedMachinePool, "GKENodePoolDeleteFailed", "Failed to delete GKE node pool %s: %v", s.scope.NodePoolName(), err)
 		return err
 	}
+	record.Eventf(s.scope.GCPManagedMachinePool, "GKENodePoolDelete", "Deleting GKE node pool %s", s.scope.NodePoolName())
 
 	return nil
 }
@@ -348,6 +583,21 @@ func (s *Service) checkDiffAndPrepareUpdateVersionOrImage(existingNodePool *cont
 			Labels: s.scope.GCPManagedMachinePool.Spec.KubernetesLabels,
 		}
 	}
+	// Machine type
+	desiredMachineType := s.scope.GCPManagedMachinePool.Spec.MachineType
+	if s.scope.GCPManagedMachinePool.Spec.MachineTypeUpdateStrategy != infrav1exp.MachineTypeUpdateStrategyReplace &&
+		desiredMachineType != "" && desiredMachineType != existingNodePool.Config.GetMachineType() {
+		needUpdate = true
+		updateNodePoolRequest.MachineType = desiredMachineType
+	}
+	// Resource labels
+	desiredResourceLabels := scope.NodePoolResourceLabels(*s.scope.GCPManagedMachinePool, s.scope.MachinePool.Spec.ClusterName)
+	if !reflect.DeepEqual(desiredResourceLabels, existingNodePool.Config.ResourceLabels) {
+		needUpdate = true
+		updateNodePoolRequest.ResourceLabels = &containerpb.ResourceLabels{
+			Labels: desiredResourceLabels,
+		}
+	}
 	// Kubernetes taints
 	desiredKubernetesTaints := infrav1exp.ConvertToSdkTaint(s.scope.GCPManagedMachinePool.Spec.KubernetesTaints)
 	if !reflect.DeepEqual(desiredKubernetesTaints, existingNodePool.Config.Taints) {
@@ -356,9 +606,44 @@ func (s *Service) checkDiffAndPrepareUpdateVersionOrImage(existingNodePool *cont
 			Taints: desiredKubernetesTaints,
 		}
 	}
+	// gVNIC
+	if desiredGvnic := s.scope.GCPManagedMachinePool.Spec.Gvnic; desiredGvnic != nil && *desiredGvnic != existingNodePool.Config.GetGvnic().GetEnabled() {
+		needUpdate = true
+		updateNodePoolRequest.Gvnic = &containerpb.VirtualNIC{
+			Enabled: *desiredGvnic,
+		}
+	}
+	// Workload metadata
+	if desiredMode := s.scope.WorkloadMetadataMode(); desiredMode != nil {
+		desiredWorkloadMetadataConfig := scope.ConvertToSdkWorkloadMetadataConfig(*desiredMode)
+		if desiredWorkloadMetadataConfig.Mode != existingNodePool.Config.GetWorkloadMetadataConfig().GetMode() {
+			needUpdate = true
+			updateNodePoolRequest.WorkloadMetadataConfig = desiredWorkloadMetadataConfig
+		}
+	}
+	// Fast Socket
+	if desiredFastSocket := s.scope.GCPManagedMachinePool.Spec.FastSocket; desiredFastSocket != nil && *desiredFastSocket != existingNodePool.Config.GetFastSocket().GetEnabled() {
+		needUpdate = true
+		updateNodePoolRequest.FastSocket = &containerpb.FastSocket{
+			Enabled: *desiredFastSocket,
+		}
+	}
 	return needUpdate, &updateNodePoolRequest
 }
 
+// checkProvisioningModelDrift returns a message if the Spot/preemptible provisioning model of the node pool differs
+// from the spec. GKE does not allow to change it on an existing node pool, a replacement node pool is required.
+func (s *Service) checkProvisioningModelDrift(existingNodePool *containerpb.NodePool) string {
+	spec := s.scope.GCPManagedMachinePool.Spec
+	desiredPreemptible := spec.Preemptible != nil && *spec.Preemptible
+	desiredSpot := spec.Spot != nil && *spec.Spot
+	if desiredPreemptible == existingNodePool.Config.GetPreemptible() && desiredSpot == existingNodePool.Config.GetSpot() {
+		return ""
+	}
+	return fmt.Sprintf("node pool has preemptible=%t and spot=%t, which cannot be changed to preemptible=%t and spot=%t; create a replacement node pool instead",
+		existingNodePool.Config.GetPreemptible(), existingNodePool.Config.GetSpot(), desiredPreemptible, desiredSpot)
+}
+
 func (s *Service) hasDesiredVersion(nodePoolVersion *string, existingNodePoolVersion string) bool {
 	if nodePoolVersion == nil {
 		return true
@@ -388,9 +673,15 @@ func (s *Service) checkDiffAndPrepareUpdateAutoscaling(existingNodePool *contain
 	var existingAutoscaling *containerpb.NodePoolAutoscaling
 	if existingNodePool.Autoscaling != nil && existingNodePool.Autoscaling.Enabled {
 		existingAutoscaling = &containerpb.NodePoolAutoscaling{
-			Enabled:      true,
-			MinNodeCount: existingNodePool.Autoscaling.MinNodeCount,
-			MaxNodeCount: existingNodePool.Autoscaling.MaxNodeCount,
+			Enabled:           true,
+			MinNodeCount:      existingNodePool.Autoscaling.MinNodeCount,
+			MaxNodeCount:      existingNodePool.Autoscaling.MaxNodeCount,
+			TotalMinNodeCount: existingNodePool.Autoscaling.TotalMinNodeCount,
+			TotalMaxNodeCount: existingNodePool.Autoscaling.TotalMaxNodeCount,
+		}
+		// GKE defaults the location policy when it is not set, only compare it when explicitly requested.
+		if desiredAutoscaling.GetLocationPolicy() != containerpb.NodePoolAutoscaling_LOCATION_POLICY_UNSPECIFIED {
+			existingAutoscaling.LocationPolicy = existingNodePool.Autoscaling.LocationPolicy
 		}
 	}
 
@@ -410,6 +701,17 @@ func (s *Service) checkDiffAndPrepareUpdateSize(existingNodePool *containerpb.No
 		Name: s.scope.NodePoolFullName(),
 	}
 
+	// Multi-host TPU slices are provisioned atomically and cannot be resized.
+	if s.scope.GCPManagedMachinePool.IsMultiHostTPUSlice() {
+		return needUpdate, &setNodePoolSizeRequest
+	}
+
+	// The size of autoscaled node pools is owned by the GKE cluster autoscaler, the number of
+	// replicas observed from the node pool instances is reported in the status instead.
+	if s.scope.GCPManagedMachinePool.Spec.Scaling != nil {
+		return needUpdate, &setNodePoolSizeRequest
+	}
+
 	replicas := *s.scope.MachinePool.Spec.Replicas
 	if shared.IsRegional(s.scope.Region()) {
 		replicas /= cloud.DefaultNumRegionsPerZone
@@ -0,0 +1,243 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterv1exp "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/internal/gketest"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestIsOwned(t *testing.T) {
+	tests := []struct {
+		name     string
+		labels   map[string]string
+		creating *clusterv1.Condition
+		want     bool
+	}{
+		{
+			name:   "node pool labeled as owned by the cluster",
+			labels: map[string]string{"capg-cluster-my-cluster": "owned"},
+			want:   true,
+		},
+		{
+			name:   "node pool labeled as owned by another cluster",
+			labels: map[string]string{"capg-cluster-other-cluster": "owned"},
+		},
+		{
+			name:   "node pool without labels",
+			labels: nil,
+		},
+		{
+			name:     "unlabeled node pool created before the label was introduced",
+			creating: conditions.FalseCondition(infrav1exp.GKEMachinePoolCreatingCondition, infrav1exp.GKEMachinePoolCreatedReason, clusterv1.ConditionSeverityInfo, ""),
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedMachinePool := &infrav1exp.GCPManagedMachinePool{}
+			if tt.creating != nil {
+				conditions.Set(managedMachinePool, tt.creating)
+			}
+			s := New(&scope.ManagedMachinePoolScope{
+				MachinePool:           &clusterv1exp.MachinePool{Spec: clusterv1exp.MachinePoolSpec{ClusterName: "my-cluster"}},
+				GCPManagedMachinePool: managedMachinePool,
+			})
+			nodePool := &containerpb.NodePool{Name: "pool-0", Config: &containerpb.NodeConfig{ResourceLabels: tt.labels}}
+			if got := s.isOwned(nodePool); got != tt.want {
+				t.Errorf("isOwned() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteRetain(t *testing.T) {
+	tests := []struct {
+		name         string
+		nodePool     *containerpb.NodePool
+		wantLabels   map[string]string
+		wantRequeues int
+	}{
+		{
+			name: "owned node pool loses the owned label before it is retained",
+			nodePool: &containerpb.NodePool{
+				Name:   "pool-0",
+				Status: containerpb.NodePool_RUNNING,
+				Config: &containerpb.NodeConfig{ResourceLabels: map[string]string{"capg-cluster-my-cluster": "owned", "team": "a"}},
+			},
+			wantLabels:   map[string]string{"team": "a"},
+			wantRequeues: 1,
+		},
+		{
+			name: "node pool without the owned label is retained as is",
+			nodePool: &containerpb.NodePool{
+				Name:   "pool-0",
+				Status: containerpb.NodePool_RUNNING,
+				Config: &containerpb.NodeConfig{ResourceLabels: map[string]string{"team": "a"}},
+			},
+			wantLabels: map[string]string{"team": "a"},
+		},
+		{
+			name: "owned node pool waits for the operation in progress",
+			nodePool: &containerpb.NodePool{
+				Name:   "pool-0",
+				Status: containerpb.NodePool_RECONCILING,
+				Config: &containerpb.NodeConfig{ResourceLabels: map[string]string{"capg-cluster-my-cluster": "owned"}},
+			},
+			wantLabels:   map[string]string{"capg-cluster-my-cluster": "owned"},
+			wantRequeues: 3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, gkeClient := gketest.NewServer(t)
+			server.SetNodePool(gketest.NodePoolFullName("pool-0"), tt.nodePool)
+			s := gketest.NewManagedMachinePoolScope(t, gkeClient, &infrav1exp.GCPManagedMachinePool{
+				Spec: infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pool-0", DeletionPolicy: infrav1exp.NodePoolDeletionPolicyRetain},
+			})
+			svc := New(s)
+
+			// The node pool is released by the reconciliation following the removal of the owned label.
+			for i := 0; i < 3; i++ {
+				result, err := svc.Delete(context.Background())
+				if err != nil {
+					t.Fatalf("Delete() error = %v", err)
+				}
+				if requeue := result.RequeueAfter > 0; requeue != (i < tt.wantRequeues) {
+					t.Fatalf("Delete() #%d requeue = %v, want %v", i, requeue, i < tt.wantRequeues)
+				}
+			}
+
+			nodePool := server.NodePool(gketest.NodePoolFullName("pool-0"))
+			if nodePool == nil {
+				t.Fatal("node pool was deleted")
+			}
+			if got := nodePool.Config.ResourceLabels; !reflect.DeepEqual(got, tt.wantLabels) {
+				t.Errorf("node pool labels = %v, want %v", got, tt.wantLabels)
+			}
+			retained := conditions.GetReason(s.GCPManagedMachinePool, infrav1exp.GKEMachinePoolDeletingCondition) == infrav1exp.GKEMachinePoolRetainedReason
+			if retained != (tt.wantRequeues < 3) {
+				t.Errorf("node pool reported retained = %v, want %v", retained, tt.wantRequeues < 3)
+			}
+		})
+	}
+}
+
+func TestAdoptNodePool(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		want         bool
+		wantCreating bool
+	}{
+		{
+			name: "node pool not adopted",
+		},
+		{
+			name:         "node pool adopted",
+			annotations:  map[string]string{infrav1exp.AdoptNodePoolAnnotation: ""},
+			want:         true,
+			wantCreating: true,
+		},
+		{
+			name:        "node pool adopted in dry-run mode",
+			annotations: map[string]string{infrav1exp.AdoptNodePoolAnnotation: "", infrav1exp.DryRunNodePoolAnnotation: ""},
+			want:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedMachinePool := &infrav1exp.GCPManagedMachinePool{}
+			managedMachinePool.Annotations = tt.annotations
+			s := New(&scope.ManagedMachinePoolScope{
+				MachinePool:           &clusterv1exp.MachinePool{Spec: clusterv1exp.MachinePoolSpec{ClusterName: "my-cluster"}},
+				GCPManagedMachinePool: managedMachinePool,
+			})
+			log := logr.Discard()
+			if got := s.adoptNodePool(&containerpb.NodePool{Name: "pool-0"}, &log); got != tt.want {
+				t.Errorf("adoptNodePool() = %v, want %v", got, tt.want)
+			}
+
+			if creating := conditions.Get(managedMachinePool, infrav1exp.GKEMachinePoolCreatingCondition); (creating != nil) != tt.wantCreating {
+				t.Errorf("adoption recorded = %v, want %v", creating != nil, tt.wantCreating)
+			}
+			if alreadyExists := conditions.GetReason(managedMachinePool, infrav1exp.GKEMachinePoolReadyCondition) == infrav1exp.GKEMachinePoolAlreadyExistsReason; alreadyExists == tt.want {
+				t.Errorf("already exists reported = %v, want %v", alreadyExists, !tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileNotOwned(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantReady   string
+		wantDryRun  bool
+	}{
+		{
+			name:      "node pool not adopted is left untouched",
+			wantReady: infrav1exp.GKEMachinePoolAlreadyExistsReason,
+		},
+		{
+			name:        "node pool adopted in dry-run mode is only reviewed",
+			annotations: map[string]string{infrav1exp.AdoptNodePoolAnnotation: "", infrav1exp.DryRunNodePoolAnnotation: ""},
+			wantDryRun:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, gkeClient := gketest.NewServer(t)
+			server.SetNodePool(gketest.NodePoolFullName("pool-0"), &containerpb.NodePool{
+				Name:   "pool-0",
+				Status: containerpb.NodePool_RUNNING,
+				Config: &containerpb.NodeConfig{ResourceLabels: map[string]string{"team": "a"}},
+			})
+			managedMachinePool := &infrav1exp.GCPManagedMachinePool{Spec: infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pool-0"}}
+			managedMachinePool.Annotations = tt.annotations
+			s := gketest.NewManagedMachinePoolScope(t, gkeClient, managedMachinePool)
+
+			if _, err := New(s).Reconcile(context.Background()); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			if requests := server.Requests(); len(requests) != 0 {
+				t.Errorf("node pool changed by %v", requests)
+			}
+			if got := conditions.GetReason(s.GCPManagedMachinePool, infrav1exp.GKEMachinePoolReadyCondition); got != tt.wantReady {
+				t.Errorf("ready reason = %q, want %q", got, tt.wantReady)
+			}
+			if creating := conditions.Get(s.GCPManagedMachinePool, infrav1exp.GKEMachinePoolCreatingCondition); creating != nil {
+				t.Errorf("adoption recorded with reason %q", creating.Reason)
+			}
+			if dryRun := conditions.Has(s.GCPManagedMachinePool, infrav1exp.GKEMachinePoolDryRunCondition); dryRun != tt.wantDryRun {
+				t.Errorf("dry run reported = %v, want %v", dryRun, tt.wantDryRun)
+			}
+		})
+	}
+}
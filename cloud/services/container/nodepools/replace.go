@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"github.com/googleapis/gax-go/v2/apierror"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"sigs.k8s.io/cluster-api/util/record"
+
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+// needsReplacement returns true if the machine type of the node pool changed and has to be applied by
+// replacing the node pool.
+func (s *Service) needsReplacement(existingNodePool *containerpb.NodePool) bool {
+	spec := s.scope.GCPManagedMachinePool.Spec
+	return spec.MachineTypeUpdateStrategy == infrav1exp.MachineTypeUpdateStrategyReplace &&
+		spec.MachineType != "" && spec.MachineType != existingNodePool.GetConfig().GetMachineType()
+}
+
+// startReplacement switches the GCPManagedMachinePool over to a new node pool, which is created by the next
+// reconciliation. The current node pool is kept as the previous node pool until the new one is running.
+func (s *Service) startReplacement(existingNodePool *containerpb.NodePool, log *logr.Logger) error {
+	managedMachinePool := s.scope.GCPManagedMachinePool
+	managedMachinePool.Status.PreviousNodePoolName = existingNodePool.Name
+	managedMachinePool.Status.NodePoolName = managedMachinePool.GenerateReplacementNodePoolName()
+	log.Info("Replacing node pool", "previous", existingNodePool.Name, "nodepool", managedMachinePool.Status.NodePoolName)
+	record.Eventf(managedMachinePool, "GKENodePoolReplace", "Replacing GKE node pool %s with %s", existingNodePool.Name, managedMachinePool.Status.NodePoolName)
+
+	return s.scope.PatchObject()
+}
+
+// reconcilePreviousNodePool deletes the node pool that has been replaced, which drains its nodes.
+// It returns true once the previous node pool is gone.
+func (s *Service) reconcilePreviousNodePool(ctx context.Context, log *logr.Logger) (bool, error) {
+	managedMachinePool := s.scope.GCPManagedMachinePool
+	fullName := s.scope.NodePoolFullNameOf(managedMachinePool.Status.PreviousNodePoolName)
+
+	previousNodePool, err := s.scope.ManagedMachinePoolClient().GetNodePool(ctx, &containerpb.GetNodePoolRequest{Name: fullName})
+	if err != nil {
+		var e *apierror.APIError
+		if ok := errors.As(err, &e); ok && e.GRPCStatus().Code() == codes.NotFound {
+			log.Info("Previous node pool deleted", "previous", managedMachinePool.Status.PreviousNodePoolName)
+			managedMachinePool.Status.PreviousNodePoolName = ""
+			return true, nil
+		}
+		return false, err
+	}
+
+	switch previousNodePool.Status {
+	case containerpb.NodePool_PROVISIONING, containerpb.NodePool_RECONCILING, containerpb.NodePool_STOPPING:
+		log.Info("Waiting for previous node pool operation", "previous", previousNodePool.Name, "status", previousNodePool.Status.String())
+		return false, nil
+	}
+
+	log.Info("Deleting previous node pool", "previous", previousNodePool.Name)
+	if _, err := s.scope.ManagedMachinePoolClient().DeleteNodePool(ctx, &containerpb.DeleteNodePoolRequest{Name: fullName}); err != nil {
+		record.Warnf(managedMachinePool, "GKENodePoolDeleteFailed", "Failed to delete previous GKE node pool %s: %v", previousNodePool.Name, err)
+		return false, err
+	}
+	record.Eventf(managedMachinePool, "GKENodePoolDelete", "Deleting previous GKE node pool %s", previousNodePool.Name)
+
+	return false, nil
+}
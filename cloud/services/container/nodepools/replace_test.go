@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/internal/gketest"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestNeedsReplacement(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     infrav1exp.GCPManagedMachinePoolSpec
+		nodePool *containerpb.NodePool
+		want     bool
+	}{
+		{
+			name:     "machine type changed with the replace strategy",
+			spec:     infrav1exp.GCPManagedMachinePoolSpec{MachineType: "e2-standard-4", MachineTypeUpdateStrategy: infrav1exp.MachineTypeUpdateStrategyReplace},
+			nodePool: &containerpb.NodePool{Config: &containerpb.NodeConfig{MachineType: "e2-medium"}},
+			want:     true,
+		},
+		{
+			name:     "machine type unchanged with the replace strategy",
+			spec:     infrav1exp.GCPManagedMachinePoolSpec{MachineType: "e2-medium", MachineTypeUpdateStrategy: infrav1exp.MachineTypeUpdateStrategyReplace},
+			nodePool: &containerpb.NodePool{Config: &containerpb.NodeConfig{MachineType: "e2-medium"}},
+		},
+		{
+			name:     "machine type not set with the replace strategy",
+			spec:     infrav1exp.GCPManagedMachinePoolSpec{MachineTypeUpdateStrategy: infrav1exp.MachineTypeUpdateStrategyReplace},
+			nodePool: &containerpb.NodePool{Config: &containerpb.NodeConfig{MachineType: "e2-medium"}},
+		},
+		{
+			name:     "machine type changed without the replace strategy",
+			spec:     infrav1exp.GCPManagedMachinePoolSpec{MachineType: "e2-standard-4"},
+			nodePool: &containerpb.NodePool{Config: &containerpb.NodeConfig{MachineType: "e2-medium"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&scope.ManagedMachinePoolScope{GCPManagedMachinePool: &infrav1exp.GCPManagedMachinePool{Spec: tt.spec}})
+			if got := s.needsReplacement(tt.nodePool); got != tt.want {
+				t.Errorf("needsReplacement() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStartReplacement(t *testing.T) {
+	ctx := context.Background()
+	_, gkeClient := gketest.NewServer(t)
+	s := gketest.NewManagedMachinePoolScope(t, gkeClient, &infrav1exp.GCPManagedMachinePool{
+		Spec: infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pool-0"},
+	})
+	log := logr.Discard()
+
+	if err := New(s).startReplacement(&containerpb.NodePool{Name: "pool-0"}, &log); err != nil {
+		t.Fatalf("startReplacement() error = %v", err)
+	}
+
+	managedMachinePool := &infrav1exp.GCPManagedMachinePool{}
+	if err := s.Client().Get(ctx, client.ObjectKeyFromObject(s.GCPManagedMachinePool), managedMachinePool); err != nil {
+		t.Fatal(err)
+	}
+	if got := managedMachinePool.Status.PreviousNodePoolName; got != "pool-0" {
+		t.Errorf("previous node pool = %q, want %q", got, "pool-0")
+	}
+	if got := managedMachinePool.NodePoolName(); !strings.HasPrefix(got, "pool-0-") {
+		t.Errorf("node pool = %q, want a replacement of pool-0", got)
+	}
+}
+
+func TestReconcilePreviousNodePool(t *testing.T) {
+	tests := []struct {
+		name         string
+		previous     *containerpb.NodePool
+		want         bool
+		wantRequests int
+		wantPrevious string
+	}{
+		{
+			name:         "previous node pool running is deleted",
+			previous:     &containerpb.NodePool{Name: "pool-0", Status: containerpb.NodePool_RUNNING},
+			wantRequests: 1,
+			wantPrevious: "pool-0",
+		},
+		{
+			name:         "previous node pool reconciling is waited for",
+			previous:     &containerpb.NodePool{Name: "pool-0", Status: containerpb.NodePool_RECONCILING},
+			wantPrevious: "pool-0",
+		},
+		{
+			name: "previous node pool deleted",
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, gkeClient := gketest.NewServer(t)
+			if tt.previous != nil {
+				server.SetNodePool(gketest.NodePoolFullName("pool-0"), tt.previous)
+			}
+			managedMachinePool := &infrav1exp.GCPManagedMachinePool{Spec: infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pool-0"}}
+			managedMachinePool.Status.NodePoolName = "pool-0-abcde"
+			managedMachinePool.Status.PreviousNodePoolName = "pool-0"
+			s := gketest.NewManagedMachinePoolScope(t, gkeClient, managedMachinePool)
+			log := logr.Discard()
+
+			got, err := New(s).reconcilePreviousNodePool(context.Background(), &log)
+			if err != nil {
+				t.Fatalf("reconcilePreviousNodePool() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("reconcilePreviousNodePool() = %v, want %v", got, tt.want)
+			}
+			requests := server.Requests()
+			if len(requests) != tt.wantRequests {
+				t.Errorf("requests = %v, want %d", requests, tt.wantRequests)
+			}
+			for _, request := range requests {
+				if deleteRequest, ok := request.(*containerpb.DeleteNodePoolRequest); !ok || deleteRequest.Name != gketest.NodePoolFullName("pool-0") {
+					t.Errorf("request = %v, want the deletion of the previous node pool", request)
+				}
+			}
+			if got := s.GCPManagedMachinePool.Status.PreviousNodePoolName; got != tt.wantPrevious {
+				t.Errorf("previous node pool = %q, want %q", got, tt.wantPrevious)
+			}
+		})
+	}
+}
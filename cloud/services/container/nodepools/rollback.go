@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+)
+
+// reconcileRollback rolls back the node pool upgrade once the rollback annotation is set and reports
+// the rollback progress. The node pool is not updated any further until the annotation is removed.
+func (s *Service) reconcileRollback(ctx context.Context, nodePool *containerpb.NodePool) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	rollingBack := conditions.Get(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolRollingBackCondition)
+	switch {
+	case shouldStartRollback(rollingBack):
+		log.Info("Rolling back node pool upgrade")
+		if err := s.rollbackNodePoolUpgrade(ctx); err != nil {
+			conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolRollingBackCondition, infrav1exp.GKEMachinePoolRollbackFailedReason, clusterv1.ConditionSeverityError, err.Error())
+			return ctrl.Result{}, err
+		}
+		conditions.MarkTrue(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolRollingBackCondition)
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
+	case rollingBack.Status == corev1.ConditionTrue:
+		if nodePool.Status == containerpb.NodePool_RECONCILING || nodePool.Status == containerpb.NodePool_PROVISIONING {
+			log.Info("Node pool upgrade rollback in progress")
+			return ctrl.Result{RequeueAfter: reconciler.PollInterval(s.scope.GCPManagedMachinePool)}, nil
+		}
+		log.Info("Node pool upgrade rolled back", "version", nodePool.Version)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolRollingBackCondition, infrav1exp.GKEMachinePoolRolledBackReason, clusterv1.ConditionSeverityInfo,
+			"remove the %s annotation to resume reconciling the node pool", infrav1exp.RollbackNodePoolUpgradeAnnotation)
+		conditions.MarkFalse(s.scope.ConditionSetter(), infrav1exp.GKEMachinePoolUpgradingCondition, infrav1exp.GKEMachinePoolRolledBackReason, clusterv1.ConditionSeverityInfo, "")
+		if err := s.releaseUpgradeSlot(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	log.V(2).Info("Node pool reconciliation is held after upgrade rollback", "annotation", infrav1exp.RollbackNodePoolUpgradeAnnotation)
+	return ctrl.Result{}, nil
+}
+
+// shouldStartRollback returns true if the rollback of the node pool upgrade has to be started, i.e. it was not
+// started yet or starting it failed, in which case it is retried.
+func shouldStartRollback(rollingBack *clusterv1.Condition) bool {
+	return rollingBack == nil || rollingBack.Reason == infrav1exp.GKEMachinePoolRollbackFailedReason
+}
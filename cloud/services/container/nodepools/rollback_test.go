@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+func TestShouldStartRollback(t *testing.T) {
+	tests := []struct {
+		name        string
+		rollingBack *clusterv1.Condition
+		want        bool
+	}{
+		{
+			name: "rollback not started",
+			want: true,
+		},
+		{
+			name: "starting the rollback failed",
+			rollingBack: &clusterv1.Condition{
+				Type:   infrav1exp.GKEMachinePoolRollingBackCondition,
+				Status: corev1.ConditionFalse,
+				Reason: infrav1exp.GKEMachinePoolRollbackFailedReason,
+			},
+			want: true,
+		},
+		{
+			name: "rollback in progress",
+			rollingBack: &clusterv1.Condition{
+				Type:   infrav1exp.GKEMachinePoolRollingBackCondition,
+				Status: corev1.ConditionTrue,
+			},
+		},
+		{
+			name: "rolled back",
+			rollingBack: &clusterv1.Condition{
+				Type:   infrav1exp.GKEMachinePoolRollingBackCondition,
+				Status: corev1.ConditionFalse,
+				Reason: infrav1exp.GKEMachinePoolRolledBackReason,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldStartRollback(tt.rollingBack); got != tt.want {
+				t.Errorf("shouldStartRollback() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,270 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+// upgradeOrder returns the upgrade order of the node pool, defaulting to 0.
+func upgradeOrder(managedMachinePool *infrav1exp.GCPManagedMachinePool) int32 {
+	if managedMachinePool.Spec.UpgradeOrder == nil {
+		return 0
+	}
+	return *managedMachinePool.Spec.UpgradeOrder
+}
+
+// isUpgradePending returns true if the node pool is upgrading or waiting to be upgraded.
+func isUpgradePending(managedMachinePool *infrav1exp.GCPManagedMachinePool) bool {
+	if conditions.IsTrue(managedMachinePool, infrav1exp.GKEMachinePoolUpgradingCondition) {
+		return true
+	}
+	switch conditions.GetReason(managedMachinePool, infrav1exp.GKEMachinePoolUpgradingCondition) {
+	case infrav1exp.WaitingForControlPlaneUpgradeReason, infrav1exp.WaitingForNodePoolUpgradesReason:
+		return true
+	}
+	return false
+}
+
+// checkUpgradeAllowed checks whether the node pool version upgrade can be started. The upgrade has to wait
+// for the control plane upgrade, for node pools with a lower upgrade order and for the maximum number of
+// concurrent node pool upgrades. If the upgrade has to wait, the reason and message are returned.
+func (s *Service) checkUpgradeAllowed(ctx context.Context) (string, string, error) {
+	controlPlane := s.scope.GCPManagedControlPlane
+	if conditions.IsTrue(controlPlane, infrav1exp.GKEControlPlaneUpdatingCondition) ||
+		!s.hasDesiredVersion(controlPlane.Spec.ControlPlaneVersion, controlPlane.Status.CurrentVersion) {
+		return infrav1exp.WaitingForControlPlaneUpgradeReason, "waiting for the control plane upgrade to complete", nil
+	}
+
+	managedMachinePools, err := s.scope.ListClusterManagedMachinePools(ctx)
+	if err != nil {
+		return "", "", err
+	}
+
+	order := upgradeOrder(s.scope.GCPManagedMachinePool)
+	upgrading := make(map[string]bool)
+	for i := range managedMachinePools {
+		managedMachinePool := &managedMachinePools[i]
+		if managedMachinePool.Name == s.scope.GCPManagedMachinePool.Name {
+			continue
+		}
+		if upgradeOrder(managedMachinePool) < order && isUpgradePending(managedMachinePool) {
+			return infrav1exp.WaitingForNodePoolUpgradesReason, fmt.Sprintf("waiting for node pool %s to be upgraded", managedMachinePool.Name), nil
+		}
+		if conditions.IsTrue(managedMachinePool, infrav1exp.GKEMachinePoolUpgradingCondition) {
+			upgrading[managedMachinePool.Name] = true
+		}
+	}
+
+	maxUpgrades := controlPlane.Spec.MaxConcurrentNodePoolUpgrades
+	if maxUpgrades == nil {
+		return "", "", nil
+	}
+
+	acquired, err := s.acquireUpgradeSlot(ctx, managedMachinePools, upgrading, *maxUpgrades)
+	if err != nil {
+		return "", "", err
+	}
+	if !acquired {
+		return infrav1exp.WaitingForNodePoolUpgradesReason, fmt.Sprintf("%d node pools are already upgrading", len(upgrading)), nil
+	}
+
+	return "", "", nil
+}
+
+// acquireUpgradeSlot takes an upgrade slot for the node pool in the status of the control plane, unless the
+// node pools holding a slot or upgrading already reach the maximum. The slots are patched with optimistic
+// locking, so that node pools reconciled concurrently cannot take the same last slot. The slots of deleted node
+// pools are released on the way. The upgrading set is completed with the holders of a slot.
+func (s *Service) acquireUpgradeSlot(ctx context.Context, managedMachinePools []infrav1exp.GCPManagedMachinePool, upgrading map[string]bool, maxUpgrades int32) (bool, error) {
+	controlPlane := s.scope.GCPManagedControlPlane
+	name := s.scope.GCPManagedMachinePool.Name
+
+	existing := make(map[string]bool, len(managedMachinePools))
+	for i := range managedMachinePools {
+		existing[managedMachinePools[i].Name] = true
+	}
+
+	holders := []string{}
+	for _, holder := range controlPlane.Status.UpgradingNodePools {
+		if holder == name {
+			return true, nil
+		}
+		if existing[holder] {
+			holders = append(holders, holder)
+			upgrading[holder] = true
+		}
+	}
+	if int32(len(upgrading)) >= maxUpgrades {
+		return false, nil
+	}
+
+	if err := s.patchUpgradeSlots(ctx, append(holders, name)); err != nil {
+		if apierrors.IsConflict(err) {
+			log.FromContext(ctx).V(2).Info("Upgrade slots changed concurrently, retrying later")
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// releaseUpgradeSlot releases the upgrade slot of the node pool, if it holds one.
+func (s *Service) releaseUpgradeSlot(ctx context.Context) error {
+	controlPlane := s.scope.GCPManagedControlPlane
+	name := s.scope.GCPManagedMachinePool.Name
+
+	holders := []string{}
+	for _, holder := range controlPlane.Status.UpgradingNodePools {
+		if holder != name {
+			holders = append(holders, holder)
+		}
+	}
+	if len(holders) == len(controlPlane.Status.UpgradingNodePools) {
+		return nil
+	}
+
+	return s.patchUpgradeSlots(ctx, holders)
+}
+
+// patchUpgradeSlots patches the upgrade slots in the status of the control plane with optimistic locking.
+func (s *Service) patchUpgradeSlots(ctx context.Context, holders []string) error {
+	controlPlane := s.scope.GCPManagedControlPlane
+	base := controlPlane.DeepCopy()
+	controlPlane.Status.UpgradingNodePools = holders
+
+	if err := s.scope.Client().Status().Patch(ctx, controlPlane, client.MergeFromWithOptions(base, client.MergeFromWithOptimisticLock{})); err != nil {
+		controlPlane.Status.UpgradingNodePools = base.Status.UpgradingNodePools
+		return err
+	}
+
+	return nil
+}
+
+// reconcileCompleteUpgrade completes a soaking blue-green upgrade of the node pool when requested through
+// the complete upgrade annotation, which is removed afterwards.
+func (s *Service) reconcileCompleteUpgrade(ctx context.Context, nodePool *containerpb.NodePool) error {
+	log := log.FromContext(ctx)
+
+	if _, ok := s.scope.GCPManagedMachinePool.Annotations[infrav1exp.CompleteNodePoolUpgradeAnnotation]; !ok {
+		return nil
+	}
+
+	phase := nodePool.GetUpdateInfo().GetBlueGreenInfo().GetPhase()
+	if phase != containerpb.NodePool_UpdateInfo_BlueGreenInfo_NODE_POOL_SOAKING {
+		log.Info("Waiting for the blue-green upgrade to soak before completing it", "phase", phase.String())
+		return nil
+	}
+
+	log.Info("Completing blue-green node pool upgrade")
+	if err := s.completeNodePoolUpgrade(ctx); err != nil {
+		return err
+	}
+	delete(s.scope.GCPManagedMachinePool.Annotations, infrav1exp.CompleteNodePoolUpgradeAnnotation)
+
+	return nil
+}
+
+// getUpgradeOperation returns the running upgrade operation of the node pool, if any.
+func (s *Service) getUpgradeOperation(ctx context.Context) (*containerpb.Operation, error) {
+	listOperationsRequest := &containerpb.ListOperationsRequest{
+		Parent: s.scope.ClusterLocation(),
+	}
+	operations, err := s.scope.ManagedMachinePoolClient().ListOperations(ctx, listOperationsRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, operation := range operations.GetOperations() {
+		if operation.GetOperationType() == containerpb.Operation_UPGRADE_NODES &&
+			operation.GetStatus() == containerpb.Operation_RUNNING &&
+			s.isNodePoolTarget(operation.GetTargetLink()) {
+			return operation, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// isNodePoolTarget returns true if the target link of an operation refers to the node pool, i.e.
+// projects/<project>/{locations,zones}/<location>/clusters/<cluster>/nodePools/<node pool> after the API
+// endpoint. The project is not compared as it may be referred to by its number, the operations being listed
+// in the project of the cluster anyway.
+func (s *Service) isNodePoolTarget(targetLink string) bool {
+	i := strings.Index(targetLink, "projects/")
+	if i < 0 {
+		return false
+	}
+	parts := strings.Split(targetLink[i:], "/")
+	return len(parts) == 8 &&
+		(parts[2] == "locations" || parts[2] == "zones") && parts[3] == s.scope.Region() &&
+		parts[4] == "clusters" && parts[5] == s.scope.GCPManagedControlPlane.Spec.ClusterName &&
+		parts[6] == "nodePools" && parts[7] == s.scope.NodePoolName()
+}
+
+// reconcileUpgradeProgress reports the progress of the running upgrade of the node pool, i.e. the upgrade
+// operation, the share of upgraded nodes and the blue-green phase, in the upgrading condition.
+func (s *Service) reconcileUpgradeProgress(ctx context.Context, nodePool *containerpb.NodePool) error {
+	operation, err := s.getUpgradeOperation(ctx)
+	if err != nil {
+		return err
+	}
+	if operation == nil {
+		return nil
+	}
+
+	progress := []string{fmt.Sprintf("operation %s", operation.GetName())}
+
+	var total, done int64
+	for _, metric := range operation.GetProgress().GetMetrics() {
+		switch metric.GetName() {
+		case "NODES_TOTAL":
+			total = metric.GetIntValue()
+		case "NODES_DONE":
+			done = metric.GetIntValue()
+		}
+	}
+	if total > 0 {
+		progress = append(progress, fmt.Sprintf("%d/%d nodes upgraded (%d%%)", done, total, done*100/total))
+	}
+
+	if phase := nodePool.GetUpdateInfo().GetBlueGreenInfo().GetPhase(); phase != containerpb.NodePool_UpdateInfo_BlueGreenInfo_PHASE_UNSPECIFIED {
+		progress = append(progress, fmt.Sprintf("blue-green phase %s", phase.String()))
+	}
+
+	conditions.Set(s.scope.ConditionSetter(), &clusterv1.Condition{
+		Type:    infrav1exp.GKEMachinePoolUpgradingCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  infrav1exp.GKEMachinePoolUpgradeInProgressReason,
+		Message: strings.Join(progress, ", "),
+	})
+
+	return nil
+}
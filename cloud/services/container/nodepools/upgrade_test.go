@@ -0,0 +1,325 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package nodepools
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"cloud.google.com/go/container/apiv1/containerpb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/container/internal/gketest"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+)
+
+// newUpgradeTestService returns the service of the node pool "pool-0", with an upgrade order of 1, along with
+// the other GCPManagedMachinePools of the cluster.
+func newUpgradeTestService(t *testing.T, controlPlane *infrav1exp.GCPManagedControlPlane, others ...*infrav1exp.GCPManagedMachinePool) (*Service, *gketest.Server) {
+	t.Helper()
+
+	controlPlane.ObjectMeta = metav1.ObjectMeta{Name: "my-cluster-control-plane", Namespace: metav1.NamespaceDefault}
+	objs := []client.Object{controlPlane}
+	for _, other := range others {
+		other.Namespace = metav1.NamespaceDefault
+		other.Labels = map[string]string{clusterv1.ClusterNameLabel: gketest.ClusterName}
+		objs = append(objs, other)
+	}
+
+	server, gkeClient := gketest.NewServer(t)
+	s := gketest.NewManagedMachinePoolScope(t, gkeClient, &infrav1exp.GCPManagedMachinePool{
+		Spec: infrav1exp.GCPManagedMachinePoolSpec{NodePoolName: "pool-0", UpgradeOrder: pointer.Int32(1)},
+	}, objs...)
+
+	return New(s), server
+}
+
+// upgradingNodePool returns a GCPManagedMachinePool with the given upgrade order and upgrading condition.
+func upgradingNodePool(name string, order int32, upgrading *clusterv1.Condition) *infrav1exp.GCPManagedMachinePool {
+	managedMachinePool := &infrav1exp.GCPManagedMachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       infrav1exp.GCPManagedMachinePoolSpec{UpgradeOrder: pointer.Int32(order)},
+	}
+	if upgrading != nil {
+		conditions.Set(managedMachinePool, upgrading)
+	}
+	return managedMachinePool
+}
+
+func TestCheckUpgradeAllowed(t *testing.T) {
+	upgrading := conditions.TrueCondition(infrav1exp.GKEMachinePoolUpgradingCondition)
+	upgraded := conditions.FalseCondition(infrav1exp.GKEMachinePoolUpgradingCondition, infrav1exp.GKEMachinePoolUpgradedReason, clusterv1.ConditionSeverityInfo, "")
+
+	tests := []struct {
+		name         string
+		controlPlane *infrav1exp.GCPManagedControlPlane
+		others       []*infrav1exp.GCPManagedMachinePool
+		wantReason   string
+		wantMessage  string
+		wantHolders  []string
+	}{
+		{
+			name: "control plane upgrade in progress",
+			controlPlane: &infrav1exp.GCPManagedControlPlane{
+				Spec:   infrav1exp.GCPManagedControlPlaneSpec{ControlPlaneVersion: pointer.String("1.28")},
+				Status: infrav1exp.GCPManagedControlPlaneStatus{CurrentVersion: "1.27.3-gke.100"},
+			},
+			wantReason:  infrav1exp.WaitingForControlPlaneUpgradeReason,
+			wantMessage: "waiting for the control plane upgrade to complete",
+		},
+		{
+			name:         "node pool with a lower upgrade order is upgrading",
+			controlPlane: &infrav1exp.GCPManagedControlPlane{},
+			others:       []*infrav1exp.GCPManagedMachinePool{upgradingNodePool("pool-1", 0, upgrading)},
+			wantReason:   infrav1exp.WaitingForNodePoolUpgradesReason,
+			wantMessage:  "waiting for node pool pool-1 to be upgraded",
+		},
+		{
+			name:         "node pools with a lower upgrade order are upgraded",
+			controlPlane: &infrav1exp.GCPManagedControlPlane{},
+			others: []*infrav1exp.GCPManagedMachinePool{
+				upgradingNodePool("pool-1", 0, upgraded),
+				upgradingNodePool("pool-2", 1, upgrading),
+			},
+		},
+		{
+			name: "maximum of concurrent upgrades reached",
+			controlPlane: &infrav1exp.GCPManagedControlPlane{
+				Spec:   infrav1exp.GCPManagedControlPlaneSpec{MaxConcurrentNodePoolUpgrades: pointer.Int32(1)},
+				Status: infrav1exp.GCPManagedControlPlaneStatus{UpgradingNodePools: []string{"pool-1"}},
+			},
+			others:      []*infrav1exp.GCPManagedMachinePool{upgradingNodePool("pool-1", 1, nil)},
+			wantReason:  infrav1exp.WaitingForNodePoolUpgradesReason,
+			wantMessage: "1 node pools are already upgrading",
+			wantHolders: []string{"pool-1"},
+		},
+		{
+			name: "maximum of concurrent upgrades reached by upgrading node pools without a slot",
+			controlPlane: &infrav1exp.GCPManagedControlPlane{
+				Spec: infrav1exp.GCPManagedControlPlaneSpec{MaxConcurrentNodePoolUpgrades: pointer.Int32(1)},
+			},
+			others:      []*infrav1exp.GCPManagedMachinePool{upgradingNodePool("pool-1", 1, upgrading)},
+			wantReason:  infrav1exp.WaitingForNodePoolUpgradesReason,
+			wantMessage: "1 node pools are already upgrading",
+		},
+		{
+			name: "slot available",
+			controlPlane: &infrav1exp.GCPManagedControlPlane{
+				Spec:   infrav1exp.GCPManagedControlPlaneSpec{MaxConcurrentNodePoolUpgrades: pointer.Int32(2)},
+				Status: infrav1exp.GCPManagedControlPlaneStatus{UpgradingNodePools: []string{"pool-1"}},
+			},
+			others:      []*infrav1exp.GCPManagedMachinePool{upgradingNodePool("pool-1", 1, nil)},
+			wantHolders: []string{"pool-1", "pool-0"},
+		},
+		{
+			name: "slot held by a deleted node pool is released",
+			controlPlane: &infrav1exp.GCPManagedControlPlane{
+				Spec:   infrav1exp.GCPManagedControlPlaneSpec{MaxConcurrentNodePoolUpgrades: pointer.Int32(1)},
+				Status: infrav1exp.GCPManagedControlPlaneStatus{UpgradingNodePools: []string{"pool-1"}},
+			},
+			wantHolders: []string{"pool-0"},
+		},
+		{
+			name: "slot already held",
+			controlPlane: &infrav1exp.GCPManagedControlPlane{
+				Spec:   infrav1exp.GCPManagedControlPlaneSpec{MaxConcurrentNodePoolUpgrades: pointer.Int32(1)},
+				Status: infrav1exp.GCPManagedControlPlaneStatus{UpgradingNodePools: []string{"pool-0"}},
+			},
+			wantHolders: []string{"pool-0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s, _ := newUpgradeTestService(t, tt.controlPlane, tt.others...)
+
+			reason, message, err := s.checkUpgradeAllowed(ctx)
+			if err != nil {
+				t.Fatalf("checkUpgradeAllowed() error = %v", err)
+			}
+			if reason != tt.wantReason || message != tt.wantMessage {
+				t.Errorf("checkUpgradeAllowed() = %q, %q, want %q, %q", reason, message, tt.wantReason, tt.wantMessage)
+			}
+
+			controlPlane := &infrav1exp.GCPManagedControlPlane{}
+			if err := s.scope.Client().Get(ctx, client.ObjectKeyFromObject(s.scope.GCPManagedControlPlane), controlPlane); err != nil {
+				t.Fatal(err)
+			}
+			if got := controlPlane.Status.UpgradingNodePools; !reflect.DeepEqual(got, tt.wantHolders) {
+				t.Errorf("upgrading node pools = %v, want %v", got, tt.wantHolders)
+			}
+		})
+	}
+}
+
+func TestAcquireUpgradeSlotConflict(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newUpgradeTestService(t, &infrav1exp.GCPManagedControlPlane{
+		Spec: infrav1exp.GCPManagedControlPlaneSpec{MaxConcurrentNodePoolUpgrades: pointer.Int32(2)},
+	}, upgradingNodePool("pool-1", 1, nil), upgradingNodePool("pool-2", 1, nil))
+
+	// Another node pool takes the last but one slot concurrently.
+	concurrent := s.scope.GCPManagedControlPlane.DeepCopy()
+	concurrent.Status.UpgradingNodePools = []string{"pool-1"}
+	if err := s.scope.Client().Status().Update(ctx, concurrent); err != nil {
+		t.Fatal(err)
+	}
+
+	managedMachinePools, err := s.scope.ListClusterManagedMachinePools(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	acquired, err := s.acquireUpgradeSlot(ctx, managedMachinePools, map[string]bool{}, 2)
+	if err != nil {
+		t.Fatalf("acquireUpgradeSlot() error = %v", err)
+	}
+	if acquired {
+		t.Fatal("acquireUpgradeSlot() acquired a slot from outdated slots")
+	}
+	if len(s.scope.GCPManagedControlPlane.Status.UpgradingNodePools) != 0 {
+		t.Errorf("upgrading node pools = %v, want them unchanged after the conflict", s.scope.GCPManagedControlPlane.Status.UpgradingNodePools)
+	}
+
+	// The slot is acquired on retry, with the slots as they are now.
+	if err := s.scope.Client().Get(ctx, client.ObjectKeyFromObject(concurrent), s.scope.GCPManagedControlPlane); err != nil {
+		t.Fatal(err)
+	}
+	acquired, err = s.acquireUpgradeSlot(ctx, managedMachinePools, map[string]bool{}, 2)
+	if err != nil {
+		t.Fatalf("acquireUpgradeSlot() error = %v", err)
+	}
+	if !acquired {
+		t.Fatal("acquireUpgradeSlot() did not acquire the last slot on retry")
+	}
+	if got, want := s.scope.GCPManagedControlPlane.Status.UpgradingNodePools, []string{"pool-1", "pool-0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("upgrading node pools = %v, want %v", got, want)
+	}
+}
+
+func TestReleaseUpgradeSlot(t *testing.T) {
+	tests := []struct {
+		name        string
+		holders     []string
+		wantHolders []string
+	}{
+		{
+			name:        "slot held",
+			holders:     []string{"pool-1", "pool-0"},
+			wantHolders: []string{"pool-1"},
+		},
+		{
+			name:        "slot not held",
+			holders:     []string{"pool-1"},
+			wantHolders: []string{"pool-1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			s, _ := newUpgradeTestService(t, &infrav1exp.GCPManagedControlPlane{
+				Status: infrav1exp.GCPManagedControlPlaneStatus{UpgradingNodePools: tt.holders},
+			})
+
+			if err := s.releaseUpgradeSlot(ctx); err != nil {
+				t.Fatalf("releaseUpgradeSlot() error = %v", err)
+			}
+
+			controlPlane := &infrav1exp.GCPManagedControlPlane{}
+			if err := s.scope.Client().Get(ctx, client.ObjectKeyFromObject(s.scope.GCPManagedControlPlane), controlPlane); err != nil {
+				t.Fatal(err)
+			}
+			if got := controlPlane.Status.UpgradingNodePools; !reflect.DeepEqual(got, tt.wantHolders) {
+				t.Errorf("upgrading node pools = %v, want %v", got, tt.wantHolders)
+			}
+		})
+	}
+}
+
+func TestReconcileUpgradeProgress(t *testing.T) {
+	upgradeOperation := func(targetLink string, status containerpb.Operation_Status) *containerpb.Operation {
+		return &containerpb.Operation{
+			Name:          "operation-1",
+			OperationType: containerpb.Operation_UPGRADE_NODES,
+			Status:        status,
+			TargetLink:    targetLink,
+			Progress: &containerpb.OperationProgress{
+				Metrics: []*containerpb.OperationProgress_Metric{
+					{Name: "NODES_TOTAL", Value: &containerpb.OperationProgress_Metric_IntValue{IntValue: 4}},
+					{Name: "NODES_DONE", Value: &containerpb.OperationProgress_Metric_IntValue{IntValue: 1}},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		operation   *containerpb.Operation
+		phase       containerpb.NodePool_UpdateInfo_BlueGreenInfo_Phase
+		wantMessage string
+	}{
+		{
+			name:        "upgrade of the node pool",
+			operation:   upgradeOperation("https://container.googleapis.com/v1/projects/123456789/locations/us-central1/clusters/my-gke-cluster/nodePools/pool-0", containerpb.Operation_RUNNING),
+			wantMessage: "operation operation-1, 1/4 nodes upgraded (25%)",
+		},
+		{
+			name:        "blue-green upgrade of the node pool of a cluster referred to by zone",
+			operation:   upgradeOperation("https://container.googleapis.com/v1/projects/123456789/zones/us-central1/clusters/my-gke-cluster/nodePools/pool-0", containerpb.Operation_RUNNING),
+			phase:       containerpb.NodePool_UpdateInfo_BlueGreenInfo_DRAINING_BLUE_POOL,
+			wantMessage: "operation operation-1, 1/4 nodes upgraded (25%), blue-green phase DRAINING_BLUE_POOL",
+		},
+		{
+			name:      "upgrade of the node pool of another cluster with the same name suffix",
+			operation: upgradeOperation("https://container.googleapis.com/v1/projects/123456789/locations/us-central1/clusters/other-my-gke-cluster/nodePools/pool-0", containerpb.Operation_RUNNING),
+		},
+		{
+			name:      "upgrade of another node pool with the same name suffix",
+			operation: upgradeOperation("https://container.googleapis.com/v1/projects/123456789/locations/us-central1/clusters/my-gke-cluster/nodePools/my-pool-0", containerpb.Operation_RUNNING),
+		},
+		{
+			name:      "upgrade done",
+			operation: upgradeOperation("https://container.googleapis.com/v1/projects/123456789/locations/us-central1/clusters/my-gke-cluster/nodePools/pool-0", containerpb.Operation_DONE),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, server := newUpgradeTestService(t, &infrav1exp.GCPManagedControlPlane{})
+			server.SetOperation(tt.operation)
+			nodePool := &containerpb.NodePool{
+				Name:       "pool-0",
+				UpdateInfo: &containerpb.NodePool_UpdateInfo{BlueGreenInfo: &containerpb.NodePool_UpdateInfo_BlueGreenInfo{Phase: tt.phase}},
+			}
+
+			if err := s.reconcileUpgradeProgress(context.Background(), nodePool); err != nil {
+				t.Fatalf("reconcileUpgradeProgress() error = %v", err)
+			}
+
+			message := ""
+			if condition := conditions.Get(s.scope.GCPManagedMachinePool, infrav1exp.GKEMachinePoolUpgradingCondition); condition != nil {
+				message = condition.Message
+			}
+			if message != tt.wantMessage {
+				t.Errorf("upgrading condition message = %q, want %q", message, tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package connections
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
+	"google.golang.org/api/compute/v1"
+	"google.golang.org/api/servicenetworking/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/gcperrors"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// serviceName is the name of the service producer network peered for private services access.
+	serviceName = "services/servicenetworking.googleapis.com"
+
+	// operationPollInterval is the interval the service networking operations are polled at.
+	operationPollInterval = 5 * time.Second
+)
+
+// Reconcile reserves the private services access range and peers it with the service producer network.
+func (s *Service) Reconcile(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.PrivateServiceAccessAddressSpec()
+	if spec == nil || s.scope.IsSharedVpc() {
+		return nil
+	}
+
+	log.Info("Reconciling private services access resources")
+	address, err := s.createOrGetAddress(ctx, spec)
+	if err != nil {
+		return err
+	}
+
+	consumerNetwork, err := s.consumerNetwork(ctx)
+	if err != nil {
+		return err
+	}
+
+	connection, err := s.getConnection(ctx, consumerNetwork)
+	if err != nil {
+		return err
+	}
+	if connection != nil {
+		for _, reservedRange := range connection.ReservedPeeringRanges {
+			if reservedRange == address.Name {
+				return nil
+			}
+		}
+
+		log.V(2).Info("Adding reserved range to private services access connection", "range", address.Name)
+		connection.ReservedPeeringRanges = append(connection.ReservedPeeringRanges, address.Name)
+		name := fmt.Sprintf("%s/connections/%s", serviceName, connection.Peering)
+		op, err := s.scope.ServiceNetworkingService().Services.Connections.Patch(name, connection).UpdateMask("reservedPeeringRanges").Context(ctx).Do()
+		if err != nil {
+			log.Error(err, "Error updating private services access connection")
+			return err
+		}
+		return s.waitForOperation(ctx, op)
+	}
+
+	log.V(2).Info("Creating private services access connection", "range", address.Name)
+	connection = &servicenetworking.Connection{
+		Network:               consumerNetwork,
+		ReservedPeeringRanges: []string{address.Name},
+	}
+	op, err := s.scope.ServiceNetworkingService().Services.Connections.Create(serviceName, connection).Context(ctx).Do()
+	if err != nil {
+		log.Error(err, "Error creating private services access connection")
+		return err
+	}
+
+	return s.waitForOperation(ctx, op)
+}
+
+// Delete removes the private services access connection and releases the reserved range if created by capg.
+func (s *Service) Delete(ctx context.Context) error {
+	log := log.FromContext(ctx)
+	spec := s.scope.PrivateServiceAccessAddressSpec()
+	if spec == nil || s.scope.IsSharedVpc() {
+		return nil
+	}
+
+	log.Info("Deleting private services access resources")
+	addressKey := meta.GlobalKey(spec.Name)
+	address, err := s.addresses.Get(ctx, addressKey)
+	if err != nil {
+		return gcperrors.IgnoreNotFound(err)
+	}
+
+	if address.Description != infrav1.ClusterTagKey(s.scope.Name()) {
+		return nil
+	}
+
+	consumerNetwork, err := s.consumerNetwork(ctx)
+	if err != nil {
+		return err
+	}
+
+	connection, err := s.getConnection(ctx, consumerNetwork)
+	if err != nil {
+		return err
+	}
+	if connection != nil {
+		log.V(2).Info("Deleting private services access connection", "peering", connection.Peering)
+		name := fmt.Sprintf("%s/connections/%s", serviceName, connection.Peering)
+		request := &servicenetworking.DeleteConnectionRequest{ConsumerNetwork: consumerNetwork}
+		op, err := s.scope.ServiceNetworkingService().Services.Connections.DeleteConnection(name, request).Context(ctx).Do()
+		if err != nil && !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error deleting private services access connection")
+			return err
+		}
+		if err == nil {
+			if err := s.waitForOperation(ctx, op); err != nil {
+				return err
+			}
+		}
+	}
+
+	log.V(2).Info("Deleting private services access address", "name", spec.Name)
+	if err := s.addresses.Delete(ctx, addressKey); err != nil && !gcperrors.IsNotFound(err) {
+		log.Error(err, "Error deleting private services access address", "name", spec.Name)
+		return err
+	}
+
+	return nil
+}
+
+// createOrGetAddress creates the global address reserving the services range if not exist otherwise return the existing one.
+func (s *Service) createOrGetAddress(ctx context.Context, spec *compute.Address) (*compute.Address, error) {
+	log := log.FromContext(ctx)
+	log.V(2).Info("Looking for private services access address", "name", spec.Name)
+	addressKey := meta.GlobalKey(spec.Name)
+	address, err := s.addresses.Get(ctx, addressKey)
+	if err != nil {
+		if !gcperrors.IsNotFound(err) {
+			log.Error(err, "Error looking for private services access address", "name", spec.Name)
+			return nil, err
+		}
+
+		log.V(2).Info("Creating private services access address", "name", spec.Name)
+		if err := s.addresses.Insert(ctx, addressKey, spec); err != nil {
+			log.Error(err, "Error creating private services access address", "name", spec.Name)
+			return nil, err
+		}
+
+		address, err = s.addresses.Get(ctx, addressKey)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return address, nil
+}
+
+// consumerNetwork returns the cluster network in the format expected by service networking, which identifies the
+// project by its number.
+func (s *Service) consumerNetwork(ctx context.Context) (string, error) {
+	project, err := s.projects.Get(ctx, s.scope.NetworkProject())
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("projects/%s/global/networks/%s", strconv.FormatUint(project.Id, 10), s.scope.NetworkName()), nil
+}
+
+// getConnection returns the private services access connection of the consumer network, if any.
+func (s *Service) getConnection(ctx context.Context, consumerNetwork string) (*servicenetworking.Connection, error) {
+	connections, err := s.scope.ServiceNetworkingService().Services.Connections.List(serviceName).Network(consumerNetwork).Context(ctx).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, connection := range connections.Connections {
+		if connection.Service == serviceName {
+			return connection, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// waitForOperation waits for the service networking operation to be done.
+func (s *Service) waitForOperation(ctx context.Context, op *servicenetworking.Operation) error {
+	for !op.Done {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(operationPollInterval):
+		}
+
+		var err error
+		op, err = s.scope.ServiceNetworkingService().Operations.Get(op.Name).Context(ctx).Do()
+		if err != nil {
+			return err
+		}
+	}
+
+	if op.Error != nil {
+		return fmt.Errorf("service networking operation %s failed: %s", op.Name, op.Error.Message)
+	}
+
+	return nil
+}
@@ -34,6 +34,15 @@ func ManagedMachinePoolPreflightCheck(managedPool *infrav1exp.GCPManagedMachineP
 		return fmt.Errorf("expect machinepool infraref (%s) to match managed machine pool name (%s)", machinePool.Spec.Template.Spec.InfrastructureRef.Name, managedPool.Name)
 	}
 
+	if managedPool.IsMultiHostTPUSlice() {
+		nodeCount, _ := infrav1exp.TPUSliceNodeCount(managedPool.Spec.MachineType, managedPool.Spec.PlacementPolicy.TpuTopology)
+		if *machinePool.Spec.Replicas != nodeCount {
+			return fmt.Errorf("a machine pool (%s) for a multi-host TPU slice with topology %s must have %d replicas", machinePool.Name, managedPool.Spec.PlacementPolicy.TpuTopology, nodeCount)
+		}
+
+		return nil
+	}
+
 	if IsRegional(location) {
 		if *machinePool.Spec.Replicas%cloud.DefaultNumRegionsPerZone != 0 {
 			return fmt.Errorf("a machine pool (%s) in a regional cluster must have replicas with a multiple of %d", machinePool.Name, cloud.DefaultNumRegionsPerZone)
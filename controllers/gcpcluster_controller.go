@@ -18,19 +18,30 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/pkg/errors"
+	"google.golang.org/api/compute/v1"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/bastions"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/firewalls"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/loadbalancers"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/networks"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/orphans"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/resourcepolicies"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/routers"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/routes"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/subnets"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/credentialsref"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
@@ -58,15 +69,33 @@ type GCPClusterReconciler struct {
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpclusters,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpclusters/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpclusteridentities,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 
 func (r *GCPClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	log := log.FromContext(ctx).WithValues("controller", "GCPCluster")
 
+	err := credentialsref.SetupIndexes(ctx, mgr, &infrav1.GCPCluster{}, func(o client.Object) (*infrav1.ObjectReference, *infrav1.ObjectReference) {
+		gcpCluster := o.(*infrav1.GCPCluster)
+		return gcpCluster.Spec.CredentialsRef, gcpCluster.Spec.IdentityRef
+	})
+	if err != nil {
+		return errors.Wrap(err, "error setting up credentials indexes")
+	}
+
 	c, err := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		For(&infrav1.GCPCluster{}).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log, r.WatchFilterValue)).
 		WithEventFilter(predicates.ResourceIsNotExternallyManaged(log)).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(credentialsref.SecretToClusters(mgr.GetClient(), &infrav1.GCPClusterList{})),
+		).
+		Watches(
+			&infrav1.GCPClusterIdentity{},
+			handler.EnqueueRequestsFromMapFunc(credentialsref.IdentityToClusters(mgr.GetClient(), &infrav1.GCPClusterList{})),
+		).
 		Build(r)
 	if err != nil {
 		return errors.Wrap(err, "error creating controller")
@@ -94,6 +123,7 @@ func (r *GCPClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 			return requests
 		}),
 		predicates.ClusterUnpaused(log),
+		predicates.ResourceHasFilterLabel(log, r.WatchFilterValue),
 	); err != nil {
 		return errors.Wrap(err, "failed adding a watch for ready clusters")
 	}
@@ -118,6 +148,11 @@ func (r *GCPClusterReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	if !reconciler.MatchesWatchFilter(gcpCluster, r.WatchFilterValue) {
+		log.V(4).Info("GCPCluster does not match the watch filter, skipping")
+		return ctrl.Result{}, nil
+	}
+
 	// Fetch the Cluster.
 	cluster, err := util.GetOwnerCluster(ctx, r.Client, gcpCluster.ObjectMeta)
 	if err != nil {
@@ -163,7 +198,9 @@ func (r *GCPClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 	log := log.FromContext(ctx)
 	log.Info("Reconciling GCPCluster")
 
-	controllerutil.AddFinalizer(clusterScope.GCPCluster, infrav1.ClusterFinalizer)
+	if controllerutil.AddFinalizer(clusterScope.GCPCluster, infrav1.ClusterFinalizer) {
+		record.Event(clusterScope.GCPCluster, "GCPClusterCreate", "Creating cluster infrastructure")
+	}
 	if err := clusterScope.PatchObject(); err != nil {
 		return ctrl.Result{}, err
 	}
@@ -178,30 +215,25 @@ func (r *GCPClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 		return ctrl.Result{}, err
 	}
 
-	failureDomains := make(clusterv1.FailureDomains, len(zones))
-	for _, zone := range zones {
-		if len(clusterScope.GCPCluster.Spec.FailureDomains) > 0 {
-			for _, fd := range clusterScope.GCPCluster.Spec.FailureDomains {
-				if fd == zone.Name {
-					failureDomains[zone.Name] = clusterv1.FailureDomainSpec{
-						ControlPlane: true,
-					}
-				}
-			}
-		} else {
-			failureDomains[zone.Name] = clusterv1.FailureDomainSpec{
-				ControlPlane: true,
-			}
+	var machineTypeZones sets.Set[string]
+	if machineTypes := clusterScope.GCPCluster.Spec.FailureDomainMachineTypes; len(machineTypes) > 0 {
+		machineTypeZones, err = zonesOfferingMachineTypes(ctx, clusterScope.ComputeService(), clusterScope.Project(), machineTypes)
+		if err != nil {
+			return ctrl.Result{}, err
 		}
 	}
 
-	clusterScope.SetFailureDomains(failureDomains)
+	clusterScope.SetFailureDomains(failureDomains(clusterScope.GCPCluster.Spec, zones, machineTypeZones))
 
 	reconcilers := []cloud.Reconciler{
 		networks.New(clusterScope),
+		routers.New(clusterScope),
+		subnets.New(clusterScope),
 		firewalls.New(clusterScope),
+		routes.New(clusterScope),
+		bastions.New(clusterScope),
 		loadbalancers.New(clusterScope),
-		subnets.New(clusterScope),
+		resourcepolicies.New(clusterScope),
 	}
 
 	for _, r := range reconcilers {
@@ -225,14 +257,84 @@ func (r *GCPClusterReconciler) reconcile(ctx context.Context, clusterScope *scop
 	return ctrl.Result{}, nil
 }
 
+// failureDomains returns the failure domains of the cluster among the zones of its region. Zones which are down,
+// which are not among the configured failure domains or which do not offer the required machine types, when
+// machineTypeZones is not nil, are left out.
+func failureDomains(spec infrav1.GCPClusterSpec, zones []*compute.Zone, machineTypeZones sets.Set[string]) clusterv1.FailureDomains {
+	allowed := sets.New(spec.FailureDomains...)
+	controlPlane := sets.New(spec.ControlPlaneFailureDomains...)
+	failureDomains := make(clusterv1.FailureDomains, len(zones))
+	for _, zone := range zones {
+		if zone.Status == "DOWN" {
+			continue
+		}
+		if allowed.Len() > 0 && !allowed.Has(zone.Name) {
+			continue
+		}
+		if machineTypeZones != nil && !machineTypeZones.Has(zone.Name) {
+			continue
+		}
+
+		failureDomains[zone.Name] = clusterv1.FailureDomainSpec{
+			ControlPlane: controlPlane.Len() == 0 || controlPlane.Has(zone.Name),
+		}
+	}
+
+	return failureDomains
+}
+
+// zonesOfferingMachineTypes returns the zones of the project which offer all the given machine types.
+func zonesOfferingMachineTypes(ctx context.Context, computeSvc *compute.Service, project string, machineTypes []string) (sets.Set[string], error) {
+	wanted := sets.New(machineTypes...)
+	filters := make([]string, 0, len(machineTypes))
+	for _, name := range sets.List(wanted) {
+		filters = append(filters, fmt.Sprintf("(name = %q)", name))
+	}
+
+	offered := make(map[string]sets.Set[string])
+	call := computeSvc.MachineTypes.AggregatedList(project).Filter(strings.Join(filters, " OR "))
+	err := call.Pages(ctx, func(list *compute.MachineTypeAggregatedList) error {
+		for _, scoped := range list.Items {
+			for _, machineType := range scoped.MachineTypes {
+				if !wanted.Has(machineType.Name) {
+					continue
+				}
+				if offered[machineType.Zone] == nil {
+					offered[machineType.Zone] = sets.New[string]()
+				}
+				offered[machineType.Zone].Insert(machineType.Name)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list machine types")
+	}
+
+	zones := sets.New[string]()
+	for zone, names := range offered {
+		if names.Len() == wanted.Len() {
+			zones.Insert(zone)
+		}
+	}
+
+	return zones, nil
+}
+
 func (r *GCPClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ClusterScope) error {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling Delete GCPCluster")
+	record.Event(clusterScope.GCPCluster, "GCPClusterDelete", "Deleting cluster infrastructure")
 
 	reconcilers := []cloud.Reconciler{
-		subnets.New(clusterScope),
+		resourcepolicies.New(clusterScope),
+		bastions.New(clusterScope),
 		loadbalancers.New(clusterScope),
+		routes.New(clusterScope),
+		subnets.New(clusterScope),
 		firewalls.New(clusterScope),
+		routers.New(clusterScope),
+		orphans.New(clusterScope),
 		networks.New(clusterScope),
 	}
 
@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"google.golang.org/api/compute/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestFailureDomains(t *testing.T) {
+	zones := []*compute.Zone{
+		{Name: "us-central1-a", Status: "UP"},
+		{Name: "us-central1-b", Status: "UP"},
+		{Name: "us-central1-c", Status: "UP"},
+		{Name: "us-central1-f", Status: "DOWN"},
+	}
+
+	tests := []struct {
+		name             string
+		spec             infrav1.GCPClusterSpec
+		machineTypeZones sets.Set[string]
+		want             clusterv1.FailureDomains
+	}{
+		{
+			name: "all zones which are up",
+			want: clusterv1.FailureDomains{
+				"us-central1-a": clusterv1.FailureDomainSpec{ControlPlane: true},
+				"us-central1-b": clusterv1.FailureDomainSpec{ControlPlane: true},
+				"us-central1-c": clusterv1.FailureDomainSpec{ControlPlane: true},
+			},
+		},
+		{
+			name: "restricted failure domains",
+			spec: infrav1.GCPClusterSpec{
+				FailureDomains: []string{"us-central1-a", "us-central1-f"},
+			},
+			want: clusterv1.FailureDomains{
+				"us-central1-a": clusterv1.FailureDomainSpec{ControlPlane: true},
+			},
+		},
+		{
+			name: "restricted control plane failure domains",
+			spec: infrav1.GCPClusterSpec{
+				ControlPlaneFailureDomains: []string{"us-central1-b"},
+			},
+			want: clusterv1.FailureDomains{
+				"us-central1-a": clusterv1.FailureDomainSpec{ControlPlane: false},
+				"us-central1-b": clusterv1.FailureDomainSpec{ControlPlane: true},
+				"us-central1-c": clusterv1.FailureDomainSpec{ControlPlane: false},
+			},
+		},
+		{
+			name:             "zones without the required machine types",
+			machineTypeZones: sets.New("us-central1-b", "us-central1-c"),
+			want: clusterv1.FailureDomains{
+				"us-central1-b": clusterv1.FailureDomainSpec{ControlPlane: true},
+				"us-central1-c": clusterv1.FailureDomainSpec{ControlPlane: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(failureDomains(tt.spec, zones, tt.machineTypeZones)).To(Equal(tt.want))
+		})
+	}
+}
@@ -47,11 +47,13 @@ type GCPMachineReconciler struct {
 	client.Client
 	ReconcileTimeout time.Duration
 	WatchFilterValue string
+	// PreemptionPollInterval is the interval at which running preemptible instances are checked for preemption.
+	PreemptionPollInterval time.Duration
 }
 
 // +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets;,verbs=get;list;watch
-// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines;machines/status,verbs=get;list;watch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmachines,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmachines/status,verbs=get;update;patch
 
@@ -84,6 +86,7 @@ func (r *GCPMachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		source.Kind(mgr.GetCache(), &clusterv1.Cluster{}),
 		handler.EnqueueRequestsFromMapFunc(clusterToObjectFunc),
 		predicates.ClusterUnpausedAndInfrastructureReady(log),
+		predicates.ResourceHasFilterLabel(log, r.WatchFilterValue),
 	); err != nil {
 		return errors.Wrap(err, "failed adding a watch for ready clusters")
 	}
@@ -146,6 +149,11 @@ func (r *GCPMachineReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	if !reconciler.MatchesWatchFilter(gcpMachine, r.WatchFilterValue) {
+		log.V(4).Info("GCPMachine does not match the watch filter, skipping")
+		return ctrl.Result{}, nil
+	}
+
 	machine, err := util.GetOwnerMachine(ctx, r.Client, gcpMachine.ObjectMeta)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -232,6 +240,18 @@ func (r *GCPMachineReconciler) reconcile(ctx context.Context, machineScope *scop
 	}
 
 	instanceState := *machineScope.GetInstanceStatus()
+	if machineScope.IsEphemeral() && isTerminatedInstanceState(instanceState) {
+		if machineScope.IsPreemptible() {
+			return ctrl.Result{}, r.deleteMachine(ctx, machineScope, "GCPMachinePreempted", "was preempted")
+		}
+		return ctrl.Result{}, r.deleteMachine(ctx, machineScope, "GCPMachineTerminated", "was terminated")
+	}
+
+	deadline := machineScope.RunDeadline()
+	if deadline != nil && !time.Now().Before(*deadline) {
+		return ctrl.Result{}, r.deleteMachine(ctx, machineScope, "GCPMachineMaxRunDurationReached", "reached its max run duration")
+	}
+
 	switch instanceState {
 	case infrav1.InstanceStatusProvisioning, infrav1.InstanceStatusStaging:
 		log.Info("GCPMachine instance is pending", "instance-id", *machineScope.GetInstanceID())
@@ -242,7 +262,14 @@ func (r *GCPMachineReconciler) reconcile(ctx context.Context, machineScope *scop
 		record.Eventf(machineScope.GCPMachine, "GCPMachineReconcile", "GCPMachine instance is running - instance-id: %s", *machineScope.GetInstanceID())
 		record.Event(machineScope.GCPMachine, "GCPMachineReconcile", "Reconciled")
 		machineScope.SetReady()
-		return ctrl.Result{}, nil
+		var requeueAfter time.Duration
+		if machineScope.IsPreemptible() && r.PreemptionPollInterval > 0 {
+			requeueAfter = r.PreemptionPollInterval
+		}
+		if deadline != nil && (requeueAfter == 0 || time.Until(*deadline) < requeueAfter) {
+			requeueAfter = time.Until(*deadline)
+		}
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
 	default:
 		machineScope.SetFailureReason(capierrors.UpdateMachineError)
 		machineScope.SetFailureMessage(errors.Errorf("GCPMachine instance state %s is unexpected", instanceState))
@@ -250,6 +277,35 @@ func (r *GCPMachineReconciler) reconcile(ctx context.Context, machineScope *scop
 	}
 }
 
+// isTerminatedInstanceState returns true if an ephemeral instance in the given state has been terminated, e.g.
+// preempted.
+func isTerminatedInstanceState(state infrav1.InstanceStatus) bool {
+	switch state {
+	case infrav1.InstanceStatusStopping, infrav1.InstanceStatusStopped, infrav1.InstanceStatusTerminated:
+		return true
+	default:
+		return false
+	}
+}
+
+// deleteMachine deletes the Machine owning an ephemeral GCPMachine whose instance is gone or expired, so that
+// its node is drained and the Machine is replaced by its owner instead of being left NotReady.
+func (r *GCPMachineReconciler) deleteMachine(ctx context.Context, machineScope *scope.MachineScope, reason, cause string) error {
+	log := log.FromContext(ctx)
+
+	if !machineScope.Machine.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	log.Info("Deleting the Machine of the ephemeral GCPMachine", "machine", machineScope.Machine.Name, "reason", reason)
+	record.Warnf(machineScope.GCPMachine, reason, "GCPMachine instance %s, deleting Machine %s", cause, machineScope.Machine.Name)
+	if err := r.Client.Delete(ctx, machineScope.Machine); err != nil && !apierrors.IsNotFound(err) {
+		return errors.Wrapf(err, "failed to delete Machine %s", machineScope.Machine.Name)
+	}
+
+	return nil
+}
+
 func (r *GCPMachineReconciler) reconcileDelete(ctx context.Context, machineScope *scope.MachineScope) error {
 	log := log.FromContext(ctx)
 	log.Info("Reconciling Delete GCPMachine")
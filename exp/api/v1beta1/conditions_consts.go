@@ -27,6 +27,10 @@ const (
 	GKEControlPlaneUpdatingCondition clusterv1.ConditionType = "GKEControlPlaneUpdating"
 	// GKEControlPlaneDeletingCondition condition reports on whether the GKE control plane is deleting.
 	GKEControlPlaneDeletingCondition clusterv1.ConditionType = "GKEControlPlaneDeleting"
+	// GKEControlPlaneKubeconfigRefreshedCondition condition reports on whether the token of the CAPI kubeconfig has been refreshed.
+	GKEControlPlaneKubeconfigRefreshedCondition clusterv1.ConditionType = "GKEControlPlaneKubeconfigRefreshed"
+	// GKEControlPlaneDryRunCondition condition reports on the changes to the GKE control plane planned in dry-run mode.
+	GKEControlPlaneDryRunCondition clusterv1.ConditionType = "GKEControlPlaneDryRun"
 
 	// GKEControlPlaneCreatingReason used to report GKE control plane being created.
 	GKEControlPlaneCreatingReason = "GKEControlPlaneCreating"
@@ -44,6 +48,16 @@ const (
 	GKEControlPlaneReconciliationFailedReason = "GKEControlPlaneReconciliationFailed"
 	// GKEControlPlaneRequiresAtLeastOneNodePoolReason used to report that no node pool is specified for the GKE control plane.
 	GKEControlPlaneRequiresAtLeastOneNodePoolReason = "GKEControlPlaneRequiresAtLeastOneNodePool"
+	// GKEControlPlaneKubeconfigRefreshFailedReason used to report failures while refreshing the token of the CAPI kubeconfig.
+	GKEControlPlaneKubeconfigRefreshFailedReason = "GKEControlPlaneKubeconfigRefreshFailed"
+	// GKEControlPlaneOperationFailedReason used to report a GKE operation on the cluster has failed.
+	GKEControlPlaneOperationFailedReason = "GKEControlPlaneOperationFailed"
+	// GKEControlPlaneChangesPlannedReason used to report the changes to the GKE control plane skipped in dry-run mode.
+	GKEControlPlaneChangesPlannedReason = "GKEControlPlaneChangesPlanned"
+	// GKEControlPlaneAlreadyExistsReason used to report a GKE cluster with the same name exists and has not been adopted.
+	GKEControlPlaneAlreadyExistsReason = "GKEControlPlaneAlreadyExists"
+	// GKEControlPlaneAdoptedReason used to report an existing GKE cluster has been adopted instead of created.
+	GKEControlPlaneAdoptedReason = "GKEControlPlaneAdopted"
 
 	// GKEMachinePoolReadyCondition condition reports on the successful reconciliation of GKE node pool.
 	GKEMachinePoolReadyCondition clusterv1.ConditionType = "GKEMachinePoolReady"
@@ -53,6 +67,12 @@ const (
 	GKEMachinePoolUpdatingCondition clusterv1.ConditionType = "GKEMachinePoolUpdating"
 	// GKEMachinePoolDeletingCondition condition reports on whether the GKE node pool is deleting.
 	GKEMachinePoolDeletingCondition clusterv1.ConditionType = "GKEMachinePoolDeleting"
+	// GKEMachinePoolUpgradingCondition condition reports on whether the GKE node pool is upgrading its Kubernetes version.
+	GKEMachinePoolUpgradingCondition clusterv1.ConditionType = "GKEMachinePoolUpgrading"
+	// GKEMachinePoolRollingBackCondition condition reports on whether the GKE node pool upgrade is rolling back.
+	GKEMachinePoolRollingBackCondition clusterv1.ConditionType = "GKEMachinePoolRollingBack"
+	// GKEMachinePoolDryRunCondition condition reports on the changes to the GKE node pool planned in dry-run mode.
+	GKEMachinePoolDryRunCondition clusterv1.ConditionType = "GKEMachinePoolDryRun"
 
 	// WaitingForGKEControlPlaneReason used when the machine pool is waiting for GKE control plane infrastructure to be ready before proceeding.
 	WaitingForGKEControlPlaneReason = "WaitingForGKEControlPlane"
@@ -62,12 +82,46 @@ const (
 	GKEMachinePoolCreatedReason = "GKEMachinePoolCreated"
 	// GKEMachinePoolUpdatedReason used to report GKE node pool is updated.
 	GKEMachinePoolUpdatedReason = "GKEMachinePoolUpdated"
+	// GKEMachinePoolProvisioningModelChangedReason used to report the Spot/preemptible provisioning model of an existing
+	// GKE node pool cannot be changed.
+	GKEMachinePoolProvisioningModelChangedReason = "GKEMachinePoolProvisioningModelChanged"
+	// GKEMachinePoolUpgradedReason used to report GKE node pool is running the desired Kubernetes version.
+	GKEMachinePoolUpgradedReason = "GKEMachinePoolUpgraded"
+	// GKEMachinePoolUpgradeInProgressReason used to report the progress of the GKE node pool upgrade operation.
+	GKEMachinePoolUpgradeInProgressReason = "GKEMachinePoolUpgradeInProgress"
+	// WaitingForControlPlaneUpgradeReason used when the node pool upgrade is waiting for the GKE control plane upgrade to complete.
+	WaitingForControlPlaneUpgradeReason = "WaitingForControlPlaneUpgrade"
+	// WaitingForNodePoolUpgradesReason used when the node pool upgrade is waiting for other node pools of the cluster to be upgraded.
+	WaitingForNodePoolUpgradesReason = "WaitingForNodePoolUpgrades"
+	// GKEMachinePoolRolledBackReason used to report GKE node pool upgrade has been rolled back.
+	GKEMachinePoolRolledBackReason = "GKEMachinePoolRolledBack"
+	// GKEMachinePoolRollbackFailedReason used to report failures while rolling back the GKE node pool upgrade.
+	GKEMachinePoolRollbackFailedReason = "GKEMachinePoolRollbackFailed"
 	// GKEMachinePoolDeletingReason used to report GKE node pool being deleted.
 	GKEMachinePoolDeletingReason = "GKEMachinePoolDeleting"
 	// GKEMachinePoolDeletedReason used to report GKE node pool is deleted.
 	GKEMachinePoolDeletedReason = "GKEMachinePoolDeleted"
+	// GKEMachinePoolAlreadyExistsReason used to report a GKE node pool with the same name exists and has not been adopted.
+	GKEMachinePoolAlreadyExistsReason = "GKEMachinePoolAlreadyExists"
+	// GKEMachinePoolAdoptedReason used to report an existing GKE node pool has been adopted.
+	GKEMachinePoolAdoptedReason = "GKEMachinePoolAdopted"
+	// GKEMachinePoolRetainedReason used to report GKE node pool is left intact on deletion.
+	GKEMachinePoolRetainedReason = "GKEMachinePoolRetained"
 	// GKEMachinePoolErrorReason used to report GKE node pool is in error state.
 	GKEMachinePoolErrorReason = "GKEMachinePoolError"
 	// GKEMachinePoolReconciliationFailedReason used to report failures while reconciling GKE node pool.
 	GKEMachinePoolReconciliationFailedReason = "GKEMachinePoolReconciliationFailed"
+	// GKEMachinePoolChangesPlannedReason used to report the changes to the GKE node pool skipped in dry-run mode.
+	GKEMachinePoolChangesPlannedReason = "GKEMachinePoolChangesPlanned"
+
+	// GCPMachinePoolReadyCondition condition reports on the successful reconciliation of the managed instance group.
+	GCPMachinePoolReadyCondition clusterv1.ConditionType = "GCPMachinePoolReady"
+
+	// WaitingForBootstrapDataReason used when the machine pool is waiting for the bootstrap data secret.
+	WaitingForBootstrapDataReason = "WaitingForBootstrapData"
+	// GCPMachinePoolUpdatingReason used to report the managed instance group is rolling out its instance template
+	// or resizing.
+	GCPMachinePoolUpdatingReason = "GCPMachinePoolUpdating"
+	// GCPMachinePoolReconciliationFailedReason used to report failures while reconciling the managed instance group.
+	GCPMachinePoolReconciliationFailedReason = "GCPMachinePoolReconciliationFailed"
 )
@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+)
+
+const (
+	// MachinePoolFinalizer allows Reconcile to clean up GCP resources associated with the GCPMachinePool before
+	// removing it from the apiserver.
+	MachinePoolFinalizer = "gcpmachinepool.infrastructure.cluster.x-k8s.io"
+)
+
+// MinInstancesForPercentUpdate is the minimum size of a managed instance group for GCE to accept percentages
+// in its update policy.
+const MinInstancesForPercentUpdate = 10
+
+// GCPMachinePoolSpec defines the desired state of GCPMachinePool.
+type GCPMachinePoolSpec struct {
+	// ProviderIDList are the identification IDs of the instances of the managed instance group.
+	// +optional
+	ProviderIDList []string `json:"providerIDList,omitempty"`
+
+	// Template is the spec of the instances of the managed instance group. A new instance template is created
+	// when it changes and rolled out to the instances according to the update strategy.
+	Template infrav1.GCPMachineSpec `json:"template"`
+
+	// Strategy configures how instances are replaced when the instance template changes.
+	// +optional
+	Strategy *GCPMachinePoolUpdateStrategy `json:"strategy,omitempty"`
+}
+
+// GCPMachinePoolUpdateStrategy defines the rolling update of the instances of a managed instance group.
+type GCPMachinePoolUpdateStrategy struct {
+	// MaxSurge is the maximum number of instances, or percentage of the target size, that can be created
+	// above the target size during the update. GCE only accepts percentages for groups of at least 10
+	// instances, the percentage of smaller groups is rounded up to a number of instances.
+	// If unspecified, defaults to 1.
+	// +optional
+	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// MaxUnavailable is the maximum number of instances, or percentage of the target size, that can be
+	// unavailable during the update. The percentage of groups smaller than 10 instances is rounded down to a
+	// number of instances. MaxSurge and MaxUnavailable cannot both be zero.
+	// If unspecified, defaults to 0.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+}
+
+// GCPMachinePoolStatus defines the observed state of GCPMachinePool.
+type GCPMachinePoolStatus struct {
+	// Ready is true when the managed instance group is stable, i.e. all of its instances are running the
+	// current instance template.
+	// +optional
+	Ready bool `json:"ready"`
+	// Replicas is the most recently observed number of replicas.
+	// +optional
+	Replicas int32 `json:"replicas"`
+	// InstanceTemplate is the name of the instance template currently used by the managed instance group.
+	// +optional
+	InstanceTemplate string `json:"instanceTemplate,omitempty"`
+	// Conditions specifies the conditions for the machine pool.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=gcpmachinepools,scope=Namespaced,categories=cluster-api,shortName=gcpmp
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.ready",description="Machine pool is ready"
+// +kubebuilder:printcolumn:name="Replicas",type="integer",JSONPath=".status.replicas",description="Number of replicas"
+// +kubebuilder:printcolumn:name="Template",type="string",JSONPath=".status.instanceTemplate",description="Instance template of the managed instance group"
+
+// GCPMachinePool is the Schema for the gcpmachinepools API.
+type GCPMachinePool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GCPMachinePoolSpec   `json:"spec,omitempty"`
+	Status GCPMachinePoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GCPMachinePoolList contains a list of GCPMachinePool.
+type GCPMachinePoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCPMachinePool `json:"items"`
+}
+
+// GetConditions returns the machine pool conditions.
+func (r *GCPMachinePool) GetConditions() clusterv1.Conditions {
+	return r.Status.Conditions
+}
+
+// SetConditions sets the status conditions for the GCPMachinePool.
+func (r *GCPMachinePool) SetConditions(conditions clusterv1.Conditions) {
+	r.Status.Conditions = conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&GCPMachinePool{}, &GCPMachinePoolList{})
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var gcpmachinepoollog = logf.Log.WithName("gcpmachinepool-resource")
+
+func (r *GCPMachinePool) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-gcpmachinepool,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=gcpmachinepools,verbs=create;update,versions=v1beta1,name=mgcpmachinepool.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &GCPMachinePool{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+func (r *GCPMachinePool) Default() {
+	gcpmachinepoollog.Info("default", "name", r.Name)
+
+	if r.Spec.Strategy == nil {
+		r.Spec.Strategy = &GCPMachinePoolUpdateStrategy{}
+	}
+	if r.Spec.Strategy.MaxSurge == nil {
+		maxSurge := intstr.FromInt(1)
+		r.Spec.Strategy.MaxSurge = &maxSurge
+	}
+	if r.Spec.Strategy.MaxUnavailable == nil {
+		maxUnavailable := intstr.FromInt(0)
+		r.Spec.Strategy.MaxUnavailable = &maxUnavailable
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-gcpmachinepool,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=gcpmachinepools,verbs=create;update,versions=v1beta1,name=vgcpmachinepool.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &GCPMachinePool{}
+
+func (r *GCPMachinePool) validateStrategy() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.Strategy != nil {
+		strategyField := field.NewPath("spec", "strategy")
+		maxSurge, errs := validateFixedOrPercent(r.Spec.Strategy.MaxSurge, strategyField.Child("maxSurge"), 1)
+		allErrs = append(allErrs, errs...)
+		maxUnavailable, errs := validateFixedOrPercent(r.Spec.Strategy.MaxUnavailable, strategyField.Child("maxUnavailable"), 0)
+		allErrs = append(allErrs, errs...)
+		if len(allErrs) == 0 && maxSurge == 0 && maxUnavailable == 0 {
+			allErrs = append(allErrs, field.Invalid(strategyField, r.Spec.Strategy, "maxSurge and maxUnavailable cannot both be zero"))
+		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+// validateFixedOrPercent validates a number of instances or a percentage of the target size of the managed
+// instance group, and returns it, or the given default when unset.
+func validateFixedOrPercent(value *intstr.IntOrString, fldPath *field.Path, defaultValue int) (int, field.ErrorList) {
+	var allErrs field.ErrorList
+	if value == nil {
+		return defaultValue, nil
+	}
+
+	if value.Type == intstr.String {
+		if !strings.HasSuffix(value.StrVal, "%") {
+			return 0, append(allErrs, field.Invalid(fldPath, value.StrVal, "must be a number of instances or a percentage"))
+		}
+		percent, err := intstr.GetScaledValueFromIntOrPercent(value, 100, false)
+		if err != nil || percent < 0 || percent > 100 {
+			return 0, append(allErrs, field.Invalid(fldPath, value.StrVal, "must be a percentage between 0% and 100%"))
+		}
+		return percent, nil
+	}
+
+	if value.IntVal < 0 {
+		return 0, append(allErrs, field.Invalid(fldPath, value.IntVal, "must be greater or equal zero"))
+	}
+	return int(value.IntVal), nil
+}
+
+func (r *GCPMachinePool) validateTemplate() field.ErrorList {
+	var allErrs field.ErrorList
+	// The instances of a machine pool are listed in spec.providerIDList, the template cannot point at one.
+	if r.Spec.Template.ProviderID != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "providerID"), "cannot be set on a machine pool"))
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPMachinePool) ValidateCreate() (admission.Warnings, error) {
+	gcpmachinepoollog.Info("validate create", "name", r.Name)
+
+	var allErrs field.ErrorList
+	allErrs = append(allErrs, r.validateStrategy()...)
+	allErrs = append(allErrs, r.validateTemplate()...)
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
+
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPMachinePool").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPMachinePool) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings, error) {
+	gcpmachinepoollog.Info("validate update", "name", r.Name)
+	var allErrs field.ErrorList
+	old := oldRaw.(*GCPMachinePool)
+
+	// Changes to the template are rolled out by replacing the instances, only the fields describing a single
+	// instance are immutable.
+	if !cmp.Equal(r.Spec.Template.ProviderID, old.Spec.Template.ProviderID) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "template", "providerID"),
+				r.Spec.Template.ProviderID, "field is immutable"),
+		)
+	}
+
+	allErrs = append(allErrs, r.validateStrategy()...)
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
+
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPMachinePool").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPMachinePool) ValidateDelete() (admission.Warnings, error) {
+	gcpmachinepoollog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+)
+
+func TestGCPMachinePool_Default(t *testing.T) {
+	g := NewWithT(t)
+
+	pool := &GCPMachinePool{}
+	pool.Default()
+	g.Expect(pool.Spec.Strategy.MaxSurge).To(Equal(intstrPtr(intstr.FromInt(1))))
+	g.Expect(pool.Spec.Strategy.MaxUnavailable).To(Equal(intstrPtr(intstr.FromInt(0))))
+
+	pool = &GCPMachinePool{Spec: GCPMachinePoolSpec{Strategy: &GCPMachinePoolUpdateStrategy{MaxSurge: intstrPtr(intstr.FromString("20%"))}}}
+	pool.Default()
+	g.Expect(pool.Spec.Strategy.MaxSurge).To(Equal(intstrPtr(intstr.FromString("20%"))))
+	g.Expect(pool.Spec.Strategy.MaxUnavailable).To(Equal(intstrPtr(intstr.FromInt(0))))
+}
+
+func TestGCPMachinePool_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+	tests := []struct {
+		name     string
+		strategy *GCPMachinePoolUpdateStrategy
+		wantErr  bool
+	}{
+		{
+			name:    "GCPMachinePool without strategy - valid",
+			wantErr: false,
+		},
+		{
+			name: "GCPMachinePool with percentages - valid",
+			strategy: &GCPMachinePoolUpdateStrategy{
+				MaxSurge:       intstrPtr(intstr.FromString("25%")),
+				MaxUnavailable: intstrPtr(intstr.FromString("0%")),
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPMachinePool with negative maxSurge - invalid",
+			strategy: &GCPMachinePoolUpdateStrategy{
+				MaxSurge: intstrPtr(intstr.FromInt(-1)),
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachinePool with maxUnavailable above 100% - invalid",
+			strategy: &GCPMachinePoolUpdateStrategy{
+				MaxUnavailable: intstrPtr(intstr.FromString("150%")),
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachinePool with maxSurge that is not a percentage - invalid",
+			strategy: &GCPMachinePoolUpdateStrategy{
+				MaxSurge: intstrPtr(intstr.FromString("two")),
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPMachinePool with zero maxSurge and maxUnavailable - invalid",
+			strategy: &GCPMachinePoolUpdateStrategy{
+				MaxSurge:       intstrPtr(intstr.FromInt(0)),
+				MaxUnavailable: intstrPtr(intstr.FromString("0%")),
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pool := &GCPMachinePool{Spec: GCPMachinePoolSpec{Strategy: test.strategy}}
+			warn, err := pool.ValidateCreate()
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(warn).To(BeEmpty())
+		})
+	}
+}
+
+func TestGCPMachinePool_ValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	old := &GCPMachinePool{}
+	pool := old.DeepCopy()
+	pool.Spec.Template.InstanceType = "n2-standard-4"
+	_, err := pool.ValidateUpdate(old)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	pool = old.DeepCopy()
+	pool.Spec.Template.ProviderID = pointer.String("gce://my-proj/us-central1-a/my-pool-abcd")
+	_, err = pool.ValidateUpdate(old)
+	g.Expect(err).To(HaveOccurred())
+
+	pool = old.DeepCopy()
+	pool.Spec.Strategy = &GCPMachinePoolUpdateStrategy{MaxUnavailable: intstrPtr(intstr.FromInt(-1))}
+	_, err = pool.ValidateUpdate(old)
+	g.Expect(err).To(HaveOccurred())
+}
+
+func intstrPtr(value intstr.IntOrString) *intstr.IntOrString {
+	return &value
+}
@@ -55,9 +55,47 @@ type GCPManagedClusterSpec struct {
 	// +optional
 	CredentialsRef *infrav1.ObjectReference `json:"credentialsRef,omitempty"`
 
+	// IdentityRef is a reference to a GCPClusterIdentity with the credentials to use for provisioning this
+	// cluster. The identity must allow the namespace of the cluster. It cannot be set along with CredentialsRef.
+	// +optional
+	IdentityRef *infrav1.ObjectReference `json:"identityRef,omitempty"`
+
+	// ImpersonateServiceAccount is the email of a service account that the credentials of CredentialsRef, or of
+	// the controller, impersonate for provisioning this cluster. The credentials need the Service Account Token
+	// Creator role on it. Identities referenced by IdentityRef configure impersonation themselves.
+	// +optional
+	ImpersonateServiceAccount *string `json:"impersonateServiceAccount,omitempty"`
+
+	// QuotaProject is the project that the quota and billing of the API requests for provisioning this cluster
+	// are attributed to, instead of the project of the credentials. It overrides the quota project of the
+	// identity referenced by IdentityRef. The credentials need the serviceusage.services.use permission on it.
+	// +optional
+	QuotaProject *string `json:"quotaProject,omitempty"`
+
 	// AddonsConfig is a configuration for the various addons available to run in the cluster.
 	// +optional
 	AddonsConfig *infrav1.AddonsConfig `json:"addonsConfig,omitempty"`
+
+	// IPAllocationPolicy configures the subnetwork and secondary ranges of a VPC-native cluster. The subnetwork
+	// is created with the pods and services secondary ranges when it doesn't exist.
+	// +optional
+	IPAllocationPolicy *IPAllocationPolicy `json:"ipAllocationPolicy,omitempty"`
+
+	// FirewallRules are additional firewall rules created in the cluster network, e.g. to allow the control plane
+	// to reach admission webhooks listening on nonstandard ports.
+	// +optional
+	FirewallRules []FirewallRule `json:"firewallRules,omitempty"`
+
+	// PrivateServiceAccess reserves an IP range in the cluster network and peers it with the Google service
+	// producer network, so that the cluster can reach services such as Cloud SQL or Memorystore privately.
+	// +optional
+	PrivateServiceAccess *PrivateServiceAccess `json:"privateServiceAccess,omitempty"`
+
+	// PrivateServiceConnectEndpoint creates a Private Service Connect endpoint targeting the service attachment
+	// of the control plane in a consumer network, e.g. the network of the management cluster, so that the
+	// private control plane can be reached without network peering.
+	// +optional
+	PrivateServiceConnectEndpoint *PrivateServiceConnectEndpoint `json:"privateServiceConnectEndpoint,omitempty"`
 }
 
 // GCPManagedClusterStatus defines the observed state of GCPManagedCluster.
@@ -65,6 +103,15 @@ type GCPManagedClusterStatus struct {
 	FailureDomains clusterv1.FailureDomains `json:"failureDomains,omitempty"`
 	Network        infrav1.Network          `json:"network,omitempty"`
 	Ready          bool                     `json:"ready"`
+	// SecondaryRanges maps the name of each subnet of the cluster to the names of its secondary IP ranges.
+	// +optional
+	SecondaryRanges map[string][]string `json:"secondaryRanges,omitempty"`
+	// NatIPs are the external IP addresses allocated to the Cloud NAT of the cluster network.
+	// +optional
+	NatIPs []string `json:"natIPs,omitempty"`
+	// PrivateServiceConnectEndpoint is the IP address of the Private Service Connect endpoint of the control plane.
+	// +optional
+	PrivateServiceConnectEndpoint *string `json:"privateServiceConnectEndpoint,omitempty"`
 	// Conditions specifies the conditions for the managed control plane
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
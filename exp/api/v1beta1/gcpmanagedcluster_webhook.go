@@ -21,6 +21,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -53,7 +54,51 @@ var _ webhook.Validator = &GCPManagedCluster{}
 func (r *GCPManagedCluster) ValidateCreate() (admission.Warnings, error) {
 	gcpmanagedclusterlog.Info("validate create", "name", r.Name)
 
-	return nil, nil
+	allErrs := r.validate()
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
+
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedCluster").GroupKind(), r.Name, allErrs)
+}
+
+// validate validates the spec of a new GCPManagedCluster.
+func (r *GCPManagedCluster) validate() field.ErrorList {
+	var allErrs field.ErrorList
+
+	if errs := r.validateIPAllocationPolicy(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if r.Spec.CredentialsRef != nil && r.Spec.IdentityRef != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "IdentityRef"), "cannot be set along with CredentialsRef"))
+	}
+
+	if r.Spec.IdentityRef != nil && r.Spec.ImpersonateServiceAccount != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "ImpersonateServiceAccount"), "cannot be set along with IdentityRef"))
+	}
+
+	if errs := r.Spec.Network.Router.Validate(field.NewPath("spec", "Network", "Router")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.Spec.Network.Subnets.Validate(field.NewPath("spec", "Network", "Subnets")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.Spec.Network.Routes.Validate(field.NewPath("spec", "Network", "Routes")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.validateFirewallRules(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.validateProxyOnlySubnets(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	return allErrs
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
@@ -83,6 +128,82 @@ func (r *GCPManagedCluster) ValidateUpdate(oldRaw runtime.Object) (admission.War
 		)
 	}
 
+	if !cmp.Equal(r.Spec.IdentityRef, old.Spec.IdentityRef) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "IdentityRef"),
+				r.Spec.IdentityRef, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(r.Spec.ImpersonateServiceAccount, old.Spec.ImpersonateServiceAccount) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "ImpersonateServiceAccount"),
+				r.Spec.ImpersonateServiceAccount, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(r.Spec.Network.MTU, old.Spec.Network.MTU) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "Network", "MTU"),
+				r.Spec.Network.MTU, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(r.Spec.Network.UseExisting, old.Spec.Network.UseExisting) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "Network", "UseExisting"),
+				r.Spec.Network.UseExisting, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(r.Spec.Network.HostProject, old.Spec.Network.HostProject) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "Network", "HostProject"),
+				r.Spec.Network.HostProject, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(r.Spec.PrivateServiceAccess, old.Spec.PrivateServiceAccess) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "PrivateServiceAccess"),
+				r.Spec.PrivateServiceAccess, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(r.Spec.PrivateServiceConnectEndpoint, old.Spec.PrivateServiceConnectEndpoint) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "PrivateServiceConnectEndpoint"),
+				r.Spec.PrivateServiceConnectEndpoint, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(r.Spec.IPAllocationPolicy, old.Spec.IPAllocationPolicy) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "IPAllocationPolicy"),
+				r.Spec.IPAllocationPolicy, "field is immutable"),
+		)
+	}
+
+	if errs := r.Spec.Network.Router.Validate(field.NewPath("spec", "Network", "Router")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.Spec.Network.Subnets.Validate(field.NewPath("spec", "Network", "Subnets")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.Spec.Network.Routes.Validate(field.NewPath("spec", "Network", "Routes")); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.validateFirewallRules(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.validateProxyOnlySubnets(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -90,6 +211,111 @@ func (r *GCPManagedCluster) ValidateUpdate(oldRaw runtime.Object) (admission.War
 	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedCluster").GroupKind(), r.Name, allErrs)
 }
 
+// validateIPAllocationPolicy validates that the IP allocation policy references a subnet of the network spec
+// and that the pods and services secondary ranges are distinct. The policy is required for shared VPC networks.
+func (r *GCPManagedCluster) validateIPAllocationPolicy() field.ErrorList {
+	policy := r.Spec.IPAllocationPolicy
+	path := field.NewPath("spec", "IPAllocationPolicy")
+	if policy == nil {
+		if r.Spec.Network.HostProject != nil {
+			return field.ErrorList{field.Required(path, "required for shared VPC networks")}
+		}
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	// Subnets of shared VPC networks are managed in the host project and don't have to be listed.
+	found := r.Spec.Network.HostProject != nil
+	for _, subnet := range r.Spec.Network.Subnets {
+		if subnet.Name == policy.Subnetwork {
+			found = true
+			if policy.StackType != nil && *policy.StackType == "IPV4_IPV6" && (subnet.StackType == nil || *subnet.StackType != "IPV4_IPV6") {
+				allErrs = append(allErrs,
+					field.Invalid(path.Child("StackType"), *policy.StackType, "requires a IPV4_IPV6 subnetwork"),
+				)
+			}
+			break
+		}
+	}
+	if !found {
+		allErrs = append(allErrs,
+			field.Invalid(path.Child("Subnetwork"), policy.Subnetwork, "must be the name of a subnet of the network spec"),
+		)
+	}
+
+	if policy.Pods.Name == policy.Services.Name {
+		allErrs = append(allErrs,
+			field.Invalid(path.Child("Services", "Name"), policy.Services.Name, "must differ from the pods secondary range name"),
+		)
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return allErrs
+}
+
+// validateFirewallRules validates that the firewall rule names are unique and that the source and destination
+// ranges match the direction of the rules.
+func (r *GCPManagedCluster) validateFirewallRules() field.ErrorList {
+	var allErrs field.ErrorList
+	names := map[string]bool{}
+	for i, rule := range r.Spec.FirewallRules {
+		path := field.NewPath("spec", "FirewallRules").Index(i)
+		if names[rule.Name] {
+			allErrs = append(allErrs, field.Duplicate(path.Child("Name"), rule.Name))
+		}
+		names[rule.Name] = true
+
+		if rule.Direction == FirewallRuleDirectionEgress {
+			if len(rule.SourceRanges) > 0 {
+				allErrs = append(allErrs, field.Forbidden(path.Child("SourceRanges"), "not allowed for egress rules"))
+			}
+		} else if len(rule.DestinationRanges) > 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("DestinationRanges"), "not allowed for ingress rules"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return allErrs
+}
+
+// validateProxyOnlySubnets validates that proxy-only subnets, used by GKE Gateway and internal HTTP(S) load
+// balancing, don't set options that only apply to subnets hosting instances.
+func (r *GCPManagedCluster) validateProxyOnlySubnets() field.ErrorList {
+	var allErrs field.ErrorList
+	for i, subnet := range r.Spec.Network.Subnets {
+		if subnet.Purpose == nil || !infrav1.IsProxyOnlySubnetPurpose(*subnet.Purpose) {
+			continue
+		}
+
+		path := field.NewPath("spec", "Network", "Subnets").Index(i)
+		if len(subnet.SecondaryCidrBlocks) > 0 {
+			allErrs = append(allErrs, field.Forbidden(path.Child("SecondaryCidrBlocks"), "not allowed for proxy-only subnets"))
+		}
+		if subnet.PrivateGoogleAccess != nil && *subnet.PrivateGoogleAccess {
+			allErrs = append(allErrs, field.Forbidden(path.Child("PrivateGoogleAccess"), "not allowed for proxy-only subnets"))
+		}
+		if subnet.EnableFlowLogs != nil && *subnet.EnableFlowLogs {
+			allErrs = append(allErrs, field.Forbidden(path.Child("EnableFlowLogs"), "not allowed for proxy-only subnets"))
+		}
+		if policy := r.Spec.IPAllocationPolicy; policy != nil && policy.Subnetwork == subnet.Name {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "IPAllocationPolicy", "Subnetwork"), policy.Subnetwork, "must not be a proxy-only subnet"))
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+
+	return allErrs
+}
+
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
 func (r *GCPManagedCluster) ValidateDelete() (admission.Warnings, error) {
 	gcpmanagedclusterlog.Info("validate delete", "name", r.Name)
@@ -0,0 +1,207 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+)
+
+func TestGCPManagedCluster_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+	tests := []struct {
+		name string
+		*GCPManagedCluster
+		wantErr bool
+	}{
+		{
+			name: "GCPManagedCluster with IP allocation policy referencing a subnet - valid",
+			GCPManagedCluster: &GCPManagedCluster{
+				Spec: GCPManagedClusterSpec{
+					Network: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{{Name: "nodes", CidrBlock: "10.0.0.0/20"}},
+					},
+					IPAllocationPolicy: &IPAllocationPolicy{
+						Subnetwork: "nodes",
+						Pods:       SecondaryRange{Name: "pods", CidrBlock: "10.4.0.0/14"},
+						Services:   SecondaryRange{Name: "services", CidrBlock: "10.8.0.0/20"},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedCluster with IP allocation policy referencing an unknown subnet - invalid",
+			GCPManagedCluster: &GCPManagedCluster{
+				Spec: GCPManagedClusterSpec{
+					IPAllocationPolicy: &IPAllocationPolicy{
+						Subnetwork: "nodes",
+						Pods:       SecondaryRange{Name: "pods"},
+						Services:   SecondaryRange{Name: "services"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedCluster with dual-stack IP allocation policy on a dual-stack subnet - valid",
+			GCPManagedCluster: &GCPManagedCluster{
+				Spec: GCPManagedClusterSpec{
+					Network: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{{
+							Name:           "nodes",
+							CidrBlock:      "10.0.0.0/20",
+							StackType:      pointer.String("IPV4_IPV6"),
+							IPv6AccessType: pointer.String("EXTERNAL"),
+						}},
+					},
+					IPAllocationPolicy: &IPAllocationPolicy{
+						Subnetwork: "nodes",
+						Pods:       SecondaryRange{Name: "pods", CidrBlock: "10.4.0.0/14"},
+						Services:   SecondaryRange{Name: "services", CidrBlock: "10.8.0.0/20"},
+						StackType:  pointer.String("IPV4_IPV6"),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedCluster with dual-stack IP allocation policy on an IPv4 subnet - invalid",
+			GCPManagedCluster: &GCPManagedCluster{
+				Spec: GCPManagedClusterSpec{
+					Network: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{{Name: "nodes", CidrBlock: "10.0.0.0/20"}},
+					},
+					IPAllocationPolicy: &IPAllocationPolicy{
+						Subnetwork: "nodes",
+						Pods:       SecondaryRange{Name: "pods", CidrBlock: "10.4.0.0/14"},
+						Services:   SecondaryRange{Name: "services", CidrBlock: "10.8.0.0/20"},
+						StackType:  pointer.String("IPV4_IPV6"),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedCluster with dual-stack subnet without IPv6 access type - invalid",
+			GCPManagedCluster: &GCPManagedCluster{
+				Spec: GCPManagedClusterSpec{
+					Network: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{{Name: "nodes", CidrBlock: "10.0.0.0/20", StackType: pointer.String("IPV4_IPV6")}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedCluster with duplicate firewall rules - invalid",
+			GCPManagedCluster: &GCPManagedCluster{
+				Spec: GCPManagedClusterSpec{
+					FirewallRules: []FirewallRule{{Name: "webhooks"}, {Name: "webhooks"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedCluster with proxy-only subnet - valid",
+			GCPManagedCluster: &GCPManagedCluster{
+				Spec: GCPManagedClusterSpec{
+					Network: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{{Name: "proxy", CidrBlock: "10.129.0.0/23", Purpose: pointer.String("REGIONAL_MANAGED_PROXY")}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedCluster with proxy-only subnet and secondary ranges - invalid",
+			GCPManagedCluster: &GCPManagedCluster{
+				Spec: GCPManagedClusterSpec{
+					Network: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{{
+							Name:                "proxy",
+							CidrBlock:           "10.129.0.0/23",
+							Purpose:             pointer.String("REGIONAL_MANAGED_PROXY"),
+							SecondaryCidrBlocks: map[string]string{"pods": "10.4.0.0/14"},
+						}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedCluster with role on a regular subnet - invalid",
+			GCPManagedCluster: &GCPManagedCluster{
+				Spec: GCPManagedClusterSpec{
+					Network: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{{Name: "nodes", CidrBlock: "10.0.0.0/20", Role: pointer.String("BACKUP")}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedCluster with custom flow log metadata fields - valid",
+			GCPManagedCluster: &GCPManagedCluster{
+				Spec: GCPManagedClusterSpec{
+					Network: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{{
+							Name:      "nodes",
+							CidrBlock: "10.0.0.0/20",
+							FlowLogs: &infrav1.SubnetFlowLogs{
+								Metadata:       pointer.String("CUSTOM_METADATA"),
+								MetadataFields: []string{"src_instance", "dest_instance"},
+							},
+						}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedCluster with flow log metadata fields without custom metadata - invalid",
+			GCPManagedCluster: &GCPManagedCluster{
+				Spec: GCPManagedClusterSpec{
+					Network: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{{
+							Name:      "nodes",
+							CidrBlock: "10.0.0.0/20",
+							FlowLogs:  &infrav1.SubnetFlowLogs{MetadataFields: []string{"src_instance"}},
+						}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			warn, err := test.GCPManagedCluster.ValidateCreate()
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(warn).To(BeNil())
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var gcpmanagedclustertemplatelog = logf.Log.WithName("gcpmanagedclustertemplate-resource")
+
+func (r *GCPManagedClusterTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-gcpmanagedclustertemplate,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedclustertemplates,verbs=create;update,versions=v1beta1,name=mgcpmanagedclustertemplate.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &GCPManagedClusterTemplate{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+func (r *GCPManagedClusterTemplate) Default() {
+	gcpmanagedclustertemplatelog.Info("default", "name", r.Name)
+}
+
+//+kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-gcpmanagedclustertemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedclustertemplates,verbs=create;update,versions=v1beta1,name=vgcpmanagedclustertemplate.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &GCPManagedClusterTemplate{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPManagedClusterTemplate) ValidateCreate() (admission.Warnings, error) {
+	gcpmanagedclustertemplatelog.Info("validate create", "name", r.Name)
+
+	// The template is validated as the GCPManagedClusters created from it.
+	cluster := &GCPManagedCluster{Spec: r.Spec.Template.Spec}
+	allErrs := cluster.validate()
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
+
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedClusterTemplate").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPManagedClusterTemplate) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings, error) {
+	gcpmanagedclustertemplatelog.Info("validate update", "name", r.Name)
+	old, ok := oldRaw.(*GCPManagedClusterTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an GCPManagedClusterTemplate but got a %T", oldRaw))
+	}
+
+	// Templates are rotated rather than updated, so that the clusters created from them are not changed.
+	if !reflect.DeepEqual(r.Spec, old.Spec) {
+		return nil, apierrors.NewBadRequest("GCPManagedClusterTemplate.Spec is immutable")
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPManagedClusterTemplate) ValidateDelete() (admission.Warnings, error) {
+	gcpmanagedclustertemplatelog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+)
+
+func TestGCPManagedClusterTemplate_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+
+	template := &GCPManagedClusterTemplate{
+		Spec: GCPManagedClusterTemplateSpec{
+			Template: GCPManagedClusterTemplateResource{
+				Spec: GCPManagedClusterSpec{
+					Project: "my-project",
+					Region:  "us-central1",
+					Network: infrav1.NetworkSpec{
+						Subnets: infrav1.Subnets{{Name: "nodes", CidrBlock: "10.0.0.0/20"}},
+					},
+				},
+			},
+		},
+	}
+	_, err := template.ValidateCreate()
+	g.Expect(err).NotTo(HaveOccurred())
+
+	template.Spec.Template.Spec.IPAllocationPolicy = &IPAllocationPolicy{
+		Subnetwork: "unknown",
+		Pods:       SecondaryRange{Name: "pods"},
+		Services:   SecondaryRange{Name: "services"},
+	}
+	_, err = template.ValidateCreate()
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestGCPManagedClusterTemplate_ValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	oldTemplate := &GCPManagedClusterTemplate{
+		Spec: GCPManagedClusterTemplateSpec{
+			Template: GCPManagedClusterTemplateResource{
+				Spec: GCPManagedClusterSpec{Project: "my-project", Region: "us-central1"},
+			},
+		},
+	}
+
+	_, err := oldTemplate.DeepCopy().ValidateUpdate(oldTemplate)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	newTemplate := oldTemplate.DeepCopy()
+	newTemplate.Spec.Template.Spec.Region = "europe-west1"
+	_, err = newTemplate.ValidateUpdate(oldTemplate)
+	g.Expect(err).To(HaveOccurred())
+}
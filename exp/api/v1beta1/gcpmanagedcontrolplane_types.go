@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
@@ -25,6 +26,14 @@ const (
 	// ManagedControlPlaneFinalizer allows Reconcile to clean up GCP resources associated with the GCPManagedControlPlane before
 	// removing it from the apiserver.
 	ManagedControlPlaneFinalizer = "gcpmanagedcontrolplane.infrastructure.cluster.x-k8s.io"
+
+	// DryRunClusterAnnotation stops the changes to the GKE cluster from being made. The calls to GCP that would have
+	// been made are reported in the GKEControlPlaneDryRun condition and in events instead.
+	DryRunClusterAnnotation = "gcpmanagedcontrolplane.infrastructure.cluster.x-k8s.io/dry-run"
+
+	// AdoptClusterAnnotation allows the GCPManagedControlPlane to take ownership of a GKE cluster with the same name
+	// that already exists in the location. Without it, an existing cluster is left untouched.
+	AdoptClusterAnnotation = "gcpmanagedcontrolplane.infrastructure.cluster.x-k8s.io/adopt"
 )
 
 // GCPManagedControlPlaneSpec defines the desired state of GCPManagedControlPlane.
@@ -63,6 +72,21 @@ type GCPManagedControlPlaneSpec struct {
 	// Ref: https://cloud.google.com/kubernetes-engine/docs/how-to/workload-identity
 	// +optional
 	EnableWorkloadIdentity bool `json:"enableWorkloadIdentity"`
+	// MaxConcurrentNodePoolUpgrades is the maximum number of node pools of the cluster that are upgraded
+	// to a new Kubernetes version at the same time. Node pool upgrades always wait for the control plane
+	// upgrade to complete first. The node pools take an upgrade slot in the status of the control plane before
+	// starting their upgrade. If not specified, the number of concurrent node pool upgrades is not limited.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentNodePoolUpgrades *int32 `json:"maxConcurrentNodePoolUpgrades,omitempty"`
+	// CredentialsRef is a reference to a Secret that contains the credentials to use for calling the container
+	// API, e.g. in the service project of a Shared VPC setup. If not supplied then the credentials of the
+	// GCPManagedCluster will be used.
+	// +optional
+	CredentialsRef *infrav1.ObjectReference `json:"credentialsRef,omitempty"`
+	// Fleet registers the GKE cluster to a fleet, which allows reaching it through the Connect Gateway.
+	// +optional
+	Fleet *Fleet `json:"fleet,omitempty"`
 }
 
 // GCPManagedControlPlaneStatus defines the observed state of GCPManagedControlPlane.
@@ -83,6 +107,31 @@ type GCPManagedControlPlaneStatus struct {
 	// CurrentVersion shows the current version of the GKE control plane.
 	// +optional
 	CurrentVersion string `json:"currentVersion,omitempty"`
+
+	// FleetMembership is the full resource name of the fleet membership of the GKE cluster.
+	// +optional
+	FleetMembership string `json:"fleetMembership,omitempty"`
+
+	// Operation is the GKE operation in progress on the cluster. It is polled until it is done, and its
+	// error is reported in the conditions of the control plane.
+	// +optional
+	Operation *GKEOperation `json:"operation,omitempty"`
+
+	// UpgradingNodePools lists the GCPManagedMachinePools holding one of the node pool upgrade slots
+	// limited by MaxConcurrentNodePoolUpgrades. A node pool takes a slot before its upgrade is started
+	// and releases it once the upgrade is done.
+	// +optional
+	UpgradingNodePools []string `json:"upgradingNodePools,omitempty"`
+}
+
+// GKEOperation is a long-running operation of GKE.
+type GKEOperation struct {
+	// Name is the name of the operation.
+	Name string `json:"name"`
+
+	// Type is the type of the operation, e.g. UPGRADE_MASTER.
+	// +optional
+	Type string `json:"type,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -125,6 +174,19 @@ const (
 	Stable ReleaseChannel = "stable"
 )
 
+// Fleet contains the fleet registration of the GKE cluster.
+type Fleet struct {
+	// Project is the fleet host project the cluster is registered to. It cannot be changed once the cluster
+	// has been registered.
+	Project string `json:"project"`
+	// ConnectGateway makes the kubeconfig Secret used by Cluster API point at the Connect Gateway endpoint
+	// of the fleet membership instead of the endpoint of the control plane, so that the management cluster
+	// can reach the cluster without a direct network path. The credentials need the Connect Gateway roles in
+	// the fleet host project.
+	// +optional
+	ConnectGateway bool `json:"connectGateway,omitempty"`
+}
+
 // MasterAuthorizedNetworksConfig contains configuration options for the master authorized networks feature.
 // Enabled master authorized networks will disallow all external traffic to access
 // Kubernetes master through HTTPS except traffic from the given CIDR blocks,
@@ -130,6 +130,20 @@ func (r *GCPManagedControlPlane) ValidateUpdate(oldRaw runtime.Object) (admissio
 		)
 	}
 
+	if !cmp.Equal(r.Spec.CredentialsRef, old.Spec.CredentialsRef) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "CredentialsRef"),
+				r.Spec.CredentialsRef, "field is immutable"),
+		)
+	}
+
+	if old.Spec.Fleet != nil && (r.Spec.Fleet == nil || r.Spec.Fleet.Project != old.Spec.Fleet.Project) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "Fleet", "Project"),
+				r.Spec.Fleet, "field is immutable once set"),
+		)
+	}
+
 	if len(allErrs) == 0 {
 		return nil, nil
 	}
@@ -0,0 +1,101 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var gcpmanagedcontrolplanetemplatelog = logf.Log.WithName("gcpmanagedcontrolplanetemplate-resource")
+
+func (r *GCPManagedControlPlaneTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-gcpmanagedcontrolplanetemplate,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedcontrolplanetemplates,verbs=create;update,versions=v1beta1,name=mgcpmanagedcontrolplanetemplate.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &GCPManagedControlPlaneTemplate{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+func (r *GCPManagedControlPlaneTemplate) Default() {
+	gcpmanagedcontrolplanetemplatelog.Info("default", "name", r.Name)
+}
+
+//+kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-gcpmanagedcontrolplanetemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedcontrolplanetemplates,verbs=create;update,versions=v1beta1,name=vgcpmanagedcontrolplanetemplate.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &GCPManagedControlPlaneTemplate{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPManagedControlPlaneTemplate) ValidateCreate() (admission.Warnings, error) {
+	gcpmanagedcontrolplanetemplatelog.Info("validate create", "name", r.Name)
+	var allErrs field.ErrorList
+	spec := r.Spec.Template.Spec
+
+	// The GKE cluster name is generated for each GCPManagedControlPlane, since the clusters created from the
+	// template cannot share it.
+	if spec.ClusterName != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "ClusterName"), "cannot be set in a template"))
+	}
+
+	if !spec.Endpoint.IsZero() {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "Endpoint"), "cannot be set in a template"))
+	}
+
+	if spec.EnableAutopilot && spec.ReleaseChannel == nil {
+		allErrs = append(allErrs, field.Required(field.NewPath("spec", "template", "spec", "ReleaseChannel"), "Release channel is required for an autopilot enabled cluster"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
+
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedControlPlaneTemplate").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPManagedControlPlaneTemplate) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings, error) {
+	gcpmanagedcontrolplanetemplatelog.Info("validate update", "name", r.Name)
+	old, ok := oldRaw.(*GCPManagedControlPlaneTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an GCPManagedControlPlaneTemplate but got a %T", oldRaw))
+	}
+
+	if !reflect.DeepEqual(r.Spec, old.Spec) {
+		return nil, apierrors.NewBadRequest("GCPManagedControlPlaneTemplate.Spec is immutable")
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPManagedControlPlaneTemplate) ValidateDelete() (admission.Warnings, error) {
+	gcpmanagedcontrolplanetemplatelog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestGCPManagedControlPlaneTemplate_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+	tests := []struct {
+		name    string
+		spec    GCPManagedControlPlaneSpec
+		wantErr bool
+	}{
+		{
+			name:    "GCPManagedControlPlaneTemplate without cluster name - valid",
+			spec:    GCPManagedControlPlaneSpec{Project: "my-project", Location: "us-central1"},
+			wantErr: false,
+		},
+		{
+			name:    "GCPManagedControlPlaneTemplate with cluster name - invalid",
+			spec:    GCPManagedControlPlaneSpec{ClusterName: "my-cluster", Project: "my-project", Location: "us-central1"},
+			wantErr: true,
+		},
+		{
+			name:    "GCPManagedControlPlaneTemplate with autopilot and no release channel - invalid",
+			spec:    GCPManagedControlPlaneSpec{Project: "my-project", Location: "us-central1", EnableAutopilot: true},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			template := &GCPManagedControlPlaneTemplate{
+				Spec: GCPManagedControlPlaneTemplateSpec{
+					Template: GCPManagedControlPlaneTemplateResource{Spec: tt.spec},
+				},
+			}
+			warn, err := template.ValidateCreate()
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(warn).To(BeNil())
+		})
+	}
+}
+
+func TestGCPManagedControlPlaneTemplate_ValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	oldTemplate := &GCPManagedControlPlaneTemplate{
+		Spec: GCPManagedControlPlaneTemplateSpec{
+			Template: GCPManagedControlPlaneTemplateResource{
+				Spec: GCPManagedControlPlaneSpec{Project: "my-project", Location: "us-central1"},
+			},
+		},
+	}
+
+	_, err := oldTemplate.DeepCopy().ValidateUpdate(oldTemplate)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	newTemplate := oldTemplate.DeepCopy()
+	newTemplate.Spec.Template.Spec.Location = "europe-west1"
+	_, err = newTemplate.ValidateUpdate(oldTemplate)
+	g.Expect(err).To(HaveOccurred())
+}
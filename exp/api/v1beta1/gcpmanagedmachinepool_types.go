@@ -17,6 +17,7 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 
@@ -27,6 +28,23 @@ const (
 	// ManagedMachinePoolFinalizer allows Reconcile to clean up GCP resources associated with the GCPManagedMachinePool before
 	// removing it from the apiserver.
 	ManagedMachinePoolFinalizer = "gcpmanagedmachinepool.infrastructure.cluster.x-k8s.io"
+
+	// RollbackNodePoolUpgradeAnnotation triggers a rollback of the in-progress or failed upgrade of the
+	// GKE node pool. While the annotation is present the node pool is held at the rolled back state,
+	// removing it resumes reconciling the node pool against its spec.
+	RollbackNodePoolUpgradeAnnotation = "gcpmanagedmachinepool.infrastructure.cluster.x-k8s.io/rollback-upgrade"
+
+	// AdoptNodePoolAnnotation allows the GCPManagedMachinePool to take ownership of a GKE node pool with the same
+	// name that already exists in the cluster. Without it, an existing node pool is left untouched.
+	AdoptNodePoolAnnotation = "gcpmanagedmachinepool.infrastructure.cluster.x-k8s.io/adopt"
+
+	// CompleteNodePoolUpgradeAnnotation completes a blue-green upgrade of the GKE node pool that is soaking,
+	// skipping the rest of the soak time. The annotation is removed once the upgrade has been completed.
+	CompleteNodePoolUpgradeAnnotation = "gcpmanagedmachinepool.infrastructure.cluster.x-k8s.io/complete-upgrade"
+
+	// DryRunNodePoolAnnotation stops the changes to the GKE node pool and its instances from being made. The calls
+	// to GCP that would have been made are reported in the GKEMachinePoolDryRun condition and in events instead.
+	DryRunNodePoolAnnotation = "gcpmanagedmachinepool.infrastructure.cluster.x-k8s.io/dry-run"
 )
 
 // GCPManagedMachinePoolSpec defines the desired state of GCPManagedMachinePool.
@@ -35,7 +53,12 @@ type GCPManagedMachinePoolSpec struct {
 	// then a default name will be created based on the namespace and name of the managed machine pool.
 	// +optional
 	NodePoolName string `json:"nodePoolName,omitempty"`
-	// Scaling specifies scaling for the node pool
+	// NodePoolNamePrefix specifies a prefix for the name of the GKE node pool, to which a random suffix is appended
+	// when the node pool is created. The generated name is kept in the status. It cannot be used together with nodePoolName.
+	// +optional
+	NodePoolNamePrefix string `json:"nodePoolNamePrefix,omitempty"`
+	// Scaling specifies scaling for the node pool. When set, the size of the node pool is managed by the
+	// GKE cluster autoscaler and the MachinePool replicas are only used as the initial node count.
 	// +optional
 	Scaling *NodePoolAutoScaling `json:"scaling,omitempty"`
 	// Management configuration for this NodePool.
@@ -51,6 +74,10 @@ type GCPManagedMachinePoolSpec struct {
 	// ones added by default.
 	// +optional
 	AdditionalLabels infrav1.Labels `json:"additionalLabels,omitempty"`
+	// ResourceLabels specifies the GCE resource labels to apply to the VM instances of the node pool,
+	// e.g. for billing or ownership. Unlike KubernetesLabels they are not applied to the Kubernetes nodes.
+	// +optional
+	ResourceLabels infrav1.Labels `json:"resourceLabels,omitempty"`
 	// ProviderIDList are the provider IDs of instances in the
 	// managed instance group corresponding to the nodegroup represented by this
 	// machine pool
@@ -59,14 +86,18 @@ type GCPManagedMachinePoolSpec struct {
 	// The name of a Google Compute Engine [machine
 	// type](https://cloud.google.com/compute/docs/machine-types)
 	//
-	// If unspecified, the default machine type is `e2-medium`.
+	// If unspecified, the default machine type is `e2-medium`. Custom machine types have the format
+	// [SERIES-]custom-VCPUS-MEMORY[-ext] with the memory in MB, e.g. `n2-custom-8-16384`.
 	MachineType string `json:"machineType,omitempty"`
 	// Size of the disk attached to each node, specified in GB.
 	// The smallest allowed disk size is 10GB.
 	//
 	// If unspecified, the default disk size is 100GB.
 	DiskSizeGb int32 `json:"diskSizeGb,omitempty"`
-	// Type of the disk attached to each node (e.g. 'pd-standard', 'pd-ssd' or 'pd-balanced')
+	// Type of the disk attached to each node (e.g. 'pd-standard', 'pd-ssd', 'pd-balanced',
+	// 'hyperdisk-balanced' or 'hyperdisk-extreme'). Hyperdisk boot disks are only supported on
+	// some machine families, see:
+	// https://cloud.google.com/compute/docs/disks/hyperdisks#machine-type-support
 	//
 	// If unspecified, the default disk type is 'pd-standard'
 	DiskType string `json:"diskType,omitempty"`
@@ -81,14 +112,87 @@ type GCPManagedMachinePoolSpec struct {
 	Preemptible *bool `json:"preemptible,omitempty"`
 	// Spot flag for enabling Spot VM, which is a rebrand of the existing preemptible flag.
 	Spot *bool `json:"spot,omitempty"`
+	// Gvnic enables Google Virtual NIC (gVNIC) on the nodes of the node pool, which provides
+	// higher network throughput on supported machine families. See:
+	// https://cloud.google.com/kubernetes-engine/docs/how-to/using-gvnic
+	// +optional
+	Gvnic *bool `json:"gvnic,omitempty"`
+	// Accelerators is the list of hardware accelerators, such as GPUs, attached to each node of the node pool.
+	// +optional
+	Accelerators []AcceleratorConfig `json:"accelerators,omitempty"`
+	// FastSocket enables NCCL Fast Socket on the nodes of the node pool to improve the performance
+	// of multi-GPU workloads. Fast Socket requires gVNIC to be enabled. See:
+	// https://cloud.google.com/kubernetes-engine/docs/how-to/nccl-fast-socket
+	// +optional
+	FastSocket *bool `json:"fastSocket,omitempty"`
+	// PlacementPolicy specifies the placement policy of the nodes in the node pool. It is required to
+	// provision multi-host TPU slices, whose size is derived from the TPU topology.
+	// +optional
+	PlacementPolicy *NodePoolPlacementPolicy `json:"placementPolicy,omitempty"`
+	// WorkloadMetadataMode configures how the metadata server is exposed to workloads running on the node pool.
+	// GKEMetadata runs the GKE metadata server, which is required for workload identity. GCEMetadata exposes
+	// the Compute Engine metadata server to workloads.
+	// If unspecified and workload identity is enabled on the control plane, GKEMetadata is used.
+	// +kubebuilder:validation:Enum=GCEMetadata;GKEMetadata
+	// +optional
+	WorkloadMetadataMode *WorkloadMetadataMode `json:"workloadMetadataMode,omitempty"`
+	// UpgradeOrder defines the order in which the node pools of a cluster are upgraded to a new Kubernetes
+	// version after the control plane has been upgraded. Node pools with a lower value are upgraded first,
+	// node pools with the same value may be upgraded concurrently.
+	// If unspecified, the node pool has an upgrade order of 0.
+	// +optional
+	UpgradeOrder *int32 `json:"upgradeOrder,omitempty"`
+	// SoleTenantConfig configures the node affinities used to schedule the nodes of the node pool on
+	// sole-tenant node groups. See:
+	// https://cloud.google.com/kubernetes-engine/docs/how-to/sole-tenancy
+	// +optional
+	SoleTenantConfig *SoleTenantConfig `json:"soleTenantConfig,omitempty"`
+	// MachineTypeUpdateStrategy specifies how a change of the machine type is applied to an existing node pool.
+	// InPlace updates the machine type of the node pool, which recreates its nodes according to the upgrade settings.
+	// Replace creates a new node pool with the new machine type and deletes the current node pool, draining its
+	// nodes, once the new node pool is running.
+	// +kubebuilder:validation:Enum=InPlace;Replace
+	// +kubebuilder:default=InPlace
+	// +optional
+	MachineTypeUpdateStrategy MachineTypeUpdateStrategy `json:"machineTypeUpdateStrategy,omitempty"`
+	// DeletionPolicy specifies what happens to the GKE node pool when the GCPManagedMachinePool is deleted.
+	// Delete removes the node pool from the cluster, Retain leaves it intact so it can be adopted by other tooling.
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// +kubebuilder:default=Delete
+	// +optional
+	DeletionPolicy NodePoolDeletionPolicy `json:"deletionPolicy,omitempty"`
+	// CredentialsRef is a reference to a Secret that contains the credentials to use for managing the node pool
+	// and its instances. If not supplied then the credentials of the GCPManagedControlPlane will be used.
+	// +optional
+	CredentialsRef *infrav1.ObjectReference `json:"credentialsRef,omitempty"`
 }
 
 // GCPManagedMachinePoolStatus defines the observed state of GCPManagedMachinePool.
 type GCPManagedMachinePoolStatus struct {
 	Ready bool `json:"ready"`
+	// NodePoolName is the generated name of the GKE node pool, either from the node pool name prefix
+	// or when the node pool has been replaced.
+	// +optional
+	NodePoolName string `json:"nodePoolName,omitempty"`
+	// PreviousNodePoolName is the name of the GKE node pool being replaced. It is deleted once the
+	// replacement node pool is running.
+	// +optional
+	PreviousNodePoolName string `json:"previousNodePoolName,omitempty"`
 	// Replicas is the most recently observed number of replicas.
 	// +optional
 	Replicas int32 `json:"replicas"`
+	// InfrastructureMachineKind is the kind of the infrastructure machines of the node pool instances,
+	// which Cluster API creates a Machine for.
+	// +optional
+	InfrastructureMachineKind string `json:"infrastructureMachineKind,omitempty"`
+	// Capacity is the resource capacity of a single node of the node pool, derived from its machine type.
+	// It is used by the cluster autoscaler to scale the node pool from zero.
+	// +optional
+	Capacity corev1.ResourceList `json:"capacity,omitempty"`
+	// CapacityMachineType is the machine type the capacity was derived from. The capacity is only derived
+	// again once the machine type of the node pool changes.
+	// +optional
+	CapacityMachineType string `json:"capacityMachineType,omitempty"`
 	// Conditions specifies the cpnditions for the managed machine pool
 	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
 }
@@ -125,6 +229,150 @@ type NodePoolAutoScaling struct {
 	// MaxCount is a maximum number of nodes for one location in the NodePool. Must be >=
 	// maxCount. There has to be enough quota to scale up the cluster.
 	MaxCount *int32 `json:"maxCount,omitempty"`
+	// TotalMinCount is the minimum number of nodes in the NodePool across all of its locations.
+	// It cannot be used together with minCount/maxCount.
+	// +optional
+	TotalMinCount *int32 `json:"totalMinCount,omitempty"`
+	// TotalMaxCount is the maximum number of nodes in the NodePool across all of its locations.
+	// It cannot be used together with minCount/maxCount.
+	// +optional
+	TotalMaxCount *int32 `json:"totalMaxCount,omitempty"`
+	// LocationPolicy specifies the algorithm used when scaling-up the node pool. Balanced tries to spread
+	// the nodes evenly across the zones of the node pool, while Any prioritizes utilization of unused
+	// reservations and is recommended for Spot VMs to improve obtainability.
+	// If unspecified, GKE defaults to Balanced.
+	// +kubebuilder:validation:Enum=Balanced;Any
+	// +optional
+	LocationPolicy *ManagedNodePoolLocationPolicy `json:"locationPolicy,omitempty"`
+}
+
+// ManagedNodePoolLocationPolicy specifies the location policy of the node pool when autoscaling is enabled.
+type ManagedNodePoolLocationPolicy string
+
+const (
+	// ManagedNodePoolLocationPolicyBalanced aims to balance the sizes of different zones.
+	ManagedNodePoolLocationPolicyBalanced ManagedNodePoolLocationPolicy = "Balanced"
+	// ManagedNodePoolLocationPolicyAny picks zones that have the highest capacity available.
+	ManagedNodePoolLocationPolicyAny ManagedNodePoolLocationPolicy = "Any"
+)
+
+// WorkloadMetadataMode is the mode of the metadata server exposed to workloads of a node pool.
+type WorkloadMetadataMode string
+
+const (
+	// WorkloadMetadataModeGCEMetadata exposes all Compute Engine metadata to workloads.
+	WorkloadMetadataModeGCEMetadata WorkloadMetadataMode = "GCEMetadata"
+	// WorkloadMetadataModeGKEMetadata runs the GKE metadata server to expose workload identity to workloads.
+	WorkloadMetadataModeGKEMetadata WorkloadMetadataMode = "GKEMetadata"
+)
+
+// AcceleratorConfig specifies a hardware accelerator attached to the nodes of a node pool.
+type AcceleratorConfig struct {
+	// Type is the accelerator type, e.g. 'nvidia-tesla-t4'. See:
+	// https://cloud.google.com/compute/docs/gpus
+	Type string `json:"type"`
+	// Count is the number of accelerators attached to each node.
+	// +kubebuilder:validation:Minimum=1
+	Count int64 `json:"count"`
+	// GPUPartitionSize is the size of the partitions to create on a multi-instance GPU, e.g. '1g.5gb'.
+	// +optional
+	GPUPartitionSize string `json:"gpuPartitionSize,omitempty"`
+	// GPUDriverVersion selects the GPU driver GKE installs on the nodes. InstallationDisabled leaves the
+	// installation of the driver to the user, Default installs the default driver version of the GKE version
+	// and Latest installs the latest driver version available for the GKE version.
+	// If unspecified, GKE does not install the GPU driver.
+	// +kubebuilder:validation:Enum=InstallationDisabled;Default;Latest
+	// +optional
+	GPUDriverVersion *GPUDriverVersion `json:"gpuDriverVersion,omitempty"`
+}
+
+// GPUDriverVersion is the GPU driver version installed by GKE.
+type GPUDriverVersion string
+
+const (
+	// GPUDriverVersionInstallationDisabled disables the GPU driver installation by GKE.
+	GPUDriverVersionInstallationDisabled GPUDriverVersion = "InstallationDisabled"
+	// GPUDriverVersionDefault installs the default GPU driver version of the GKE version.
+	GPUDriverVersionDefault GPUDriverVersion = "Default"
+	// GPUDriverVersionLatest installs the latest GPU driver version available for the GKE version.
+	GPUDriverVersionLatest GPUDriverVersion = "Latest"
+)
+
+// MachineTypeUpdateStrategy is the strategy used to change the machine type of an existing node pool.
+type MachineTypeUpdateStrategy string
+
+const (
+	// MachineTypeUpdateStrategyInPlace updates the machine type of the existing node pool.
+	MachineTypeUpdateStrategyInPlace MachineTypeUpdateStrategy = "InPlace"
+	// MachineTypeUpdateStrategyReplace replaces the node pool with a new node pool.
+	MachineTypeUpdateStrategyReplace MachineTypeUpdateStrategy = "Replace"
+)
+
+// NodePoolDeletionPolicy is the policy applied to the GKE node pool when the GCPManagedMachinePool is deleted.
+type NodePoolDeletionPolicy string
+
+const (
+	// NodePoolDeletionPolicyDelete deletes the GKE node pool.
+	NodePoolDeletionPolicyDelete NodePoolDeletionPolicy = "Delete"
+	// NodePoolDeletionPolicyRetain leaves the GKE node pool intact.
+	NodePoolDeletionPolicyRetain NodePoolDeletionPolicy = "Retain"
+)
+
+// SoleTenantConfig contains the node affinities of a node pool running on sole-tenant nodes.
+type SoleTenantConfig struct {
+	// NodeAffinities is the list of node affinities used to select the sole-tenant nodes.
+	// +kubebuilder:validation:MinItems=1
+	NodeAffinities []NodeAffinity `json:"nodeAffinities"`
+}
+
+// NodeAffinityOperator is the operator of a sole-tenant node affinity.
+type NodeAffinityOperator string
+
+const (
+	// NodeAffinityOperatorIn requires the node affinity label to have one of the values.
+	NodeAffinityOperatorIn NodeAffinityOperator = "In"
+	// NodeAffinityOperatorNotIn requires the node affinity label to have none of the values.
+	NodeAffinityOperatorNotIn NodeAffinityOperator = "NotIn"
+)
+
+// NodeAffinity is a node affinity label of a sole-tenant node group, e.g. the
+// 'compute.googleapis.com/node-group-name' label.
+type NodeAffinity struct {
+	// Key is the key of the node affinity label.
+	Key string `json:"key"`
+	// Operator specifies how the values are matched against the node affinity label.
+	// +kubebuilder:validation:Enum=In;NotIn
+	Operator NodeAffinityOperator `json:"operator"`
+	// Values are the values of the node affinity label.
+	// +kubebuilder:validation:MinItems=1
+	Values []string `json:"values"`
+}
+
+// PlacementPolicyType is the type of placement policy of a node pool.
+type PlacementPolicyType string
+
+const (
+	// PlacementPolicyCompact places the nodes of the node pool closer together to lower network latency.
+	PlacementPolicyCompact PlacementPolicyType = "Compact"
+)
+
+// NodePoolPlacementPolicy specifies the placement of the nodes in a node pool.
+type NodePoolPlacementPolicy struct {
+	// Type is the type of placement.
+	// +kubebuilder:validation:Enum=Compact
+	// +optional
+	Type *PlacementPolicyType `json:"type,omitempty"`
+	// TpuTopology is the TPU placement topology for a TPU slice node pool (e.g. '2x2x2').
+	// A multi-host TPU slice is provisioned and scaled as a single unit, so the replicas of the
+	// corresponding MachinePool must match the number of nodes in the slice and all of them must be
+	// placed in a single zone. See:
+	// https://cloud.google.com/tpu/docs/types-topologies#tpu_topologies
+	// +optional
+	TpuTopology string `json:"tpuTopology,omitempty"`
+	// PolicyName is the name of a custom compact placement resource policy in the same project and region
+	// as the node pool.
+	// +optional
+	PolicyName string `json:"policyName,omitempty"`
 }
 
 // NodeManagement defines the set of node management services turned on for the
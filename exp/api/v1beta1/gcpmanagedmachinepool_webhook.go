@@ -18,11 +18,14 @@ package v1beta1
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/strings/slices"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -33,6 +36,12 @@ const (
 	maxNodePoolNameLength = 40
 )
 
+// hyperdiskSupportedMachineSeries lists the machine series that support each Hyperdisk type as boot disk.
+var hyperdiskSupportedMachineSeries = map[string][]string{
+	"hyperdisk-balanced": {"a3", "c3", "c3d", "c4", "h3", "m1", "m2", "m3", "n4", "z3"},
+	"hyperdisk-extreme":  {"c3", "c3d", "c4", "m1", "m2", "m3", "n2"},
+}
+
 // log is for logging in this package.
 var gcpmanagedmachinepoollog = logf.Log.WithName("gcpmanagedmachinepool-resource")
 
@@ -49,12 +58,48 @@ var _ webhook.Defaulter = &GCPManagedMachinePool{}
 // Default implements webhook.Defaulter so a webhook will be registered for the type.
 func (r *GCPManagedMachinePool) Default() {
 	gcpmanagedmachinepoollog.Info("default", "name", r.Name)
+
+	// GKE taints the nodes of Arm node pools so that workloads not built for arm64 are not scheduled on
+	// them. Keep the taint in the spec so it is not removed when the node pool taints are reconciled.
+	if r.IsArm() {
+		found := false
+		for _, taint := range r.Spec.KubernetesTaints {
+			if taint.Key == ArchTaintKey {
+				found = true
+				break
+			}
+		}
+		if !found {
+			r.Spec.KubernetesTaints = append(r.Spec.KubernetesTaints, Taint{
+				Key:    ArchTaintKey,
+				Value:  ArchArm64,
+				Effect: TaintEffectNoSchedule,
+			})
+		}
+	}
 }
 
 //+kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-gcpmanagedmachinepool,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepools,verbs=create;update,versions=v1beta1,name=vgcpmanagedmachinepool.kb.io,admissionReviewVersions=v1
 
 var _ webhook.Validator = &GCPManagedMachinePool{}
 
+func (r *GCPManagedMachinePool) validateNodePoolNamePrefix() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.NodePoolNamePrefix != "" {
+		prefixField := field.NewPath("spec", "nodePoolNamePrefix")
+		if r.Spec.NodePoolName != "" {
+			allErrs = append(allErrs, field.Forbidden(prefixField, "cannot be used together with spec.nodePoolName"))
+		}
+		if maxLength := maxNodePoolNameLength - nodePoolNameSuffixLength; len(r.Spec.NodePoolNamePrefix) > maxLength {
+			allErrs = append(allErrs, field.Invalid(prefixField, r.Spec.NodePoolNamePrefix, fmt.Sprintf("node pool name prefix cannot have more than %d characters", maxLength)))
+		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
 func (r *GCPManagedMachinePool) validateScaling() field.ErrorList {
 	var allErrs field.ErrorList
 	if r.Spec.Scaling != nil {
@@ -70,6 +115,89 @@ func (r *GCPManagedMachinePool) validateScaling() field.ErrorList {
 				allErrs = append(allErrs, field.Invalid(maxField, *max, fmt.Sprintf("must be greater than field %s", minField.String())))
 			}
 		}
+
+		totalMinField := field.NewPath("spec", "scaling", "totalMinCount")
+		totalMaxField := field.NewPath("spec", "scaling", "totalMaxCount")
+		totalMin := r.Spec.Scaling.TotalMinCount
+		totalMax := r.Spec.Scaling.TotalMaxCount
+		if (totalMin != nil || totalMax != nil) && (min != nil || max != nil) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "scaling"), "totalMinCount/totalMaxCount cannot be used together with minCount/maxCount"))
+		}
+		if totalMin != nil {
+			if *totalMin < 0 {
+				allErrs = append(allErrs, field.Invalid(totalMinField, *totalMin, "must be greater or equal zero"))
+			}
+			if totalMax != nil && *totalMax < *totalMin {
+				allErrs = append(allErrs, field.Invalid(totalMaxField, *totalMax, fmt.Sprintf("must be greater than field %s", totalMinField.String())))
+			}
+		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+func (r *GCPManagedMachinePool) validateNodeNetworking() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.FastSocket != nil && *r.Spec.FastSocket {
+		if r.Spec.Gvnic == nil || !*r.Spec.Gvnic {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "fastSocket"), *r.Spec.FastSocket, "requires spec.gvnic to be enabled"))
+		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+func (r *GCPManagedMachinePool) validateDiskType() field.ErrorList {
+	var allErrs field.ErrorList
+	if strings.HasPrefix(r.Spec.DiskType, "hyperdisk-") {
+		diskTypeField := field.NewPath("spec", "diskType")
+		supportedMachineSeries, ok := hyperdiskSupportedMachineSeries[r.Spec.DiskType]
+		machineSeries := infrav1.MachineSeries(r.Spec.MachineType)
+		switch {
+		case !ok:
+			allErrs = append(allErrs, field.NotSupported(diskTypeField, r.Spec.DiskType, []string{"hyperdisk-balanced", "hyperdisk-extreme"}))
+		case !slices.Contains(supportedMachineSeries, machineSeries):
+			allErrs = append(allErrs, field.Invalid(diskTypeField, r.Spec.DiskType, fmt.Sprintf("requires machine type in the following series: %s", supportedMachineSeries)))
+		}
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+func (r *GCPManagedMachinePool) validateMachineType() field.ErrorList {
+	var allErrs field.ErrorList
+	if err := infrav1.ValidateCustomMachineType(r.Spec.MachineType); err != nil {
+		allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "machineType"), r.Spec.MachineType, err.Error()))
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+func (r *GCPManagedMachinePool) validateImageType() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.IsArm() && r.Spec.ImageType != "" && !slices.Contains(armImageTypes, strings.ToUpper(r.Spec.ImageType)) {
+		allErrs = append(allErrs, field.NotSupported(field.NewPath("spec", "imageType"), r.Spec.ImageType, armImageTypes))
+	}
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return allErrs
+}
+
+func (r *GCPManagedMachinePool) validatePlacementPolicy() field.ErrorList {
+	var allErrs field.ErrorList
+	if r.Spec.PlacementPolicy != nil && r.Spec.PlacementPolicy.TpuTopology != "" {
+		if _, err := TPUSliceNodeCount(r.Spec.MachineType, r.Spec.PlacementPolicy.TpuTopology); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "placementPolicy", "tpuTopology"), r.Spec.PlacementPolicy.TpuTopology, err.Error()))
+		}
 	}
 	if len(allErrs) == 0 {
 		return nil
@@ -80,6 +208,17 @@ func (r *GCPManagedMachinePool) validateScaling() field.ErrorList {
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
 func (r *GCPManagedMachinePool) ValidateCreate() (admission.Warnings, error) {
 	gcpmanagedmachinepoollog.Info("validate create", "name", r.Name)
+
+	allErrs := r.validate()
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
+
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedMachinePool").GroupKind(), r.Name, allErrs)
+}
+
+// validate validates the spec of a new GCPManagedMachinePool.
+func (r *GCPManagedMachinePool) validate() field.ErrorList {
 	var allErrs field.ErrorList
 
 	if len(r.Spec.NodePoolName) > maxNodePoolNameLength {
@@ -89,23 +228,48 @@ func (r *GCPManagedMachinePool) ValidateCreate() (admission.Warnings, error) {
 		)
 	}
 
+	if errs := r.validateNodePoolNamePrefix(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if errs := r.validateScaling(); errs != nil || len(errs) == 0 {
 		allErrs = append(allErrs, errs...)
 	}
 
-	if len(allErrs) == 0 {
-		return nil, nil
+	if errs := r.validateNodeNetworking(); errs != nil {
+		allErrs = append(allErrs, errs...)
 	}
 
-	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedMachinePool").GroupKind(), r.Name, allErrs)
+	if errs := r.validatePlacementPolicy(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.validateMachineType(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.validateDiskType(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.validateImageType(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	return allErrs
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
 func (r *GCPManagedMachinePool) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings, error) {
 	gcpmanagedmachinepoollog.Info("validate update", "name", r.Name)
 	var allErrs field.ErrorList
+	var warnings admission.Warnings
 	old := oldRaw.(*GCPManagedMachinePool)
 
+	if !cmp.Equal(r.Spec.Preemptible, old.Spec.Preemptible) || !cmp.Equal(r.Spec.Spot, old.Spec.Spot) {
+		warnings = append(warnings, "spec.preemptible and spec.spot cannot be changed on an existing GKE node pool, create a replacement GCPManagedMachinePool to migrate the nodes")
+	}
+
 	if !cmp.Equal(r.Spec.NodePoolName, old.Spec.NodePoolName) {
 		allErrs = append(allErrs,
 			field.Invalid(field.NewPath("spec", "NodePoolName"),
@@ -113,15 +277,70 @@ func (r *GCPManagedMachinePool) ValidateUpdate(oldRaw runtime.Object) (admission
 		)
 	}
 
+	if !cmp.Equal(r.Spec.NodePoolNamePrefix, old.Spec.NodePoolNamePrefix) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "NodePoolNamePrefix"),
+				r.Spec.NodePoolNamePrefix, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(r.Spec.PlacementPolicy, old.Spec.PlacementPolicy) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "PlacementPolicy"),
+				r.Spec.PlacementPolicy, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(r.Spec.CredentialsRef, old.Spec.CredentialsRef) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "CredentialsRef"),
+				r.Spec.CredentialsRef, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(r.Spec.Accelerators, old.Spec.Accelerators) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "Accelerators"),
+				r.Spec.Accelerators, "field is immutable"),
+		)
+	}
+
+	if !cmp.Equal(r.Spec.SoleTenantConfig, old.Spec.SoleTenantConfig) {
+		allErrs = append(allErrs,
+			field.Invalid(field.NewPath("spec", "SoleTenantConfig"),
+				r.Spec.SoleTenantConfig, "field is immutable"),
+		)
+	}
+
 	if errs := r.validateScaling(); errs != nil || len(errs) == 0 {
 		allErrs = append(allErrs, errs...)
 	}
 
+	if errs := r.validateNodeNetworking(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.validatePlacementPolicy(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.validateMachineType(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.validateDiskType(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
+	if errs := r.validateImageType(); errs != nil {
+		allErrs = append(allErrs, errs...)
+	}
+
 	if len(allErrs) == 0 {
-		return nil, nil
+		return warnings, nil
 	}
 
-	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedMachinePool").GroupKind(), r.Name, allErrs)
+	return warnings, apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedMachinePool").GroupKind(), r.Name, allErrs)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
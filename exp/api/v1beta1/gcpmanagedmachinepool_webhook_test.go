@@ -0,0 +1,321 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+func TestGCPManagedMachinePool_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+	tests := []struct {
+		name string
+		*GCPManagedMachinePool
+		wantErr bool
+	}{
+		{
+			name: "GCPManagedMachinePool with FastSocket and gVNIC enabled - valid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					Gvnic:      pointer.Bool(true),
+					FastSocket: pointer.Bool(true),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedMachinePool with FastSocket enabled and gVNIC unset - invalid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					FastSocket: pointer.Bool(true),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedMachinePool with FastSocket enabled and gVNIC disabled - invalid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					Gvnic:      pointer.Bool(false),
+					FastSocket: pointer.Bool(true),
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedMachinePool with custom machine type - valid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType: "n2-custom-8-16384",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedMachinePool with E2 custom machine type with extended memory - invalid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType: "e2-custom-4-65536-ext",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedMachinePool with custom machine type with too much memory per vCPU - invalid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType: "n2-custom-2-32768",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedMachinePool with TPU topology matching the machine type - valid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType: "ct4p-hightpu-4t",
+					PlacementPolicy: &NodePoolPlacementPolicy{
+						TpuTopology: "2x2x2",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedMachinePool with TPU topology and non-TPU machine type - invalid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType: "n2-standard-4",
+					PlacementPolicy: &NodePoolPlacementPolicy{
+						TpuTopology: "2x2x2",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedMachinePool with malformed TPU topology - invalid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType: "ct5lp-hightpu-4t",
+					PlacementPolicy: &NodePoolPlacementPolicy{
+						TpuTopology: "2by4",
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedMachinePool with total node counts - valid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					Scaling: &NodePoolAutoScaling{
+						TotalMinCount: pointer.Int32(3),
+						TotalMaxCount: pointer.Int32(9),
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedMachinePool with both total and per-zone node counts - invalid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					Scaling: &NodePoolAutoScaling{
+						MinCount:      pointer.Int32(1),
+						TotalMaxCount: pointer.Int32(9),
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedMachinePool with hyperdisk-balanced on a supported machine type - valid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType: "c3-standard-8",
+					DiskType:    "hyperdisk-balanced",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedMachinePool with hyperdisk-balanced on an unsupported machine type - invalid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType: "e2-medium",
+					DiskType:    "hyperdisk-balanced",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedMachinePool with Arm machine type and containerd image - valid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType: "t2a-standard-4",
+					ImageType:   "cos_containerd",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedMachinePool with Arm machine type and Windows image - invalid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType: "c4a-standard-8",
+					ImageType:   "WINDOWS_LTSC_CONTAINERD",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "GCPManagedMachinePool with node pool name prefix - valid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					NodePoolNamePrefix: "workers-",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "GCPManagedMachinePool with both node pool name and prefix - invalid",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					NodePoolName:       "workers",
+					NodePoolNamePrefix: "workers-",
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			warn, err := test.GCPManagedMachinePool.ValidateCreate()
+			if test.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(warn).To(BeNil())
+		})
+	}
+}
+
+func TestGCPManagedMachinePool_Default(t *testing.T) {
+	g := NewWithT(t)
+	archTaint := Taint{Key: ArchTaintKey, Value: ArchArm64, Effect: TaintEffectNoSchedule}
+	tests := []struct {
+		name string
+		*GCPManagedMachinePool
+		wantTaints Taints
+	}{
+		{
+			name: "Arm node pool gets the arch taint",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType: "t2a-standard-4",
+				},
+			},
+			wantTaints: Taints{archTaint},
+		},
+		{
+			name: "Arm node pool keeps an existing arch taint",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType:      "c4a-standard-8",
+					KubernetesTaints: Taints{{Key: ArchTaintKey, Value: ArchArm64, Effect: TaintEffectNoExecute}},
+				},
+			},
+			wantTaints: Taints{{Key: ArchTaintKey, Value: ArchArm64, Effect: TaintEffectNoExecute}},
+		},
+		{
+			name: "x86 node pool is not tainted",
+			GCPManagedMachinePool: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					MachineType: "e2-medium",
+				},
+			},
+			wantTaints: nil,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			test.GCPManagedMachinePool.Default()
+			g.Expect(test.GCPManagedMachinePool.Spec.KubernetesTaints).To(Equal(test.wantTaints))
+		})
+	}
+}
+
+func TestGCPManagedMachinePool_ValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+	tests := []struct {
+		name     string
+		old      *GCPManagedMachinePool
+		new      *GCPManagedMachinePool
+		wantWarn bool
+	}{
+		{
+			name: "GCPManagedMachinePool changing from preemptible to spot - warning",
+			old: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					Preemptible: pointer.Bool(true),
+				},
+			},
+			new: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					Spot: pointer.Bool(true),
+				},
+			},
+			wantWarn: true,
+		},
+		{
+			name: "GCPManagedMachinePool keeping spot - no warning",
+			old: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					Spot: pointer.Bool(true),
+				},
+			},
+			new: &GCPManagedMachinePool{
+				Spec: GCPManagedMachinePoolSpec{
+					Spot: pointer.Bool(true),
+				},
+			},
+			wantWarn: false,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			warn, err := test.new.ValidateUpdate(test.old)
+			g.Expect(err).NotTo(HaveOccurred())
+			if test.wantWarn {
+				g.Expect(warn).NotTo(BeEmpty())
+			} else {
+				g.Expect(warn).To(BeEmpty())
+			}
+		})
+	}
+}
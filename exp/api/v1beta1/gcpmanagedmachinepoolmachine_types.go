@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ManagedMachinePoolMachineFinalizer allows the GCPManagedMachinePool to recreate the instance of a
+	// GCPManagedMachinePoolMachine before removing it from the apiserver.
+	ManagedMachinePoolMachineFinalizer = "gcpmanagedmachinepoolmachine.infrastructure.cluster.x-k8s.io"
+
+	// GCPManagedMachinePoolMachineKind is the kind of the infrastructure machines of a GCPManagedMachinePool.
+	GCPManagedMachinePoolMachineKind = "GCPManagedMachinePoolMachine"
+)
+
+// GCPManagedMachinePoolMachineSpec defines the desired state of GCPManagedMachinePoolMachine.
+type GCPManagedMachinePoolMachineSpec struct {
+	// ProviderID is the identification ID of the node pool instance.
+	// +optional
+	ProviderID string `json:"providerID,omitempty"`
+}
+
+// GCPManagedMachinePoolMachineStatus defines the observed state of GCPManagedMachinePoolMachine.
+type GCPManagedMachinePoolMachineStatus struct {
+	// Ready is true when the instance is running and no action is pending on it in its managed instance group.
+	// +optional
+	Ready bool `json:"ready"`
+	// InstanceStatus is the status of the instance, e.g. RUNNING.
+	// +optional
+	InstanceStatus string `json:"instanceStatus,omitempty"`
+	// CurrentAction is the action the managed instance group is performing on the instance, e.g. RECREATING.
+	// +optional
+	CurrentAction string `json:"currentAction,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.instanceStatus"
+// +kubebuilder:printcolumn:name="ProviderID",type="string",JSONPath=".spec.providerID"
+// +kubebuilder:resource:path=gcpmanagedmachinepoolmachines,scope=Namespaced,categories=cluster-api,shortName=gcpmmpm
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+
+// GCPManagedMachinePoolMachine is the Schema for the gcpmanagedmachinepoolmachines API. It represents an
+// instance of the GKE node pool of a GCPManagedMachinePool.
+type GCPManagedMachinePoolMachine struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GCPManagedMachinePoolMachineSpec   `json:"spec,omitempty"`
+	Status GCPManagedMachinePoolMachineStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GCPManagedMachinePoolMachineList contains a list of GCPManagedMachinePoolMachine.
+type GCPManagedMachinePoolMachineList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GCPManagedMachinePoolMachine `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GCPManagedMachinePoolMachine{}, &GCPManagedMachinePoolMachineList{})
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"reflect"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var gcpmanagedmachinepooltemplatelog = logf.Log.WithName("gcpmanagedmachinepooltemplate-resource")
+
+func (r *GCPManagedMachinePoolTemplate) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/mutate-infrastructure-cluster-x-k8s-io-v1beta1-gcpmanagedmachinepooltemplate,mutating=true,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepooltemplates,verbs=create;update,versions=v1beta1,name=mgcpmanagedmachinepooltemplate.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Defaulter = &GCPManagedMachinePoolTemplate{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+func (r *GCPManagedMachinePoolTemplate) Default() {
+	gcpmanagedmachinepooltemplatelog.Info("default", "name", r.Name)
+
+	// The template is defaulted as the GCPManagedMachinePools created from it, so that the topology controller
+	// does not see a difference between them.
+	pool := &GCPManagedMachinePool{Spec: r.Spec.Template.Spec}
+	pool.Default()
+	r.Spec.Template.Spec = pool.Spec
+}
+
+//+kubebuilder:webhook:path=/validate-infrastructure-cluster-x-k8s-io-v1beta1-gcpmanagedmachinepooltemplate,mutating=false,failurePolicy=fail,sideEffects=None,groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepooltemplates,verbs=create;update,versions=v1beta1,name=vgcpmanagedmachinepooltemplate.kb.io,admissionReviewVersions=v1
+
+var _ webhook.Validator = &GCPManagedMachinePoolTemplate{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPManagedMachinePoolTemplate) ValidateCreate() (admission.Warnings, error) {
+	gcpmanagedmachinepooltemplatelog.Info("validate create", "name", r.Name)
+
+	pool := &GCPManagedMachinePool{Spec: r.Spec.Template.Spec}
+	allErrs := pool.validate()
+
+	// A fixed node pool name would be shared by all the machine pools of a cluster created from the template.
+	if r.Spec.Template.Spec.NodePoolName != "" {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "template", "spec", "NodePoolName"), "cannot be set in a template, use NodePoolNamePrefix instead"))
+	}
+
+	if len(allErrs) == 0 {
+		return nil, nil
+	}
+
+	return nil, apierrors.NewInvalid(GroupVersion.WithKind("GCPManagedMachinePoolTemplate").GroupKind(), r.Name, allErrs)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPManagedMachinePoolTemplate) ValidateUpdate(oldRaw runtime.Object) (admission.Warnings, error) {
+	gcpmanagedmachinepooltemplatelog.Info("validate update", "name", r.Name)
+	old, ok := oldRaw.(*GCPManagedMachinePoolTemplate)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected an GCPManagedMachinePoolTemplate but got a %T", oldRaw))
+	}
+
+	if !reflect.DeepEqual(r.Spec, old.Spec) {
+		return nil, apierrors.NewBadRequest("GCPManagedMachinePoolTemplate.Spec is immutable")
+	}
+
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (r *GCPManagedMachinePoolTemplate) ValidateDelete() (admission.Warnings, error) {
+	gcpmanagedmachinepooltemplatelog.Info("validate delete", "name", r.Name)
+
+	return nil, nil
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestGCPManagedMachinePoolTemplate_Default(t *testing.T) {
+	g := NewWithT(t)
+
+	template := &GCPManagedMachinePoolTemplate{
+		Spec: GCPManagedMachinePoolTemplateSpec{
+			Template: GCPManagedMachinePoolTemplateResource{
+				Spec: GCPManagedMachinePoolSpec{MachineType: "t2a-standard-4"},
+			},
+		},
+	}
+	template.Default()
+	g.Expect(template.Spec.Template.Spec.KubernetesTaints).To(ContainElement(Taint{
+		Key:    ArchTaintKey,
+		Value:  ArchArm64,
+		Effect: TaintEffectNoSchedule,
+	}))
+}
+
+func TestGCPManagedMachinePoolTemplate_ValidateCreate(t *testing.T) {
+	g := NewWithT(t)
+	tests := []struct {
+		name    string
+		spec    GCPManagedMachinePoolSpec
+		wantErr bool
+	}{
+		{
+			name:    "GCPManagedMachinePoolTemplate with node pool name prefix - valid",
+			spec:    GCPManagedMachinePoolSpec{NodePoolNamePrefix: "workers"},
+			wantErr: false,
+		},
+		{
+			name:    "GCPManagedMachinePoolTemplate with node pool name - invalid",
+			spec:    GCPManagedMachinePoolSpec{NodePoolName: "workers"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			template := &GCPManagedMachinePoolTemplate{
+				Spec: GCPManagedMachinePoolTemplateSpec{
+					Template: GCPManagedMachinePoolTemplateResource{Spec: tt.spec},
+				},
+			}
+			warn, err := template.ValidateCreate()
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+			g.Expect(warn).To(BeNil())
+		})
+	}
+}
+
+func TestGCPManagedMachinePoolTemplate_ValidateUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	oldTemplate := &GCPManagedMachinePoolTemplate{
+		Spec: GCPManagedMachinePoolTemplateSpec{
+			Template: GCPManagedMachinePoolTemplateResource{
+				Spec: GCPManagedMachinePoolSpec{MachineType: "e2-standard-4"},
+			},
+		},
+	}
+
+	_, err := oldTemplate.DeepCopy().ValidateUpdate(oldTemplate)
+	g.Expect(err).NotTo(HaveOccurred())
+
+	newTemplate := oldTemplate.DeepCopy()
+	newTemplate.Spec.Template.Spec.MachineType = "e2-standard-8"
+	_, err = newTemplate.ValidateUpdate(oldTemplate)
+	g.Expect(err).To(HaveOccurred())
+}
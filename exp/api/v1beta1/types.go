@@ -17,15 +17,43 @@ limitations under the License.
 package v1beta1
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
 
 	"cloud.google.com/go/container/apiv1/containerpb"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
 	"k8s.io/utils/pointer"
 )
 
 // TaintEffect is the effect for a Kubernetes taint.
 type TaintEffect string
 
+const (
+	// TaintEffectNoSchedule is the NoSchedule taint effect.
+	TaintEffectNoSchedule TaintEffect = "NoSchedule"
+	// TaintEffectNoExecute is the NoExecute taint effect.
+	TaintEffectNoExecute TaintEffect = "NoExecute"
+	// TaintEffectPreferNoSchedule is the PreferNoSchedule taint effect.
+	TaintEffectPreferNoSchedule TaintEffect = "PreferNoSchedule"
+)
+
+const (
+	// ArchTaintKey is the key of the taint GKE adds to the nodes of Arm node pools.
+	ArchTaintKey = "kubernetes.io/arch"
+	// ArchArm64 is the architecture value of Arm nodes.
+	ArchArm64 = "arm64"
+)
+
+// nodePoolNameSuffixLength is the length of the random suffix appended to the node pool name prefix.
+const nodePoolNameSuffixLength = 5
+
+// armMachineSeries lists the machine series backed by Arm processors.
+var armMachineSeries = []string{"t2a", "c4a"}
+
+// armImageTypes lists the node image types that support Arm nodes.
+var armImageTypes = []string{"COS_CONTAINERD", "UBUNTU_CONTAINERD"}
+
 // Taint represents a Kubernetes taint.
 type Taint struct {
 	// Effect specifies the effect for the taint.
@@ -42,11 +70,11 @@ type Taints []Taint
 
 func convertToSdkTaintEffect(effect TaintEffect) containerpb.NodeTaint_Effect {
 	switch effect {
-	case "NoSchedule":
+	case TaintEffectNoSchedule:
 		return containerpb.NodeTaint_NO_SCHEDULE
-	case "NoExecute":
+	case TaintEffectNoExecute:
 		return containerpb.NodeTaint_NO_EXECUTE
-	case "PreferNoSchedule":
+	case TaintEffectPreferNoSchedule:
 		return containerpb.NodeTaint_PREFER_NO_SCHEDULE
 	default:
 		return containerpb.NodeTaint_EFFECT_UNSPECIFIED
@@ -87,3 +115,218 @@ func NormalizeMachineVersion(version *string) *string {
 
 	return pointer.String(strings.TrimPrefix(*version, "v"))
 }
+
+// TPUSliceNodeCount returns the number of nodes that make up a TPU slice with the given topology on the
+// given TPU machine type. The topology is a list of chip dimensions (e.g. '2x2x4') and the machine type
+// encodes the number of chips attached to each node in its last segment (e.g. 'ct4p-hightpu-4t').
+func TPUSliceNodeCount(machineType, topology string) (int32, error) {
+	chips := int64(1)
+	for _, dim := range strings.Split(topology, "x") {
+		n, err := strconv.ParseInt(dim, 10, 32)
+		if err != nil || n < 1 {
+			return 0, fmt.Errorf("invalid TPU topology %q", topology)
+		}
+		chips *= n
+	}
+
+	segments := strings.Split(machineType, "-")
+	suffix := segments[len(segments)-1]
+	if !strings.HasPrefix(machineType, "ct") || !strings.HasSuffix(suffix, "t") {
+		return 0, fmt.Errorf("machine type %q is not a TPU machine type", machineType)
+	}
+	chipsPerNode, err := strconv.ParseInt(strings.TrimSuffix(suffix, "t"), 10, 32)
+	if err != nil || chipsPerNode < 1 {
+		return 0, fmt.Errorf("machine type %q is not a TPU machine type", machineType)
+	}
+
+	if chips%chipsPerNode != 0 {
+		return 0, fmt.Errorf("TPU topology %q is not compatible with machine type %q", topology, machineType)
+	}
+
+	return int32(chips / chipsPerNode), nil
+}
+
+// IsMultiHostTPUSlice returns true if the node pool is a TPU slice spanning more than one node.
+func (r *GCPManagedMachinePool) IsMultiHostTPUSlice() bool {
+	if r.Spec.PlacementPolicy == nil || r.Spec.PlacementPolicy.TpuTopology == "" {
+		return false
+	}
+	count, err := TPUSliceNodeCount(r.Spec.MachineType, r.Spec.PlacementPolicy.TpuTopology)
+	return err == nil && count > 1
+}
+
+// IsArmMachineType returns true if the machine type belongs to an Arm machine series (e.g. 't2a-standard-4').
+func IsArmMachineType(machineType string) bool {
+	series := strings.Split(machineType, "-")[0]
+	for _, arm := range armMachineSeries {
+		if series == arm {
+			return true
+		}
+	}
+	return false
+}
+
+// IsArm returns true if the node pool runs on Arm nodes.
+func (r *GCPManagedMachinePool) IsArm() bool {
+	return IsArmMachineType(r.Spec.MachineType)
+}
+
+// NodePoolName returns the name of the GKE node pool: the generated name from the status, the name from the
+// spec or, if neither is set, the name of the GCPManagedMachinePool.
+func (r *GCPManagedMachinePool) NodePoolName() string {
+	switch {
+	case r.Status.NodePoolName != "":
+		return r.Status.NodePoolName
+	case r.Spec.NodePoolName != "":
+		return r.Spec.NodePoolName
+	}
+	return r.Name
+}
+
+// GenerateReplacementNodePoolName generates a new name for the GKE node pool, used to create a node pool
+// replacing the current one.
+func (r *GCPManagedMachinePool) GenerateReplacementNodePoolName() string {
+	base := r.Spec.NodePoolNamePrefix
+	if base == "" {
+		base = r.Spec.NodePoolName
+		if base == "" {
+			base = r.Name
+		}
+		base += "-"
+	}
+	if maxLength := maxNodePoolNameLength - nodePoolNameSuffixLength; len(base) > maxLength {
+		base = base[:maxLength]
+	}
+	return base + utilrand.String(nodePoolNameSuffixLength)
+}
+
+// EnsureNodePoolName generates the name of the node pool from the spec prefix if it has not been generated yet.
+// It returns true if a name has been generated, which has to be persisted in the status.
+func (r *GCPManagedMachinePool) EnsureNodePoolName() bool {
+	if r.Spec.NodePoolNamePrefix == "" || r.Status.NodePoolName != "" {
+		return false
+	}
+	r.Status.NodePoolName = r.Spec.NodePoolNamePrefix + utilrand.String(nodePoolNameSuffixLength)
+	return true
+}
+
+// IPAllocationPolicy defines the subnetwork and the secondary ranges used by a VPC-native cluster.
+type IPAllocationPolicy struct {
+	// Subnetwork is the name of the subnetwork the cluster is placed in. It must be one of the subnets of the
+	// network spec.
+	Subnetwork string `json:"subnetwork"`
+
+	// Pods is the secondary range of the subnetwork used for pod IPs.
+	Pods SecondaryRange `json:"pods"`
+
+	// Services is the secondary range of the subnetwork used for service IPs.
+	Services SecondaryRange `json:"services"`
+
+	// StackType is the IP stack of the cluster. IPV4_IPV6 clusters assign IPv6 addresses to pods and services
+	// and require a IPV4_IPV6 subnetwork.
+	// +kubebuilder:validation:Enum=IPV4;IPV4_IPV6
+	// +kubebuilder:default=IPV4
+	// +optional
+	StackType *string `json:"stackType,omitempty"`
+}
+
+// SecondaryRange is a named secondary range of a subnetwork.
+type SecondaryRange struct {
+	// Name is the name of the secondary range.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// CidrBlock is the IP range of the secondary range, e.g. 10.4.0.0/14. The range is added to the subnetwork
+	// when it is created.
+	// +optional
+	CidrBlock string `json:"cidrBlock,omitempty"`
+}
+
+// FirewallRuleDirection is the direction of the traffic a firewall rule applies to.
+// +kubebuilder:validation:Enum=Ingress;Egress
+type FirewallRuleDirection string
+
+const (
+	// FirewallRuleDirectionIngress applies the rule to incoming traffic.
+	FirewallRuleDirectionIngress = FirewallRuleDirection("Ingress")
+	// FirewallRuleDirectionEgress applies the rule to outgoing traffic.
+	FirewallRuleDirectionEgress = FirewallRuleDirection("Egress")
+)
+
+// FirewallRule defines a firewall rule allowing traffic in the cluster network.
+type FirewallRule struct {
+	// Name is the name of the firewall rule.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Direction is the direction of the traffic the rule applies to.
+	// +kubebuilder:default=Ingress
+	// +optional
+	Direction FirewallRuleDirection `json:"direction,omitempty"`
+
+	// Protocol is the IP protocol the rule allows, e.g. tcp or udp.
+	// +kubebuilder:default=tcp
+	// +optional
+	Protocol string `json:"protocol,omitempty"`
+
+	// Ports are the ports or port ranges the rule allows, e.g. 8443 or 9000-9100. All ports are allowed when empty.
+	// +optional
+	Ports []string `json:"ports,omitempty"`
+
+	// SourceRanges are the source IP ranges of ingress traffic, in CIDR format.
+	// +optional
+	SourceRanges []string `json:"sourceRanges,omitempty"`
+
+	// DestinationRanges are the destination IP ranges of egress traffic, in CIDR format.
+	// +optional
+	DestinationRanges []string `json:"destinationRanges,omitempty"`
+
+	// TargetTags are the network tags of the instances the rule applies to. The rule applies to all instances of the
+	// network when empty.
+	// +optional
+	TargetTags []string `json:"targetTags,omitempty"`
+}
+
+// PrivateServiceAccess configures the private services access of the cluster network.
+type PrivateServiceAccess struct {
+	// AddressName is the name of the global address reserving the IP range of the services. Defaults to the name
+	// of the network suffixed with "-psa".
+	// +optional
+	AddressName *string `json:"addressName,omitempty"`
+
+	// Address is the first IP address of the reserved range. The range is allocated by GCP when not set.
+	// +optional
+	Address *string `json:"address,omitempty"`
+
+	// PrefixLength is the prefix length of the reserved range.
+	// +kubebuilder:validation:Minimum=8
+	// +kubebuilder:validation:Maximum=29
+	// +kubebuilder:default=16
+	// +optional
+	PrefixLength int64 `json:"prefixLength,omitempty"`
+}
+
+// PrivateServiceConnectEndpoint configures a consumer Private Service Connect endpoint of the control plane.
+type PrivateServiceConnectEndpoint struct {
+	// Name is the name of the address and forwarding rule of the endpoint. Defaults to `<cluster>-psc`.
+	// +optional
+	Name *string `json:"name,omitempty"`
+
+	// Network is the name of the consumer network the endpoint is created in.
+	// +kubebuilder:validation:MinLength=1
+	Network string `json:"network"`
+
+	// Subnetwork is the name of the subnetwork of the consumer network the endpoint address is reserved in.
+	// +kubebuilder:validation:MinLength=1
+	Subnetwork string `json:"subnetwork"`
+
+	// Address is the internal IP address of the endpoint. An address of the subnetwork is allocated when it is
+	// omitted.
+	// +optional
+	Address *string `json:"address,omitempty"`
+
+	// ServiceAttachment is the self-link of the service attachment exposing the control plane.
+	// +kubebuilder:validation:MinLength=1
+	ServiceAttachment string `json:"serviceAttachment"`
+}
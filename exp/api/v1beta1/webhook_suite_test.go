@@ -107,6 +107,9 @@ var _ = BeforeSuite(func() {
 	err = (&GCPManagedMachinePool{}).SetupWebhookWithManager(mgr)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = (&GCPMachinePool{}).SetupWebhookWithManager(mgr)
+	Expect(err).NotTo(HaveOccurred())
+
 	//+kubebuilder:scaffold:webhook
 
 	go func() {
@@ -22,11 +22,215 @@ limitations under the License.
 package v1beta1
 
 import (
+	"k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	apiv1beta1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
-	cluster_apiapiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	cluster_api_provider_gcpapiv1beta1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AcceleratorConfig) DeepCopyInto(out *AcceleratorConfig) {
+	*out = *in
+	if in.GPUDriverVersion != nil {
+		in, out := &in.GPUDriverVersion, &out.GPUDriverVersion
+		*out = new(GPUDriverVersion)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AcceleratorConfig.
+func (in *AcceleratorConfig) DeepCopy() *AcceleratorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AcceleratorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FirewallRule) DeepCopyInto(out *FirewallRule) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SourceRanges != nil {
+		in, out := &in.SourceRanges, &out.SourceRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DestinationRanges != nil {
+		in, out := &in.DestinationRanges, &out.DestinationRanges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TargetTags != nil {
+		in, out := &in.TargetTags, &out.TargetTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FirewallRule.
+func (in *FirewallRule) DeepCopy() *FirewallRule {
+	if in == nil {
+		return nil
+	}
+	out := new(FirewallRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Fleet) DeepCopyInto(out *Fleet) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Fleet.
+func (in *Fleet) DeepCopy() *Fleet {
+	if in == nil {
+		return nil
+	}
+	out := new(Fleet)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachinePool) DeepCopyInto(out *GCPMachinePool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachinePool.
+func (in *GCPMachinePool) DeepCopy() *GCPMachinePool {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachinePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPMachinePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachinePoolList) DeepCopyInto(out *GCPMachinePoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPMachinePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachinePoolList.
+func (in *GCPMachinePoolList) DeepCopy() *GCPMachinePoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachinePoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPMachinePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachinePoolSpec) DeepCopyInto(out *GCPMachinePoolSpec) {
+	*out = *in
+	if in.ProviderIDList != nil {
+		in, out := &in.ProviderIDList, &out.ProviderIDList
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Template.DeepCopyInto(&out.Template)
+	if in.Strategy != nil {
+		in, out := &in.Strategy, &out.Strategy
+		*out = new(GCPMachinePoolUpdateStrategy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachinePoolSpec.
+func (in *GCPMachinePoolSpec) DeepCopy() *GCPMachinePoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachinePoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachinePoolStatus) DeepCopyInto(out *GCPMachinePoolStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachinePoolStatus.
+func (in *GCPMachinePoolStatus) DeepCopy() *GCPMachinePoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachinePoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPMachinePoolUpdateStrategy) DeepCopyInto(out *GCPMachinePoolUpdateStrategy) {
+	*out = *in
+	if in.MaxSurge != nil {
+		in, out := &in.MaxSurge, &out.MaxSurge
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPMachinePoolUpdateStrategy.
+func (in *GCPMachinePoolUpdateStrategy) DeepCopy() *GCPMachinePoolUpdateStrategy {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPMachinePoolUpdateStrategy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPManagedCluster) DeepCopyInto(out *GCPManagedCluster) {
 	*out = *in
@@ -93,19 +297,56 @@ func (in *GCPManagedClusterSpec) DeepCopyInto(out *GCPManagedClusterSpec) {
 	in.Network.DeepCopyInto(&out.Network)
 	if in.AdditionalLabels != nil {
 		in, out := &in.AdditionalLabels, &out.AdditionalLabels
-		*out = make(apiv1beta1.Labels, len(*in))
+		*out = make(cluster_api_provider_gcpapiv1beta1.Labels, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
 	}
 	if in.CredentialsRef != nil {
 		in, out := &in.CredentialsRef, &out.CredentialsRef
-		*out = new(apiv1beta1.ObjectReference)
+		*out = new(cluster_api_provider_gcpapiv1beta1.ObjectReference)
+		**out = **in
+	}
+	if in.IdentityRef != nil {
+		in, out := &in.IdentityRef, &out.IdentityRef
+		*out = new(cluster_api_provider_gcpapiv1beta1.ObjectReference)
+		**out = **in
+	}
+	if in.ImpersonateServiceAccount != nil {
+		in, out := &in.ImpersonateServiceAccount, &out.ImpersonateServiceAccount
+		*out = new(string)
+		**out = **in
+	}
+	if in.QuotaProject != nil {
+		in, out := &in.QuotaProject, &out.QuotaProject
+		*out = new(string)
 		**out = **in
 	}
 	if in.AddonsConfig != nil {
 		in, out := &in.AddonsConfig, &out.AddonsConfig
-		*out = new(apiv1beta1.AddonsConfig)
+		*out = new(cluster_api_provider_gcpapiv1beta1.AddonsConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.IPAllocationPolicy != nil {
+		in, out := &in.IPAllocationPolicy, &out.IPAllocationPolicy
+		*out = new(IPAllocationPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FirewallRules != nil {
+		in, out := &in.FirewallRules, &out.FirewallRules
+		*out = make([]FirewallRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PrivateServiceAccess != nil {
+		in, out := &in.PrivateServiceAccess, &out.PrivateServiceAccess
+		*out = new(PrivateServiceAccess)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PrivateServiceConnectEndpoint != nil {
+		in, out := &in.PrivateServiceConnectEndpoint, &out.PrivateServiceConnectEndpoint
+		*out = new(PrivateServiceConnectEndpoint)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -125,15 +366,40 @@ func (in *GCPManagedClusterStatus) DeepCopyInto(out *GCPManagedClusterStatus) {
 	*out = *in
 	if in.FailureDomains != nil {
 		in, out := &in.FailureDomains, &out.FailureDomains
-		*out = make(cluster_apiapiv1beta1.FailureDomains, len(*in))
+		*out = make(apiv1beta1.FailureDomains, len(*in))
 		for key, val := range *in {
 			(*out)[key] = *val.DeepCopy()
 		}
 	}
 	in.Network.DeepCopyInto(&out.Network)
+	if in.SecondaryRanges != nil {
+		in, out := &in.SecondaryRanges, &out.SecondaryRanges
+		*out = make(map[string][]string, len(*in))
+		for key, val := range *in {
+			var outVal []string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.NatIPs != nil {
+		in, out := &in.NatIPs, &out.NatIPs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrivateServiceConnectEndpoint != nil {
+		in, out := &in.PrivateServiceConnectEndpoint, &out.PrivateServiceConnectEndpoint
+		*out = new(string)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make(cluster_apiapiv1beta1.Conditions, len(*in))
+		*out = make(apiv1beta1.Conditions, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -151,26 +417,25 @@ func (in *GCPManagedClusterStatus) DeepCopy() *GCPManagedClusterStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedControlPlane) DeepCopyInto(out *GCPManagedControlPlane) {
+func (in *GCPManagedClusterTemplate) DeepCopyInto(out *GCPManagedClusterTemplate) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlane.
-func (in *GCPManagedControlPlane) DeepCopy() *GCPManagedControlPlane {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedClusterTemplate.
+func (in *GCPManagedClusterTemplate) DeepCopy() *GCPManagedClusterTemplate {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedControlPlane)
+	out := new(GCPManagedClusterTemplate)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPManagedControlPlane) DeepCopyObject() runtime.Object {
+func (in *GCPManagedClusterTemplate) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -178,31 +443,31 @@ func (in *GCPManagedControlPlane) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedControlPlaneList) DeepCopyInto(out *GCPManagedControlPlaneList) {
+func (in *GCPManagedClusterTemplateList) DeepCopyInto(out *GCPManagedClusterTemplateList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GCPManagedControlPlane, len(*in))
+		*out = make([]GCPManagedClusterTemplate, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneList.
-func (in *GCPManagedControlPlaneList) DeepCopy() *GCPManagedControlPlaneList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedClusterTemplateList.
+func (in *GCPManagedClusterTemplateList) DeepCopy() *GCPManagedClusterTemplateList {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedControlPlaneList)
+	out := new(GCPManagedClusterTemplateList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPManagedControlPlaneList) DeepCopyObject() runtime.Object {
+func (in *GCPManagedClusterTemplateList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -210,60 +475,40 @@ func (in *GCPManagedControlPlaneList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedControlPlaneSpec) DeepCopyInto(out *GCPManagedControlPlaneSpec) {
+func (in *GCPManagedClusterTemplateResource) DeepCopyInto(out *GCPManagedClusterTemplateResource) {
 	*out = *in
-	if in.ReleaseChannel != nil {
-		in, out := &in.ReleaseChannel, &out.ReleaseChannel
-		*out = new(ReleaseChannel)
-		**out = **in
-	}
-	if in.ControlPlaneVersion != nil {
-		in, out := &in.ControlPlaneVersion, &out.ControlPlaneVersion
-		*out = new(string)
-		**out = **in
-	}
-	out.Endpoint = in.Endpoint
-	if in.MasterAuthorizedNetworksConfig != nil {
-		in, out := &in.MasterAuthorizedNetworksConfig, &out.MasterAuthorizedNetworksConfig
-		*out = new(MasterAuthorizedNetworksConfig)
-		(*in).DeepCopyInto(*out)
-	}
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneSpec.
-func (in *GCPManagedControlPlaneSpec) DeepCopy() *GCPManagedControlPlaneSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedClusterTemplateResource.
+func (in *GCPManagedClusterTemplateResource) DeepCopy() *GCPManagedClusterTemplateResource {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedControlPlaneSpec)
+	out := new(GCPManagedClusterTemplateResource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedControlPlaneStatus) DeepCopyInto(out *GCPManagedControlPlaneStatus) {
+func (in *GCPManagedClusterTemplateSpec) DeepCopyInto(out *GCPManagedClusterTemplateSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make(cluster_apiapiv1beta1.Conditions, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	in.Template.DeepCopyInto(&out.Template)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneStatus.
-func (in *GCPManagedControlPlaneStatus) DeepCopy() *GCPManagedControlPlaneStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedClusterTemplateSpec.
+func (in *GCPManagedClusterTemplateSpec) DeepCopy() *GCPManagedClusterTemplateSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedControlPlaneStatus)
+	out := new(GCPManagedClusterTemplateSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedMachinePool) DeepCopyInto(out *GCPManagedMachinePool) {
+func (in *GCPManagedControlPlane) DeepCopyInto(out *GCPManagedControlPlane) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -271,18 +516,18 @@ func (in *GCPManagedMachinePool) DeepCopyInto(out *GCPManagedMachinePool) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePool.
-func (in *GCPManagedMachinePool) DeepCopy() *GCPManagedMachinePool {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlane.
+func (in *GCPManagedControlPlane) DeepCopy() *GCPManagedControlPlane {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedMachinePool)
+	out := new(GCPManagedControlPlane)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPManagedMachinePool) DeepCopyObject() runtime.Object {
+func (in *GCPManagedControlPlane) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -290,31 +535,31 @@ func (in *GCPManagedMachinePool) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedMachinePoolList) DeepCopyInto(out *GCPManagedMachinePoolList) {
+func (in *GCPManagedControlPlaneList) DeepCopyInto(out *GCPManagedControlPlaneList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GCPManagedMachinePool, len(*in))
+		*out = make([]GCPManagedControlPlane, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolList.
-func (in *GCPManagedMachinePoolList) DeepCopy() *GCPManagedMachinePoolList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneList.
+func (in *GCPManagedControlPlaneList) DeepCopy() *GCPManagedControlPlaneList {
 	if in == nil {
 		return nil
 	}
-	out := new(GCPManagedMachinePoolList)
+	out := new(GCPManagedControlPlaneList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GCPManagedMachinePoolList) DeepCopyObject() runtime.Object {
+func (in *GCPManagedControlPlaneList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -322,21 +567,338 @@ func (in *GCPManagedMachinePoolList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GCPManagedMachinePoolSpec) DeepCopyInto(out *GCPManagedMachinePoolSpec) {
+func (in *GCPManagedControlPlaneSpec) DeepCopyInto(out *GCPManagedControlPlaneSpec) {
 	*out = *in
-	if in.Scaling != nil {
-		in, out := &in.Scaling, &out.Scaling
-		*out = new(NodePoolAutoScaling)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Management != nil {
-		in, out := &in.Management, &out.Management
-		*out = new(NodeManagement)
-		(*in).DeepCopyInto(*out)
+	if in.ReleaseChannel != nil {
+		in, out := &in.ReleaseChannel, &out.ReleaseChannel
+		*out = new(ReleaseChannel)
+		**out = **in
 	}
-	if in.KubernetesLabels != nil {
-		in, out := &in.KubernetesLabels, &out.KubernetesLabels
-		*out = make(apiv1beta1.Labels, len(*in))
+	if in.ControlPlaneVersion != nil {
+		in, out := &in.ControlPlaneVersion, &out.ControlPlaneVersion
+		*out = new(string)
+		**out = **in
+	}
+	out.Endpoint = in.Endpoint
+	if in.MasterAuthorizedNetworksConfig != nil {
+		in, out := &in.MasterAuthorizedNetworksConfig, &out.MasterAuthorizedNetworksConfig
+		*out = new(MasterAuthorizedNetworksConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MaxConcurrentNodePoolUpgrades != nil {
+		in, out := &in.MaxConcurrentNodePoolUpgrades, &out.MaxConcurrentNodePoolUpgrades
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(cluster_api_provider_gcpapiv1beta1.ObjectReference)
+		**out = **in
+	}
+	if in.Fleet != nil {
+		in, out := &in.Fleet, &out.Fleet
+		*out = new(Fleet)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneSpec.
+func (in *GCPManagedControlPlaneSpec) DeepCopy() *GCPManagedControlPlaneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedControlPlaneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedControlPlaneStatus) DeepCopyInto(out *GCPManagedControlPlaneStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(apiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Operation != nil {
+		in, out := &in.Operation, &out.Operation
+		*out = new(GKEOperation)
+		**out = **in
+	}
+	if in.UpgradingNodePools != nil {
+		in, out := &in.UpgradingNodePools, &out.UpgradingNodePools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneStatus.
+func (in *GCPManagedControlPlaneStatus) DeepCopy() *GCPManagedControlPlaneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedControlPlaneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedControlPlaneTemplate) DeepCopyInto(out *GCPManagedControlPlaneTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneTemplate.
+func (in *GCPManagedControlPlaneTemplate) DeepCopy() *GCPManagedControlPlaneTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedControlPlaneTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedControlPlaneTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedControlPlaneTemplateList) DeepCopyInto(out *GCPManagedControlPlaneTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPManagedControlPlaneTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneTemplateList.
+func (in *GCPManagedControlPlaneTemplateList) DeepCopy() *GCPManagedControlPlaneTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedControlPlaneTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedControlPlaneTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedControlPlaneTemplateResource) DeepCopyInto(out *GCPManagedControlPlaneTemplateResource) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneTemplateResource.
+func (in *GCPManagedControlPlaneTemplateResource) DeepCopy() *GCPManagedControlPlaneTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedControlPlaneTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedControlPlaneTemplateSpec) DeepCopyInto(out *GCPManagedControlPlaneTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedControlPlaneTemplateSpec.
+func (in *GCPManagedControlPlaneTemplateSpec) DeepCopy() *GCPManagedControlPlaneTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedControlPlaneTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePool) DeepCopyInto(out *GCPManagedMachinePool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePool.
+func (in *GCPManagedMachinePool) DeepCopy() *GCPManagedMachinePool {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedMachinePool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolList) DeepCopyInto(out *GCPManagedMachinePoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPManagedMachinePool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolList.
+func (in *GCPManagedMachinePoolList) DeepCopy() *GCPManagedMachinePoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedMachinePoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolMachine) DeepCopyInto(out *GCPManagedMachinePoolMachine) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolMachine.
+func (in *GCPManagedMachinePoolMachine) DeepCopy() *GCPManagedMachinePoolMachine {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolMachine)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedMachinePoolMachine) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolMachineList) DeepCopyInto(out *GCPManagedMachinePoolMachineList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPManagedMachinePoolMachine, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolMachineList.
+func (in *GCPManagedMachinePoolMachineList) DeepCopy() *GCPManagedMachinePoolMachineList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolMachineList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedMachinePoolMachineList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolMachineSpec) DeepCopyInto(out *GCPManagedMachinePoolMachineSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolMachineSpec.
+func (in *GCPManagedMachinePoolMachineSpec) DeepCopy() *GCPManagedMachinePoolMachineSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolMachineSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolMachineStatus) DeepCopyInto(out *GCPManagedMachinePoolMachineStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolMachineStatus.
+func (in *GCPManagedMachinePoolMachineStatus) DeepCopy() *GCPManagedMachinePoolMachineStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolMachineStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolSpec) DeepCopyInto(out *GCPManagedMachinePoolSpec) {
+	*out = *in
+	if in.Scaling != nil {
+		in, out := &in.Scaling, &out.Scaling
+		*out = new(NodePoolAutoScaling)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Management != nil {
+		in, out := &in.Management, &out.Management
+		*out = new(NodeManagement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubernetesLabels != nil {
+		in, out := &in.KubernetesLabels, &out.KubernetesLabels
+		*out = make(cluster_api_provider_gcpapiv1beta1.Labels, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
@@ -348,7 +910,14 @@ func (in *GCPManagedMachinePoolSpec) DeepCopyInto(out *GCPManagedMachinePoolSpec
 	}
 	if in.AdditionalLabels != nil {
 		in, out := &in.AdditionalLabels, &out.AdditionalLabels
-		*out = make(apiv1beta1.Labels, len(*in))
+		*out = make(cluster_api_provider_gcpapiv1beta1.Labels, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.ResourceLabels != nil {
+		in, out := &in.ResourceLabels, &out.ResourceLabels
+		*out = make(cluster_api_provider_gcpapiv1beta1.Labels, len(*in))
 		for key, val := range *in {
 			(*out)[key] = val
 		}
@@ -368,6 +937,48 @@ func (in *GCPManagedMachinePoolSpec) DeepCopyInto(out *GCPManagedMachinePoolSpec
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Gvnic != nil {
+		in, out := &in.Gvnic, &out.Gvnic
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Accelerators != nil {
+		in, out := &in.Accelerators, &out.Accelerators
+		*out = make([]AcceleratorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FastSocket != nil {
+		in, out := &in.FastSocket, &out.FastSocket
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PlacementPolicy != nil {
+		in, out := &in.PlacementPolicy, &out.PlacementPolicy
+		*out = new(NodePoolPlacementPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.WorkloadMetadataMode != nil {
+		in, out := &in.WorkloadMetadataMode, &out.WorkloadMetadataMode
+		*out = new(WorkloadMetadataMode)
+		**out = **in
+	}
+	if in.UpgradeOrder != nil {
+		in, out := &in.UpgradeOrder, &out.UpgradeOrder
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SoleTenantConfig != nil {
+		in, out := &in.SoleTenantConfig, &out.SoleTenantConfig
+		*out = new(SoleTenantConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(cluster_api_provider_gcpapiv1beta1.ObjectReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolSpec.
@@ -383,9 +994,16 @@ func (in *GCPManagedMachinePoolSpec) DeepCopy() *GCPManagedMachinePoolSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GCPManagedMachinePoolStatus) DeepCopyInto(out *GCPManagedMachinePoolStatus) {
 	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(v1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make(cluster_apiapiv1beta1.Conditions, len(*in))
+		*out = make(apiv1beta1.Conditions, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
@@ -402,6 +1020,134 @@ func (in *GCPManagedMachinePoolStatus) DeepCopy() *GCPManagedMachinePoolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolTemplate) DeepCopyInto(out *GCPManagedMachinePoolTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolTemplate.
+func (in *GCPManagedMachinePoolTemplate) DeepCopy() *GCPManagedMachinePoolTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedMachinePoolTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolTemplateList) DeepCopyInto(out *GCPManagedMachinePoolTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GCPManagedMachinePoolTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolTemplateList.
+func (in *GCPManagedMachinePoolTemplateList) DeepCopy() *GCPManagedMachinePoolTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GCPManagedMachinePoolTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolTemplateResource) DeepCopyInto(out *GCPManagedMachinePoolTemplateResource) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolTemplateResource.
+func (in *GCPManagedMachinePoolTemplateResource) DeepCopy() *GCPManagedMachinePoolTemplateResource {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolTemplateResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GCPManagedMachinePoolTemplateSpec) DeepCopyInto(out *GCPManagedMachinePoolTemplateSpec) {
+	*out = *in
+	in.Template.DeepCopyInto(&out.Template)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GCPManagedMachinePoolTemplateSpec.
+func (in *GCPManagedMachinePoolTemplateSpec) DeepCopy() *GCPManagedMachinePoolTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GCPManagedMachinePoolTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GKEOperation) DeepCopyInto(out *GKEOperation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GKEOperation.
+func (in *GKEOperation) DeepCopy() *GKEOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(GKEOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IPAllocationPolicy) DeepCopyInto(out *IPAllocationPolicy) {
+	*out = *in
+	out.Pods = in.Pods
+	out.Services = in.Services
+	if in.StackType != nil {
+		in, out := &in.StackType, &out.StackType
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IPAllocationPolicy.
+func (in *IPAllocationPolicy) DeepCopy() *IPAllocationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(IPAllocationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MasterAuthorizedNetworksConfig) DeepCopyInto(out *MasterAuthorizedNetworksConfig) {
 	*out = *in
@@ -448,6 +1194,26 @@ func (in *MasterAuthorizedNetworksConfigCidrBlock) DeepCopy() *MasterAuthorizedN
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeAffinity) DeepCopyInto(out *NodeAffinity) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeAffinity.
+func (in *NodeAffinity) DeepCopy() *NodeAffinity {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeAffinity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NodeManagement) DeepCopyInto(out *NodeManagement) {
 	*out = *in
@@ -486,6 +1252,21 @@ func (in *NodePoolAutoScaling) DeepCopyInto(out *NodePoolAutoScaling) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.TotalMinCount != nil {
+		in, out := &in.TotalMinCount, &out.TotalMinCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TotalMaxCount != nil {
+		in, out := &in.TotalMaxCount, &out.TotalMaxCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LocationPolicy != nil {
+		in, out := &in.LocationPolicy, &out.LocationPolicy
+		*out = new(ManagedNodePoolLocationPolicy)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolAutoScaling.
@@ -498,6 +1279,113 @@ func (in *NodePoolAutoScaling) DeepCopy() *NodePoolAutoScaling {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodePoolPlacementPolicy) DeepCopyInto(out *NodePoolPlacementPolicy) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(PlacementPolicyType)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodePoolPlacementPolicy.
+func (in *NodePoolPlacementPolicy) DeepCopy() *NodePoolPlacementPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(NodePoolPlacementPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateServiceAccess) DeepCopyInto(out *PrivateServiceAccess) {
+	*out = *in
+	if in.AddressName != nil {
+		in, out := &in.AddressName, &out.AddressName
+		*out = new(string)
+		**out = **in
+	}
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateServiceAccess.
+func (in *PrivateServiceAccess) DeepCopy() *PrivateServiceAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateServiceAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivateServiceConnectEndpoint) DeepCopyInto(out *PrivateServiceConnectEndpoint) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Address != nil {
+		in, out := &in.Address, &out.Address
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivateServiceConnectEndpoint.
+func (in *PrivateServiceConnectEndpoint) DeepCopy() *PrivateServiceConnectEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivateServiceConnectEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecondaryRange) DeepCopyInto(out *SecondaryRange) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecondaryRange.
+func (in *SecondaryRange) DeepCopy() *SecondaryRange {
+	if in == nil {
+		return nil
+	}
+	out := new(SecondaryRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SoleTenantConfig) DeepCopyInto(out *SoleTenantConfig) {
+	*out = *in
+	if in.NodeAffinities != nil {
+		in, out := &in.NodeAffinities, &out.NodeAffinities
+		*out = make([]NodeAffinity, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SoleTenantConfig.
+func (in *SoleTenantConfig) DeepCopy() *SoleTenantConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(SoleTenantConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Taint) DeepCopyInto(out *Taint) {
 	*out = *in
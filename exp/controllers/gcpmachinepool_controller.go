@@ -0,0 +1,240 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/instancegroupmanagers"
+	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expclusterv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// GCPMachinePoolReconciler reconciles a GCPMachinePool object.
+type GCPMachinePoolReconciler struct {
+	client.Client
+	ReconcileTimeout time.Duration
+	WatchFilterValue string
+}
+
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmachinepools,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmachinepools/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmachinepools/finalizers,verbs=update
+//+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools;machinepools/status,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GCPMachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	log := log.FromContext(ctx).WithValues("controller", "GCPMachinePool")
+
+	gvk, err := apiutil.GVKForObject(new(infrav1exp.GCPMachinePool), mgr.GetScheme())
+	if err != nil {
+		return errors.Wrapf(err, "failed to find GVK for GCPMachinePool")
+	}
+
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(options).
+		For(&infrav1exp.GCPMachinePool{}).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(log, r.WatchFilterValue)).
+		Watches(
+			&expclusterv1.MachinePool{},
+			handler.EnqueueRequestsFromMapFunc(machinePoolToInfrastructureMapFunc(gvk)),
+		).
+		Build(r)
+	if err != nil {
+		return errors.Wrap(err, "error creating controller")
+	}
+
+	clusterToObjectFunc, err := util.ClusterToTypedObjectsMapper(r.Client, &infrav1exp.GCPMachinePoolList{}, mgr.GetScheme())
+	if err != nil {
+		return errors.Wrap(err, "failed to create mapper for Cluster to GCPMachinePools")
+	}
+
+	// Add a watch on clusterv1.Cluster object for unpause & ready notifications.
+	if err := c.Watch(
+		source.Kind(mgr.GetCache(), &clusterv1.Cluster{}),
+		handler.EnqueueRequestsFromMapFunc(clusterToObjectFunc),
+		predicates.ClusterUnpausedAndInfrastructureReady(log),
+		predicates.ResourceHasFilterLabel(log, r.WatchFilterValue),
+	); err != nil {
+		return errors.Wrap(err, "failed adding a watch for ready clusters")
+	}
+
+	return nil
+}
+
+func (r *GCPMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx, cancel := context.WithTimeout(ctx, reconciler.DefaultedLoopTimeout(r.ReconcileTimeout))
+	defer cancel()
+
+	log := ctrl.LoggerFrom(ctx)
+	gcpMachinePool := &infrav1exp.GCPMachinePool{}
+	if err := r.Get(ctx, req.NamespacedName, gcpMachinePool); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+
+		return ctrl.Result{}, err
+	}
+
+	if !reconciler.MatchesWatchFilter(gcpMachinePool, r.WatchFilterValue) {
+		log.V(4).Info("GCPMachinePool does not match the watch filter, skipping")
+		return ctrl.Result{}, nil
+	}
+
+	machinePool, err := getOwnerMachinePool(ctx, r.Client, gcpMachinePool.ObjectMeta)
+	if err != nil {
+		log.Error(err, "Failed to retrieve owner MachinePool from the API Server")
+		return ctrl.Result{}, err
+	}
+	if machinePool == nil {
+		log.Info("MachinePool Controller has not yet set OwnerRef")
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("machinePool", machinePool.Name)
+	cluster, err := util.GetClusterFromMetadata(ctx, r.Client, machinePool.ObjectMeta)
+	if err != nil {
+		log.Info("MachinePool is missing cluster label or cluster does not exist")
+		return ctrl.Result{}, nil
+	}
+
+	if annotations.IsPaused(cluster, gcpMachinePool) {
+		log.Info("GCPMachinePool or linked Cluster is marked as paused. Won't reconcile")
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("cluster", cluster.Name)
+	gcpCluster := &infrav1.GCPCluster{}
+	gcpClusterKey := client.ObjectKey{
+		Namespace: gcpMachinePool.Namespace,
+		Name:      cluster.Spec.InfrastructureRef.Name,
+	}
+	if err := r.Client.Get(ctx, gcpClusterKey, gcpCluster); err != nil {
+		log.Info("GCPCluster is not available yet")
+		return ctrl.Result{}, nil
+	}
+
+	// Create the cluster scope
+	clusterScope, err := scope.NewClusterScope(ctx, scope.ClusterScopeParams{
+		Client:     r.Client,
+		Cluster:    cluster,
+		GCPCluster: gcpCluster,
+	})
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Create the machine pool scope
+	machinePoolScope, err := scope.NewMachinePoolScope(scope.MachinePoolScopeParams{
+		Client:         r.Client,
+		MachinePool:    machinePool,
+		GCPMachinePool: gcpMachinePool,
+		ClusterGetter:  clusterScope,
+	})
+	if err != nil {
+		return ctrl.Result{}, errors.Errorf("failed to create scope: %+v", err)
+	}
+
+	// Always close the scope when exiting this function so we can persist any GCPMachinePool changes.
+	defer func() {
+		if err := machinePoolScope.Close(); err != nil && reterr == nil {
+			reterr = err
+		}
+	}()
+
+	// Handle deleted machine pools
+	if !gcpMachinePool.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.reconcileDelete(ctx, machinePoolScope)
+	}
+
+	// Handle non-deleted machine pools
+	return r.reconcile(ctx, cluster, machinePoolScope)
+}
+
+func (r *GCPMachinePoolReconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster, machinePoolScope *scope.MachinePoolScope) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling GCPMachinePool")
+
+	controllerutil.AddFinalizer(machinePoolScope.GCPMachinePool, infrav1exp.MachinePoolFinalizer)
+	if err := machinePoolScope.PatchObject(); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if !cluster.Status.InfrastructureReady {
+		log.Info("Cluster infrastructure is not ready yet")
+		return ctrl.Result{}, nil
+	}
+
+	if machinePoolScope.MachinePool.Spec.Template.Spec.Bootstrap.DataSecretName == nil {
+		log.Info("Bootstrap data secret reference is not yet available")
+		conditions.MarkFalse(machinePoolScope.GCPMachinePool, infrav1exp.GCPMachinePoolReadyCondition, infrav1exp.WaitingForBootstrapDataReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{}, nil
+	}
+
+	if err := instancegroupmanagers.New(machinePoolScope).Reconcile(ctx); err != nil {
+		log.Error(err, "Error reconciling managed instance group resources")
+		record.Warnf(machinePoolScope.GCPMachinePool, "GCPMachinePoolReconcile", "Reconcile error - %v", err)
+		conditions.MarkFalse(machinePoolScope.GCPMachinePool, infrav1exp.GCPMachinePoolReadyCondition, infrav1exp.GCPMachinePoolReconciliationFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	if !machinePoolScope.GCPMachinePool.Status.Ready {
+		log.Info("Managed instance group is updating", "template", machinePoolScope.GCPMachinePool.Status.InstanceTemplate)
+		conditions.MarkFalse(machinePoolScope.GCPMachinePool, infrav1exp.GCPMachinePoolReadyCondition, infrav1exp.GCPMachinePoolUpdatingReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(machinePoolScope.GCPMachinePool)}, nil
+	}
+
+	conditions.MarkTrue(machinePoolScope.GCPMachinePool, infrav1exp.GCPMachinePoolReadyCondition)
+	record.Event(machinePoolScope.GCPMachinePool, "GCPMachinePoolReconcile", "Reconciled")
+	return ctrl.Result{}, nil
+}
+
+func (r *GCPMachinePoolReconciler) reconcileDelete(ctx context.Context, machinePoolScope *scope.MachinePoolScope) error {
+	log := log.FromContext(ctx)
+	log.Info("Reconciling Delete GCPMachinePool")
+
+	if err := instancegroupmanagers.New(machinePoolScope).Delete(ctx); err != nil {
+		log.Error(err, "Error deleting managed instance group resources")
+		record.Warnf(machinePoolScope.GCPMachinePool, "GCPMachinePoolReconcile", "Delete error - %v", err)
+		return err
+	}
+
+	controllerutil.RemoveFinalizer(machinePoolScope.GCPMachinePool, infrav1exp.MachinePoolFinalizer)
+	record.Event(machinePoolScope.GCPMachinePool, "GCPMachinePoolReconcile", "Reconciled")
+	return nil
+}
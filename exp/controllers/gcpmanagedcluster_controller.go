@@ -24,14 +24,23 @@ import (
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/filter"
 	"github.com/GoogleCloudPlatform/k8s-cloud-provider/pkg/cloud/meta"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/klog/v2"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/firewalls"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/networks"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/orphans"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/pscendpoints"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/routers"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/routes"
 	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/compute/subnets"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/services/servicenetworking/connections"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/util/credentialsref"
 	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
@@ -58,6 +67,7 @@ type GCPManagedClusterReconciler struct {
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedclusters/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedclusters/finalizers,verbs=update
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedcontrolplanes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpclusteridentities,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -80,6 +90,11 @@ func (r *GCPManagedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Re
 		return ctrl.Result{}, err
 	}
 
+	if !reconciler.MatchesWatchFilter(gcpCluster, r.WatchFilterValue) {
+		log.V(4).Info("GCPManagedCluster does not match the watch filter, skipping")
+		return ctrl.Result{}, nil
+	}
+
 	// Fetch the Cluster.
 	cluster, err := util.GetOwnerCluster(ctx, r.Client, gcpCluster.ObjectMeta)
 	if err != nil {
@@ -142,6 +157,14 @@ func (r *GCPManagedClusterReconciler) Reconcile(ctx context.Context, req ctrl.Re
 func (r *GCPManagedClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	log := ctrl.LoggerFrom(ctx)
 
+	err := credentialsref.SetupIndexes(ctx, mgr, &infrav1exp.GCPManagedCluster{}, func(o client.Object) (*infrav1.ObjectReference, *infrav1.ObjectReference) {
+		gcpManagedCluster := o.(*infrav1exp.GCPManagedCluster)
+		return gcpManagedCluster.Spec.CredentialsRef, gcpManagedCluster.Spec.IdentityRef
+	})
+	if err != nil {
+		return fmt.Errorf("setting up credentials indexes: %v", err)
+	}
+
 	c, err := ctrl.NewControllerManagedBy(mgr).
 		WithOptions(options).
 		For(&infrav1exp.GCPManagedCluster{}).
@@ -150,6 +173,14 @@ func (r *GCPManagedClusterReconciler) SetupWithManager(ctx context.Context, mgr
 			&infrav1exp.GCPManagedControlPlane{},
 			handler.EnqueueRequestsFromMapFunc(r.managedControlPlaneMapper()),
 		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(credentialsref.SecretToClusters(mgr.GetClient(), &infrav1exp.GCPManagedClusterList{})),
+		).
+		Watches(
+			&infrav1.GCPClusterIdentity{},
+			handler.EnqueueRequestsFromMapFunc(credentialsref.IdentityToClusters(mgr.GetClient(), &infrav1exp.GCPManagedClusterList{})),
+		).
 		Build(r)
 	if err != nil {
 		return fmt.Errorf("creating controller: %v", err)
@@ -194,20 +225,34 @@ func (r *GCPManagedClusterReconciler) reconcile(ctx context.Context, clusterScop
 	}
 	clusterScope.SetFailureDomains(failureDomains)
 
-	reconcilers := map[string]cloud.Reconciler{
-		"networks": networks.New(clusterScope),
-		"subnets":  subnets.New(clusterScope),
+	// The reconcilers are called in order as all of them depend on the network.
+	reconcilers := []struct {
+		name       string
+		reconciler cloud.Reconciler
+	}{
+		{"networks", networks.New(clusterScope)},
+		{"routers", routers.New(clusterScope)},
+		{"subnets", subnets.New(clusterScope)},
+		{"firewalls", firewalls.New(clusterScope)},
+		{"routes", routes.New(clusterScope)},
+		{"privateserviceaccess", connections.New(clusterScope)},
+		{"pscendpoints", pscendpoints.New(clusterScope)},
 	}
 
-	for name, r := range reconcilers {
-		log.V(4).Info("Calling reconciler", "reconciler", name)
-		if err := r.Reconcile(ctx); err != nil {
-			log.Error(err, "Reconcile error", "reconciler", name)
+	for _, r := range reconcilers {
+		log.V(4).Info("Calling reconciler", "reconciler", r.name)
+		if err := r.reconciler.Reconcile(ctx); err != nil {
+			log.Error(err, "Reconcile error", "reconciler", r.name)
 			record.Warnf(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Reconcile error - %v", err)
 			return err
 		}
 	}
 
+	if err := r.reconcileNetworkStatus(ctx, clusterScope); err != nil {
+		log.Error(err, "Failed to resolve network resources")
+		return err
+	}
+
 	clusterScope.SetReady()
 	record.Event(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Ready")
 
@@ -224,24 +269,75 @@ func (r *GCPManagedClusterReconciler) reconcile(ctx context.Context, clusterScop
 	return nil
 }
 
+// reconcileNetworkStatus records the secondary ranges of the subnets and the NAT IPs of the router in the status,
+// so that they can be consumed without querying GCP.
+func (r *GCPManagedClusterReconciler) reconcileNetworkStatus(ctx context.Context, clusterScope *scope.ManagedClusterScope) error {
+	secondaryRanges := make(map[string][]string)
+	for _, spec := range clusterScope.SubnetSpecs() {
+		if _, ok := clusterScope.Network().Subnets[spec.Name]; !ok {
+			continue
+		}
+
+		subnet, err := clusterScope.Cloud().Subnetworks().Get(ctx, meta.RegionalKey(spec.Name, spec.Region))
+		if err != nil {
+			return err
+		}
+
+		names := make([]string, 0, len(subnet.SecondaryIpRanges))
+		for _, secondaryRange := range subnet.SecondaryIpRanges {
+			names = append(names, secondaryRange.RangeName)
+		}
+		secondaryRanges[spec.Name] = names
+	}
+	clusterScope.SetSecondaryRanges(secondaryRanges)
+
+	var natIPs []string
+	if clusterScope.Network().Router != nil {
+		routerName := clusterScope.NatRouterSpec().Name
+		status, err := clusterScope.Cloud().Routers().GetRouterStatus(ctx, meta.RegionalKey(routerName, clusterScope.Region()))
+		if err != nil {
+			return err
+		}
+
+		if status.Result != nil {
+			for _, nat := range status.Result.NatStatus {
+				natIPs = append(natIPs, nat.AutoAllocatedNatIps...)
+				natIPs = append(natIPs, nat.UserAllocatedNatIps...)
+			}
+		}
+	}
+	clusterScope.SetNatIPs(natIPs)
+
+	return nil
+}
+
 func (r *GCPManagedClusterReconciler) reconcileDelete(ctx context.Context, clusterScope *scope.ManagedClusterScope) (ctrl.Result, error) {
 	log := log.FromContext(ctx).WithValues("controller", "gcpmanagedcluster", "action", "delete")
 	log.Info("Reconciling Delete GCPManagedCluster")
 
 	if clusterScope.GCPManagedControlPlane != nil {
 		log.Info("GCPManagedControlPlane not deleted yet, retry later")
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(clusterScope.GCPManagedCluster)}, nil
 	}
 
-	reconcilers := map[string]cloud.Reconciler{
-		"subnets":  subnets.New(clusterScope),
-		"networks": networks.New(clusterScope),
+	reconcilers := []struct {
+		name       string
+		reconciler cloud.Reconciler
+	}{
+		{"pscendpoints", pscendpoints.New(clusterScope)},
+		{"privateserviceaccess", connections.New(clusterScope)},
+		{"routes", routes.New(clusterScope)},
+		{"firewalls", firewalls.New(clusterScope)},
+		{"subnets", subnets.New(clusterScope)},
+		{"routers", routers.New(clusterScope)},
+		{"orphans", orphans.New(clusterScope)},
+		{"networks", networks.New(clusterScope)},
 	}
 
-	for name, r := range reconcilers {
-		log.V(4).Info("Calling reconciler delete", "reconciler", name)
-		if err := r.Delete(ctx); err != nil {
-			log.Error(err, "Reconcile error", "reconciler", name)
+	for _, r := range reconcilers {
+		log.V(4).Info("Calling reconciler delete", "reconciler", r.name)
+		if err := r.reconciler.Delete(ctx); err != nil {
+			log.Error(err, "Reconcile error", "reconciler", r.name)
 			record.Warnf(clusterScope.GCPManagedCluster, "GCPManagedClusterReconcile", "Reconcile error - %v", err)
 			return ctrl.Result{}, err
 		}
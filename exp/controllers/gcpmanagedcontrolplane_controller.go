@@ -51,6 +51,8 @@ type GCPManagedControlPlaneReconciler struct {
 	client.Client
 	ReconcileTimeout time.Duration
 	WatchFilterValue string
+	// KubeconfigRefreshWindow is the time before the expiry of the token of the CAPI kubeconfig at which it is refreshed.
+	KubeconfigRefreshWindow time.Duration
 }
 
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedcontrolplanes,verbs=get;list;watch;create;update;patch;delete
@@ -78,6 +80,7 @@ func (r *GCPManagedControlPlaneReconciler) SetupWithManager(ctx context.Context,
 		source.Kind(mgr.GetCache(), &clusterv1.Cluster{}),
 		handler.EnqueueRequestsFromMapFunc(util.ClusterToInfrastructureMapFunc(ctx, gcpManagedControlPlane.GroupVersionKind(), mgr.GetClient(), &infrav1exp.GCPManagedControlPlane{})),
 		predicates.ClusterUnpausedAndInfrastructureReady(log),
+		predicates.ResourceHasFilterLabel(log, r.WatchFilterValue),
 	); err != nil {
 		return fmt.Errorf("failed adding a watch for ready clusters: %w", err)
 	}
@@ -100,6 +103,11 @@ func (r *GCPManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ct
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	if !reconciler.MatchesWatchFilter(gcpManagedControlPlane, r.WatchFilterValue) {
+		log.V(4).Info("GCPManagedControlPlane does not match the watch filter, skipping")
+		return ctrl.Result{}, nil
+	}
+
 	// Get the cluster
 	cluster, err := util.GetOwnerCluster(ctx, r.Client, gcpManagedControlPlane.ObjectMeta)
 	if err != nil {
@@ -128,10 +136,11 @@ func (r *GCPManagedControlPlaneReconciler) Reconcile(ctx context.Context, req ct
 	}
 
 	managedControlPlaneScope, err := scope.NewManagedControlPlaneScope(ctx, scope.ManagedControlPlaneScopeParams{
-		Client:                 r.Client,
-		Cluster:                cluster,
-		GCPManagedCluster:      managedCluster,
-		GCPManagedControlPlane: gcpManagedControlPlane,
+		Client:                  r.Client,
+		Cluster:                 cluster,
+		GCPManagedCluster:       managedCluster,
+		GCPManagedControlPlane:  gcpManagedControlPlane,
+		KubeconfigRefreshWindow: r.KubeconfigRefreshWindow,
 	})
 	if err != nil {
 		return ctrl.Result{}, errors.Errorf("failed to create scope: %+v", err)
@@ -164,7 +173,7 @@ func (r *GCPManagedControlPlaneReconciler) reconcile(ctx context.Context, manage
 
 	if !managedControlPlaneScope.GCPManagedCluster.Status.Ready {
 		log.Info("GCPManagedCluster not ready yet, retry later")
-		return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+		return ctrl.Result{RequeueAfter: reconciler.PollInterval(managedControlPlaneScope.GCPManagedControlPlane)}, nil
 	}
 
 	reconcilers := map[string]cloud.ReconcilerWithResult{
@@ -221,5 +230,5 @@ func (r *GCPManagedControlPlaneReconciler) reconcileDelete(ctx context.Context,
 		controllerutil.RemoveFinalizer(managedControlPlaneScope.GCPManagedControlPlane, infrav1exp.ManagedControlPlaneFinalizer)
 	}
 
-	return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+	return ctrl.Result{RequeueAfter: reconciler.PollInterval(managedControlPlaneScope.GCPManagedControlPlane)}, nil
 }
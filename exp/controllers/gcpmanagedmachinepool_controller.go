@@ -166,6 +166,10 @@ func (r *GCPManagedMachinePoolReconciler) SetupWithManager(ctx context.Context,
 			&infrav1exp.GCPManagedControlPlane{},
 			handler.EnqueueRequestsFromMapFunc(managedControlPlaneToManagedMachinePoolMapFunc(r.Client, gvk, log)),
 		).
+		Watches(
+			&infrav1exp.GCPManagedMachinePoolMachine{},
+			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &infrav1exp.GCPManagedMachinePool{}),
+		).
 		Build(r)
 	if err != nil {
 		return errors.Wrap(err, "error creating controller")
@@ -181,6 +185,7 @@ func (r *GCPManagedMachinePoolReconciler) SetupWithManager(ctx context.Context,
 		source.Kind(mgr.GetCache(), &clusterv1.Cluster{}),
 		handler.EnqueueRequestsFromMapFunc(clusterToObjectFunc),
 		predicates.ClusterUnpausedAndInfrastructureReady(log),
+		predicates.ResourceHasFilterLabel(log, r.WatchFilterValue),
 	); err != nil {
 		return errors.Wrap(err, "failed adding a watch for ready clusters")
 	}
@@ -218,9 +223,13 @@ func getOwnerMachinePool(ctx context.Context, c client.Client, obj metav1.Object
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepools,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepools/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepools/finalizers,verbs=update
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepoolmachines,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedmachinepoolmachines/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedcontrolplanes,verbs=get;list;watch
+//+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedcontrolplanes/status,verbs=get;patch
 //+kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=gcpmanagedclusters,verbs=get;list;watch
 //+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinepools;machinepools/status,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machines,verbs=get;list;watch;delete
 //+kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters;clusters/status,verbs=get;list;watch
 
 func (r *GCPManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
@@ -238,6 +247,11 @@ func (r *GCPManagedMachinePoolReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{Requeue: true}, nil
 	}
 
+	if !reconciler.MatchesWatchFilter(gcpManagedMachinePool, r.WatchFilterValue) {
+		log.V(4).Info("GCPManagedMachinePool does not match the watch filter, skipping")
+		return ctrl.Result{}, nil
+	}
+
 	// Get the machine pool
 	machinePool, err := getOwnerMachinePool(ctx, r.Client, gcpManagedMachinePool.ObjectMeta)
 	if err != nil {
@@ -336,7 +350,7 @@ func (r *GCPManagedMachinePoolReconciler) reconcile(ctx context.Context, managed
 			if ok := errors.As(err, &e); ok {
 				if e.GRPCStatus().Code() == codes.FailedPrecondition {
 					log.Info("Cannot perform update when there's other operation, retry later", "reconciler", name)
-					return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+					return ctrl.Result{RequeueAfter: reconciler.ErrorBackoff(managedMachinePoolScope.GCPManagedMachinePool)}, nil
 				}
 			}
 			log.Error(err, "Reconcile error", "reconciler", name)
@@ -382,9 +396,10 @@ func (r *GCPManagedMachinePoolReconciler) reconcileDelete(ctx context.Context, m
 		}
 	}
 
-	if conditions.Get(managedMachinePoolScope.GCPManagedMachinePool, infrav1exp.GKEMachinePoolDeletingCondition).Reason == infrav1exp.GKEMachinePoolDeletedReason {
+	switch conditions.Get(managedMachinePoolScope.GCPManagedMachinePool, infrav1exp.GKEMachinePoolDeletingCondition).Reason {
+	case infrav1exp.GKEMachinePoolDeletedReason, infrav1exp.GKEMachinePoolRetainedReason:
 		controllerutil.RemoveFinalizer(managedMachinePoolScope.GCPManagedMachinePool, infrav1exp.ManagedMachinePoolFinalizer)
 	}
 
-	return ctrl.Result{RequeueAfter: reconciler.DefaultRetryTime}, nil
+	return ctrl.Result{RequeueAfter: reconciler.PollInterval(managedMachinePoolScope.GCPManagedMachinePool)}, nil
 }
@@ -30,6 +30,10 @@ const (
 	// owner: @richardchen331 & @richardcase
 	// alpha: v0.1
 	GKE featuregate.Feature = "GKE"
+
+	// MachinePool is used to enable machine pools backed by managed instance groups
+	// alpha: v0.1
+	MachinePool featuregate.Feature = "MachinePool"
 )
 
 func init() {
@@ -39,5 +43,6 @@ func init() {
 // defaultCAPGFeatureGates consists of all known capg-specific feature keys.
 // To add a new feature, define a key for it above and add it here.
 var defaultCAPGFeatureGates = map[featuregate.Feature]featuregate.FeatureSpec{
-	GKE: {Default: false, PreRelease: featuregate.Alpha},
+	GKE:         {Default: false, PreRelease: featuregate.Alpha},
+	MachinePool: {Default: false, PreRelease: featuregate.Alpha},
 }
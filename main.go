@@ -36,6 +36,7 @@ import (
 	infrav1alpha3 "sigs.k8s.io/cluster-api-provider-gcp/api/v1alpha3" //nolint: staticcheck
 	infrav1alpha4 "sigs.k8s.io/cluster-api-provider-gcp/api/v1alpha4" //nolint: staticcheck
 	infrav1beta1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/cluster-api-provider-gcp/cloud/scope"
 	"sigs.k8s.io/cluster-api-provider-gcp/controllers"
 	infrav1exp "sigs.k8s.io/cluster-api-provider-gcp/exp/api/v1beta1"
 	expcontrollers "sigs.k8s.io/cluster-api-provider-gcp/exp/controllers"
@@ -80,10 +81,18 @@ var (
 	gcpMachineConcurrency       int
 	webhookPort                 int
 	reconcileTimeout            time.Duration
+	preemptionPollInterval      time.Duration
+	kubeconfigRefreshWindow     time.Duration
 	syncPeriod                  time.Duration
 	leaderElectionLeaseDuration time.Duration
 	leaderElectionRenewDeadline time.Duration
 	leaderElectionRetryPeriod   time.Duration
+	gcpOAuthScopes              []string
+	gcpAPIEndpoints             infrav1beta1.APIEndpoints
+	gcpCABundle                 string
+	gcpComputeRateLimit         scope.APIRateLimit
+	gcpContainerRateLimit       scope.APIRateLimit
+	reconcileIntervals          reconciler.Intervals
 )
 
 func main() {
@@ -91,6 +100,15 @@ func main() {
 	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
 	pflag.Parse()
 
+	scope.SetOAuthScopes(gcpOAuthScopes)
+	scope.SetAPIEndpoints(gcpAPIEndpoints)
+	scope.SetAPIRateLimits(gcpComputeRateLimit, gcpContainerRateLimit)
+	reconciler.SetIntervals(reconcileIntervals)
+	if err := scope.SetCABundle(gcpCABundle); err != nil {
+		setupLog.Error(err, "unable to load the CA bundle of the GCP clients")
+		os.Exit(1)
+	}
+
 	if watchNamespace != "" {
 		setupLog.Info("Watching cluster-api objects only in namespace for reconciliation", "namespace", watchNamespace)
 	}
@@ -172,9 +190,10 @@ func main() {
 
 func setupReconcilers(ctx context.Context, mgr ctrl.Manager) error {
 	if err := (&controllers.GCPMachineReconciler{
-		Client:           mgr.GetClient(),
-		ReconcileTimeout: reconcileTimeout,
-		WatchFilterValue: watchFilterValue,
+		Client:                 mgr.GetClient(),
+		ReconcileTimeout:       reconcileTimeout,
+		WatchFilterValue:       watchFilterValue,
+		PreemptionPollInterval: preemptionPollInterval,
 	}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpMachineConcurrency}); err != nil {
 		return fmt.Errorf("setting up GCPMachine controller: %w", err)
 	}
@@ -198,9 +217,10 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) error {
 		}
 
 		if err := (&expcontrollers.GCPManagedControlPlaneReconciler{
-			Client:           mgr.GetClient(),
-			ReconcileTimeout: reconcileTimeout,
-			WatchFilterValue: watchFilterValue,
+			Client:                  mgr.GetClient(),
+			ReconcileTimeout:        reconcileTimeout,
+			WatchFilterValue:        watchFilterValue,
+			KubeconfigRefreshWindow: kubeconfigRefreshWindow,
 		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpClusterConcurrency}); err != nil {
 			return fmt.Errorf("setting up GCPManagedControlPlane controller: %w", err)
 		}
@@ -214,6 +234,18 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) error {
 		}
 	}
 
+	if feature.Gates.Enabled(feature.MachinePool) {
+		setupLog.Info("Enabling MachinePool reconcilers")
+
+		if err := (&expcontrollers.GCPMachinePoolReconciler{
+			Client:           mgr.GetClient(),
+			ReconcileTimeout: reconcileTimeout,
+			WatchFilterValue: watchFilterValue,
+		}).SetupWithManager(ctx, mgr, controller.Options{MaxConcurrentReconciles: gcpMachineConcurrency}); err != nil {
+			return fmt.Errorf("setting up GCPMachinePool controller: %w", err)
+		}
+	}
+
 	return nil
 }
 
@@ -237,12 +269,29 @@ func setupWebhooks(mgr ctrl.Manager) error {
 		if err := (&infrav1exp.GCPManagedCluster{}).SetupWebhookWithManager(mgr); err != nil {
 			return fmt.Errorf("setting up GCPManagedCluster webhook: %w", err)
 		}
+		if err := (&infrav1exp.GCPManagedClusterTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+			return fmt.Errorf("setting up GCPManagedClusterTemplate webhook: %w", err)
+		}
 		if err := (&infrav1exp.GCPManagedControlPlane{}).SetupWebhookWithManager(mgr); err != nil {
 			return fmt.Errorf("setting up GCPManagedControlPlane webhook: %w", err)
 		}
+		if err := (&infrav1exp.GCPManagedControlPlaneTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+			return fmt.Errorf("setting up GCPManagedControlPlaneTemplate webhook: %w", err)
+		}
 		if err := (&infrav1exp.GCPManagedMachinePool{}).SetupWebhookWithManager(mgr); err != nil {
 			return fmt.Errorf("setting up GCPManagedMachinePool webhook: %w", err)
 		}
+		if err := (&infrav1exp.GCPManagedMachinePoolTemplate{}).SetupWebhookWithManager(mgr); err != nil {
+			return fmt.Errorf("setting up GCPManagedMachinePoolTemplate webhook: %w", err)
+		}
+	}
+
+	if feature.Gates.Enabled(feature.MachinePool) {
+		setupLog.Info("Enabling MachinePool webhooks")
+
+		if err := (&infrav1exp.GCPMachinePool{}).SetupWebhookWithManager(mgr); err != nil {
+			return fmt.Errorf("setting up GCPMachinePool webhook: %w", err)
+		}
 	}
 
 	return nil
@@ -366,5 +415,83 @@ func initFlags(fs *pflag.FlagSet) {
 		"The maximum duration a reconcile loop can run (e.g. 90m)",
 	)
 
+	fs.DurationVar(&preemptionPollInterval,
+		"preemption-poll-interval",
+		30*time.Second,
+		"The interval at which running preemptible and Spot instances are checked for preemption, 0 disables polling (e.g. 30s)",
+	)
+
+	fs.DurationVar(&kubeconfigRefreshWindow,
+		"gke-kubeconfig-refresh-window",
+		scope.DefaultKubeconfigRefreshWindow,
+		"The time before the expiry of the token of the kubeconfig of a GKE cluster at which it is refreshed (e.g. 10m)",
+	)
+
+	fs.StringSliceVar(&gcpOAuthScopes,
+		"gcp-oauth-scopes",
+		nil,
+		"The OAuth scopes requested by the GCP clients, which default to cloud-platform and userinfo.email (e.g. https://www.googleapis.com/auth/compute)",
+	)
+
+	fs.StringVar(&gcpAPIEndpoints.Compute,
+		"gcp-compute-endpoint",
+		"",
+		"The base URL of the compute API, which defaults to the public endpoint (e.g. https://compute-private.googleapis.com)",
+	)
+
+	fs.StringVar(&gcpAPIEndpoints.Container,
+		"gcp-container-endpoint",
+		"",
+		"The host and port of the gRPC container API, which defaults to the public endpoint (e.g. container-private.googleapis.com:443)",
+	)
+
+	fs.StringVar(&gcpCABundle,
+		"gcp-ca-bundle",
+		"",
+		"The path of a PEM file with CA certificates trusted by the GCP clients in addition to the system CAs, e.g. the CA of a TLS-intercepting proxy",
+	)
+
+	fs.Float32Var(&gcpComputeRateLimit.QPS,
+		"gcp-compute-api-qps",
+		0,
+		"The maximum number of calls per second to the compute API shared by all the controllers, 0 disables the rate limit",
+	)
+
+	fs.IntVar(&gcpComputeRateLimit.Burst,
+		"gcp-compute-api-burst",
+		10,
+		"The maximum burst of calls to the compute API when its rate is limited",
+	)
+
+	fs.Float32Var(&gcpContainerRateLimit.QPS,
+		"gcp-container-api-qps",
+		0,
+		"The maximum number of calls per second to the container API shared by all the controllers, 0 disables the rate limit",
+	)
+
+	fs.IntVar(&gcpContainerRateLimit.Burst,
+		"gcp-container-api-burst",
+		10,
+		"The maximum burst of calls to the container API when its rate is limited",
+	)
+
+	fs.DurationVar(&reconcileIntervals.Poll,
+		"poll-interval",
+		reconciler.DefaultRetryTime,
+		"The interval at which GCP resources being provisioned, updated or deleted are checked again (e.g. 30s)",
+	)
+
+	fs.DurationVar(&reconcileIntervals.Resync,
+		"resync-interval",
+		0,
+		"The interval at which reconciled GKE clusters and node pools are reconciled again, 0 relies on the sync period (e.g. 30m)",
+	)
+
+	fs.DurationVar(&reconcileIntervals.ErrorBackoff,
+		"error-backoff",
+		reconciler.DefaultRetryTime,
+		"The time to wait before retrying after a GKE operation failed or was rejected (e.g. 5m)",
+	)
+
 	feature.MutableGates.AddFlag(fs)
 }
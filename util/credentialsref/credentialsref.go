@@ -0,0 +1,141 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package credentialsref maps the Secrets and GCPClusterIdentities holding GCP credentials to the clusters that
+// use them, so that the clusters are reconciled with new clients as soon as their credentials change.
+package credentialsref
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// CredentialsRefField is the field index of the Secrets referenced by the CredentialsRef of clusters.
+	CredentialsRefField = "spec.credentialsRef"
+
+	// IdentityRefField is the field index of the GCPClusterIdentities referenced by the IdentityRef of clusters.
+	IdentityRefField = "spec.identityRef"
+)
+
+// RefsFunc returns the credentials and identity references of a cluster object.
+type RefsFunc func(obj client.Object) (credentialsRef, identityRef *infrav1.ObjectReference)
+
+// SetupIndexes indexes the cluster objects by their credentials and identity references.
+func SetupIndexes(ctx context.Context, mgr manager.Manager, obj client.Object, refs RefsFunc) error {
+	indexer := mgr.GetFieldIndexer()
+	if err := indexer.IndexField(ctx, obj, CredentialsRefField, func(o client.Object) []string {
+		credentialsRef, _ := refs(o)
+		return indexValues(credentialsRef)
+	}); err != nil {
+		return fmt.Errorf("indexing %s: %w", CredentialsRefField, err)
+	}
+
+	if err := indexer.IndexField(ctx, obj, IdentityRefField, func(o client.Object) []string {
+		_, identityRef := refs(o)
+		return indexValues(identityRef)
+	}); err != nil {
+		return fmt.Errorf("indexing %s: %w", IdentityRefField, err)
+	}
+
+	return nil
+}
+
+// SecretToClusters returns a handler.MapFunc that maps a Secret to the cluster objects of the list type that
+// use it, either directly or through a GCPClusterIdentity.
+func SecretToClusters(c client.Client, list client.ObjectList) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		secret, ok := o.(*corev1.Secret)
+		if !ok {
+			return nil
+		}
+
+		requests := listClusters(ctx, c, list, CredentialsRefField, secret.Namespace, secret.Name)
+
+		identities := &infrav1.GCPClusterIdentityList{}
+		if err := c.List(ctx, identities, client.InNamespace(secret.Namespace)); err != nil {
+			log.FromContext(ctx).Error(err, "Failed to list cluster identities", "namespace", secret.Namespace)
+			return requests
+		}
+		for _, identity := range identities.Items {
+			if identity.Spec.SecretRef != nil && identity.Spec.SecretRef.Name == secret.Name {
+				requests = append(requests, listClusters(ctx, c, list, IdentityRefField, identity.Namespace, identity.Name)...)
+			}
+		}
+
+		return requests
+	}
+}
+
+// IdentityToClusters returns a handler.MapFunc that maps a GCPClusterIdentity to the cluster objects of the list
+// type that reference it.
+func IdentityToClusters(c client.Client, list client.ObjectList) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		if _, ok := o.(*infrav1.GCPClusterIdentity); !ok {
+			return nil
+		}
+
+		return listClusters(ctx, c, list, IdentityRefField, o.GetNamespace(), o.GetName())
+	}
+}
+
+// listClusters returns the requests of the cluster objects whose index field references the object.
+func listClusters(ctx context.Context, c client.Client, list client.ObjectList, field, namespace, name string) []reconcile.Request {
+	list, _ = list.DeepCopyObject().(client.ObjectList)
+	if err := c.List(ctx, list, client.MatchingFields{field: indexKey(namespace, name)}); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list clusters", "field", field, "namespace", namespace, "name", name)
+		return nil
+	}
+
+	objs, err := meta.ExtractList(list)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to extract clusters")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(objs))
+	for _, obj := range objs {
+		if o, ok := obj.(client.Object); ok {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: o.GetNamespace(), Name: o.GetName()},
+			})
+		}
+	}
+
+	return requests
+}
+
+func indexValues(ref *infrav1.ObjectReference) []string {
+	if ref == nil {
+		return nil
+	}
+
+	return []string{indexKey(ref.Namespace, ref.Name)}
+}
+
+func indexKey(namespace, name string) string {
+	return types.NamespacedName{Namespace: namespace, Name: name}.String()
+}
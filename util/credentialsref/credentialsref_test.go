@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+
+package credentialsref
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	infrav1 "sigs.k8s.io/cluster-api-provider-gcp/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestSecretToClusters(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(infrav1.AddToScheme(scheme)).To(Succeed())
+
+	cluster := func(name string, credentialsRef, identityRef *infrav1.ObjectReference) *infrav1.GCPCluster {
+		return &infrav1.GCPCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "tenant"},
+			Spec:       infrav1.GCPClusterSpec{CredentialsRef: credentialsRef, IdentityRef: identityRef},
+		}
+	}
+	refs := func(o client.Object) []string {
+		gcpCluster := o.(*infrav1.GCPCluster)
+		return indexValues(gcpCluster.Spec.CredentialsRef)
+	}
+	identityRefs := func(o client.Object) []string {
+		gcpCluster := o.(*infrav1.GCPCluster)
+		return indexValues(gcpCluster.Spec.IdentityRef)
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithIndex(&infrav1.GCPCluster{}, CredentialsRefField, refs).
+		WithIndex(&infrav1.GCPCluster{}, IdentityRefField, identityRefs).
+		WithObjects(
+			cluster("direct", &infrav1.ObjectReference{Namespace: "tenant", Name: "credentials"}, nil),
+			cluster("through-identity", nil, &infrav1.ObjectReference{Namespace: "identities", Name: "team"}),
+			cluster("other", &infrav1.ObjectReference{Namespace: "tenant", Name: "other-credentials"}, nil),
+			cluster("default", nil, nil),
+			&infrav1.GCPClusterIdentity{
+				ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "identities"},
+				Spec: infrav1.GCPClusterIdentitySpec{
+					Type:      infrav1.ServiceAccountKeyIdentity,
+					SecretRef: &corev1.LocalObjectReference{Name: "credentials"},
+				},
+			},
+		).
+		Build()
+
+	request := func(name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "tenant", Name: name}}
+	}
+
+	secretToClusters := SecretToClusters(c, &infrav1.GCPClusterList{})
+	g.Expect(secretToClusters(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "tenant"},
+	})).To(ConsistOf(request("direct")))
+	g.Expect(secretToClusters(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "credentials", Namespace: "identities"},
+	})).To(ConsistOf(request("through-identity")))
+	g.Expect(secretToClusters(context.TODO(), &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "unused", Namespace: "tenant"},
+	})).To(BeEmpty())
+
+	identityToClusters := IdentityToClusters(c, &infrav1.GCPClusterList{})
+	g.Expect(identityToClusters(context.TODO(), &infrav1.GCPClusterIdentity{
+		ObjectMeta: metav1.ObjectMeta{Name: "team", Namespace: "identities"},
+	})).To(ConsistOf(request("through-identity")))
+}
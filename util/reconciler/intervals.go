@@ -0,0 +1,95 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PollIntervalAnnotation overrides the poll interval of the object it is set on (e.g. 30s).
+	PollIntervalAnnotation = "infrastructure.cluster.x-k8s.io/poll-interval"
+	// ResyncIntervalAnnotation overrides the resync interval of the object it is set on (e.g. 30m).
+	ResyncIntervalAnnotation = "infrastructure.cluster.x-k8s.io/resync-interval"
+	// ErrorBackoffAnnotation overrides the error backoff of the object it is set on (e.g. 5m).
+	ErrorBackoffAnnotation = "infrastructure.cluster.x-k8s.io/error-backoff"
+)
+
+// Intervals are the times after which the controllers reconcile an object again.
+type Intervals struct {
+	// Poll is the time to wait before checking again on a resource being provisioned, updated or deleted.
+	Poll time.Duration
+	// Resync is the time after which a reconciled object is reconciled again, 0 relies on the sync period of the
+	// manager.
+	Resync time.Duration
+	// ErrorBackoff is the time to wait before retrying an operation that failed on the GCP side.
+	ErrorBackoff time.Duration
+}
+
+var intervals = Intervals{
+	Poll:         DefaultRetryTime,
+	ErrorBackoff: DefaultRetryTime,
+}
+
+// SetIntervals sets the intervals of all the controllers. Non-positive poll interval and error backoff keep the
+// default of DefaultRetryTime.
+func SetIntervals(i Intervals) {
+	if i.Poll <= 0 {
+		i.Poll = DefaultRetryTime
+	}
+	if i.Resync < 0 {
+		i.Resync = 0
+	}
+	if i.ErrorBackoff <= 0 {
+		i.ErrorBackoff = DefaultRetryTime
+	}
+	intervals = i
+}
+
+// PollInterval returns the time to wait before checking again on the resources of the object.
+func PollInterval(o metav1.Object) time.Duration {
+	return interval(o, PollIntervalAnnotation, intervals.Poll)
+}
+
+// ResyncInterval returns the time after which the object is reconciled again once reconciled, 0 when it is left to
+// the sync period of the manager.
+func ResyncInterval(o metav1.Object) time.Duration {
+	return interval(o, ResyncIntervalAnnotation, intervals.Resync)
+}
+
+// ErrorBackoff returns the time to wait before retrying a failed operation on the resources of the object.
+func ErrorBackoff(o metav1.Object) time.Duration {
+	return interval(o, ErrorBackoffAnnotation, intervals.ErrorBackoff)
+}
+
+// interval returns the duration set in the annotation of the object, or the given default when the annotation is
+// missing or is not a positive duration.
+func interval(o metav1.Object, annotation string, defaultInterval time.Duration) time.Duration {
+	value, ok := o.GetAnnotations()[annotation]
+	if !ok {
+		return defaultInterval
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return defaultInterval
+	}
+
+	return d
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+)
+
+func TestIntervals(t *testing.T) {
+	reconciler.SetIntervals(reconciler.Intervals{Poll: 30 * time.Second, Resync: -time.Minute})
+	defer reconciler.SetIntervals(reconciler.Intervals{})
+
+	cases := []struct {
+		Name                 string
+		Annotations          map[string]string
+		ExpectedPoll         time.Duration
+		ExpectedResync       time.Duration
+		ExpectedErrorBackoff time.Duration
+	}{
+		{
+			Name:                 "WithoutAnnotations",
+			ExpectedPoll:         30 * time.Second,
+			ExpectedResync:       0,
+			ExpectedErrorBackoff: reconciler.DefaultRetryTime,
+		},
+		{
+			Name: "WithAnnotations",
+			Annotations: map[string]string{
+				reconciler.PollIntervalAnnotation:   "10s",
+				reconciler.ResyncIntervalAnnotation: "30m",
+				reconciler.ErrorBackoffAnnotation:   "5m",
+			},
+			ExpectedPoll:         10 * time.Second,
+			ExpectedResync:       30 * time.Minute,
+			ExpectedErrorBackoff: 5 * time.Minute,
+		},
+		{
+			Name: "WithInvalidAnnotations",
+			Annotations: map[string]string{
+				reconciler.PollIntervalAnnotation:   "soon",
+				reconciler.ResyncIntervalAnnotation: "-30m",
+				reconciler.ErrorBackoffAnnotation:   "0s",
+			},
+			ExpectedPoll:         30 * time.Second,
+			ExpectedResync:       0,
+			ExpectedErrorBackoff: reconciler.DefaultRetryTime,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			obj := &metav1.ObjectMeta{Annotations: c.Annotations}
+			g.Expect(reconciler.PollInterval(obj)).To(gomega.Equal(c.ExpectedPoll))
+			g.Expect(reconciler.ResyncInterval(obj)).To(gomega.Equal(c.ExpectedResync))
+			g.Expect(reconciler.ErrorBackoff(obj)).To(gomega.Equal(c.ExpectedErrorBackoff))
+		})
+	}
+}
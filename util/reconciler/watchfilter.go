@@ -0,0 +1,28 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/cluster-api/util/labels"
+)
+
+// MatchesWatchFilter returns true if the object is reconciled by a controller started with the given watch filter
+// value, that is when no value is set or when the object has the watch label with this value.
+func MatchesWatchFilter(o metav1.Object, watchFilterValue string) bool {
+	return watchFilterValue == "" || labels.HasWatchLabel(o, watchFilterValue)
+}
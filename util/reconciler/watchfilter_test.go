@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconciler_test
+
+import (
+	"testing"
+
+	"github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"sigs.k8s.io/cluster-api-provider-gcp/util/reconciler"
+)
+
+func TestMatchesWatchFilter(t *testing.T) {
+	cases := []struct {
+		Name        string
+		Labels      map[string]string
+		FilterValue string
+		Expected    bool
+	}{
+		{
+			Name:     "WithoutFilter",
+			Expected: true,
+		},
+		{
+			Name:        "WithMatchingLabel",
+			Labels:      map[string]string{clusterv1.WatchLabel: "shard-a"},
+			FilterValue: "shard-a",
+			Expected:    true,
+		},
+		{
+			Name:        "WithOtherLabel",
+			Labels:      map[string]string{clusterv1.WatchLabel: "shard-b"},
+			FilterValue: "shard-a",
+			Expected:    false,
+		},
+		{
+			Name:        "WithoutLabel",
+			FilterValue: "shard-a",
+			Expected:    false,
+		},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			t.Parallel()
+			g := gomega.NewWithT(t)
+			obj := &metav1.ObjectMeta{Labels: c.Labels}
+			g.Expect(reconciler.MatchesWatchFilter(obj, c.FilterValue)).To(gomega.Equal(c.Expected))
+		})
+	}
+}